@@ -298,8 +298,14 @@ func CreateLogArgParser(isTableFunction bool) *argparser.ArgParser {
 	ap.SupportsStringList(NotFlag, "", "revision", "Excludes commits from revision.")
 	ap.SupportsFlag(AllFlag, "", "Automatically select every branch in database")
 	ap.SupportsFlag(ShowSignatureFlag, "", "Shows the signature of each commit.")
+	ap.SupportsString(AuthorParam, "", "pattern", "Limits the log to commits whose author, formatted as {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}{{.LessThan}}email{{.GreaterThan}}{{.GreaterThan}}, matches the regular expression.")
+	ap.SupportsString(GrepParam, "", "pattern", "Limits the log to commits whose message matches the regular expression.")
+	ap.SupportsString(SinceParam, "", "date", "Limits the log to commits made on or after the given date.")
+	ap.SupportsString(UntilParam, "", "date", "Limits the log to commits made on or before the given date.")
+	ap.SupportsString(PickaxeParam, "", "value", "Limits the log to commits which changed the number of cells equal to the value, meaning the value appeared or disappeared. Use a {{.LessThan}}table{{.GreaterThan}}.{{.LessThan}}column{{.GreaterThan}} pathspec to only look at one column.")
+	ap.SupportsString(DiffFilterParam, "", "ops", "Limits the log to commits which added (A), modified (M) or deleted (D) rows. Letters can be combined, e.g. AD.")
 	if isTableFunction {
-		ap.SupportsStringList(TablesFlag, "t", "table", "Restricts the log to commits that modified the specified tables.")
+		ap.SupportsStringList(TablesFlag, "t", "table", "Restricts the log to commits that modified the specified tables, or the specified columns given as {{.LessThan}}table{{.GreaterThan}}.{{.LessThan}}column{{.GreaterThan}}.")
 	} else {
 		ap.SupportsFlag(OneLineFlag, "", "Shows logs in a compact format.")
 		ap.SupportsFlag(StatFlag, "", "Shows the diffstat for each commit.")
@@ -317,7 +323,7 @@ func CreateDiffArgParser(isTableFunction bool) *argparser.ArgParser {
 		ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 		ap.SupportsFlag(StatFlag, "", "Show stats of data changes")
 		ap.SupportsFlag(SummaryFlag, "", "Show summary of data and schema changes")
-		ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
+		ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json, html. Defaults to tabular.")
 		ap.SupportsString(WhereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 		ap.SupportsInt(LimitParam, "", "record_count", "limits to the first N diffs.")
 		ap.SupportsFlag(StagedFlag, "", "Show only the staged data changes.")
@@ -327,6 +333,18 @@ func CreateDiffArgParser(isTableFunction bool) *argparser.ArgParser {
 		ap.SupportsFlag(ReverseFlag, "R", "Reverses the direction of the diff.")
 		ap.SupportsFlag(NameOnlyFlag, "", "Only shows table names.")
 		ap.SupportsFlag(SystemFlag, "", "Show system tables in addition to user tables")
+		ap.SupportsString(PatchFileParam, "", "file", "Writes the diff to a patch file which can be applied with {{.EmphasisLeft}}dolt apply{{.EmphasisRight}}, instead of displaying it.")
+	}
+	return ap
+}
+
+func CreateBlameArgParser(isTableFunction bool) *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("blame", 2)
+	ap.SupportsString(ColumnsParam, "", "columns", "Only show which revision last modified the given comma separated columns.")
+	ap.SupportsString(SinceParam, "", "date", "Only show changes made on or after the given date. Columns last modified before it are not shown.")
+	ap.SupportsString(UntilParam, "", "date", "Ignore changes made after the given date.")
+	if !isTableFunction {
+		ap.SupportsFlag(CellsFlag, "", "Show which revision last modified each column of each row, rather than each row.")
 	}
 	return ap
 }
@@ -91,16 +91,32 @@ const (
 
 // Flags used by `dolt diff` command and `dolt_diff()` table function.
 const (
-	SkinnyFlag   = "skinny"
-	IncludeCols  = "include-cols"
-	DataFlag     = "data"
-	SchemaFlag   = "schema"
-	NameOnlyFlag = "name-only"
-	SummaryFlag  = "summary"
-	WhereParam   = "where"
-	LimitParam   = "limit"
-	MergeBase    = "merge-base"
-	DiffMode     = "diff-mode"
-	ReverseFlag  = "reverse"
-	FormatFlag   = "result-format"
+	SkinnyFlag     = "skinny"
+	IncludeCols    = "include-cols"
+	DataFlag       = "data"
+	SchemaFlag     = "schema"
+	NameOnlyFlag   = "name-only"
+	SummaryFlag    = "summary"
+	WhereParam     = "where"
+	LimitParam     = "limit"
+	MergeBase      = "merge-base"
+	DiffMode       = "diff-mode"
+	ReverseFlag    = "reverse"
+	FormatFlag     = "result-format"
+	PatchFileParam = "patch-file"
+)
+
+// Flags used by `dolt blame` command and `dolt_blame()` table function.
+const (
+	CellsFlag    = "cells"
+	ColumnsParam = "columns"
+	SinceParam   = "since"
+	UntilParam   = "until"
+)
+
+// Flags used by `dolt log` command and `dolt_log()` table function.
+const (
+	GrepParam       = "grep"
+	PickaxeParam    = "S"
+	DiffFilterParam = "diff-filter"
 )
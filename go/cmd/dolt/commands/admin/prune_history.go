@@ -0,0 +1,150 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const pruneBeforeParam = "before"
+
+type PruneHistoryCmd struct {
+}
+
+var pruneHistoryDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Drop commits older than a cutoff from local storage",
+	LongDesc: `Admin command to prune old history from the database. Commits older than the cutoff given with {{.EmphasisLeft}}--before{{.EmphasisRight}} are turned into ghost commits, in the same way a shallow clone leaves the history it did not fetch, and a full garbage collection then removes their data from storage.
+
+The cutoff is either a date, in which case every commit made before it is pruned, or a commit, in which case its ancestors are pruned. The commits at the head of every branch, tag, remote branch and stash are never pruned, and neither is any commit reachable from them through commits which are kept.
+
+Pruned commits remain known by address, so fetching from and pushing to remotes which have the full history continues to work. Log, diff and merge operations which need pruned commits fail as they do in a shallow clone.
+
+Pruning cannot be undone, and cannot be run while a merge or rebase is in progress.`,
+	Synopsis: []string{
+		"--before {{.LessThan}}date|commit{{.GreaterThan}}",
+	},
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd PruneHistoryCmd) Name() string {
+	return "prune-history"
+}
+
+// Description returns a description of the command
+func (cmd PruneHistoryCmd) Description() string {
+	return pruneHistoryDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd PruneHistoryCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd PruneHistoryCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(pruneHistoryDocs, ap)
+}
+
+func (cmd PruneHistoryCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsString(pruneBeforeParam, "", "date|commit", "Prune commits made before this date, or the ancestors of this commit.")
+	return ap
+}
+
+func (cmd PruneHistoryCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd PruneHistoryCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, pruneHistoryDocs, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	before, ok := apr.GetValue(pruneBeforeParam)
+	if !ok || before == "" {
+		verr := errhand.BuildDError("--before is required").SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	ddb := dEnv.DoltDB(ctx)
+	prune, verr := pruneHistoryPredicate(ctx, dEnv, ddb, before)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	res, err := actions.PruneHistory(ctx, ddb, prune)
+	if err != nil {
+		verr := errhand.BuildDError("failed to prune history").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if res.Pruned == 0 {
+		cli.Printf("No commits to prune.\n")
+		return 0
+	}
+
+	cli.Printf("Kept %d commits. %d commits are now ghost commits.\n", res.Kept, res.Ghosts)
+	cli.Printf("Running full garbage collection...\n")
+	err = ddb.GC(ctx, types.GCModeFull, chunks.SimpleArchive, nil)
+	if err != nil {
+		verr := errhand.BuildDError("failed to garbage collect pruned history").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cli.Printf("Pruned history before %s.\n", before)
+	return 0
+}
+
+// pruneHistoryPredicate returns the predicate for the commits to prune given the value of --before. The value is
+// treated as a date if it parses as one, and as a commit spec otherwise.
+func pruneHistoryPredicate(ctx context.Context, dEnv *env.DoltEnv, ddb *doltdb.DoltDB, before string) (func(context.Context, *doltdb.Commit) (bool, error), errhand.VerboseError) {
+	if t, err := dconfig.ParseDate(before); err == nil {
+		return actions.PruneBefore(t), nil
+	}
+
+	cs, err := doltdb.NewCommitSpec(before)
+	if err != nil {
+		return nil, errhand.BuildDError("'%s' is not a valid date or commit", before).SetPrintUsage().Build()
+	}
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	optCmt, err := ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, errhand.BuildDError("'%s' is not a valid date or commit", before).AddCause(err).Build()
+	}
+	cmt, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, errhand.BuildDError("'%s' has already been pruned", before).Build()
+	}
+	prune, err := actions.PruneAncestorsOf(ctx, cmt)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	return prune, nil
+}
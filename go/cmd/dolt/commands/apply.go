@@ -0,0 +1,678 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+)
+
+const (
+	applyCheckFlag = "check"
+	apply3WayFlag  = "3way"
+)
+
+var applyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply a patch file to the working set",
+	LongDesc: `Applies a patch file written by {{.EmphasisLeft}}dolt diff --patch-file{{.EmphasisRight}} to the working set of the current branch. The changes are not staged or committed.
+
+A patch file records the rows and schemas its changes replace. Before changing anything, {{.EmphasisLeft}}dolt apply{{.EmphasisRight}} checks that every changed table, row, view, trigger and event still matches the patch. If any does not, the patch is not applied and the mismatches are listed.
+
+With {{.EmphasisLeft}}--3way{{.EmphasisRight}}, a patch which does not apply cleanly is instead merged into the working set. The patch is applied to the commit it was created from, which must be present in this database, and the result is merged with HEAD. Changes which cannot be merged are recorded as conflicts, which can be resolved with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}. This requires a clean working set.
+`,
+	Synopsis: []string{
+		`[--check | --3way] {{.LessThan}}patch-file{{.GreaterThan}}`,
+	},
+}
+
+var errPatchDoesNotApply = errors.New("patch does not apply")
+
+type ApplyCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command.
+func (cmd ApplyCmd) Name() string {
+	return "apply"
+}
+
+// Description returns a description of the command.
+func (cmd ApplyCmd) Description() string {
+	return "Apply a patch file to the working set."
+}
+
+func (cmd ApplyCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(applyDocs, ap)
+}
+
+func (cmd ApplyCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"patch-file", "The patch file to apply."})
+	ap.SupportsFlag(applyCheckFlag, "", "Check whether the patch applies cleanly, without applying it.")
+	ap.SupportsFlag(apply3WayFlag, "3", "If the patch does not apply cleanly, merge it using the commit it was created from, recording conflicts.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd ApplyCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	apr, usage, terminate, status := ParseArgsOrPrintHelp(ap, commandStr, args, applyDocs)
+	if terminate {
+		return status
+	}
+
+	if apr.NArg() != 1 {
+		return HandleVErrAndExitCode(errhand.BuildDError("a patch file is required").SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(applyCheckFlag) && apr.Contains(apply3WayFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError("--%s cannot be combined with --%s", applyCheckFlag, apply3WayFlag).Build(), usage)
+	}
+
+	patch, err := readPatchFile(apr.Arg(0))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	queryEngine, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	queryist, sqlCtx := queryEngine.Queryist, queryEngine.Context
+
+	mismatches, err := applyPatch(queryist, sqlCtx, patch, apr.Contains(applyCheckFlag))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	if len(mismatches) > 0 && apr.Contains(apply3WayFlag) {
+		err = applyPatch3Way(queryist, sqlCtx, patch)
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	for _, mismatch := range mismatches {
+		cli.PrintErrln("error: " + mismatch)
+	}
+	if len(mismatches) > 0 {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(errPatchDoesNotApply), usage)
+	}
+	return 0
+}
+
+// checkPatch returns a description of each change in |patch| whose preimage does not match the current working set.
+func checkPatch(queryist cli.Queryist, sqlCtx *sql.Context, patch *patchFile) ([]string, error) {
+	var mismatches []string
+	for _, t := range patch.Tables {
+		tableMismatches, err := checkPatchTable(queryist, sqlCtx, t)
+		if err != nil {
+			return nil, err
+		}
+		mismatches = append(mismatches, tableMismatches...)
+	}
+
+	for _, d := range patch.Definitions {
+		q, err := dbr.InterpolateForDialect("select fragment from dolt_schemas where type = ? and name = ?", []interface{}{d.Type, d.Name}, dialect.MySQL)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := cli.GetRowsForSql(queryist, sqlCtx, q)
+		if err != nil && !sql.ErrTableNotFound.Is(err) {
+			return nil, err
+		}
+		current := ""
+		if len(rows) > 0 {
+			current, _, err = sql.Unwrap[string](sqlCtx, rows[0][0])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !patchDefinitionsEqual(current, d.From) {
+			if d.From == "" {
+				mismatches = append(mismatches, fmt.Sprintf("%s '%s' already exists", d.Type, d.Name))
+			} else {
+				mismatches = append(mismatches, fmt.Sprintf("%s '%s' does not match the patch", d.Type, d.Name))
+			}
+		}
+	}
+	return mismatches, nil
+}
+
+// checkPatchTable returns a description of each change to |t| whose preimage does not match the current working set.
+func checkPatchTable(queryist cli.Queryist, sqlCtx *sql.Context, t *patchTable) ([]string, error) {
+	if t.FromName == "" {
+		exists, err := patchTableExists(queryist, sqlCtx, t.ToName)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return []string{fmt.Sprintf("table '%s' already exists", t.ToName)}, nil
+		}
+		return nil, nil
+	}
+
+	exists, err := patchTableExists(queryist, sqlCtx, t.FromName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []string{fmt.Sprintf("table '%s' does not exist", t.FromName)}, nil
+	}
+
+	if len(t.SchemaStatements) > 0 {
+		rows, err := cli.GetRowsForSql(queryist, sqlCtx, "show create table "+sqlfmt.QuoteIdentifier(t.FromName))
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("no schema found for table '%s'", t.FromName)
+		}
+		createStmt, _, err := sql.Unwrap[string](sqlCtx, rows[0][1])
+		if err != nil {
+			return nil, err
+		}
+		if !patchDefinitionsEqual(createStmt, t.FromCreate) {
+			// rows can't be checked against a schema which differs from the one they were diffed with
+			return []string{fmt.Sprintf("schema of table '%s' does not match the patch", t.FromName)}, nil
+		}
+	}
+
+	if len(t.SchemaStatements) == 0 && len(t.Rows) > 0 {
+		// without its schema changes, the table must already have every column the rows were diffed with
+		cols, err := patchTableColumns(queryist, sqlCtx, t.FromName)
+		if err != nil {
+			return nil, err
+		}
+		var mismatches []string
+		for _, col := range append(append([]string{}, t.FromColumns...), t.ToColumns...) {
+			if !cols.Contains(strings.ToLower(col)) {
+				mismatches = append(mismatches, fmt.Sprintf("column '%s' does not exist in table '%s'", col, t.FromName))
+				cols.Add(strings.ToLower(col))
+			}
+		}
+		if len(mismatches) > 0 {
+			return mismatches, nil
+		}
+	}
+
+	var mismatches []string
+	for _, r := range t.Rows {
+		if r.From == nil {
+			if len(t.PrimaryKey) == 0 {
+				continue
+			}
+			// an added row must not replace an existing row
+			where, params := patchRowFilter(t.PrimaryKey, t.ToColumns, r.To)
+			current, err := patchCurrentRow(queryist, sqlCtx, t.FromName, t.PrimaryKey, where, params)
+			if err != nil {
+				return nil, err
+			}
+			if current != nil {
+				mismatches = append(mismatches, fmt.Sprintf("row %s already exists in table '%s'", patchRowKey(t.PrimaryKey, t.ToColumns, r.To), t.FromName))
+			}
+			continue
+		}
+
+		keyCols := t.PrimaryKey
+		if len(keyCols) == 0 {
+			keyCols = t.FromColumns
+		}
+		where, params := patchRowFilter(keyCols, t.FromColumns, r.From)
+		current, err := patchCurrentRow(queryist, sqlCtx, t.FromName, t.FromColumns, where, params)
+		if err != nil {
+			return nil, err
+		}
+		key := patchRowKey(keyCols, t.FromColumns, r.From)
+		if current == nil {
+			mismatches = append(mismatches, fmt.Sprintf("row %s does not exist in table '%s'", key, t.FromName))
+		} else if !patchValuesEqual(current, r.From) {
+			mismatches = append(mismatches, fmt.Sprintf("row %s in table '%s' does not match the patch", key, t.FromName))
+		}
+	}
+	return mismatches, nil
+}
+
+func patchTableExists(queryist cli.Queryist, sqlCtx *sql.Context, tableName string) (bool, error) {
+	q, err := dbr.InterpolateForDialect("select count(*) from information_schema.tables where table_schema = database() and table_name = ?", []interface{}{tableName}, dialect.MySQL)
+	if err != nil {
+		return false, err
+	}
+	rows, err := cli.GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return false, err
+	}
+	count, err := getInt64ColAsInt64(rows[0][0])
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// patchTableColumns returns the lower-cased names of the columns of |tableName|.
+func patchTableColumns(queryist cli.Queryist, sqlCtx *sql.Context, tableName string) (*set.StrSet, error) {
+	q, err := dbr.InterpolateForDialect("select lower(column_name) from information_schema.columns where table_schema = database() and table_name = ?", []interface{}{tableName}, dialect.MySQL)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := cli.GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return nil, err
+	}
+	cols := set.NewStrSet(nil)
+	for _, row := range rows {
+		col, _, err := sql.Unwrap[string](sqlCtx, row[0])
+		if err != nil {
+			return nil, err
+		}
+		cols.Add(col)
+	}
+	return cols, nil
+}
+
+// patchRowFilter returns a WHERE clause and its parameters which match the row with |values| for |cols| on |keyCols|.
+func patchRowFilter(keyCols, cols []string, values []patchValue) (string, []interface{}) {
+	conds := make([]string, len(keyCols))
+	params := make([]interface{}, len(keyCols))
+	for i, col := range keyCols {
+		conds[i] = sqlfmt.QuoteIdentifier(col) + " <=> ?"
+		params[i] = patchColumnValue(cols, values, col).sqlValue()
+	}
+	return strings.Join(conds, " and "), params
+}
+
+// patchRowKey returns the values of |keyCols| of a row, for messages.
+func patchRowKey(keyCols, cols []string, values []patchValue) string {
+	strs := make([]string, len(keyCols))
+	for i, col := range keyCols {
+		strs[i] = patchColumnValue(cols, values, col).String()
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+// patchColumnValue returns the value of |col| in a row with |values| for |cols|.
+func patchColumnValue(cols []string, values []patchValue, col string) patchValue {
+	for i, c := range cols {
+		if strings.EqualFold(c, col) && i < len(values) {
+			return values[i]
+		}
+	}
+	return patchValue{Null: true}
+}
+
+// patchCurrentRow returns the values of |cols| of the first row of |tableName| matching |where|, or nil if there is
+// no such row.
+func patchCurrentRow(queryist cli.Queryist, sqlCtx *sql.Context, tableName string, cols []string, where string, params []interface{}) ([]patchValue, error) {
+	quoted := make([]string, len(cols))
+	for i, col := range cols {
+		quoted[i] = sqlfmt.QuoteIdentifier(col)
+	}
+	q, err := dbr.InterpolateForDialect(fmt.Sprintf("select %s from %s where %s limit 1", strings.Join(quoted, ", "), sqlfmt.QuoteIdentifier(tableName), where), params, dialect.MySQL)
+	if err != nil {
+		return nil, err
+	}
+	sch, rowIter, _, err := queryist.Query(sqlCtx, q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	values := make([]patchValue, len(cols))
+	for i, v := range rows[0] {
+		if v == nil {
+			values[i] = patchValue{Null: true}
+			continue
+		}
+		str, err := sqlutil.SqlColToStr(sqlCtx, sch[i].Type, v)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = patchValue{Value: str}
+	}
+	return values, nil
+}
+
+// patchDefinitionsEqual returns whether two CREATE statements are equal. The statements in patch files are
+// terminated with a semicolon, which those read from the database are not.
+func patchDefinitionsEqual(a, b string) bool {
+	return strings.TrimSuffix(strings.TrimSpace(a), ";") == strings.TrimSuffix(strings.TrimSpace(b), ";")
+}
+
+func patchValuesEqual(a, b []patchValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// applyPatch checks |patch| against the working set and, if every change matches and |checkOnly| is false, applies
+// it, all in a single transaction. It returns a description of each change which does not match, in which case nothing
+// is applied. Foreign key checks are disabled while the changes are applied, since they may be in any order, so the
+// constraints are verified before the transaction is committed and the patch is rolled back if any are violated.
+func applyPatch(queryist cli.Queryist, sqlCtx *sql.Context, patch *patchFile, checkOnly bool) (mismatches []string, err error) {
+	stmts, err := patchStatements(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreFkChecks, err := setSessionVar(queryist, sqlCtx, "foreign_key_checks", 0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, restoreFkChecks())
+	}()
+
+	_, err = cli.GetRowsForSql(queryist, sqlCtx, "start transaction")
+	if err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, rollbackErr := cli.GetRowsForSql(queryist, sqlCtx, "rollback")
+			err = errors.Join(err, rollbackErr)
+		}
+	}()
+
+	mismatches, err = checkPatch(queryist, sqlCtx, patch)
+	if err != nil || len(mismatches) > 0 || checkOnly {
+		return mismatches, err
+	}
+
+	for _, stmt := range stmts {
+		_, err = cli.GetRowsForSql(queryist, sqlCtx, stmt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply patch: %w", err)
+		}
+	}
+	if err = verifyPatchConstraints(queryist, sqlCtx); err != nil {
+		return nil, err
+	}
+
+	_, err = cli.GetRowsForSql(queryist, sqlCtx, "commit")
+	committed = err == nil
+	return nil, err
+}
+
+// verifyPatchConstraints returns an error naming the tables with constraint violations in the working set of the
+// current transaction, if there are any.
+func verifyPatchConstraints(queryist cli.Queryist, sqlCtx *sql.Context) error {
+	rows, err := cli.GetRowsForSql(queryist, sqlCtx, "call dolt_verify_constraints()")
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return errors.New("unexpected result from dolt_verify_constraints")
+	}
+	violations, err := getInt64ColAsInt64(rows[0][0])
+	if err != nil {
+		return err
+	}
+	if violations == 0 {
+		return nil
+	}
+
+	rows, err = cli.GetRowsForSql(queryist, sqlCtx, "select `table`, num_violations from dolt_constraint_violations order by `table`")
+	if err != nil {
+		return err
+	}
+	tables := make([]string, len(rows))
+	for i, row := range rows {
+		tables[i] = fmt.Sprintf("%v (%v)", row[0], row[1])
+	}
+	return fmt.Errorf("%w: it would violate constraints in %s", errPatchDoesNotApply, strings.Join(tables, ", "))
+}
+
+// setSessionVar sets the session variable |name| to |value| and returns a function which restores its previous value.
+func setSessionVar(queryist cli.Queryist, sqlCtx *sql.Context, name string, value int) (func() error, error) {
+	rows, err := cli.GetRowsForSql(queryist, sqlCtx, "select @@session."+name)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return nil, fmt.Errorf("unexpected result reading @@%s", name)
+	}
+	prev := rows[0][0]
+	if s, ok := prev.(string); ok {
+		// Remote queryists return every value as a string.
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			prev = i
+		}
+	}
+
+	if _, err = cli.GetRowsForSql(queryist, sqlCtx, fmt.Sprintf("set @@session.%s = %d", name, value)); err != nil {
+		return nil, err
+	}
+	return func() error {
+		q, err := dbr.InterpolateForDialect("set @@session."+name+" = ?", []interface{}{prev}, dialect.MySQL)
+		if err != nil {
+			return err
+		}
+		_, err = cli.GetRowsForSql(queryist, sqlCtx, q)
+		return err
+	}, nil
+}
+
+// patchStatements returns the SQL statements which apply the changes in |patch|.
+func patchStatements(patch *patchFile) ([]string, error) {
+	var stmts []string
+	for _, t := range patch.Tables {
+		stmts = append(stmts, t.SchemaStatements...)
+
+		tableName := sqlfmt.QuoteIdentifier(t.name())
+		if t.ToName != "" {
+			tableName = sqlfmt.QuoteIdentifier(t.ToName)
+		}
+		for _, r := range t.Rows {
+			stmt, err := patchRowStatement(t, tableName, r)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, stmt)
+		}
+	}
+
+	for _, d := range patch.Definitions {
+		if d.From != "" {
+			stmts = append(stmts, fmt.Sprintf("drop %s if exists %s", d.Type, sqlfmt.QuoteIdentifier(d.Name)))
+		}
+		if d.To != "" {
+			// the definition is stored as written, so it must not keep the semicolon the diff added
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(d.To), ";"))
+		}
+	}
+	return stmts, nil
+}
+
+func patchRowStatement(t *patchTable, tableName string, r patchRow) (string, error) {
+	switch {
+	case r.From == nil:
+		cols := make([]string, len(t.ToColumns))
+		placeholders := make([]string, len(t.ToColumns))
+		params := make([]interface{}, len(t.ToColumns))
+		for i, col := range t.ToColumns {
+			cols[i] = sqlfmt.QuoteIdentifier(col)
+			placeholders[i] = "?"
+			params[i] = r.To[i].sqlValue()
+		}
+		return dbr.InterpolateForDialect(fmt.Sprintf("insert into %s (%s) values (%s)", tableName, strings.Join(cols, ", "), strings.Join(placeholders, ", ")), params, dialect.MySQL)
+	case r.To == nil:
+		if len(t.PrimaryKey) == 0 {
+			where, params := patchRowFilter(t.FromColumns, t.FromColumns, r.From)
+			return dbr.InterpolateForDialect(fmt.Sprintf("delete from %s where %s limit 1", tableName, where), params, dialect.MySQL)
+		}
+		where, params := patchRowFilter(t.PrimaryKey, t.FromColumns, r.From)
+		return dbr.InterpolateForDialect(fmt.Sprintf("delete from %s where %s", tableName, where), params, dialect.MySQL)
+	default:
+		sets := make([]string, len(t.ToColumns))
+		params := make([]interface{}, 0, len(t.ToColumns)+len(t.PrimaryKey))
+		for i, col := range t.ToColumns {
+			sets[i] = sqlfmt.QuoteIdentifier(col) + " = ?"
+			params = append(params, r.To[i].sqlValue())
+		}
+		where, whereParams := patchRowFilter(t.PrimaryKey, t.FromColumns, r.From)
+		params = append(params, whereParams...)
+		return dbr.InterpolateForDialect(fmt.Sprintf("update %s set %s where %s", tableName, strings.Join(sets, ", "), where), params, dialect.MySQL)
+	}
+}
+
+// applyPatch3Way applies |patch| to its base commit on a temporary branch, and squash merges the result into the
+// working set, recording any conflicts.
+func applyPatch3Way(queryist cli.Queryist, sqlCtx *sql.Context, patch *patchFile) (err error) {
+	if patch.Base == "" {
+		return fmt.Errorf("%w: the patch was not created from a commit, so it cannot be merged", errPatchDoesNotApply)
+	}
+	if _, err = getHashOf(queryist, sqlCtx, patch.Base); err != nil {
+		return fmt.Errorf("%w: the commit %s it was created from is not in this database", errPatchDoesNotApply, patch.Base)
+	}
+
+	hasStagedChanges, hasUnstagedChanges, err := hasStagedAndUnstagedChanged(queryist, sqlCtx)
+	if err != nil {
+		return err
+	}
+	if hasStagedChanges || hasUnstagedChanges {
+		return errors.New("error: your local changes would be overwritten by apply --3way.\n" +
+			"hint: commit your changes (dolt commit -am \"<message>\") or reset them (dolt reset --hard) to proceed.")
+	}
+
+	dbName := sqlCtx.GetCurrentDatabase()
+	if dbName == "" {
+		return errors.New("apply --3way requires a current database")
+	}
+	branchName := "dolt-apply-" + patch.Base
+
+	q, err := dbr.InterpolateForDialect("call dolt_branch(?, ?)", []interface{}{branchName, patch.Base}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	if _, err = cli.GetRowsForSql(queryist, sqlCtx, q); err != nil {
+		return err
+	}
+	defer func() {
+		q, qErr := dbr.InterpolateForDialect("call dolt_branch('-D', ?)", []interface{}{branchName}, dialect.MySQL)
+		if qErr == nil {
+			_, qErr = cli.GetRowsForSql(queryist, sqlCtx, q)
+		}
+		if err == nil {
+			err = qErr
+		}
+	}()
+
+	if err = applyPatchToBranch(queryist, sqlCtx, patch, dbName, branchName); err != nil {
+		return err
+	}
+
+	restoreAllowConflicts, err := setSessionVar(queryist, sqlCtx, "dolt_allow_commit_conflicts", 1)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, restoreAllowConflicts())
+	}()
+	restoreForceCommit, err := setSessionVar(queryist, sqlCtx, "dolt_force_transaction_commit", 1)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, restoreForceCommit())
+	}()
+	q, err = dbr.InterpolateForDialect("call dolt_merge('--squash', ?)", []interface{}{branchName}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	rows, err := cli.GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 || len(rows[0]) < 3 {
+		return errors.New("unexpected result from dolt_merge")
+	}
+	conflicts, err := getInt64ColAsInt64(rows[0][2])
+	if err != nil {
+		return err
+	}
+	if conflicts == 0 {
+		cli.Println("Applied patch cleanly with a 3-way merge.")
+		return nil
+	}
+
+	rows, err = cli.GetRowsForSql(queryist, sqlCtx, "select `table`, num_conflicts from dolt_conflicts order by `table`")
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cli.Printf("CONFLICT (content): Merge conflict in %v\n", row[0])
+	}
+	rows, err = cli.GetRowsForSql(queryist, sqlCtx, "select distinct `table` from dolt_constraint_violations order by `table`")
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cli.Printf("CONSTRAINT VIOLATION: Merge created constraint violations in %v\n", row[0])
+	}
+	return errors.New("Applied patch with conflicts. Resolve them with dolt conflicts, then add and commit the result.")
+}
+
+// applyPatchToBranch applies |patch| to the branch |branchName| of |dbName| and commits it.
+func applyPatchToBranch(queryist cli.Queryist, sqlCtx *sql.Context, patch *patchFile, dbName, branchName string) (err error) {
+	_, err = cli.GetRowsForSql(queryist, sqlCtx, "use "+sqlfmt.QuoteIdentifier(dbName+"/"+branchName))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, useErr := cli.GetRowsForSql(queryist, sqlCtx, "use "+sqlfmt.QuoteIdentifier(dbName))
+		if err == nil {
+			err = useErr
+		}
+	}()
+
+	mismatches, err := applyPatch(queryist, sqlCtx, patch, false)
+	if err != nil {
+		return err
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: it does not match the commit %s it was created from: %s", errPatchDoesNotApply, patch.Base, mismatches[0])
+	}
+
+	q, err := dbr.InterpolateForDialect("call dolt_commit('-Am', ?)", []interface{}{"apply patch " + patch.From + ".." + patch.To}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	_, err = cli.GetRowsForSql(queryist, sqlCtx, q)
+	return err
+}
@@ -0,0 +1,453 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	bisectStartCmd = "start"
+	bisectBadCmd   = "bad"
+	bisectGoodCmd  = "good"
+	bisectSkipCmd  = "skip"
+	bisectResetCmd = "reset"
+	bisectLogCmd   = "log"
+	bisectRunCmd   = "run"
+
+	bisectTestParam  = "test"
+	bisectQueryParam = "query"
+)
+
+var errNotBisecting = errors.New("not bisecting. Start a bisect session with 'dolt bisect start'")
+
+var bisectDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Use binary search to find the commit that introduced a change.",
+	LongDesc: `Finds the first commit in which the data changed in some way, typically the commit which broke a data invariant. Start a session with {{.EmphasisLeft}}dolt bisect start{{.EmphasisRight}}, then mark a commit where the invariant is broken as bad and a commit where it holds as good. Dolt then names a commit between them to test. Mark it good or bad, and repeat until the first bad commit is found. A commit which cannot be tested can be skipped.
+
+Unlike git, Dolt does not check out the commit to test. Test it with an {{.EmphasisLeft}}AS OF{{.EmphasisRight}} query, or by using its revision database, e.g. {{.EmphasisLeft}}dolt sql -q "use \` + "`mydb/<commit>`" + `; select ..."{{.EmphasisRight}}. {{.EmphasisLeft}}good{{.EmphasisRight}}, {{.EmphasisLeft}}bad{{.EmphasisRight}} and {{.EmphasisLeft}}skip{{.EmphasisRight}} apply to the commit to test when no commit is given, and to HEAD before there is one.
+
+{{.EmphasisLeft}}dolt bisect run{{.EmphasisRight}} tests the remaining commits automatically. With {{.EmphasisLeft}}--test{{.EmphasisRight}}, a commit is good if every test in the given dolt_tests group passes. The tests are run as they are defined at each commit. With {{.EmphasisLeft}}--query{{.EmphasisRight}}, a commit is good if the query returns the same result it returns at the first good commit.
+
+The state of the session is kept in the repository until {{.EmphasisLeft}}dolt bisect reset{{.EmphasisRight}}. {{.EmphasisLeft}}dolt bisect log{{.EmphasisRight}} prints the session as the commands which would repeat it.`,
+	Synopsis: []string{
+		"start [{{.LessThan}}bad{{.GreaterThan}} [{{.LessThan}}good{{.GreaterThan}}...]]",
+		"bad [{{.LessThan}}commit{{.GreaterThan}}]",
+		"good [{{.LessThan}}commit{{.GreaterThan}}...]",
+		"skip [{{.LessThan}}commit{{.GreaterThan}}...]",
+		"run (--test {{.LessThan}}group{{.GreaterThan}} | --query {{.LessThan}}query{{.GreaterThan}})",
+		"log",
+		"reset",
+	},
+}
+
+type BisectCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BisectCmd) Name() string {
+	return "bisect"
+}
+
+// Description returns a description of the command
+func (cmd BisectCmd) Description() string {
+	return bisectDocs.ShortDesc
+}
+
+func (cmd BisectCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bisectDocs, ap)
+}
+
+func (cmd BisectCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.SupportsString(bisectTestParam, "", "group", "For run, the dolt_tests group which passes at good commits.")
+	ap.SupportsString(bisectQueryParam, "", "query", "For run, the query whose result at good commits matches its result at the first good commit.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd BisectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	apr, usage, terminate, status := ParseArgsOrPrintHelp(ap, commandStr, args, bisectDocs)
+	if terminate {
+		return status
+	}
+	if apr.NArg() == 0 {
+		return HandleVErrAndExitCode(errhand.BuildDError("a bisect subcommand is required").SetPrintUsage().Build(), usage)
+	}
+
+	subcommand, revs := strings.ToLower(apr.Arg(0)), apr.Args[1:]
+	if subcommand != bisectRunCmd && (apr.Contains(bisectTestParam) || apr.Contains(bisectQueryParam)) {
+		return HandleVErrAndExitCode(errhand.BuildDError("--%s and --%s are only supported by run", bisectTestParam, bisectQueryParam).SetPrintUsage().Build(), usage)
+	}
+
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	state, err := dEnv.GetBisectState()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	b := &bisector{dEnv: dEnv, ddb: dEnv.DoltDB(ctx), headRef: headRef, state: state}
+
+	switch subcommand {
+	case bisectStartCmd:
+		err = b.start(ctx, revs)
+	case bisectBadCmd:
+		if len(revs) > 1 {
+			err = errors.New("only one commit can be marked bad")
+			break
+		}
+		err = b.markAndAdvance(ctx, subcommand, revs)
+	case bisectGoodCmd, bisectSkipCmd:
+		err = b.markAndAdvance(ctx, subcommand, revs)
+	case bisectRunCmd:
+		err = b.run(ctx, apr, cliCtx)
+	case bisectLogCmd:
+		if b.state == nil {
+			err = errNotBisecting
+			break
+		}
+		for _, l := range b.state.Log {
+			cli.Println(l)
+		}
+	case bisectResetCmd:
+		if b.state == nil {
+			cli.Println("Not bisecting.")
+			break
+		}
+		err = dEnv.SetBisectState(nil)
+	default:
+		return HandleVErrAndExitCode(errhand.BuildDError("unknown bisect subcommand %s", subcommand).SetPrintUsage().Build(), usage)
+	}
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	return 0
+}
+
+// bisector performs the bisect subcommands against the state of the session in progress.
+type bisector struct {
+	dEnv    *env.DoltEnv
+	ddb     *doltdb.DoltDB
+	headRef ref.DoltRef
+	state   *env.BisectState
+}
+
+// start begins a new session, discarding any in progress. |revs| are an optional bad commit followed by good commits.
+func (b *bisector) start(ctx context.Context, revs []string) error {
+	b.state = &env.BisectState{Log: []string{"dolt bisect start"}}
+	for i, rev := range revs {
+		term := bisectGoodCmd
+		if i == 0 {
+			term = bisectBadCmd
+		}
+		if err := b.mark(ctx, term, rev); err != nil {
+			return err
+		}
+	}
+	_, err := b.advance(ctx)
+	return err
+}
+
+// markAndAdvance marks |revs| with |term|, or the commit to test if there are none, and prints the next step.
+func (b *bisector) markAndAdvance(ctx context.Context, term string, revs []string) error {
+	if b.state == nil {
+		return errNotBisecting
+	}
+	if len(revs) == 0 {
+		revs = []string{"HEAD"}
+		if b.state.Current != "" {
+			revs = []string{b.state.Current}
+		}
+	}
+	for _, rev := range revs {
+		if err := b.mark(ctx, term, rev); err != nil {
+			return err
+		}
+	}
+	_, err := b.advance(ctx)
+	return err
+}
+
+// mark records the commit |rev| as |term|, which is one of good, bad or skip.
+func (b *bisector) mark(ctx context.Context, term, rev string) error {
+	cmt, err := b.resolve(ctx, rev)
+	if err != nil {
+		return err
+	}
+	h, err := cmt.HashOf()
+	if err != nil {
+		return err
+	}
+	summary, err := bisectSummary(ctx, cmt)
+	if err != nil {
+		return err
+	}
+
+	switch term {
+	case bisectBadCmd:
+		b.state.Bad = h.String()
+	case bisectGoodCmd:
+		if !slices.Contains(b.state.Good, h.String()) {
+			b.state.Good = append(b.state.Good, h.String())
+		}
+	case bisectSkipCmd:
+		if !slices.Contains(b.state.Skip, h.String()) {
+			b.state.Skip = append(b.state.Skip, h.String())
+		}
+	}
+	b.state.Log = append(b.state.Log, fmt.Sprintf("# %s: %s", term, summary), fmt.Sprintf("dolt bisect %s %s", term, h.String()))
+	return nil
+}
+
+// advance chooses the next commit to test, prints it, and saves the state of the session. It returns true once no
+// commits are left to test.
+func (b *bisector) advance(ctx context.Context) (bool, error) {
+	b.state.Current = ""
+	if b.state.Bad == "" || len(b.state.Good) == 0 {
+		switch {
+		case b.state.Bad == "" && len(b.state.Good) == 0:
+			cli.Println("status: waiting for both good and bad commits")
+		case b.state.Bad == "":
+			cli.Printf("status: waiting for bad commit, %d good commit(s) known\n", len(b.state.Good))
+		default:
+			cli.Println("status: waiting for good commit(s), bad commit known")
+		}
+		return false, b.dEnv.SetBisectState(b.state)
+	}
+
+	good := make([]hash.Hash, len(b.state.Good))
+	for i, g := range b.state.Good {
+		good[i] = hash.Parse(g)
+	}
+	skip := hash.NewHashSet()
+	for _, s := range b.state.Skip {
+		skip.Insert(hash.Parse(s))
+	}
+	step, err := commitwalk.BisectNext(ctx, b.ddb, hash.Parse(b.state.Bad), good, skip)
+	if err != nil {
+		return false, err
+	}
+
+	done := true
+	switch {
+	case step.FirstBad != nil:
+		summary, err := bisectSummary(ctx, step.FirstBad)
+		if err != nil {
+			return false, err
+		}
+		h, err := step.FirstBad.HashOf()
+		if err != nil {
+			return false, err
+		}
+		meta, err := step.FirstBad.GetCommitMeta(ctx)
+		if err != nil {
+			return false, err
+		}
+		cli.Printf("%s is the first bad commit\n", h.String())
+		cli.Printf("commit %s\nAuthor: %s <%s>\nDate:  %s\n\n\t%s\n", h.String(), meta.Name, meta.Email, meta.FormatTS(), meta.Description)
+		b.state.Log = append(b.state.Log, "# first bad commit: "+summary)
+	case step.Untestable != nil:
+		cli.Println("There are only 'skip'ped commits left to test.")
+		cli.Println("The first bad commit could be any of:")
+		for _, c := range step.Untestable {
+			h, err := c.HashOf()
+			if err != nil {
+				return false, err
+			}
+			cli.Println(h.String())
+		}
+		b.state.Log = append(b.state.Log, "# only skipped commits left to test")
+	default:
+		summary, err := bisectSummary(ctx, step.Next)
+		if err != nil {
+			return false, err
+		}
+		h, err := step.Next.HashOf()
+		if err != nil {
+			return false, err
+		}
+		b.state.Current = h.String()
+		cli.Printf("Bisecting: %d revisions left to test after this (roughly %d steps)\n", step.Remaining, step.Steps)
+		cli.Println(summary)
+		done = false
+	}
+	return done, b.dEnv.SetBisectState(b.state)
+}
+
+// run tests the commits chosen by the session in progress until the first bad commit is found.
+func (b *bisector) run(ctx context.Context, apr *argparser.ArgParseResults, cliCtx cli.CliContext) error {
+	if b.state == nil {
+		return errNotBisecting
+	}
+	group, isTest := apr.GetValue(bisectTestParam)
+	query, isQuery := apr.GetValue(bisectQueryParam)
+	if isTest == isQuery {
+		return fmt.Errorf("exactly one of --%s and --%s is required", bisectTestParam, bisectQueryParam)
+	}
+	if b.state.Current == "" {
+		if b.state.Bad == "" || len(b.state.Good) == 0 {
+			return errors.New("run requires a good and a bad commit")
+		}
+		return errors.New("no commits left to test")
+	}
+
+	queryEngine, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return err
+	}
+	queryist, sqlCtx := queryEngine.Queryist, queryEngine.Context
+	dbName := sqlCtx.GetCurrentDatabase()
+	if dbName == "" {
+		return errors.New("run requires a current database")
+	}
+
+	var isGood func(h hash.Hash) (bool, error)
+	if isTest {
+		testQuery, err := dbr.InterpolateForDialect("SELECT status FROM dolt_test_run(?)", []interface{}{group}, dialect.MySQL)
+		if err != nil {
+			return err
+		}
+		isGood = func(h hash.Hash) (bool, error) {
+			rows, err := bisectRowsAt(sqlCtx, queryist, dbName, h, testQuery)
+			if err != nil {
+				return false, err
+			}
+			if len(rows) == 0 {
+				return false, fmt.Errorf("dolt_test_run returned no tests for %s at commit %s", group, h.String())
+			}
+			for _, r := range rows {
+				if fmt.Sprint(r[0]) != "PASS" {
+					return false, nil
+				}
+			}
+			return true, nil
+		}
+	} else {
+		expected, err := bisectQueryResultAt(sqlCtx, queryist, dbName, hash.Parse(b.state.Good[0]), query)
+		if err != nil {
+			return err
+		}
+		isGood = func(h hash.Hash) (bool, error) {
+			res, err := bisectQueryResultAt(sqlCtx, queryist, dbName, h, query)
+			if err != nil {
+				return false, err
+			}
+			return res == expected, nil
+		}
+	}
+
+	for b.state.Current != "" {
+		h := hash.Parse(b.state.Current)
+		good, err := isGood(h)
+		if err != nil {
+			return fmt.Errorf("error testing commit %s: %w", h.String(), err)
+		}
+		term := bisectBadCmd
+		if good {
+			term = bisectGoodCmd
+		}
+		if err := b.mark(ctx, term, h.String()); err != nil {
+			return err
+		}
+		done, err := b.advance(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+	return nil
+}
+
+func (b *bisector) resolve(ctx context.Context, rev string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(rev)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := b.ddb.Resolve(ctx, cs, b.headRef)
+	if err != nil {
+		return nil, err
+	}
+	cmt, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cmt, nil
+}
+
+// bisectSummary returns the hash and first line of the message of |cmt|.
+func bisectSummary(ctx context.Context, cmt *doltdb.Commit) (string, error) {
+	h, err := cmt.HashOf()
+	if err != nil {
+		return "", err
+	}
+	meta, err := cmt.GetCommitMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+	subject, _, _ := strings.Cut(meta.Description, "\n")
+	return fmt.Sprintf("[%s] %s", h.String(), subject), nil
+}
+
+// bisectRowsAt returns the rows of |query| run against the revision database of |dbName| at commit |h|.
+func bisectRowsAt(sqlCtx *sql.Context, queryist cli.Queryist, dbName string, h hash.Hash, query string) (rows []sql.Row, err error) {
+	_, err = cli.GetRowsForSql(queryist, sqlCtx, "USE "+sqlfmt.QuoteIdentifier(dbName+"/"+h.String()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, useErr := cli.GetRowsForSql(queryist, sqlCtx, "USE "+sqlfmt.QuoteIdentifier(dbName))
+		if err == nil {
+			err = useErr
+		}
+	}()
+	return cli.GetRowsForSql(queryist, sqlCtx, query)
+}
+
+// bisectQueryResultAt returns the result of |query| at commit |h| in a form which can be compared between commits.
+func bisectQueryResultAt(sqlCtx *sql.Context, queryist cli.Queryist, dbName string, h hash.Hash, query string) (string, error) {
+	rows, err := bisectRowsAt(sqlCtx, queryist, dbName, h, query)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, r := range rows {
+		fmt.Fprintf(&sb, "%v\n", r)
+	}
+	return sb.String(), nil
+}
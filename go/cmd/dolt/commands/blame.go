@@ -18,8 +18,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
@@ -37,9 +40,12 @@ const (
 
 var blameDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show what revision and author last modified each row of a table`,
-	LongDesc:  `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.`,
+	LongDesc: `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.
+
+With {{.EmphasisLeft}}--cells{{.EmphasisRight}}, each column of each row is annotated separately with the revision which last modified it, one line per column. {{.EmphasisLeft}}--columns{{.EmphasisRight}} limits this to the given columns. {{.EmphasisLeft}}--since{{.EmphasisRight}} and {{.EmphasisLeft}}--until{{.EmphasisRight}} limit the revisions considered to a window of time: changes made after {{.EmphasisLeft}}--until{{.EmphasisRight}} are ignored, and columns last modified before {{.EmphasisLeft}}--since{{.EmphasisRight}} are not shown. These options imply {{.EmphasisLeft}}--cells{{.EmphasisRight}}, and are also available in the {{.EmphasisLeft}}dolt_blame(){{.EmphasisRight}} table function.`,
 	Synopsis: []string{
 		`[{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
+		`--cells [--columns {{.LessThan}}column{{.GreaterThan}},...] [--since {{.LessThan}}date{{.GreaterThan}}] [--until {{.LessThan}}date{{.GreaterThan}}] [{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
 	},
 }
 
@@ -61,8 +67,7 @@ func (cmd BlameCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd BlameCmd) ArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
-	return ap
+	return cli.CreateBlameArgParser(false)
 }
 
 func (cmd BlameCmd) RequiresRepo() bool {
@@ -110,7 +115,13 @@ func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	var schema sql.Schema
 	var ri sql.RowIter
-	if apr.NArg() == 1 {
+	if isCellBlame(apr) {
+		var query string
+		query, err = constructInterpolatedDoltBlameQuery(apr)
+		if err == nil {
+			schema, ri, _, err = queryist.Queryist.Query(queryist.Context, query)
+		}
+	} else if apr.NArg() == 1 {
 		schema, ri, _, err = queryist.Queryist.Query(queryist.Context, fmt.Sprintf(blameQueryTemplate, apr.Arg(0), "HEAD"))
 	} else {
 		// validate input
@@ -127,7 +138,7 @@ func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
-	err = engine.PrettyPrintResults(queryist.Context, engine.FormatTabular, schema, ri, false, false, true, false)
+	err = engine.PrettyPrintResults(queryist.Context, engine.FormatTabular, schema, ri, engine.PagerOff, false, true, false)
 	if err != nil {
 		iohelp.WriteLine(cli.CliOut, err.Error())
 		return 1
@@ -136,6 +147,30 @@ func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return 0
 }
 
+// isCellBlame returns whether each column should be blamed separately, using the dolt_blame() table function.
+func isCellBlame(apr *argparser.ArgParseResults) bool {
+	return apr.ContainsAny(cli.CellsFlag, cli.ColumnsParam, cli.SinceParam, cli.UntilParam)
+}
+
+// constructInterpolatedDoltBlameQuery constructs the sql query to select from the DOLT_BLAME() table function.
+func constructInterpolatedDoltBlameQuery(apr *argparser.ArgParseResults) (string, error) {
+	var params []interface{}
+	var args []string
+
+	for _, param := range []string{cli.ColumnsParam, cli.SinceParam, cli.UntilParam} {
+		if val, ok := apr.GetValue(param); ok {
+			args = append(args, "'--"+param+"'", "?")
+			params = append(params, val)
+		}
+	}
+	for _, arg := range apr.Args {
+		args = append(args, "?")
+		params = append(params, arg)
+	}
+
+	return dbr.InterpolateForDialect("SELECT * FROM dolt_blame("+strings.Join(args, ", ")+")", params, dialect.MySQL)
+}
+
 func isValidHeadRef(s string) bool {
 	var refRegex = regexp.MustCompile(`(?i)^head[\~\^0-9]*$`)
 	return refRegex.MatchString(s)
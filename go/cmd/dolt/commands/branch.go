@@ -121,6 +121,12 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
+	if apr.ContainsAny(cli.MoveFlag, cli.DeleteFlag, cli.DeleteForceFlag) && !apr.Contains(cli.RemoteParam) {
+		if err := checkBranchesNotInWorktrees(dEnv, apr); err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
 	switch {
 	case apr.Contains(cli.MoveFlag):
 		return moveBranch(queryist.Context, queryist.Queryist, apr, args, usage)
@@ -145,6 +151,27 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 	}
 }
 
+// checkBranchesNotInWorktrees returns an error if a branch being moved or deleted is checked out in another worktree.
+func checkBranchesNotInWorktrees(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) error {
+	if dEnv == nil || !dEnv.HasDoltDir() || apr.NArg() == 0 {
+		return nil
+	}
+	names := apr.Args
+	if apr.Contains(cli.MoveFlag) {
+		names = names[:1]
+		if apr.NArg() == 1 {
+			// A single argument renames the current branch, which is not checked out anywhere else.
+			return nil
+		}
+	}
+	for _, name := range names {
+		if err := env.CheckBranchNotInWorktree(dEnv.FS, ref.NewBranchRef(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type branchMeta struct {
 	name   string
 	hash   string
@@ -0,0 +1,33 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlecmds
+
+import (
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+)
+
+var Commands = cli.NewSubCommandHandler("bundle", "Move history between databases with bundle files.", []cli.Command{
+	CreateCmd{},
+	VerifyCmd{},
+	UnbundleCmd{},
+})
+
+// printRefs prints the refs carried by |header|, one per line.
+func printRefs(header *bundle.Header) {
+	for _, r := range header.Refs {
+		cli.Printf("%s %s\n", r.Hash, r.Name)
+	}
+}
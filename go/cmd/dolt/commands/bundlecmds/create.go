@@ -0,0 +1,167 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlecmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const sinceParam = "since"
+
+var createDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Create a bundle file of branches and tags",
+	LongDesc: `Writes the branches and tags given to a bundle file, along with the history needed to complete them. The bundle can be copied to another database, without a remote, and applied with {{.EmphasisLeft}}dolt bundle unbundle{{.EmphasisRight}} or {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--since{{.EmphasisRight}}, the bundle only holds the history which is not reachable from the given commit. The commit becomes a prerequisite of the bundle, which can only be applied to databases which already have it.`,
+	Synopsis: []string{
+		"[--since {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}file{{.GreaterThan}} {{.LessThan}}ref{{.GreaterThan}}...",
+		"[--since {{.LessThan}}commit{{.GreaterThan}}] --all {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type CreateCmd struct{}
+
+// Name implements cli.Command.
+func (cmd CreateCmd) Name() string {
+	return "create"
+}
+
+// Description implements cli.Command.
+func (cmd CreateCmd) Description() string {
+	return createDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd CreateCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(createDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd CreateCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The bundle file to write."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch or tag to include in the bundle."})
+	ap.SupportsString(sinceParam, "", "commit", "Only include history which is not reachable from this commit.")
+	ap.SupportsFlag(cli.AllFlag, "a", "Include all branches and tags.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd CreateCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, createDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a bundle file is required").SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(cli.AllFlag) == (apr.NArg() > 1) {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("either refs or --all are required, but not both").SetPrintUsage().Build(), usage)
+	}
+
+	header, err := createBundle(ctx, dEnv, apr)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	printRefs(header)
+	cli.Printf("Created bundle %s with %d refs and %d chunks.\n", apr.Arg(0), len(header.Refs), header.TableFile.NumChunks)
+	return 0
+}
+
+func createBundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*bundle.Header, error) {
+	ddb := dEnv.DoltDB(ctx)
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []ref.DoltRef
+	if apr.Contains(cli.AllFlag) {
+		if refs, err = ddb.GetBranches(ctx); err != nil {
+			return nil, err
+		}
+		tags, err := ddb.GetTags(ctx)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, tags...)
+	} else {
+		for _, name := range apr.Args[1:] {
+			r, err := resolveBundleRef(ctx, ddb, headRef, name)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, r)
+		}
+	}
+
+	var prerequisites []hash.Hash
+	if since, ok := apr.GetValue(sinceParam); ok {
+		cs, err := doltdb.NewCommitSpec(since)
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err := ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		h, err := optCmt.Commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		prerequisites = append(prerequisites, h)
+	}
+
+	tempDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	return bundle.Create(ctx, ddb, apr.Arg(0), tempDir, refs, prerequisites)
+}
+
+// resolveBundleRef returns the branch or tag named |name|.
+func resolveBundleRef(ctx context.Context, ddb *doltdb.DoltDB, headRef ref.DoltRef, name string) (ref.DoltRef, error) {
+	if strings.EqualFold(name, "HEAD") {
+		return headRef, nil
+	}
+	if ref.IsRef(name) {
+		return ref.Parse(name)
+	}
+	if branch, ok, err := ddb.HasBranch(ctx, name); err != nil {
+		return nil, err
+	} else if ok {
+		return ref.NewBranchRef(branch), nil
+	}
+	if tag, ok, err := ddb.HasTag(ctx, name); err != nil {
+		return nil, err
+	} else if ok {
+		return ref.NewTagRef(tag), nil
+	}
+	return nil, fmt.Errorf("'%s' is not a branch or tag", name)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var unbundleDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Add the history in a bundle file to this database",
+	LongDesc: `Verifies a bundle file and adds the history it holds to this database, without updating any branches or tags. The refs carried by the bundle are listed with the commits they point to, which can then be merged, branched from or tagged.
+
+Use {{.EmphasisLeft}}dolt fetch {{.LessThan}}file{{.GreaterThan}}{{.EmphasisRight}} to also update remote-tracking branches for the bundle's branches.`,
+	Synopsis: []string{
+		"{{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type UnbundleCmd struct{}
+
+// Name implements cli.Command.
+func (cmd UnbundleCmd) Name() string {
+	return "unbundle"
+}
+
+// Description implements cli.Command.
+func (cmd UnbundleCmd) Description() string {
+	return unbundleDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd UnbundleCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(unbundleDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd UnbundleCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The bundle file to apply."})
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd UnbundleCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, unbundleDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a bundle file is required").SetPrintUsage().Build(), usage)
+	}
+
+	header, err := bundle.Unbundle(ctx, dEnv.DoltDB(ctx), apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	printRefs(header)
+	return 0
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var verifyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Check that a bundle file can be applied to this database",
+	LongDesc:  `Checks that a bundle file is intact, and that this database has the prerequisite commits it was created relative to. The refs carried by the bundle are listed.`,
+	Synopsis: []string{
+		"{{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type VerifyCmd struct{}
+
+// Name implements cli.Command.
+func (cmd VerifyCmd) Name() string {
+	return "verify"
+}
+
+// Description implements cli.Command.
+func (cmd VerifyCmd) Description() string {
+	return verifyDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd VerifyCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(verifyDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd VerifyCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The bundle file to verify."})
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd VerifyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, verifyDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a bundle file is required").SetPrintUsage().Build(), usage)
+	}
+
+	header, err := bundle.Verify(ctx, dEnv.DoltDB(ctx), apr.Arg(0))
+	if header != nil {
+		cli.Printf("The bundle contains %d refs:\n", len(header.Refs))
+		printRefs(header)
+		if len(header.Prerequisites) > 0 {
+			cli.Printf("The bundle requires %d commits:\n", len(header.Prerequisites))
+			for _, p := range header.Prerequisites {
+				cli.Println(p)
+			}
+		} else {
+			cli.Println("The bundle records a complete history.")
+		}
+	}
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	cli.Printf("%s is okay\n", apr.Arg(0))
+	return 0
+}
@@ -133,6 +133,14 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 		branchName = apr.Arg(0)
 	}
 
+	// a branch can only be checked out in one worktree at a time
+	if dEnv != nil && dEnv.HasDoltDir() && branchName != "" && !apr.Contains(cli.CheckoutCreateBranch) && apr.NArg() <= 1 {
+		err = env.CheckBranchNotInWorktree(dEnv.FS, ref.NewBranchRef(branchName))
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
 	sqlQuery, err := generateCheckoutSql(args)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
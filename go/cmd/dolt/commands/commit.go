@@ -690,6 +690,9 @@ const (
 	ignoredHeader     = `Ignored tables:`
 	ignoredHeaderHelp = `  (use "dolt add -f <table>" to include in what will be committed)`
 
+	submodulesHeader     = `Submodules pinned to new commits:`
+	submodulesHeaderHelp = `  (use "dolt submodule update" to check out the pinned commits)`
+
 	conflictedIgnoredHeader     = `Tables with conflicting dolt_ignore patterns:`
 	conflictedIgnoredHeaderHelp = `  (use "dolt add -f <table>" to include in what will be committed)`
 
@@ -60,6 +60,8 @@ const (
 	TabularDiffOutput diffOutput = 1
 	SQLDiffOutput     diffOutput = 2
 	JsonDiffOutput    diffOutput = 3
+	HTMLDiffOutput    diffOutput = 4
+	PatchDiffOutput   diffOutput = 5
 )
 
 var diffDocs = cli.CommandDocumentationContent{
@@ -84,6 +86,10 @@ Show changes between the working and staged tables, changes between the working
 
 The diffs displayed can be limited to show the first N by providing the parameter {{.EmphasisLeft}}--limit N{{.EmphasisRight}} where {{.EmphasisLeft}}N{{.EmphasisRight}} is the number of diffs to display.
 
+With {{.EmphasisLeft}}-r html{{.EmphasisRight}}, the diff is written as a single, self-contained HTML report with side-by-side schema and row diffs, changed cells highlighted, and a summary of each table. Row diffs are split into pages with anchors, and {{.EmphasisLeft}}--limit{{.EmphasisRight}} limits the row diffs of each table in the report.
+
+With {{.EmphasisLeft}}--patch-file <file>{{.EmphasisRight}}, the diff is written to a patch file instead of being displayed. The patch file records the schema and row changes along with the rows they replace, and can be applied to another database or branch with {{.EmphasisLeft}}dolt apply{{.EmphasisRight}}. It cannot be combined with {{.EmphasisLeft}}--result-format{{.EmphasisRight}}, {{.EmphasisLeft}}--stat{{.EmphasisRight}}, {{.EmphasisLeft}}--summary{{.EmphasisRight}}, {{.EmphasisLeft}}--name-only{{.EmphasisRight}}, {{.EmphasisLeft}}--skinny{{.EmphasisRight}}, {{.EmphasisLeft}}--include-cols{{.EmphasisRight}}, {{.EmphasisLeft}}--where{{.EmphasisRight}} or {{.EmphasisLeft}}--limit{{.EmphasisRight}}, since the patch must contain every changed row in full.
+
 To filter which data rows are displayed, use {{.EmphasisLeft}}--where <SQL expression>{{.EmphasisRight}}. Table column names in the filter expression must be prefixed with {{.EmphasisLeft}}from_{{.EmphasisRight}} or {{.EmphasisLeft}}to_{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}to_COLUMN_NAME > 100{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME + to_COLUMN_NAME = 0{{.EmphasisRight}}.
 
 The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
@@ -102,6 +108,8 @@ type diffDisplaySettings struct {
 	where       string
 	skinny      bool
 	includeCols []string
+	// patchFile is the path of the patch file written for PatchDiffOutput
+	patchFile string
 }
 
 type diffDatasets struct {
@@ -113,6 +121,10 @@ type diffArgs struct {
 	*diffDisplaySettings
 	*diffDatasets
 	tableSet *set.StrSet
+	// commit is the commit whose changes are shown by dolt show, if any
+	commit *CommitInfo
+	// patchBase is the hash of the from commit recorded in patch files, if the diff is from a commit
+	patchBase string
 }
 
 type diffStatistics struct {
@@ -215,11 +227,19 @@ func (cmd DiffCmd) validateArgs(apr *argparser.ArgParseResults) errhand.VerboseE
 
 	f, _ := apr.GetValue(FormatFlag)
 	switch strings.ToLower(f) {
-	case "tabular", "sql", "json", "":
+	case "tabular", "sql", "json", "html", "":
 	default:
 		return errhand.BuildDError("invalid output format: %s", f).Build()
 	}
 
+	if apr.Contains(cli.PatchFileParam) {
+		for _, flag := range []string{FormatFlag, cli.StatFlag, cli.SummaryFlag, cli.NameOnlyFlag, cli.SkinnyFlag, cli.IncludeCols, cli.WhereParam, cli.LimitParam} {
+			if apr.Contains(flag) {
+				return errhand.BuildDError("invalid Arguments: --%s cannot be combined with --%s", cli.PatchFileParam, flag).Build()
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -263,6 +283,13 @@ func parseDiffDisplaySettings(apr *argparser.ArgParseResults) *diffDisplaySettin
 		displaySettings.diffOutput = SQLDiffOutput
 	case "json":
 		displaySettings.diffOutput = JsonDiffOutput
+	case "html":
+		displaySettings.diffOutput = HTMLDiffOutput
+	}
+
+	if patchFile, ok := apr.GetValue(cli.PatchFileParam); ok {
+		displaySettings.diffOutput = PatchDiffOutput
+		displaySettings.patchFile = patchFile
 	}
 
 	displaySettings.limit, _ = apr.GetInt(cli.LimitParam)
@@ -297,6 +324,11 @@ func parseDiffArgs(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.Ar
 
 	dArgs.tableSet = tableSet
 
+	if dArgs.diffOutput == PatchDiffOutput && !strings.EqualFold(dArgs.fromRef, doltdb.Working) && !strings.EqualFold(dArgs.fromRef, doltdb.Staged) {
+		// the base is only used by dolt apply --3way, so a patch is still written without one
+		dArgs.patchBase, _ = getHashOf(queryist, sqlCtx, dArgs.fromRef)
+	}
+
 	return dArgs, nil
 }
 
@@ -349,6 +381,7 @@ var doltSystemTables = []string{
 	"dolt_procedures",
 	"dolt_schemas",
 	"dolt_tests",
+	"dolt_submodules",
 }
 
 func getTableNamesAtRef(queryist cli.Queryist, sqlCtx *sql.Context, ref string) (map[string]bool, error) {
@@ -775,7 +808,7 @@ func diffUserTables(queryist cli.Queryist, sqlCtx *sql.Context, dArgs *diffArgs)
 		return printDiffSummary(sqlCtx, deltas, dArgs)
 	}
 
-	dw, err := newDiffWriter(dArgs.diffOutput)
+	dw, err := newDiffWriter(dArgs)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
@@ -1190,7 +1223,7 @@ func diffUserTable(
 		}
 	}
 
-	if tableSummary.IsDrop() && dArgs.diffOutput == SQLDiffOutput {
+	if tableSummary.IsDrop() && (dArgs.diffOutput == SQLDiffOutput || dArgs.diffOutput == PatchDiffOutput && dArgs.diffParts&SchemaOnlyDiff != 0) {
 		return nil // don't output DELETE FROM statements after DROP TABLE
 	}
 
@@ -59,17 +59,21 @@ type diffWriter interface {
 	Close(ctx context.Context) error
 }
 
-// newDiffWriter returns a diffWriter for the output format given
-func newDiffWriter(diffOutput diffOutput) (diffWriter, error) {
-	switch diffOutput {
+// newDiffWriter returns a diffWriter for the output format of the args given
+func newDiffWriter(dArgs *diffArgs) (diffWriter, error) {
+	switch dArgs.diffOutput {
 	case TabularDiffOutput:
 		return tabularDiffWriter{}, nil
 	case SQLDiffOutput:
 		return sqlDiffWriter{}, nil
 	case JsonDiffOutput:
 		return newJsonDiffWriter(iohelp.NopWrCloser(cli.CliOut))
+	case HTMLDiffOutput:
+		return newHtmlDiffWriter(cli.CliOut, dArgs.fromRef, dArgs.toRef, dArgs.commit, dArgs.limit)
+	case PatchDiffOutput:
+		return newPatchDiffWriter(dArgs.patchFile, dArgs.patchBase, dArgs.fromRef, dArgs.toRef)
 	default:
-		panic(fmt.Sprintf("unexpected diff output: %v", diffOutput))
+		panic(fmt.Sprintf("unexpected diff output: %v", dArgs.diffOutput))
 	}
 }
 
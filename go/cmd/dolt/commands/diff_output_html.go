@@ -0,0 +1,522 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	textdiff "github.com/andreyvit/diff"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// htmlDiffPageSize is the number of row diffs in each page of a table's row diffs. Each page has an anchor, so that
+// the report of a large diff can be navigated.
+const htmlDiffPageSize = 500
+
+const htmlDiffStyle = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; padding: 0 2em 2em; display: flex; flex-direction: column; color: #1f2328; }
+header { order: -2; }
+#summary { order: -1; }
+code, pre, td { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 12px; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+.badge { display: inline-block; padding: 0 .6em; border-radius: 1em; font-size: 12px; font-weight: normal; background: #ddf4ff; vertical-align: middle; }
+.badge.added { background: #dafbe1; }
+.badge.dropped { background: #ffebe9; }
+.scroll { overflow-x: auto; }
+table { border-collapse: collapse; margin: .5em 0 1em; }
+th, td { border: 1px solid #d0d7de; padding: 2px 6px; text-align: left; vertical-align: top; white-space: pre-wrap; }
+th { background: #f6f8fa; }
+th.side { text-align: center; }
+td.marker { color: #57606a; }
+td.sep, th.sep { border-top: none; border-bottom: none; padding: 0 2px; background: #fff; }
+tr.added td.to, tr.added td.marker { background: #dafbe1; }
+tr.removed td.from, tr.removed td.marker { background: #ffebe9; }
+td.empty { background: #f6f8fa; }
+td.old { background: #ffcecb; }
+td.new { background: #aceebb; }
+td.null { color: #8c959f; font-style: italic; }
+tr.page td { background: #f6f8fa; font-family: inherit; }
+table.defn td { min-width: 30em; }
+table.defn td.removed { background: #ffebe9; }
+table.defn td.added { background: #dafbe1; }
+nav.pages a { margin-right: .5em; }
+.note { color: #57606a; }
+`
+
+// htmlTableSummary is the summary of the diff of a table, view, trigger or event, written to the summary section of
+// the report.
+type htmlTableSummary struct {
+	name          string
+	anchor        string
+	kind          string
+	change        string
+	schemaChanged bool
+	added         uint64
+	deleted       uint64
+	modified      uint64
+	cellsModified uint64
+}
+
+// htmlDiffWriter writes a diff as a single, self-contained HTML document. Schema and definition diffs are shown side
+// by side, as are the old and new values of modified rows, with the changed cells highlighted. Row diffs are written
+// in pages with anchors, and a summary of every table is written at the end of the document and displayed first.
+type htmlDiffWriter struct {
+	wr     io.Writer
+	err    error
+	limit  int
+	tables []*htmlTableSummary
+	// current is the table currently being written, if any
+	current *htmlTableSummary
+}
+
+var _ diffWriter = (*htmlDiffWriter)(nil)
+
+// newHtmlDiffWriter returns a diffWriter which writes an HTML report of the diff from |fromRef| to |toRef| to |wr|.
+// |commit| is the commit being shown, if any, and |limit| is the limit on row diffs per table, or negative if there is
+// none.
+func newHtmlDiffWriter(wr io.Writer, fromRef, toRef string, commit *CommitInfo, limit int) (*htmlDiffWriter, error) {
+	h := &htmlDiffWriter{wr: wr, limit: limit}
+	title := fmt.Sprintf("Diff from %s to %s", fromRef, toRef)
+	if commit != nil {
+		title = fmt.Sprintf("Commit %s", commit.commitHash)
+	}
+
+	h.printf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", h.esc(title), htmlDiffStyle)
+	h.printf("<header>\n<h1>%s</h1>\n", h.esc(title))
+	if commit != nil && commit.commitMeta != nil {
+		h.printf("<table>\n")
+		h.printf("<tr><th>Author</th><td>%s &lt;%s&gt;</td></tr>\n", h.esc(commit.commitMeta.Name), h.esc(commit.commitMeta.Email))
+		h.printf("<tr><th>Date</th><td>%s</td></tr>\n", h.esc(commit.commitMeta.FormatTS()))
+		if len(commit.parentHashes) > 0 {
+			h.printf("<tr><th>Parents</th><td>%s</td></tr>\n", h.esc(strings.Join(commit.parentHashes, " ")))
+		}
+		h.printf("<tr><th>Message</th><td>%s</td></tr>\n", h.esc(commit.commitMeta.Description))
+		h.printf("</table>\n")
+	}
+	h.printf("<p>From <code>%s</code> to <code>%s</code>.</p>\n", h.esc(fromRef), h.esc(toRef))
+	if limit >= 0 {
+		h.printf("<p class=\"note\">Row diffs are limited to the first %d of each table.</p>\n", limit)
+	}
+	h.printf("</header>\n<main>\n")
+	return h, h.err
+}
+
+// printf writes to the document. The first error is kept and returned by the diffWriter methods, and later writes
+// are skipped.
+func (h *htmlDiffWriter) printf(format string, args ...interface{}) {
+	if h.err != nil {
+		return
+	}
+	_, h.err = fmt.Fprintf(h.wr, format, args...)
+}
+
+func (h *htmlDiffWriter) esc(s string) string {
+	return html.EscapeString(s)
+}
+
+// beginSection ends the section of the previous table, if any, and begins the section of a new one.
+func (h *htmlDiffWriter) beginSection(kind, name, change string) {
+	h.endSection()
+	h.current = &htmlTableSummary{
+		name:   name,
+		anchor: fmt.Sprintf("t%d", len(h.tables)+1),
+		kind:   kind,
+		change: change,
+	}
+	h.tables = append(h.tables, h.current)
+
+	h.printf("<section id=\"%s\">\n<h2>%s <code>%s</code>", h.current.anchor, kind, h.esc(name))
+	if change != "" {
+		h.printf(" <span class=\"badge %s\">%s</span>", change, change)
+	}
+	h.printf("</h2>\n")
+}
+
+func (h *htmlDiffWriter) endSection() {
+	if h.current == nil {
+		return
+	}
+	h.printf("</section>\n")
+	h.current = nil
+}
+
+func (h *htmlDiffWriter) BeginTable(fromTableName, toTableName string, isAdd, isDrop bool) error {
+	name, change := toTableName, "modified"
+	switch {
+	case isAdd:
+		change = "added"
+	case isDrop:
+		name, change = fromTableName, "dropped"
+	case fromTableName != toTableName:
+		name, change = fmt.Sprintf("%s → %s", fromTableName, toTableName), "renamed"
+	}
+	h.beginSection("Table", name, change)
+	return h.err
+}
+
+func (h *htmlDiffWriter) WriteTableSchemaDiff(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary) error {
+	var fromCreateStmt, toCreateStmt string
+	if fromTableInfo != nil {
+		fromCreateStmt = fromTableInfo.CreateStmt
+	}
+	if toTableInfo != nil {
+		toCreateStmt = toTableInfo.CreateStmt
+	}
+	if fromCreateStmt == toCreateStmt {
+		return nil
+	}
+
+	if h.current != nil {
+		h.current.schemaChanged = true
+	}
+	h.printf("<h3>Schema</h3>\n")
+	h.writeDefinitionDiff(fromCreateStmt, toCreateStmt)
+	return h.err
+}
+
+// writeDefinitionDiff writes a side by side, line by line diff of two definitions. Removed lines are paired with the
+// added lines which replace them.
+func (h *htmlDiffWriter) writeDefinitionDiff(oldDefn, newDefn string) {
+	h.printf("<div class=\"scroll\"><table class=\"defn\">\n<tr><th class=\"side\">From</th><th class=\"side\">To</th></tr>\n")
+	var removed, added []string
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			h.printf("<tr>")
+			h.writeDefinitionLine(removed, i, "removed")
+			h.writeDefinitionLine(added, i, "added")
+			h.printf("</tr>\n")
+		}
+		removed, added = nil, nil
+	}
+
+	for _, line := range textdiff.LineDiffAsLines(oldDefn, newDefn) {
+		if len(line) == 0 {
+			continue
+		}
+		switch line[0] {
+		case '-':
+			removed = append(removed, line[1:])
+		case '+':
+			added = append(added, line[1:])
+		default:
+			flush()
+			h.printf("<tr><td>%s</td><td>%s</td></tr>\n", h.esc(line[1:]), h.esc(line[1:]))
+		}
+	}
+	flush()
+	h.printf("</table></div>\n")
+}
+
+func (h *htmlDiffWriter) writeDefinitionLine(lines []string, i int, class string) {
+	if i < len(lines) {
+		h.printf("<td class=\"%s\">%s</td>", class, h.esc(lines[i]))
+	} else {
+		h.printf("<td class=\"empty\"></td>")
+	}
+}
+
+func (h *htmlDiffWriter) WriteEventDiff(ctx context.Context, eventName, oldDefn, newDefn string) error {
+	return h.writeDefinitionSection("Event", eventName, oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) WriteTriggerDiff(ctx context.Context, triggerName, oldDefn, newDefn string) error {
+	return h.writeDefinitionSection("Trigger", triggerName, oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) WriteViewDiff(ctx context.Context, viewName, oldDefn, newDefn string) error {
+	return h.writeDefinitionSection("View", viewName, oldDefn, newDefn)
+}
+
+func (h *htmlDiffWriter) writeDefinitionSection(kind, name, oldDefn, newDefn string) error {
+	change := "modified"
+	if oldDefn == "" {
+		change = "added"
+	} else if newDefn == "" {
+		change = "dropped"
+	}
+	h.beginSection(kind, name, change)
+	h.current.schemaChanged = true
+	h.writeDefinitionDiff(oldDefn, newDefn)
+	return h.err
+}
+
+func (h *htmlDiffWriter) WriteTableDiffStats(diffStats []diffStatistics, oldColLen, newColLen int, areTablesKeyless bool) error {
+	acc := diff.DiffStatProgress{}
+	for _, diffStat := range diffStats {
+		acc.Adds += diffStat.RowsAdded
+		acc.Removes += diffStat.RowsDeleted
+		acc.Changes += diffStat.RowsModified
+		acc.CellChanges += diffStat.CellsModified
+		acc.NewRowSize += diffStat.NewRowCount
+		acc.OldRowSize += diffStat.OldRowCount
+		acc.NewCellSize += diffStat.NewCellCount
+		acc.OldCellSize += diffStat.OldCellCount
+	}
+	if h.current != nil {
+		h.current.added, h.current.deleted, h.current.modified, h.current.cellsModified = acc.Adds, acc.Removes, acc.Changes, acc.CellChanges
+	}
+
+	h.printf("<h3>Statistics</h3>\n")
+	if (acc.Adds+acc.Removes+acc.Changes) == 0 && (acc.OldCellSize-acc.NewCellSize) == 0 {
+		h.printf("<p class=\"note\">No data changes.</p>\n")
+		return h.err
+	}
+
+	h.printf("<table>\n")
+	h.printf("<tr><th>Rows Added</th><td>%d</td></tr>\n", acc.Adds)
+	h.printf("<tr><th>Rows Deleted</th><td>%d</td></tr>\n", acc.Removes)
+	if !areTablesKeyless {
+		cellAdds, cellDeletes := dtablefunctions.GetCellsAddedAndDeleted(acc, newColLen)
+		h.printf("<tr><th>Rows Modified</th><td>%d</td></tr>\n", acc.Changes)
+		h.printf("<tr><th>Rows Unmodified</th><td>%d</td></tr>\n", acc.OldRowSize-acc.Changes-acc.Removes)
+		h.printf("<tr><th>Cells Added</th><td>%d</td></tr>\n", cellAdds)
+		h.printf("<tr><th>Cells Deleted</th><td>%d</td></tr>\n", cellDeletes)
+		h.printf("<tr><th>Cells Modified</th><td>%d</td></tr>\n", acc.CellChanges)
+		h.printf("<tr><th>Row Entries</th><td>%d vs %d</td></tr>\n", acc.OldRowSize, acc.NewRowSize)
+	}
+	h.printf("</table>\n")
+	return h.err
+}
+
+func (h *htmlDiffWriter) RowWriter(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary, unionSch sql.Schema) (diff.SqlRowDiffWriter, error) {
+	return &htmlRowDiffWriter{h: h, table: h.current, sch: unionSch}, nil
+}
+
+func (h *htmlDiffWriter) Close(ctx context.Context) error {
+	h.endSection()
+	h.printf("</main>\n<section id=\"summary\">\n<h2>Summary</h2>\n")
+	if len(h.tables) == 0 {
+		h.printf("<p>No changes.</p>\n")
+	} else {
+		h.printf("<table>\n<tr><th>Name</th><th>Type</th><th>Change</th><th>Schema</th><th>Rows Added</th><th>Rows Deleted</th><th>Rows Modified</th><th>Cells Modified</th></tr>\n")
+		for _, t := range h.tables {
+			schemaChange := ""
+			if t.schemaChanged {
+				schemaChange = "changed"
+			}
+			h.printf("<tr><td><a href=\"#%s\">%s</a></td><td>%s</td><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+				t.anchor, h.esc(t.name), strings.ToLower(t.kind), t.change, schemaChange, t.added, t.deleted, t.modified, t.cellsModified)
+		}
+		h.printf("</table>\n")
+		if h.limit >= 0 {
+			h.printf("<p class=\"note\">Row counts are of the row diffs shown, which are limited to the first %d of each table.</p>\n", h.limit)
+		}
+	}
+	h.printf("</section>\n</body>\n</html>\n")
+	return h.err
+}
+
+// htmlRowDiffWriter writes the row diffs of a table to its section of an htmlDiffWriter's document. The table of row
+// diffs is begun when the first row is written, so that nothing is written for a table without row diffs.
+type htmlRowDiffWriter struct {
+	h     *htmlDiffWriter
+	table *htmlTableSummary
+	sch   sql.Schema
+	// diffs is the number of row diffs written
+	diffs int
+	// pages is the number of pages begun
+	pages int
+	// pendingOld is the old row of a modified row, which is written with its new row
+	pendingOld sql.Row
+	closed     bool
+}
+
+var _ diff.SqlRowDiffWriter = (*htmlRowDiffWriter)(nil)
+
+func (w *htmlRowDiffWriter) WriteRow(ctx *sql.Context, row sql.Row, diffType diff.ChangeType, colDiffTypes []diff.ChangeType) error {
+	if len(row) != len(colDiffTypes) {
+		return fmt.Errorf("expected the same size for columns and diff types, got %d and %d", len(row), len(colDiffTypes))
+	}
+
+	switch diffType {
+	case diff.ModifiedOld:
+		w.pendingOld = row
+		return nil
+	case diff.ModifiedNew:
+		oldRow := w.pendingOld
+		w.pendingOld = nil
+		return w.WriteCombinedRow(ctx, oldRow, row, diff.ModeRow)
+	case diff.Added:
+		return w.writeDiff(ctx, "added", "+", nil, row)
+	case diff.Removed:
+		return w.writeDiff(ctx, "removed", "-", row, nil)
+	}
+	return nil
+}
+
+func (w *htmlRowDiffWriter) WriteCombinedRow(ctx *sql.Context, oldRow, newRow sql.Row, mode diff.Mode) error {
+	return w.writeDiff(ctx, "modified", "*", oldRow, newRow)
+}
+
+// writeDiff writes a row diff as the old and new values of the row side by side. Either row is nil if the row was
+// added or removed. The cells which differ between the old and new row are highlighted.
+func (w *htmlRowDiffWriter) writeDiff(ctx *sql.Context, class, marker string, oldRow, newRow sql.Row) error {
+	h := w.h
+	if w.diffs%htmlDiffPageSize == 0 {
+		w.beginPage()
+	}
+	w.diffs++
+	if w.table != nil {
+		switch class {
+		case "added":
+			w.table.added++
+		case "removed":
+			w.table.deleted++
+		case "modified":
+			w.table.modified++
+		}
+	}
+
+	oldStrs, err := w.rowStrings(ctx, oldRow)
+	if err != nil {
+		return err
+	}
+	newStrs, err := w.rowStrings(ctx, newRow)
+	if err != nil {
+		return err
+	}
+
+	h.printf("<tr class=\"%s\"><td class=\"marker\">%s</td>", class, marker)
+	for i := range w.sch {
+		changed := w.cellChanged(oldRow, newRow, oldStrs, newStrs, i)
+		if changed && w.table != nil {
+			w.table.cellsModified++
+		}
+		w.writeCell(oldRow, oldStrs, i, "from", changed, "old")
+	}
+	h.printf("<td class=\"sep\"></td>")
+	for i := range w.sch {
+		changed := w.cellChanged(oldRow, newRow, oldStrs, newStrs, i)
+		w.writeCell(newRow, newStrs, i, "to", changed, "new")
+	}
+	h.printf("</tr>\n")
+	return h.err
+}
+
+// cellChanged returns whether the cell at |i| differs between a modified row's old and new values.
+func (w *htmlRowDiffWriter) cellChanged(oldRow, newRow sql.Row, oldStrs, newStrs []string, i int) bool {
+	if oldRow == nil || newRow == nil {
+		return false
+	}
+	if (oldRow[i] == nil) != (newRow[i] == nil) {
+		return true
+	}
+	return oldStrs[i] != newStrs[i]
+}
+
+func (w *htmlRowDiffWriter) writeCell(row sql.Row, strs []string, i int, side string, changed bool, changedClass string) {
+	h := w.h
+	switch {
+	case row == nil:
+		h.printf("<td class=\"%s empty\"></td>", side)
+	case row[i] == nil:
+		class := side + " null"
+		if changed {
+			class += " " + changedClass
+		}
+		h.printf("<td class=\"%s\">NULL</td>", class)
+	case changed:
+		h.printf("<td class=\"%s %s\">%s</td>", side, changedClass, h.esc(strs[i]))
+	default:
+		h.printf("<td class=\"%s\">%s</td>", side, h.esc(strs[i]))
+	}
+}
+
+func (w *htmlRowDiffWriter) rowStrings(ctx *sql.Context, row sql.Row) ([]string, error) {
+	if row == nil {
+		return nil, nil
+	}
+	strs := make([]string, len(row))
+	for i, v := range row {
+		if i >= len(w.sch) {
+			break
+		}
+		var err error
+		strs[i], err = sqlutil.SqlColToStr(ctx, w.sch[i].Type, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return strs, nil
+}
+
+// beginPage begins a page of row diffs, beginning the table of row diffs first if this is the first page.
+func (w *htmlRowDiffWriter) beginPage() {
+	h := w.h
+	if w.pages == 0 {
+		h.printf("<h3>Rows</h3>\n<div class=\"scroll\"><table class=\"rows\">\n<thead>\n")
+		h.printf("<tr><th></th><th class=\"side\" colspan=\"%d\">From</th><th class=\"sep\"></th><th class=\"side\" colspan=\"%d\">To</th></tr>\n", len(w.sch), len(w.sch))
+		h.printf("<tr><th></th>")
+		for _, col := range w.sch {
+			h.printf("<th>%s</th>", h.esc(col.Name))
+		}
+		h.printf("<th class=\"sep\"></th>")
+		for _, col := range w.sch {
+			h.printf("<th>%s</th>", h.esc(col.Name))
+		}
+		h.printf("</tr>\n</thead>\n")
+	} else {
+		h.printf("</tbody>\n")
+	}
+
+	w.pages++
+	anchor := w.pageAnchor(w.pages)
+	h.printf("<tbody id=\"%s\">\n", anchor)
+	if w.pages > 1 {
+		h.printf("<tr class=\"page\"><td colspan=\"%d\"><a href=\"#%s\">Page %d</a>: row diffs %d to %d. <a href=\"#%s\">Previous page</a> <a href=\"#%s\">Top of table</a></td></tr>\n",
+			2*len(w.sch)+2, anchor, w.pages, w.diffs+1, w.diffs+htmlDiffPageSize, w.pageAnchor(w.pages-1), w.tableAnchor())
+	}
+}
+
+func (w *htmlRowDiffWriter) tableAnchor() string {
+	if w.table == nil {
+		return ""
+	}
+	return w.table.anchor
+}
+
+func (w *htmlRowDiffWriter) pageAnchor(page int) string {
+	return fmt.Sprintf("%s-p%d", w.tableAnchor(), page)
+}
+
+func (w *htmlRowDiffWriter) Close(ctx context.Context) error {
+	if w.closed || w.pages == 0 {
+		w.closed = true
+		return w.h.err
+	}
+	w.closed = true
+
+	h := w.h
+	h.printf("</tbody>\n</table></div>\n")
+	if w.h.limit >= 0 && w.diffs >= w.h.limit {
+		h.printf("<p class=\"note\">Showing the first %d row diffs.</p>\n", w.diffs)
+	}
+	if w.pages > 1 {
+		h.printf("<nav class=\"pages\">Pages:")
+		for p := 1; p <= w.pages; p++ {
+			h.printf(" <a href=\"#%s\">%d</a>", w.pageAnchor(p), p)
+		}
+		h.printf("</nav>\n")
+	}
+	return h.err
+}
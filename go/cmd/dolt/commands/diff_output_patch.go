@@ -0,0 +1,238 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// patchDiffWriter collects a diff into a patchFile, which is written to |path| on Close.
+type patchDiffWriter struct {
+	path  string
+	patch *patchFile
+	// current is the table currently being written, or nil if its changes are not part of the patch
+	current *patchTable
+}
+
+var _ diffWriter = (*patchDiffWriter)(nil)
+
+func newPatchDiffWriter(path, base, fromRef, toRef string) (*patchDiffWriter, error) {
+	return &patchDiffWriter{
+		path: path,
+		patch: &patchFile{
+			Version: patchFileVersion,
+			Base:    base,
+			From:    fromRef,
+			To:      toRef,
+			Tables:  []*patchTable{},
+		},
+	}, nil
+}
+
+func (p *patchDiffWriter) BeginTable(fromTableName, toTableName string, isAdd, isDrop bool) error {
+	if strings.HasPrefix(fromTableName, diff.DBPrefix) || strings.HasPrefix(toTableName, diff.DBPrefix) {
+		cli.PrintErrln("warning: database level changes are not included in patch files")
+		p.current = nil
+		return nil
+	}
+
+	p.current = &patchTable{}
+	if !isAdd {
+		p.current.FromName = fromTableName
+	}
+	if !isDrop {
+		p.current.ToName = toTableName
+	}
+	p.patch.Tables = append(p.patch.Tables, p.current)
+	return nil
+}
+
+func (p *patchDiffWriter) WriteTableSchemaDiff(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary) error {
+	if p.current == nil {
+		return nil
+	}
+	if fromTableInfo != nil {
+		p.current.FromCreate = fromTableInfo.CreateStmt
+	}
+	if toTableInfo != nil {
+		p.current.ToCreate = toTableInfo.CreateStmt
+	}
+
+	stmts := tds.AlterStmts
+	if tds.IsAdd() {
+		stmts = []string{toTableInfo.CreateStmt}
+	} else if tds.IsDrop() {
+		stmts = []string{sqlfmt.DropTableStmt(fromTableInfo.Name)}
+	}
+	for _, stmt := range stmts {
+		if len(stmt) > 0 {
+			p.current.SchemaStatements = append(p.current.SchemaStatements, stmt)
+		}
+	}
+	return nil
+}
+
+func (p *patchDiffWriter) WriteEventDiff(ctx context.Context, eventName, oldDefn, newDefn string) error {
+	return p.writeDefinition("event", eventName, oldDefn, newDefn)
+}
+
+func (p *patchDiffWriter) WriteTriggerDiff(ctx context.Context, triggerName, oldDefn, newDefn string) error {
+	return p.writeDefinition("trigger", triggerName, oldDefn, newDefn)
+}
+
+func (p *patchDiffWriter) WriteViewDiff(ctx context.Context, viewName, oldDefn, newDefn string) error {
+	return p.writeDefinition("view", viewName, oldDefn, newDefn)
+}
+
+func (p *patchDiffWriter) writeDefinition(typ, name, oldDefn, newDefn string) error {
+	p.current = nil
+	p.patch.Definitions = append(p.patch.Definitions, &patchDefinition{Type: typ, Name: name, From: oldDefn, To: newDefn})
+	return nil
+}
+
+func (p *patchDiffWriter) WriteTableDiffStats(diffStats []diffStatistics, oldColLen, newColLen int, areTablesKeyless bool) error {
+	return errors.New("diff stats cannot be written to a patch file")
+}
+
+func (p *patchDiffWriter) RowWriter(fromTableInfo, toTableInfo *diff.TableInfo, tds diff.TableDeltaSummary, unionSch sql.Schema) (diff.SqlRowDiffWriter, error) {
+	if p.current == nil {
+		return &patchRowDiffWriter{}, nil
+	}
+	if !arePrimaryKeySetsDiffable(fromTableInfo, toTableInfo) {
+		return nil, fmt.Errorf("cannot create a patch for table '%s': its primary key changed", p.current.name())
+	}
+
+	t := p.current
+	w := &patchRowDiffWriter{table: t}
+	if fromTableInfo != nil {
+		t.FromColumns = fromTableInfo.Sch.GetAllCols().GetColumnNames()
+		w.fromIdx = unionIndexes(unionSch, t.FromColumns)
+	}
+	if toTableInfo != nil {
+		t.ToColumns = toTableInfo.Sch.GetAllCols().GetColumnNames()
+		w.toIdx = unionIndexes(unionSch, t.ToColumns)
+	}
+
+	pkSch := fromTableInfo
+	if toTableInfo != nil {
+		pkSch = toTableInfo
+	}
+	if pkSch != nil && !schema.IsKeyless(pkSch.Sch) {
+		t.PrimaryKey = pkSch.Sch.GetPKCols().GetColumnNames()
+	}
+	w.sch = unionSch
+	return w, nil
+}
+
+// unionIndexes returns the index in |unionSch| of each of |cols|.
+func unionIndexes(unionSch sql.Schema, cols []string) []int {
+	idx := make([]int, len(cols))
+	for i, col := range cols {
+		idx[i] = unionSch.IndexOfColName(col)
+	}
+	return idx
+}
+
+func (p *patchDiffWriter) Close(ctx context.Context) error {
+	if err := writePatchFile(p.path, p.patch); err != nil {
+		return fmt.Errorf("failed to write patch file '%s': %w", p.path, err)
+	}
+	return nil
+}
+
+// patchRowDiffWriter adds the row diffs of a table to its patchTable.
+type patchRowDiffWriter struct {
+	table          *patchTable
+	sch            sql.Schema
+	fromIdx, toIdx []int
+	// pendingOld is the old row of a modified row, which is added with its new row
+	pendingOld []patchValue
+}
+
+var _ diff.SqlRowDiffWriter = (*patchRowDiffWriter)(nil)
+
+func (w *patchRowDiffWriter) WriteRow(ctx *sql.Context, row sql.Row, diffType diff.ChangeType, colDiffTypes []diff.ChangeType) error {
+	if w.table == nil {
+		return nil
+	}
+
+	switch diffType {
+	case diff.Added:
+		to, err := w.values(ctx, row, w.toIdx)
+		if err != nil {
+			return err
+		}
+		w.table.Rows = append(w.table.Rows, patchRow{To: to})
+	case diff.Removed:
+		from, err := w.values(ctx, row, w.fromIdx)
+		if err != nil {
+			return err
+		}
+		w.table.Rows = append(w.table.Rows, patchRow{From: from})
+	case diff.ModifiedOld:
+		from, err := w.values(ctx, row, w.fromIdx)
+		if err != nil {
+			return err
+		}
+		w.pendingOld = from
+	case diff.ModifiedNew:
+		to, err := w.values(ctx, row, w.toIdx)
+		if err != nil {
+			return err
+		}
+		w.table.Rows = append(w.table.Rows, patchRow{From: w.pendingOld, To: to})
+		w.pendingOld = nil
+	}
+	return nil
+}
+
+func (w *patchRowDiffWriter) WriteCombinedRow(ctx *sql.Context, oldRow, newRow sql.Row, mode diff.Mode) error {
+	if err := w.WriteRow(ctx, oldRow, diff.ModifiedOld, nil); err != nil {
+		return err
+	}
+	return w.WriteRow(ctx, newRow, diff.ModifiedNew, nil)
+}
+
+// values returns the values of |row|, a row of the union schema, at |idx|.
+func (w *patchRowDiffWriter) values(ctx *sql.Context, row sql.Row, idx []int) ([]patchValue, error) {
+	vals := make([]patchValue, len(idx))
+	for i, j := range idx {
+		if j < 0 || j >= len(row) || row[j] == nil {
+			vals[i] = patchValue{Null: true}
+			continue
+		}
+		str, err := sqlutil.SqlColToStr(ctx, w.sch[j].Type, row[j])
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = patchValue{Value: str}
+	}
+	return vals, nil
+}
+
+func (w *patchRowDiffWriter) Close(ctx context.Context) error {
+	return nil
+}
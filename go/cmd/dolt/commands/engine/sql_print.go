@@ -56,17 +56,27 @@ const (
 	PrintRowCountAndTiming                      = 1
 )
 
+// PagerMode is whether query results are sent through a pager.
+type PagerMode byte
+
+const (
+	PagerOff PagerMode = iota
+	PagerOn
+	// PagerAuto pages results only when they are too long to fit on the terminal
+	PagerAuto
+)
+
 // PrettyPrintResults prints the result of a query in the format provided
-func PrettyPrintResults(ctx *sql.Context, resultFormat PrintResultFormat, sqlSch sql.Schema, rowIter sql.RowIter, pageResults, showWarnings, printOkResult, binaryAsHex bool) (rerr error) {
-	return prettyPrintResultsWithSummary(ctx, resultFormat, sqlSch, rowIter, PrintNoSummary, pageResults, showWarnings, printOkResult, binaryAsHex)
+func PrettyPrintResults(ctx *sql.Context, resultFormat PrintResultFormat, sqlSch sql.Schema, rowIter sql.RowIter, pager PagerMode, showWarnings, printOkResult, binaryAsHex bool) (rerr error) {
+	return prettyPrintResultsWithSummary(ctx, resultFormat, sqlSch, rowIter, PrintNoSummary, pager, showWarnings, printOkResult, binaryAsHex)
 }
 
 // PrettyPrintResultsExtended prints the result of a query in the format provided, including row count and timing info
-func PrettyPrintResultsExtended(ctx *sql.Context, resultFormat PrintResultFormat, sqlSch sql.Schema, rowIter sql.RowIter, pageResults, showWarnings, printOkResult, binaryAsHex bool) (rerr error) {
-	return prettyPrintResultsWithSummary(ctx, resultFormat, sqlSch, rowIter, PrintRowCountAndTiming, pageResults, showWarnings, printOkResult, binaryAsHex)
+func PrettyPrintResultsExtended(ctx *sql.Context, resultFormat PrintResultFormat, sqlSch sql.Schema, rowIter sql.RowIter, pager PagerMode, showWarnings, printOkResult, binaryAsHex bool) (rerr error) {
+	return prettyPrintResultsWithSummary(ctx, resultFormat, sqlSch, rowIter, PrintRowCountAndTiming, pager, showWarnings, printOkResult, binaryAsHex)
 }
 
-func prettyPrintResultsWithSummary(ctx *sql.Context, resultFormat PrintResultFormat, sqlSch sql.Schema, rowIter sql.RowIter, summary PrintSummaryBehavior, pageResults, showWarnings, printOkResult, binaryAsHex bool) (rerr error) {
+func prettyPrintResultsWithSummary(ctx *sql.Context, resultFormat PrintResultFormat, sqlSch sql.Schema, rowIter sql.RowIter, summary PrintSummaryBehavior, pager PagerMode, showWarnings, printOkResult, binaryAsHex bool) (rerr error) {
 	defer func() {
 		closeErr := rowIter.Close(ctx)
 		if rerr == nil && closeErr != nil {
@@ -94,10 +104,19 @@ func prettyPrintResultsWithSummary(ctx *sql.Context, resultFormat PrintResultFor
 	// a result, we need to depend on side effects to numRows and err to determine if it was successful.
 	printEm := func() {
 		writerStream := cli.CliOut
-		if pageResults {
-			pager := outputpager.Start()
-			defer pager.Stop()
-			writerStream = pager.Writer
+		switch pager {
+		case PagerOn:
+			p := outputpager.Start()
+			defer p.Stop()
+			writerStream = p.Writer
+		case PagerAuto:
+			p := outputpager.StartAuto(writerStream)
+			defer func() {
+				if stopErr := p.Stop(); err == nil {
+					err = stopErr
+				}
+			}()
+			writerStream = p
 		}
 
 		switch resultFormat {
@@ -135,7 +154,7 @@ func prettyPrintResultsWithSummary(ctx *sql.Context, resultFormat PrintResultFor
 		numRows, err = writeResultSet(ctx, rowIter, wr)
 	}
 
-	if pageResults {
+	if pager != PagerOff {
 		cli.ExecuteWithStdioRestored(printEm)
 	} else {
 		printEm()
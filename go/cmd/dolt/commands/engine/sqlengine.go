@@ -145,10 +145,18 @@ func NewSqlEngine(
 	// from MultiRepoEnv, and they are all real databases based on
 	// DoltDB instances. |all| is going to include some extension,
 	// informational databases like |dolt_cluster| sometimes,
-	// depending on config.
+	// depending on config, and the read-only databases of any
+	// submodules which have been cloned.
 	all := make([]dsess.SqlDatabase, len(dbs))
 	copy(all, dbs)
 
+	submoduleDBs, submoduleLocations, err := CollectSubmoduleDBs(ctx, mrEnv, config.Bulk)
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, submoduleDBs...)
+	locations = append(locations, submoduleLocations...)
+
 	clusterDB := config.ClusterController.ClusterDatabase()
 	if clusterDB != nil {
 		all = append(all, clusterDB.(dsess.SqlDatabase))
@@ -222,6 +230,7 @@ func NewSqlEngine(
 	})
 
 	if config.AutoGCController != nil {
+		config.AutoGCController.SetStorageMaintenanceLog(pro.StorageMaintenanceLog())
 		err = config.AutoGCController.RunBackgroundThread(bThreads, sqlEngine.NewDefaultContext)
 		if err != nil {
 			return nil, err
@@ -261,6 +270,9 @@ func NewSqlEngine(
 	sqlEngine.fs = pro.FileSystem()
 
 	pro.InstallReplicationInitDatabaseHook(bThreads, sqlEngine.NewDefaultContext)
+	if err = pro.IndexBuildController().RunBackgroundThread(bThreads, sqlEngine.NewDefaultContext); err != nil {
+		return nil, err
+	}
 	if err = config.ClusterController.RunCommitHooks(bThreads, sqlEngine.NewDefaultContext); err != nil {
 		return nil, err
 	}
@@ -269,6 +281,11 @@ func NewSqlEngine(
 			return nil, err
 		}
 	}
+	if _, remote, ok := sql.SystemVariables.GetGlobal(dsess.ReadReplicaRemote); ok && remote != "" {
+		if err = pro.RunReadReplicaPuller(bThreads, sqlEngine.NewDefaultContext); err != nil {
+			return nil, err
+		}
+	}
 
 	sqlCtx, err := sqlEngine.NewDefaultContext(ctx)
 	if err != nil {
@@ -16,7 +16,11 @@ package engine
 
 import (
 	"context"
+	"strings"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
@@ -50,6 +54,66 @@ func CollectDBs(ctx context.Context, mrEnv *env.MultiRepoEnv, useBulkEditor bool
 	return dbs, locations, nil
 }
 
+// CollectSubmoduleDBs creates read-only Database objects for the submodules of each environment in a MultiRepoEnv
+// which have been cloned. Submodules whose names clash with another database, or which can't be loaded, are skipped
+// with a warning so that a broken submodule doesn't keep its parent database from being served.
+func CollectSubmoduleDBs(ctx context.Context, mrEnv *env.MultiRepoEnv, useBulkEditor bool) ([]dsess.SqlDatabase, []filesys.Filesys, error) {
+	var dbs []dsess.SqlDatabase
+	var locations []filesys.Filesys
+
+	names := make(map[string]bool)
+	err := mrEnv.Iter(func(name string, _ *env.DoltEnv) (stop bool, err error) {
+		names[strings.ToLower(name)] = true
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = mrEnv.Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+		root, err := dEnv.WorkingRoot(ctx)
+		if err != nil {
+			return false, err
+		}
+		submodules, err := doltdb.GetSubmodules(ctx, root)
+		if err != nil {
+			logrus.Warnf("not attaching submodules of database '%s': %s", name, err.Error())
+			return false, nil
+		}
+
+		for _, sm := range submodules {
+			smEnv, err := env.LoadSubmoduleEnv(ctx, dEnv, sm.Name)
+			if err != nil {
+				logrus.Warnf("not attaching submodule '%s' of database '%s': %s", sm.Name, name, err.Error())
+				continue
+			}
+			if smEnv == nil {
+				continue
+			}
+			if names[strings.ToLower(sm.Name)] {
+				logrus.Warnf("not attaching submodule '%s' of database '%s': a database with that name already exists", sm.Name, name)
+				continue
+			}
+			names[strings.ToLower(sm.Name)] = true
+
+			db, err := newDatabase(ctx, sm.Name, smEnv, useBulkEditor)
+			if err != nil {
+				logrus.Warnf("not attaching submodule '%s' of database '%s': %s", sm.Name, name, err.Error())
+				continue
+			}
+			dbs = append(dbs, sqle.ReadOnlyDatabase{Database: db})
+			locations = append(locations, smEnv.FS)
+		}
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dbs, locations, nil
+}
+
 func newDatabase(ctx context.Context, name string, dEnv *env.DoltEnv, useBulkEditor bool) (sqle.Database, error) {
 	deaf := dEnv.DbEaFactory(ctx)
 	if useBulkEditor {
@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -25,7 +26,9 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
 )
@@ -37,10 +40,13 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+A bundle file created with {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}} can be given in place of a remote. Its branches are fetched to remote-tracking branches named {{.EmphasisLeft}}bundle/{{.LessThan}}branch{{.GreaterThan}}{{.EmphasisRight}} unless refspecs are given, and its tags are created if they don't already exist.
 `,
 
 	Synopsis: []string{
 		"[{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"{{.LessThan}}bundle-file{{.GreaterThan}} [{{.LessThan}}refspec{{.GreaterThan}} ...]",
 	},
 }
 
@@ -80,6 +86,10 @@ func (cmd FetchCmd) Exec(ctx context.Context, commandStr string, args []string,
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, fetchDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
+	if apr.NArg() > 0 && bundle.IsBundle(apr.Arg(0)) {
+		return fetchBundle(ctx, dEnv, apr, usage)
+	}
+
 	queryist, err := cliCtx.QueryEngine(ctx)
 	if err != nil {
 		cli.PrintErrln(err)
@@ -161,3 +171,64 @@ func constructInterpolatedDoltFetchQuery(apr *argparser.ArgParseResults) (string
 
 	return interpolatedQuery, nil
 }
+
+// fetchBundle fetches the branches and tags of the bundle file named by the first argument of |apr| into the local
+// database. Bundles are read directly rather than through dolt_fetch, since they are files on the client.
+func fetchBundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if !dEnv.Valid() {
+		return HandleVErrAndExitCode(errhand.BuildDError("The current directory is not a valid dolt repository.").Build(), usage)
+	}
+	if apr.Contains(cli.PruneFlag) || apr.Contains(cli.UserFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError("--prune and --user cannot be used when fetching from a bundle").Build(), usage)
+	}
+
+	path := apr.Arg(0)
+	specStrs := apr.Args[1:]
+	if len(specStrs) == 0 {
+		specStrs = []string{bundle.DefaultFetchRefSpec}
+	}
+	var refSpecs []ref.RefSpec
+	for _, specStr := range specStrs {
+		if !strings.Contains(specStr, ":") && !ref.IsRef(specStr) {
+			specStr = fmt.Sprintf("refs/heads/%s:refs/remotes/bundle/%s", specStr, specStr)
+		}
+		rs, err := ref.ParseRefSpecForRemote("bundle", specStr)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: '%s' is not a valid refspec.", specStr).AddCause(err).Build(), usage)
+		}
+		refSpecs = append(refSpecs, rs)
+	}
+
+	// Fetching into the checked out branch would leave its working set behind, so it's refused before anything is
+	// written, as it is for remotes.
+	header, _, err := bundle.ReadHeader(path)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	for _, r := range header.Refs {
+		src, err := r.DoltRef()
+		if err != nil || src.GetType() != ref.BranchRefType {
+			continue
+		}
+		for _, rs := range refSpecs {
+			if dest := rs.DestRef(src); dest != nil && ref.Equals(dest, headRef) {
+				return HandleVErrAndExitCode(errhand.BuildDError("error: refusing to fetch into checked out branch '%s'", headRef.GetPath()).Build(), usage)
+			}
+		}
+	}
+
+	updated, err := bundle.Fetch(ctx, dEnv.DoltDB(ctx), path, refSpecs)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if !apr.Contains(cli.SilentFlag) {
+		for _, r := range updated {
+			cli.Printf("%s %s\n", r.Hash.String(), r.Ref.String())
+		}
+	}
+	return 0
+}
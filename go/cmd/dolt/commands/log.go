@@ -50,6 +50,9 @@ The command takes options to control what is shown and how.
 {{.EmphasisLeft}}dolt log [<revisions>...] -- <table>{{.EmphasisRight}}
   Lists commit logs starting from revisions, only including commits with changes to table.
 	
+{{.EmphasisLeft}}dolt log [<revisions>...] -- <table>.<column>{{.EmphasisRight}}
+  Lists commit logs starting from revisions, only including commits which changed a value in the column of table.
+
 {{.EmphasisLeft}}dolt log <revisionB>..<revisionA>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log <revisionA> --not <revisionB>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log ^<revisionB> <revisionA>{{.EmphasisRight}}
@@ -57,7 +60,15 @@ The command takes options to control what is shown and how.
 	
 {{.EmphasisLeft}}dolt log <revisionB>...<revisionA>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log <revisionA> <revisionB> --not $(dolt merge-base <revisionA> <revisionB>){{.EmphasisRight}}
-  Different ways to list three dot logs. These will list commit logs reachable by revisionA OR revisionB, while excluding commits reachable by BOTH revisionA AND revisionB.`,
+  Different ways to list three dot logs. These will list commit logs reachable by revisionA OR revisionB, while excluding commits reachable by BOTH revisionA AND revisionB.
+
+Commits can also be limited by who made them and what they changed:
+
+{{.EmphasisLeft}}--author{{.EmphasisRight}} and {{.EmphasisLeft}}--grep{{.EmphasisRight}} only list commits whose author or message match a regular expression. {{.EmphasisLeft}}--since{{.EmphasisRight}} and {{.EmphasisLeft}}--until{{.EmphasisRight}} only list commits made within a date range.
+
+{{.EmphasisLeft}}-S <value>{{.EmphasisRight}} only lists commits in which the number of cells equal to value changed, meaning the value appeared or disappeared. Combine it with {{.EmphasisLeft}}<table>.<column>{{.EmphasisRight}} to only look at one column.
+
+{{.EmphasisLeft}}--diff-filter=[A|M|D]{{.EmphasisRight}} only lists commits which added (A), modified (M) or deleted (D) rows, within the given tables if any. Letters can be combined.`,
 	Synopsis: []string{
 		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [{{.LessThan}}revision-range{{.GreaterThan}}] [[--] {{.LessThan}}table{{.GreaterThan}}]`,
 	},
@@ -181,8 +192,10 @@ func collectTables(apr *argparser.ArgParseResults, queryist cli.Queryist, sqlCtx
 	}
 
 	for i := startIndex; i < apr.NArg(); i++ {
-		if _, ok := existingTables[apr.Args[i]]; !ok && apr.PositionalArgsSeparatorIndex < 0 {
-			return nil, fmt.Errorf("error: table %s does not exist", apr.Args[i])
+		// a <table>.<column> pathspec limits the log to changes to a single column
+		tableName, _, _ := strings.Cut(apr.Args[i], ".")
+		if _, ok := existingTables[tableName]; !ok && apr.PositionalArgsSeparatorIndex < 0 {
+			return nil, fmt.Errorf("error: table %s does not exist", tableName)
 		}
 		tableNames = append(tableNames, apr.Args[i])
 	}
@@ -247,6 +260,14 @@ func constructInterpolatedDoltLogQuery(apr *argparser.ArgParseResults, queryist
 		}
 	}
 
+	for _, param := range []string{cli.AuthorParam, cli.GrepParam, cli.SinceParam, cli.UntilParam, cli.PickaxeParam, cli.DiffFilterParam} {
+		if value, ok := apr.GetValue(param); ok {
+			writeToBuffer("?")
+			writeToBuffer("?")
+			params = append(params, "--"+param, value)
+		}
+	}
+
 	// included to check for invalid --decorate options
 	if decorate, hasDecorate := apr.GetValue(cli.DecorateFlag); hasDecorate {
 		writeToBuffer("?")
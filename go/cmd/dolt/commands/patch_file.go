@@ -0,0 +1,164 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+// patchFileVersion is the version of the patch file format written by dolt diff --patch-file. dolt apply refuses
+// patch files with a newer version.
+const patchFileVersion = 1
+
+// patchFile is a diff which can be applied to another database by dolt apply. Alongside the changes, it carries the
+// preimage of every changed schema and row, so that dolt apply can detect when its target has diverged from the side
+// of the diff the patch was created from.
+type patchFile struct {
+	Version int `json:"version"`
+	// Base is the hash of the commit the diff was from, if it was from a commit. dolt apply --3way merges the patch
+	// with this commit as the merge base.
+	Base        string             `json:"base,omitempty"`
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Tables      []*patchTable      `json:"tables"`
+	Definitions []*patchDefinition `json:"definitions,omitempty"`
+}
+
+// patchTable is the change to a single table. FromName is empty for added tables, and ToName is empty for dropped
+// tables.
+type patchTable struct {
+	FromName string `json:"from_name,omitempty"`
+	ToName   string `json:"to_name,omitempty"`
+	// FromCreate and ToCreate are the CREATE TABLE statements of the table before and after the change. They are
+	// empty if the patch does not include the table's schema changes.
+	FromCreate       string     `json:"from_create,omitempty"`
+	ToCreate         string     `json:"to_create,omitempty"`
+	SchemaStatements []string   `json:"schema_statements,omitempty"`
+	PrimaryKey       []string   `json:"primary_key,omitempty"`
+	FromColumns      []string   `json:"from_columns,omitempty"`
+	ToColumns        []string   `json:"to_columns,omitempty"`
+	Rows             []patchRow `json:"rows,omitempty"`
+}
+
+// name returns the name of the table before the change, or after it if the table was added.
+func (t *patchTable) name() string {
+	if t.FromName != "" {
+		return t.FromName
+	}
+	return t.ToName
+}
+
+// patchRow is the change to a single row. From is the preimage of the row, with a value for each of the table's
+// FromColumns, and is nil for added rows. To is the row after the change, with a value for each of the table's
+// ToColumns, and is nil for removed rows.
+type patchRow struct {
+	From []patchValue `json:"from,omitempty"`
+	To   []patchValue `json:"to,omitempty"`
+}
+
+// patchDefinition is the change to the definition of a view, trigger or event. From is empty if it was added, and To
+// is empty if it was dropped.
+type patchDefinition struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// patchValue is a single value of a row. Values are the string form of the SQL value, so that they can be compared
+// across databases and written back with SQL statements. Values which are not valid UTF-8 are encoded as base64.
+type patchValue struct {
+	Null  bool
+	Value string
+}
+
+func (v patchValue) MarshalJSON() ([]byte, error) {
+	if v.Null {
+		return []byte("null"), nil
+	}
+	if !utf8.ValidString(v.Value) {
+		return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString([]byte(v.Value))})
+	}
+	return json.Marshal(v.Value)
+}
+
+func (v *patchValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*v = patchValue{Null: true}
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var encoded map[string]string
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return err
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded["base64"])
+		if err != nil {
+			return err
+		}
+		*v = patchValue{Value: string(decoded)}
+		return nil
+	default:
+		*v = patchValue{}
+		return json.Unmarshal(data, &v.Value)
+	}
+}
+
+// sqlValue returns the value as a parameter for a SQL statement.
+func (v patchValue) sqlValue() interface{} {
+	if v.Null {
+		return nil
+	}
+	if !utf8.ValidString(v.Value) {
+		return []byte(v.Value)
+	}
+	return v.Value
+}
+
+func (v patchValue) String() string {
+	if v.Null {
+		return "NULL"
+	}
+	return v.Value
+}
+
+func writePatchFile(path string, patch *patchFile) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func readPatchFile(path string) (*patchFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var patch patchFile
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid patch file: %w", path, err)
+	}
+	if patch.Version < 1 || patch.Version > patchFileVersion {
+		return nil, fmt.Errorf("'%s' has unsupported patch file version %d", path, patch.Version)
+	}
+	return &patch, nil
+}
@@ -87,7 +87,7 @@ func (cmd ShowCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(cli.SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 	ap.SupportsFlag(cli.StatFlag, "", "Show stats of data changes")
 	ap.SupportsFlag(cli.SummaryFlag, "", "Show summary of data and schema changes")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json, html. Defaults to tabular.")
 	ap.SupportsString(cli.WhereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 	ap.SupportsInt(cli.LimitParam, "", "record_count", "limits to the first N diffs.")
 	ap.SupportsFlag(cli.CachedFlag, "c", "Show only the staged data changes.")
@@ -144,6 +144,9 @@ func (cmd ShowCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	if len(resolvedRefs) == 0 {
 		resolvedRefs = []string{"HEAD"}
 	}
+	if opts.diffOutput == HTMLDiffOutput && len(resolvedRefs) > 1 {
+		return handleErrAndExit(errors.New("html output supports showing a single commit"))
+	}
 
 	// There are two response formats:
 	//  - "pretty", which shows commits in a human-readable fashion
@@ -283,7 +286,7 @@ func (cmd ShowCmd) validateArgs(apr *argparser.ArgParseResults) errhand.VerboseE
 
 	f, _ := apr.GetValue(FormatFlag)
 	switch strings.ToLower(f) {
-	case "tabular", "sql", "json", "":
+	case "tabular", "sql", "json", "html", "":
 	default:
 		return errhand.BuildDError("invalid output format: %s", f).Build()
 	}
@@ -335,14 +338,25 @@ func fetchAndPrintCommit(queryist cli.Queryist, sqlCtx *sql.Context, opts *showO
 	cmHash := commit.commitHash
 	parents := commit.parentHashes
 
-	cli.ExecuteWithStdioRestored(func() {
-		pager := outputpager.Start()
-		defer pager.Stop()
+	// The HTML report describes the commit itself
+	isHTML := opts.diffOutput == HTMLDiffOutput
+	if !isHTML {
+		cli.ExecuteWithStdioRestored(func() {
+			pager := outputpager.Start()
+			defer pager.Stop()
 
-		PrintCommitInfo(pager, 0, opts.showParents, false, opts.decoration, commit)
-	})
+			PrintCommitInfo(pager, 0, opts.showParents, false, opts.decoration, commit)
+		})
+	}
 
 	if len(parents) == 0 {
+		if isHTML {
+			dw, err := newHtmlDiffWriter(cli.CliOut, "", cmHash, commit, opts.limit)
+			if err != nil {
+				return err
+			}
+			return dw.Close(sqlCtx)
+		}
 		return nil
 	}
 	if len(parents) > 1 {
@@ -367,6 +381,7 @@ func fetchAndPrintCommit(queryist cli.Queryist, sqlCtx *sql.Context, opts *showO
 		diffDisplaySettings: opts.diffDisplaySettings,
 		diffDatasets:        datasets,
 		tableSet:            tableSet,
+		commit:              commit,
 	}
 
 	return diffUserTables(queryist, sqlCtx, dArgs)
@@ -42,6 +42,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -105,6 +106,10 @@ const (
 	// MySQL handles both --binary-as-hex and --skip-binary-as-hex with one option definition
 	// and uses disabled_my_option to distinguish between enable/disable
 
+	sqlHistoryFileName = ".sqlhistory"
+	// editShorthand is accepted in the shell in place of \edit
+	editShorthand = "\\e"
+
 	welcomeMsg = `# Welcome to the DoltSQL shell.
 # Statements must be terminated with ';'.
 # "exit" or "quit" (or Ctrl-D) to exit. "\help" for help.`
@@ -464,7 +469,7 @@ func execSingleQuery(
 	}
 
 	if rowIter != nil {
-		err = engine.PrettyPrintResults(sqlCtx, format, sqlSch, rowIter, false, false, false, binaryAsHex)
+		err = engine.PrettyPrintResults(sqlCtx, format, sqlSch, rowIter, engine.PagerOff, false, false, binaryAsHex)
 		if err != nil {
 			return errhand.VerboseErrorFromError(err)
 		}
@@ -675,7 +680,7 @@ func execBatchMode(ctx *sql.Context, qryist cli.Queryist, input io.Reader, conti
 					fileReadProg.printNewLineIfNeeded()
 				}
 			}
-			err = engine.PrettyPrintResults(ctx, format, sqlSch, rowIter, false, false, false, binaryAsHex)
+			err = engine.PrettyPrintResults(ctx, format, sqlSch, rowIter, engine.PagerOff, false, false, binaryAsHex)
 			if err != nil {
 				err = buildBatchSqlErr(scanner.state.statementStartLine, query, err)
 				if !continueOnErr {
@@ -703,7 +708,7 @@ func buildBatchSqlErr(stmtStartLine int, query string, err error) error {
 // be updated by any queries which were processed.
 func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResultFormat, cliCtx cli.CliContext, binaryAsHex bool) error {
 	_ = iohelp.WriteLine(cli.CliOut, welcomeMsg)
-	historyFile := filepath.Join(".sqlhistory") // history file written to working dir
+	historyFile := sqlHistoryFile()
 
 	db, branch, _ := getDBBranchFromSession(sqlCtx, qryist)
 	dirty := false
@@ -728,6 +733,7 @@ func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResu
 	for _, cmd := range slashCmds {
 		backSlashCommands = append(backSlashCommands, "\\"+cmd.Name())
 	}
+	backSlashCommands = append(backSlashCommands, editShorthand)
 
 	shellConf := ishell.UninterpretedConfig{
 		ReadlineConfig: &rlConf,
@@ -771,7 +777,7 @@ func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResu
 	}
 
 	toggleWarnings := true
-	pagerEnabled := false
+	pagerMode := engine.PagerAuto
 	// Used for the \edit command.
 	lastSqlCmd := ""
 
@@ -828,7 +834,7 @@ func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResu
 					if err != nil {
 						shell.Println(color.RedString(err.Error()))
 					} else {
-						pagerEnabled = p
+						pagerMode = p
 					}
 				} else if okOn || okOff {
 					w, err := handleWarningCommand(query)
@@ -866,9 +872,9 @@ func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResu
 					} else if rowIter != nil {
 						switch closureFormat {
 						case engine.FormatTabular, engine.FormatVertical:
-							err = engine.PrettyPrintResultsExtended(sqlCtx, closureFormat, sqlSch, rowIter, pagerEnabled, toggleWarnings, true, binaryAsHex)
+							err = engine.PrettyPrintResultsExtended(sqlCtx, closureFormat, sqlSch, rowIter, pagerMode, toggleWarnings, true, binaryAsHex)
 						default:
-							err = engine.PrettyPrintResults(sqlCtx, closureFormat, sqlSch, rowIter, pagerEnabled, toggleWarnings, true, binaryAsHex)
+							err = engine.PrettyPrintResults(sqlCtx, closureFormat, sqlSch, rowIter, pagerMode, toggleWarnings, true, binaryAsHex)
 						}
 						if err != nil {
 							verr := formatQueryError("", err)
@@ -903,6 +909,38 @@ func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResu
 	return nil
 }
 
+// sqlHistoryFile returns the path of the shell's history file, which is kept in the user's dolt directory so that it is
+// shared by every shell session. Falls back to the working directory if there is no usable home directory.
+func sqlHistoryFile() string {
+	homeDir, err := env.GetCurrentUserHomeDir()
+	if err != nil {
+		return sqlHistoryFileName
+	}
+	doltDir := filepath.Join(homeDir, dbfactory.DoltDir)
+	if err := os.MkdirAll(doltDir, os.ModePerm); err != nil {
+		return sqlHistoryFileName
+	}
+	historyFile := filepath.Join(doltDir, sqlHistoryFileName)
+	migrateSqlHistory(sqlHistoryFileName, historyFile)
+	return historyFile
+}
+
+// migrateSqlHistory copies the history file that older versions wrote to the working directory, |oldFile|, to
+// |newFile| if there is no history there yet. The old file is left in place.
+func migrateSqlHistory(oldFile, newFile string) {
+	if _, err := os.Stat(newFile); !os.IsNotExist(err) {
+		return
+	}
+	if absOld, err := filepath.Abs(oldFile); err != nil || absOld == newFile {
+		return
+	}
+	data, err := os.ReadFile(oldFile)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(newFile, data, 0600)
+}
+
 func trackHistory(shell *ishell.Shell, query string) {
 	// TODO: there's a bug in the readline library when editing multi-line history entries.
 	// Longer term we need to switch to a new readline library, like in this bug:
@@ -935,7 +973,7 @@ func preprocessQuery(query, lastQuery string, cliCtx cli.CliContext) (CommandTyp
 	// strip leading whitespace
 	query = strings.TrimLeft(query, " \t\n\r\v\f")
 	if strings.HasPrefix(query, "\\") {
-		if query == "\\edit" {
+		if query == "\\edit" || query == editShorthand {
 			// \edit is a special case. Maybe we'll generalize this in the future.
 			updatedQuery, err := execEditor(lastQuery, ".sql", cliCtx)
 			if err != nil {
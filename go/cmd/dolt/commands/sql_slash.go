@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
@@ -209,7 +210,7 @@ func (s SlashEdit) Exec(ctx context.Context, commandStr string, args []string, d
 func (s SlashEdit) Docs() *cli.CommandDocumentation {
 	return &cli.CommandDocumentation{
 		ShortDesc: "Use $EDITOR to edit the last command.",
-		LongDesc:  "Start a text editor to edit your last command. Command will be executed after you finish editing.",
+		LongDesc:  "Start a text editor to edit your last command. Command will be executed after you finish editing. \\e is a shorthand for \\edit.",
 		Synopsis:  []string{},
 		ArgParser: s.ArgParser(),
 	}
@@ -225,7 +226,7 @@ type SlashPager struct{}
 func (s SlashPager) Docs() *cli.CommandDocumentation {
 	return &cli.CommandDocumentation{
 		ShortDesc: "Enable or Disable the result pager",
-		LongDesc:  "Returns results in pager form. Use pager [on|off].",
+		LongDesc:  "Returns results in pager form. Use pager [on|off|auto]. With auto, the default, only results too long to fit on the terminal are paged.",
 		Synopsis:  []string{},
 		ArgParser: s.ArgParser(),
 	}
@@ -250,28 +251,30 @@ func (s SlashPager) Exec(ctx context.Context, commandStr string, args []string,
 	panic("runtime error. SlashPager.Exec should never be called.")
 }
 
-// handlePagerCommand executes the pager command and returns true if pager should be on, or false otherwise. An error
-// could come up if they provided weird input.
-func handlePagerCommand(fullCmd string) (bool, error) {
+// handlePagerCommand executes the pager command and returns the pager mode the shell should use. An error could come
+// up if they provided weird input.
+func handlePagerCommand(fullCmd string) (engine.PagerMode, error) {
 	tokens := strings.Split(fullCmd, " ")
 
 	if len(tokens) == 0 || tokens[0] != "\\pager" {
-		return false, fmt.Errorf("runtime error: Expected \\pager command.")
+		return engine.PagerOff, fmt.Errorf("runtime error: Expected \\pager command.")
 	}
 
 	if len(tokens) == 1 {
-		return false, fmt.Errorf("Usage: \\pager [on|off]")
+		return engine.PagerOff, fmt.Errorf("Usage: \\pager [on|off|auto]")
 	}
 
 	// Kind of sloppy here,`\pager foo bar on` will work, but not the end of the world.
-	if tokens[len(tokens)-1] == "on" {
-		return true, nil
-	}
-	if tokens[len(tokens)-1] == "off" {
-		return false, nil
+	switch tokens[len(tokens)-1] {
+	case "on":
+		return engine.PagerOn, nil
+	case "off":
+		return engine.PagerOff, nil
+	case "auto":
+		return engine.PagerAuto, nil
 	}
 
-	return false, fmt.Errorf("Usage: \\pager [on|off]")
+	return engine.PagerOff, fmt.Errorf("Usage: \\pager [on|off|auto]")
 }
 
 type WarningCmd struct{}
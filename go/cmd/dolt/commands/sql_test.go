@@ -17,6 +17,8 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
@@ -25,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
@@ -582,3 +585,68 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrateSqlHistory(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "old", sqlHistoryFileName)
+	newFile := filepath.Join(dir, "new", sqlHistoryFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(oldFile), os.ModePerm))
+	require.NoError(t, os.MkdirAll(filepath.Dir(newFile), os.ModePerm))
+
+	// nothing to migrate
+	migrateSqlHistory(oldFile, newFile)
+	assert.NoFileExists(t, newFile)
+
+	require.NoError(t, os.WriteFile(oldFile, []byte("select 1;\n"), 0600))
+	migrateSqlHistory(oldFile, newFile)
+	data, err := os.ReadFile(newFile)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1;\n", string(data))
+	assert.FileExists(t, oldFile)
+
+	// an existing history is not overwritten
+	require.NoError(t, os.WriteFile(oldFile, []byte("select 2;\n"), 0600))
+	migrateSqlHistory(oldFile, newFile)
+	data, err = os.ReadFile(newFile)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1;\n", string(data))
+}
+
+func TestHandlePagerCommand(t *testing.T) {
+	tests := []struct {
+		cmd      string
+		expected engine.PagerMode
+		err      bool
+	}{
+		{`\pager on`, engine.PagerOn, false},
+		{`\pager off`, engine.PagerOff, false},
+		{`\pager auto`, engine.PagerAuto, false},
+		{`\pager`, engine.PagerOff, true},
+		{`\pager sometimes`, engine.PagerOff, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.cmd, func(t *testing.T) {
+			mode, err := handlePagerCommand(test.cmd)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, mode)
+			}
+		})
+	}
+}
+
+func TestPreprocessQuerySlashCommands(t *testing.T) {
+	cmdType, cmd, _, err := preprocessQuery(`\pager auto`, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, DoltCliCommand, cmdType)
+	assert.Equal(t, SlashPager{}, cmd)
+
+	cmdType, cmd, query, err := preprocessQuery("select 1", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, SqlShellCommand, cmdType)
+	assert.Nil(t, cmd)
+	assert.Equal(t, "select 1", query)
+}
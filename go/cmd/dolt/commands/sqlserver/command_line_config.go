@@ -358,6 +358,18 @@ func (cfg *commandLineServerConfig) ClusterConfig() servercfg.ClusterConfig {
 	return nil
 }
 
+func (cfg *commandLineServerConfig) PostgresReplicationConfig() servercfg.PostgresReplicationConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) TracingConfig() servercfg.TracingConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) AuditLogConfig() servercfg.AuditLogConfig {
+	return nil
+}
+
 // PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 // JSON string.
 func (cfg *commandLineServerConfig) PrivilegeFilePath() string {
@@ -591,3 +603,7 @@ func (stubAutoGCBehavior) Enable() bool {
 }
 
 func (stubAutoGCBehavior) ArchiveLevel() int { return servercfg.DefaultCompressionLevel }
+
+func (stubAutoGCBehavior) StorageMaintenance() servercfg.StorageMaintenanceBehavior {
+	return &servercfg.StorageMaintenanceYAMLConfig{}
+}
@@ -22,20 +22,32 @@ import (
 	"github.com/dolthub/go-mysql-server/server"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/clusterdb"
 	"github.com/dolthub/dolt/go/libraries/utils/version"
+	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
 const (
 	clusterUpdateInterval = time.Second * 5
 
-	dbLabel     = "database"
-	roleLabel   = "role"
-	remoteLabel = "remote"
+	dbLabel         = "database"
+	roleLabel       = "role"
+	remoteLabel     = "remote"
+	directionLabel  = "direction"
+	sourceUuidLabel = "source_uuid"
 )
 
 var _ server.ServerEventListener = (*metricsListener)(nil)
+var _ doltdb.TransferObserver = (*metricsListener)(nil)
+
+// readReplicaStatusProvider reports the replication state of read replica databases.
+type readReplicaStatusProvider interface {
+	ReadReplicaStatus() []sqle.ReadReplicaStatus
+}
 
 type metricsListener struct {
 	labels prometheus.Labels
@@ -50,15 +62,34 @@ type metricsListener struct {
 	// replication metrics
 	isReplicaGauges      *prometheus.GaugeVec
 	replicationLagGauges *prometheus.GaugeVec
-
-	// used in updating cluster metrics
-	clusterStatus  clusterdb.ClusterStatusProvider
-	mu             *sync.Mutex
-	done           bool
-	clusterSeenDbs map[string]struct{}
+	clusterRoleGauges    *prometheus.GaugeVec
+	clusterEpochGauges   *prometheus.GaugeVec
+
+	// read replica metrics
+	readReplicaSincePullGauges *prometheus.GaugeVec
+	readReplicaLagCommitGauges *prometheus.GaugeVec
+
+	// binlog replica metrics
+	binlogReplicaSequenceGauges  *prometheus.GaugeVec
+	binlogReplicaTimestampGauges *prometheus.GaugeVec
+
+	// remote transfer metrics
+	cntTransferBytes  *prometheus.CounterVec
+	cntTransferChunks *prometheus.CounterVec
+	cntTransferErrors *prometheus.CounterVec
+	histTransferDur   *prometheus.HistogramVec
+
+	// used in updating cluster and read replica metrics
+	clusterStatus      clusterdb.ClusterStatusProvider
+	readReplicaStatus  readReplicaStatusProvider
+	mu                 *sync.Mutex
+	done               bool
+	clusterSeenDbs     map[string]struct{}
+	readReplicaSeenDbs map[string]struct{}
+	binlogSeenSource   string
 }
 
-func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStatus clusterdb.ClusterStatusProvider) (*metricsListener, error) {
+func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStatus clusterdb.ClusterStatusProvider, readReplicaStatus readReplicaStatusProvider) (*metricsListener, error) {
 	ml := &metricsListener{
 		labels: labels,
 		cntConnections: prometheus.NewCounter(prometheus.CounterOpts{
@@ -102,9 +133,62 @@ func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStat
 			Help:        "one if the server is currently in this role, zero otherwise",
 			ConstLabels: labels,
 		}, []string{dbLabel}),
-		clusterStatus:  clusterStatus,
-		mu:             &sync.Mutex{},
-		clusterSeenDbs: make(map[string]struct{}),
+		clusterRoleGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_cluster_role",
+			Help:        "One for the cluster role this server is currently running as for the database, zero for the other roles.",
+			ConstLabels: labels,
+		}, []string{dbLabel, roleLabel}),
+		clusterEpochGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_cluster_epoch",
+			Help:        "The epoch of this server's current cluster role for the database.",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		readReplicaSincePullGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_read_replica_seconds_since_pull",
+			Help:        "Seconds since the read replica database last finished a pull from its remote, or -1 if it has not.",
+			ConstLabels: labels,
+		}, []string{dbLabel, remoteLabel}),
+		readReplicaLagCommitGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_read_replica_lag_commits",
+			Help:        fmt.Sprintf("The number of commits the read replica database was behind its remote when its last pull started, counting at most %d per branch.", sqle.MaxCountedReplicaCommits),
+			ConstLabels: labels,
+		}, []string{dbLabel, remoteLabel}),
+		binlogReplicaSequenceGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_binlog_replica_gtid_sequence",
+			Help:        "The GTID sequence number of the last source transaction the binlog replica applied.",
+			ConstLabels: labels,
+		}, []string{sourceUuidLabel}),
+		binlogReplicaTimestampGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_binlog_replica_source_timestamp",
+			Help:        "The unix time at which the source committed the last transaction the binlog replica applied.",
+			ConstLabels: labels,
+		}, []string{sourceUuidLabel}),
+		cntTransferBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "dss_remote_transfer_bytes",
+			Help:        "Count of chunk bytes pushed to or pulled from a remote",
+			ConstLabels: labels,
+		}, []string{dbLabel, remoteLabel, directionLabel}),
+		cntTransferChunks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "dss_remote_transfer_chunks",
+			Help:        "Count of chunks pushed to or pulled from a remote",
+			ConstLabels: labels,
+		}, []string{dbLabel, remoteLabel, directionLabel}),
+		cntTransferErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "dss_remote_transfer_errors",
+			Help:        "Count of pushes to or pulls from a remote which failed",
+			ConstLabels: labels,
+		}, []string{dbLabel, remoteLabel, directionLabel}),
+		histTransferDur: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "dss_remote_transfer_duration",
+			Help:        "Histogram of the runtimes of pushes to and pulls from a remote, in seconds",
+			ConstLabels: labels,
+			Buckets:     []float64{0.01, 0.1, 1.0, 10.0, 100.0, 1000.0}, // 10 ms to 16 mins 40 secs
+		}, []string{dbLabel, remoteLabel, directionLabel}),
+		clusterStatus:      clusterStatus,
+		readReplicaStatus:  readReplicaStatus,
+		mu:                 &sync.Mutex{},
+		clusterSeenDbs:     make(map[string]struct{}),
+		readReplicaSeenDbs: make(map[string]struct{}),
 	}
 
 	u32Version, err := version.Encode(versionStr)
@@ -126,6 +210,18 @@ func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStat
 	prometheus.MustRegister(ml.histQueryDur)
 	prometheus.MustRegister(ml.replicationLagGauges)
 	prometheus.MustRegister(ml.isReplicaGauges)
+	prometheus.MustRegister(ml.clusterRoleGauges)
+	prometheus.MustRegister(ml.clusterEpochGauges)
+	prometheus.MustRegister(ml.readReplicaSincePullGauges)
+	prometheus.MustRegister(ml.readReplicaLagCommitGauges)
+	prometheus.MustRegister(ml.binlogReplicaSequenceGauges)
+	prometheus.MustRegister(ml.binlogReplicaTimestampGauges)
+	prometheus.MustRegister(ml.cntTransferBytes)
+	prometheus.MustRegister(ml.cntTransferChunks)
+	prometheus.MustRegister(ml.cntTransferErrors)
+	prometheus.MustRegister(ml.histTransferDur)
+
+	doltdb.SetTransferObserver(ml)
 
 	go func() {
 		for ml.updateReplMetrics() {
@@ -145,6 +241,9 @@ func (ml *metricsListener) updateReplMetrics() bool {
 		return false
 	}
 
+	ml.updateReadReplicaMetrics()
+	ml.updateBinlogReplicaMetrics()
+
 	perDbStatus := ml.clusterStatus.GetClusterStatus()
 	if perDbStatus == nil {
 		return true
@@ -155,6 +254,15 @@ func (ml *metricsListener) updateReplMetrics() bool {
 		dbName := status.Database
 		dbNames[dbName] = struct{}{}
 
+		for _, role := range []cluster.Role{cluster.RolePrimary, cluster.RoleStandby, cluster.RoleDetectedBrokenConfig} {
+			if status.Role == string(role) {
+				ml.clusterRoleGauges.WithLabelValues(status.Database, string(role)).Set(1.0)
+			} else {
+				ml.clusterRoleGauges.WithLabelValues(status.Database, string(role)).Set(0.0)
+			}
+		}
+		ml.clusterEpochGauges.WithLabelValues(status.Database).Set(float64(status.Epoch))
+
 		if status.Role == string(cluster.RolePrimary) {
 			ml.isReplicaGauges.WithLabelValues(status.Database).Set(0.0)
 
@@ -174,6 +282,8 @@ func (ml *metricsListener) updateReplMetrics() bool {
 		if _, ok := dbNames[db]; !ok {
 			ml.isReplicaGauges.DeletePartialMatch(prometheus.Labels{"database": db})
 			ml.replicationLagGauges.DeletePartialMatch(prometheus.Labels{"database": db})
+			ml.clusterRoleGauges.DeletePartialMatch(prometheus.Labels{"database": db})
+			ml.clusterEpochGauges.DeletePartialMatch(prometheus.Labels{"database": db})
 		}
 	}
 	ml.clusterSeenDbs = dbNames
@@ -181,6 +291,61 @@ func (ml *metricsListener) updateReplMetrics() bool {
 	return true
 }
 
+// updateReadReplicaMetrics updates the lag metrics of the read replica databases. Must be called with |ml.mu| held.
+func (ml *metricsListener) updateReadReplicaMetrics() {
+	if ml.readReplicaStatus == nil {
+		return
+	}
+
+	dbNames := make(map[string]struct{})
+	for _, status := range ml.readReplicaStatus.ReadReplicaStatus() {
+		dbNames[status.Database] = struct{}{}
+		if status.LastPull.IsZero() {
+			ml.readReplicaSincePullGauges.WithLabelValues(status.Database, status.Remote).Set(-1.0)
+		} else {
+			ml.readReplicaSincePullGauges.WithLabelValues(status.Database, status.Remote).Set(time.Since(status.LastPull).Seconds())
+		}
+		ml.readReplicaLagCommitGauges.WithLabelValues(status.Database, status.Remote).Set(float64(status.CommitsBehind))
+	}
+
+	// deregister metrics for deleted databases
+	for db := range ml.readReplicaSeenDbs {
+		if _, ok := dbNames[db]; !ok {
+			ml.readReplicaSincePullGauges.DeletePartialMatch(prometheus.Labels{"database": db})
+			ml.readReplicaLagCommitGauges.DeletePartialMatch(prometheus.Labels{"database": db})
+		}
+	}
+	ml.readReplicaSeenDbs = dbNames
+}
+
+// updateBinlogReplicaMetrics updates the position of the binlog replica. Must be called with |ml.mu| held.
+func (ml *metricsListener) updateBinlogReplicaMetrics() {
+	position := binlogreplication.DoltBinlogReplicaController.Position()
+	if position.SourceUuid == "" {
+		return
+	}
+
+	if ml.binlogSeenSource != "" && ml.binlogSeenSource != position.SourceUuid {
+		ml.binlogReplicaSequenceGauges.DeleteLabelValues(ml.binlogSeenSource)
+		ml.binlogReplicaTimestampGauges.DeleteLabelValues(ml.binlogSeenSource)
+	}
+	ml.binlogSeenSource = position.SourceUuid
+
+	ml.binlogReplicaSequenceGauges.WithLabelValues(position.SourceUuid).Set(float64(position.Sequence))
+	ml.binlogReplicaTimestampGauges.WithLabelValues(position.SourceUuid).Set(float64(position.SourceTimestamp.Unix()))
+}
+
+// TransferCompleted implements doltdb.TransferObserver
+func (ml *metricsListener) TransferCompleted(labels doltdb.TransferLabels, stats pull.Stats, d time.Duration, err error) {
+	labelValues := []string{labels.Database, labels.Remote, string(labels.Direction)}
+	ml.cntTransferBytes.WithLabelValues(labelValues...).Add(float64(stats.FetchedSourceBytes))
+	ml.cntTransferChunks.WithLabelValues(labelValues...).Add(float64(stats.FetchedSourceChunks))
+	ml.histTransferDur.WithLabelValues(labelValues...).Observe(d.Seconds())
+	if err != nil {
+		ml.cntTransferErrors.WithLabelValues(labelValues...).Add(1.0)
+	}
+}
+
 func (ml *metricsListener) ClientConnected() {
 	ml.gaugeConcurrentConn.Add(1.0)
 	ml.cntConnections.Add(1.0)
@@ -201,12 +366,18 @@ func (ml *metricsListener) QueryCompleted(success bool, duration time.Duration)
 }
 
 func (ml *metricsListener) Close() {
+	doltdb.SetTransferObserver(nil)
+
 	prometheus.Unregister(ml.gaugeVersion)
 	prometheus.Unregister(ml.cntConnections)
 	prometheus.Unregister(ml.cntDisconnects)
 	prometheus.Unregister(ml.gaugeConcurrentConn)
 	prometheus.Unregister(ml.gaugeConcurrentQueries)
 	prometheus.Unregister(ml.histQueryDur)
+	prometheus.Unregister(ml.cntTransferBytes)
+	prometheus.Unregister(ml.cntTransferChunks)
+	prometheus.Unregister(ml.cntTransferErrors)
+	prometheus.Unregister(ml.histTransferDur)
 
 	ml.closeReplicationMetrics()
 }
@@ -217,6 +388,12 @@ func (ml *metricsListener) closeReplicationMetrics() {
 
 	prometheus.Unregister(ml.replicationLagGauges)
 	prometheus.Unregister(ml.isReplicaGauges)
+	prometheus.Unregister(ml.clusterRoleGauges)
+	prometheus.Unregister(ml.clusterEpochGauges)
+	prometheus.Unregister(ml.readReplicaSincePullGauges)
+	prometheus.Unregister(ml.readReplicaLagCommitGauges)
+	prometheus.Unregister(ml.binlogReplicaSequenceGauges)
+	prometheus.Unregister(ml.binlogReplicaTimestampGauges)
 
 	ml.done = true
 }
@@ -44,16 +44,19 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/audit"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/pgreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
@@ -170,6 +173,9 @@ func ConfigureServices(
 
 	controller.Register(newHeartbeatService(cfg.Version, cfg.DoltEnv))
 
+	tracing := newTracingService(cfg.ServerConfig.TracingConfig(), cfg.Version)
+	controller.Register(tracing)
+
 	fs := cfg.DoltEnv.FS
 	InitFailsafes := &svcs.AnonService{
 		InitF: func(ctx context.Context) (err error) {
@@ -226,11 +232,41 @@ func ConfigureServices(
 	LoadServerConfig := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
 			serverConf, err = getConfigFromServerConfig(cfg.ServerConfig, cfg.ProtocolListenerFactory)
-			return err
+			if err != nil {
+				return err
+			}
+			if tracer := tracing.queryTracer(); tracer != nil {
+				serverConf.Tracer = tracer
+			}
+			return nil
 		},
 	}
 	controller.Register(LoadServerConfig)
 
+	var auditLog *audit.Log
+	InitAuditLog := &svcs.AnonService{
+		InitF: func(context.Context) (err error) {
+			auditCfg := cfg.ServerConfig.AuditLogConfig()
+			if auditCfg == nil {
+				return nil
+			}
+			auditLog, err = audit.Open(auditCfg.File(), int64(auditCfg.MaxSizeMB())*1024*1024, auditCfg.MaxFiles())
+			if err != nil {
+				return err
+			}
+			branch_control.SetDecisionObserver(auditLog)
+			return nil
+		},
+		StopF: func() error {
+			if auditLog == nil {
+				return nil
+			}
+			branch_control.SetDecisionObserver(nil)
+			return auditLog.Close()
+		},
+	}
+	controller.Register(InitAuditLog)
+
 	// Create SQL Engine with users
 	var config *engine.SqlEngineConfig
 	InitSqlEngineConfig := &svcs.AnonService{
@@ -280,6 +316,20 @@ func ConfigureServices(
 					return fmt.Errorf("invalid value for %s: %d", cli.ArchiveLevelParam, cmp)
 				}
 				config.AutoGCController = sqle.NewAutoGCController(cmp, lgr)
+				if maintenance := cfg.ServerConfig.AutoGCBehavior().StorageMaintenance(); maintenance != nil && maintenance.Enable() {
+					if maintenance.ArchiveZstdLevel() < 0 || maintenance.ArchiveZstdLevel() > servercfg.MaxStorageMaintenanceArchiveZstdLevel {
+						return fmt.Errorf("invalid value for storage_maintenance.archive_zstd_level: %d", maintenance.ArchiveZstdLevel())
+					}
+					if maintenance.CheckInterval() <= 0 {
+						return fmt.Errorf("invalid value for storage_maintenance.check_interval_secs: %v", maintenance.CheckInterval())
+					}
+					config.AutoGCController.EnableStorageMaintenance(sqle.StorageMaintenanceConfig{
+						CheckInterval:      maintenance.CheckInterval(),
+						ConjoinMinFiles:    maintenance.ConjoinMinFiles(),
+						ConjoinMaxFileSize: maintenance.ConjoinMaxFileSize(),
+						ArchiveZstdLevel:   maintenance.ArchiveZstdLevel(),
+					})
+				}
 			}
 			return nil
 		},
@@ -428,6 +478,20 @@ func ConfigureServices(
 				binlogreplication.BinlogBranch = logBinBranch
 			}
 
+			_, logBinBranchDatabasesValue, ok := sql.SystemVariables.GetGlobal("log_bin_branch_databases")
+			if !ok {
+				return fmt.Errorf("unable to load @@log_bin_branch_databases system variable")
+			}
+			logBinBranchDatabases, ok := logBinBranchDatabasesValue.(string)
+			if !ok {
+				return fmt.Errorf("unexpected type for @@log_bin_branch_databases system variable: %T", logBinBranchDatabasesValue)
+			}
+			branchDatabases, err := binlogreplication.ParseBranchDatabases(logBinBranchDatabases)
+			if err != nil {
+				return fmt.Errorf("invalid @@log_bin_branch_databases value %q: %w", logBinBranchDatabases, err)
+			}
+			binlogreplication.BranchDatabases = branchDatabases
+
 			if logBin == 1 {
 				logrus.Infof("Enabling binary logging for branch %s", logBinBranch)
 				binlogProducer, err := binlogreplication.NewBinlogProducer(sqlCtx, cfg.DoltEnv.FS)
@@ -523,7 +587,13 @@ func ConfigureServices(
 	InitMetricsListener := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
 			labels := cfg.ServerConfig.MetricsLabels()
-			metListener, err = newMetricsListener(labels, cfg.Version, clusterController)
+			var readReplicaStatus readReplicaStatusProvider
+			if doltProvider, ok := sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.DbProvider.(*sqle.DoltDatabaseProvider); ok {
+				readReplicaStatus = doltProvider
+			}
+			// Counting the commits read replicas pull is only worth its cost when the metrics are served
+			sqle.SetCountReadReplicaCommits(cfg.ServerConfig.MetricsHost() != "" && cfg.ServerConfig.MetricsPort() > 0)
+			metListener, err = newMetricsListener(labels, cfg.Version, clusterController, readReplicaStatus)
 			return err
 		},
 		StopF: func() error {
@@ -779,6 +849,14 @@ func ConfigureServices(
 	var sqlServerClosed bool
 	InitSQLServer := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
+			auditHandler := func(h mysql.Handler) mysql.Handler {
+				if auditLog == nil {
+					return h
+				}
+				return audit.NewHandler(h, auditLog, func(c *mysql.Conn) string {
+					return mySQLServer.SessionManager().GetCurrentDB(c)
+				})
+			}
 			v, ok := cfg.ServerConfig.(servercfg.ValidatingServerConfig)
 			if ok && v.GoldenMysqlConnectionString() != "" {
 				mySQLServer, err = server.NewServerWithHandler(
@@ -788,16 +866,19 @@ func ConfigureServices(
 					newSessionBuilder(sqlEngine, cfg.ServerConfig),
 					metListener,
 					func(h mysql.Handler) (mysql.Handler, error) {
-						return golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
+						return golden.NewValidatingHandler(auditHandler(h), v.GoldenMysqlConnectionString(), logrus.StandardLogger())
 					},
 				)
 			} else {
-				mySQLServer, err = server.NewServer(
+				mySQLServer, err = server.NewServerWithHandler(
 					serverConf,
 					sqlEngine.GetUnderlyingEngine(),
 					sqlEngine.ContextFactory,
 					newSessionBuilder(sqlEngine, cfg.ServerConfig),
 					metListener,
+					func(h mysql.Handler) (mysql.Handler, error) {
+						return auditHandler(h), nil
+					},
 				)
 			}
 			if errors.Is(err, server.UnixSocketInUseError) {
@@ -834,6 +915,40 @@ func ConfigureServices(
 	}
 	controller.Register(AutoStartBinlogReplica)
 
+	// Apply the changes streamed from a Postgres publication, if postgres_replication is configured
+	var pgSubscriber *pgreplication.Subscriber
+	var pgSubscriberCtx *sql.Context
+	RunPostgresReplication := &svcs.AnonService{
+		InitF: func(ctx context.Context) error {
+			pgConfig := cfg.ServerConfig.PostgresReplicationConfig()
+			if pgConfig == nil {
+				return nil
+			}
+			sqlCtx, err := sqlEngine.NewDefaultContext(ctx)
+			if err != nil {
+				return fmt.Errorf("unable to start postgres replication, could not create session: %w", err)
+			}
+			pgSubscriberCtx = sqlCtx
+			pgSubscriber = pgreplication.NewSubscriber(pgConfig, sqlEngine.GetUnderlyingEngine(), lgr)
+			return nil
+		},
+		RunF: func(context.Context) {
+			if pgSubscriber == nil {
+				return
+			}
+			defer sql.SessionEnd(pgSubscriberCtx.Session)
+			pgSubscriber.Run(pgSubscriberCtx)
+		},
+		StopF: func() error {
+			if pgSubscriber == nil {
+				return nil
+			}
+			pgSubscriber.Stop()
+			return nil
+		},
+	}
+	controller.Register(RunPostgresReplication)
+
 	RunClusterController := &svcs.AnonService{
 		InitF: func(context.Context) error {
 			if clusterController == nil {
@@ -107,6 +107,16 @@ SUPPORTED CONFIG FILE FIELDS:
 
 {{.EmphasisLeft}}behavior.auto_gc_behavior.enabled{{.EmphasisRight}}: If true, garbage collection will run automatically in the background. 
 
+{{.EmphasisLeft}}behavior.auto_gc_behavior.storage_maintenance.enable{{.EmphasisRight}}: If true, and automatic garbage collection is enabled, the storage files of each database's old generation are periodically converted to archives and small files are conjoined. Every garbage collection and maintenance operation is recorded in the {{.EmphasisLeft}}dolt_storage_maintenance_log{{.EmphasisRight}} system table. Defaults to false.
+
+{{.EmphasisLeft}}behavior.auto_gc_behavior.storage_maintenance.check_interval_secs{{.EmphasisRight}}: How often each database is checked for maintenance work. Defaults to 600.
+
+{{.EmphasisLeft}}behavior.auto_gc_behavior.storage_maintenance.conjoin_min_files{{.EmphasisRight}}: The number of small storage files which must accumulate before they are conjoined. Defaults to 8.
+
+{{.EmphasisLeft}}behavior.auto_gc_behavior.storage_maintenance.conjoin_max_file_mb{{.EmphasisRight}}: Storage files of at most this many megabytes are considered small. Defaults to 64.
+
+{{.EmphasisLeft}}behavior.auto_gc_behavior.storage_maintenance.archive_zstd_level{{.EmphasisRight}}: The zstd compression level, from 1 to 22, used when converting table files to archives. 0 disables archiving. Defaults to 3.
+
 {{.EmphasisLeft}}listener.host{{.EmphasisRight}}: The host address that the server will run on.  This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
 
 {{.EmphasisLeft}}listener.port{{.EmphasisRight}}: The port that the server should listen on
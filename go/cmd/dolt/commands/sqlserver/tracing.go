@@ -0,0 +1,127 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+)
+
+// queryTracerName is the instrumentation name of the spans go-mysql-server records for parsing, analyzing and
+// executing queries.
+const queryTracerName = "github.com/dolthub/dolt/go/cmd/dolt/commands/sqlserver"
+
+// tracingService installs a global OpenTelemetry TracerProvider configured by the server's tracing config, so that
+// spans recorded by the sql engine, the storage layer and remote clients are exported. Spans are linked to those of
+// remote servers through W3C trace context propagation.
+type tracingService struct {
+	config  servercfg.TracingConfig
+	version string
+
+	provider *tracesdk.TracerProvider
+}
+
+var _ svcs.Service = (*tracingService)(nil)
+
+func newTracingService(config servercfg.TracingConfig, version string) *tracingService {
+	return &tracingService{config: config, version: version}
+}
+
+func (s *tracingService) Init(ctx context.Context) error {
+	if s.config == nil {
+		return nil
+	}
+	exporter, err := newSpanExporter(ctx, s.config)
+	if err != nil {
+		return err
+	}
+	s.provider = tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(exporter),
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(s.config.SampleRatio()))),
+		tracesdk.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(s.config.ServiceName()),
+			semconv.ServiceVersionKey.String(s.version),
+		)),
+	)
+	otel.SetTracerProvider(s.provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return nil
+}
+
+func (s *tracingService) Run(context.Context) {
+}
+
+func (s *tracingService) Stop() error {
+	if s.provider == nil {
+		return nil
+	}
+	return s.provider.Shutdown(context.Background())
+}
+
+// queryTracer returns the tracer the sql server should record query spans with, or nil if tracing is not configured.
+func (s *tracingService) queryTracer() trace.Tracer {
+	if s.provider == nil {
+		return nil
+	}
+	return s.provider.Tracer(queryTracerName)
+}
+
+func newSpanExporter(ctx context.Context, config servercfg.TracingConfig) (tracesdk.SpanExporter, error) {
+	switch config.Exporter() {
+	case servercfg.TracingExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint())}
+		if config.Insecure() {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	case servercfg.TracingExporterFile:
+		f, err := os.OpenFile(config.File(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: could not open %s: %w", config.File(), err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			return nil, errors.Join(err, f.Close())
+		}
+		return fileSpanExporter{exporter, f}, nil
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", config.Exporter())
+	}
+}
+
+// fileSpanExporter closes the file spans are written to when the exporter is shut down.
+type fileSpanExporter struct {
+	tracesdk.SpanExporter
+	f *os.File
+}
+
+func (e fileSpanExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.SpanExporter.Shutdown(ctx), e.f.Close())
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func TestTracingService(t *testing.T) {
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		s := newTracingService(nil, "0.0.0")
+		require.NoError(t, s.Init(context.Background()))
+		assert.Nil(t, s.queryTracer())
+		require.NoError(t, s.Stop())
+	})
+
+	t.Run("FileExporter", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "spans.json")
+		exporter := servercfg.TracingExporterFile
+		s := newTracingService(&servercfg.TracingYAMLConfig{Exporter_: &exporter, File_: &path}, "0.0.0")
+		require.NoError(t, s.Init(context.Background()))
+		require.NotNil(t, s.queryTracer())
+
+		_, span := s.queryTracer().Start(context.Background(), "query")
+		span.End()
+		_, span = otel.Tracer("test").Start(context.Background(), "nbs.Get")
+		span.End()
+		require.NoError(t, s.Stop())
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `"Name":"query"`)
+		assert.Contains(t, string(contents), `"Name":"nbs.Get"`)
+		assert.Contains(t, string(contents), servercfg.DefaultTracingServiceName)
+	})
+}
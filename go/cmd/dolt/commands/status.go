@@ -58,6 +58,13 @@ type printData struct {
 
 	ignorePatterns doltdb.IgnorePatterns
 	ignoredTables  doltdb.IgnoredTables
+
+	submoduleChanges []submoduleChange
+}
+
+// submoduleChange is a change to the commit a submodule is pinned to, between HEAD and the working set.
+type submoduleChange struct {
+	name, status, from, to string
 }
 
 var statusDocs = cli.CommandDocumentationContent{
@@ -294,6 +301,14 @@ func createPrintData(err error, queryist cli.Queryist, sqlCtx *sql.Context, show
 		filteredUntrackedTables[tableName] = status
 	}
 
+	var submoduleChanges []submoduleChange
+	if stagedTables[doltdb.SubmodulesTableName] != "" || unstagedTables[doltdb.SubmodulesTableName] != "" || untrackedTables[doltdb.SubmodulesTableName] != "" {
+		submoduleChanges, err = getSubmoduleChanges(queryist, sqlCtx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	pd := printData{
 		branchName:                branchName,
 		remoteName:                remoteName,
@@ -314,10 +329,40 @@ func createPrintData(err error, queryist cli.Queryist, sqlCtx *sql.Context, show
 		schemaConflictTables:      schemaConflictTables,
 		ignorePatterns:            ignorePatterns,
 		dataConflictTables:        dataConflictTables,
+		submoduleChanges:          submoduleChanges,
 	}
 	return &pd, nil
 }
 
+func getSubmoduleChanges(queryist cli.Queryist, sqlCtx *sql.Context) ([]submoduleChange, error) {
+	q := fmt.Sprintf("select diff_type, from_%[1]s, to_%[1]s, from_%[2]s, to_%[2]s from dolt_diff('HEAD', 'WORKING', '%[3]s') order by coalesce(to_%[1]s, from_%[1]s)",
+		doltdb.SubmodulesNameCol, doltdb.SubmodulesCommitCol, doltdb.SubmodulesTableName)
+	rows, err := cli.GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []submoduleChange
+	for _, row := range rows {
+		var vals [5]string
+		for i := range vals {
+			if row[i] != nil {
+				vals[i] = fmt.Sprint(row[i])
+			}
+		}
+		diffType, fromName, toName, from, to := vals[0], vals[1], vals[2], vals[3], vals[4]
+		switch {
+		case diffType == "added":
+			changes = append(changes, submoduleChange{name: toName, status: "new submodule", to: to})
+		case diffType == "removed":
+			changes = append(changes, submoduleChange{name: fromName, status: "deleted", from: from})
+		case from != to:
+			changes = append(changes, submoduleChange{name: toName, status: "modified", from: from, to: to})
+		}
+	}
+	return changes, nil
+}
+
 func getConflictedTables(statusRows []sql.Row) map[string]bool {
 	conflictedTables := make(map[string]bool)
 	for _, row := range statusRows {
@@ -626,6 +671,26 @@ and have %v and %v different commits each, respectively.
 		}
 	}
 
+	// submodules
+	if len(data.submoduleChanges) > 0 {
+		cli.Println()
+		cli.Println(submodulesHeader)
+		cli.Println(submodulesHeaderHelp)
+		for _, change := range data.submoduleChanges {
+			var text string
+			switch change.status {
+			case "modified":
+				text = fmt.Sprintf(statusFmt, change.status+":", fmt.Sprintf("%s (%s -> %s)", change.name, change.from, change.to))
+			case "deleted":
+				text = fmt.Sprintf(statusFmt, change.status+":", change.name)
+			default:
+				text = fmt.Sprintf(statusFmt, change.status+":", fmt.Sprintf("%s (%s)", change.name, change.to))
+			}
+			cli.Println(color.YellowString(text))
+		}
+		changesPresent = true
+	}
+
 	// untracked tables
 	if len(data.untrackedTables) > 0 {
 		if changesPresent {
@@ -0,0 +1,125 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submodulecmds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var addDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Add another database as a submodule",
+	LongDesc: `Clones the database at {{.LessThan}}url{{.GreaterThan}} as submodule {{.LessThan}}name{{.GreaterThan}}, and adds a row pinning it to {{.LessThan}}commit{{.GreaterThan}} to the {{.EmphasisLeft}}dolt_submodules{{.EmphasisRight}} table. {{.LessThan}}commit{{.GreaterThan}} can be any commit spec, resolved in the submodule, and defaults to the submodule's default branch.
+
+The row is committed like any other change. The pinned commit is checked out in the clone, which SQL sessions see as a read-only database named {{.LessThan}}name{{.GreaterThan}}.`,
+	Synopsis: []string{
+		"{{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}} [{{.LessThan}}commit{{.GreaterThan}}]",
+	},
+}
+
+type AddCmd struct{}
+
+// Name implements cli.Command.
+func (cmd AddCmd) Name() string {
+	return "add"
+}
+
+// Description implements cli.Command.
+func (cmd AddCmd) Description() string {
+	return addDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd AddCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(addDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd AddCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 3)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the submodule, which is also the name of its database."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"url", "The url of the database to add, in any form accepted by dolt clone."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit of the database to pin the submodule to."})
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, addDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() < 2 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a name and a url are required").SetPrintUsage().Build(), usage)
+	}
+	name, url := apr.Arg(0), apr.Arg(1)
+	cSpecStr := "HEAD"
+	if apr.NArg() == 3 {
+		cSpecStr = apr.Arg(2)
+	}
+
+	root, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	submodules, err := doltdb.GetSubmodules(ctx, root)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	for _, sm := range submodules {
+		if sm.Name == name {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("submodule '%s' already exists", name).Build(), usage)
+		}
+	}
+
+	smEnv, err := actions.FetchSubmodule(ctx, dEnv, name, url)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	commit, err := actions.ResolveSubmoduleCommit(ctx, smEnv, cSpecStr)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: could not resolve '%s' in submodule '%s'", cSpecStr, name).AddCause(err).Build(), usage)
+	}
+	if err = actions.CheckoutSubmodule(ctx, smEnv, commit); err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	q, err := dbr.InterpolateForDialect(fmt.Sprintf("insert into %s values (?, ?, ?)", doltdb.SubmodulesTableName), []interface{}{name, url, commit.String()}, dialect.MySQL)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if _, err = cli.GetRowsForSql(queryist.Queryist, queryist.Context, q); err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	cli.Printf("Added submodule '%s' at %s\n", name, commit.String())
+	return 0
+}
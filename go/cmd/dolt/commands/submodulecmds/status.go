@@ -0,0 +1,91 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submodulecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var statusDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Show the state of submodules",
+	LongDesc: `Lists the submodules in the working set's {{.EmphasisLeft}}dolt_submodules{{.EmphasisRight}} table with the commit each is pinned to, its url, and a prefix describing its clone:
+
+{{.EmphasisLeft}}-{{.EmphasisRight}} the submodule has not been cloned.
+{{.EmphasisLeft}}+{{.EmphasisRight}} the clone has a different commit checked out.
+
+Run {{.EmphasisLeft}}dolt submodule update{{.EmphasisRight}} to check out the pinned commits.`,
+	Synopsis: []string{
+		"[{{.LessThan}}name{{.GreaterThan}}...]",
+	},
+}
+
+type StatusCmd struct{}
+
+// Name implements cli.Command.
+func (cmd StatusCmd) Name() string {
+	return "status"
+}
+
+// Description implements cli.Command.
+func (cmd StatusCmd) Description() string {
+	return statusDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd StatusCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(statusDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd StatusCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The submodules to show."})
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, statusDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	submodules, verr := selectSubmodules(ctx, dEnv, apr.Args)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	for _, sm := range submodules {
+		prefix := " "
+		smEnv, err := env.LoadSubmoduleEnv(ctx, dEnv, sm.Name)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		if smEnv == nil {
+			prefix = "-"
+		} else if head, err := actions.SubmoduleHead(ctx, smEnv); err != nil || head != sm.Commit {
+			prefix = "+"
+		}
+		cli.Printf("%s%s %s (%s)\n", prefix, sm.Commit.String(), sm.Name, sm.URL)
+	}
+	return 0
+}
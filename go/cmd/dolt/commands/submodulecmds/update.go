@@ -0,0 +1,114 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submodulecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var updateDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Check out the commits submodules are pinned to",
+	LongDesc:  `Clones each submodule listed in the working set's {{.EmphasisLeft}}dolt_submodules{{.EmphasisRight}} table, or fetches it if it has already been cloned, and checks out the commit it is pinned to. If {{.LessThan}}name{{.GreaterThan}}s are given, only those submodules are updated.`,
+	Synopsis: []string{
+		"[{{.LessThan}}name{{.GreaterThan}}...]",
+	},
+}
+
+type UpdateCmd struct{}
+
+// Name implements cli.Command.
+func (cmd UpdateCmd) Name() string {
+	return "update"
+}
+
+// Description implements cli.Command.
+func (cmd UpdateCmd) Description() string {
+	return updateDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd UpdateCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(updateDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd UpdateCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The submodules to update."})
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd UpdateCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, updateDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	submodules, verr := selectSubmodules(ctx, dEnv, apr.Args)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	for _, sm := range submodules {
+		smEnv, err := actions.FetchSubmodule(ctx, dEnv, sm.Name, sm.URL)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		if err = actions.CheckoutSubmodule(ctx, smEnv, sm.Commit); err != nil {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("error: failed to update submodule '%s'", sm.Name).AddCause(err).Build(), usage)
+		}
+		cli.Printf("Submodule '%s': checked out '%s'\n", sm.Name, sm.Commit.String())
+	}
+	return 0
+}
+
+// selectSubmodules returns the submodules in the working set of |dEnv| named in |names|, or all of them if |names| is
+// empty.
+func selectSubmodules(ctx context.Context, dEnv *env.DoltEnv, names []string) ([]doltdb.Submodule, errhand.VerboseError) {
+	root, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	submodules, err := doltdb.GetSubmodules(ctx, root)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	if len(names) == 0 {
+		return submodules, nil
+	}
+
+	byName := make(map[string]doltdb.Submodule, len(submodules))
+	for _, sm := range submodules {
+		byName[sm.Name] = sm
+	}
+	selected := make([]doltdb.Submodule, 0, len(names))
+	for _, name := range names {
+		sm, ok := byName[name]
+		if !ok {
+			return nil, errhand.BuildDError("error: no submodule named '%s'", name).Build()
+		}
+		selected = append(selected, sm)
+	}
+	return selected, nil
+}
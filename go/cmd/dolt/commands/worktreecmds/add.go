@@ -0,0 +1,110 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktreecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var addDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Check out a branch in a new directory",
+	LongDesc: `Creates a new worktree at {{.LessThan}}path{{.GreaterThan}} with {{.LessThan}}branch{{.GreaterThan}} checked out. The worktree shares this repository's database, so commits, branches and tags made in either are visible in both, but it has its own current branch and working set.
+
+A branch can only be checked out in one worktree at a time. With {{.EmphasisLeft}}-b{{.EmphasisRight}}, a new branch is created from {{.LessThan}}branch{{.GreaterThan}}, or from HEAD if it is omitted, and checked out in the worktree.
+
+Remotes and branch tracking configuration are copied into the new worktree.`,
+	Synopsis: []string{
+		"{{.LessThan}}path{{.GreaterThan}} {{.LessThan}}branch{{.GreaterThan}}",
+		"-b {{.LessThan}}new_branch{{.GreaterThan}} {{.LessThan}}path{{.GreaterThan}} [{{.LessThan}}start_point{{.GreaterThan}}]",
+	},
+}
+
+type AddCmd struct{}
+
+// Name implements cli.Command.
+func (cmd AddCmd) Name() string {
+	return "add"
+}
+
+// Description implements cli.Command.
+func (cmd AddCmd) Description() string {
+	return addDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd AddCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(addDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd AddCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"path", "The directory to create the worktree in. It must not exist, or be empty."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"branch", "The branch to check out in the worktree."})
+	ap.SupportsString(cli.CheckoutCreateBranch, "", "new_branch", "Create a new branch and check it out in the worktree.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, addDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	newBranch, creating := apr.GetValue(cli.CheckoutCreateBranch)
+	if apr.NArg() == 0 || (!creating && apr.NArg() != 2) {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a path and a branch are required").SetPrintUsage().Build(), usage)
+	}
+	path := apr.Arg(0)
+
+	var branchName string
+	if creating {
+		startPt := "HEAD"
+		if apr.NArg() == 2 {
+			startPt = apr.Arg(1)
+		}
+		err := actions.CreateBranchWithStartPt(ctx, dEnv.DbData(ctx), newBranch, startPt, false, nil)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		branchName = newBranch
+	} else {
+		branchName = apr.Arg(1)
+		ok, err := actions.IsBranch(ctx, dEnv.DoltDB(ctx), branchName)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		if !ok {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("branch '%s' not found", branchName).Build(), usage)
+		}
+	}
+
+	absPath, err := env.AddWorktree(dEnv.FS, dEnv.RepoState, path, ref.NewBranchRef(branchName))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	cli.Printf("Checked out branch '%s' in worktree %s\n", branchName, absPath)
+	return 0
+}
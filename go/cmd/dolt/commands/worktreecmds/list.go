@@ -0,0 +1,97 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktreecmds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var listDocs = cli.CommandDocumentationContent{
+	ShortDesc: "List the worktrees of this repository",
+	LongDesc:  `Lists the repository's own directory, followed by each worktree added with {{.EmphasisLeft}}dolt worktree add{{.EmphasisRight}}, with the commit and branch checked out in it. Worktrees whose directory can no longer be read are marked as missing.`,
+	Synopsis:  []string{""},
+}
+
+type ListCmd struct{}
+
+// Name implements cli.Command.
+func (cmd ListCmd) Name() string {
+	return "list"
+}
+
+// Description implements cli.Command.
+func (cmd ListCmd) Description() string {
+	return listDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd ListCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(listDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd ListCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+// Exec implements cli.Command.
+func (cmd ListCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, listDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	worktrees, err := env.ListWorktrees(dEnv.FS)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	width := 0
+	for _, wt := range worktrees {
+		width = max(width, len(wt.Path))
+	}
+	for _, wt := range worktrees {
+		desc, err := describeHead(ctx, dEnv, wt.Head)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		cli.Printf("%-*s  %s\n", width, wt.Path, desc)
+	}
+	return 0
+}
+
+// describeHead returns the commit a worktree has checked out, followed by its branch.
+func describeHead(ctx context.Context, dEnv *env.DoltEnv, head ref.DoltRef) (string, error) {
+	if head == nil {
+		return "(missing)", nil
+	}
+	cm, err := dEnv.DoltDB(ctx).ResolveCommitRef(ctx, head)
+	if err != nil {
+		return fmt.Sprintf("(unknown) [%s]", head.GetPath()), nil
+	}
+	h, err := cm.HashOf()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s [%s]", h.String(), head.GetPath()), nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktreecmds
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var removeDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Delete a worktree",
+	LongDesc: `Deletes the directory of a worktree added with {{.EmphasisLeft}}dolt worktree add{{.EmphasisRight}}, and forgets it. The branch it had checked out is kept, but its working set must not have uncommitted changes unless {{.EmphasisLeft}}--force{{.EmphasisRight}} is given.
+
+A worktree whose directory was already deleted is forgotten.`,
+	Synopsis: []string{
+		"[-f] {{.LessThan}}path{{.GreaterThan}}",
+	},
+}
+
+type RemoveCmd struct{}
+
+// Name implements cli.Command.
+func (cmd RemoveCmd) Name() string {
+	return "remove"
+}
+
+// Description implements cli.Command.
+func (cmd RemoveCmd) Description() string {
+	return removeDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd RemoveCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(removeDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd RemoveCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"path", "The directory of the worktree."})
+	ap.SupportsFlag(cli.ForceFlag, "f", "Remove the worktree even if it has uncommitted changes.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd RemoveCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, removeDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a worktree path is required").SetPrintUsage().Build(), usage)
+	}
+
+	absPath, err := dEnv.FS.Abs(apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	current, err := dEnv.FS.Abs(".")
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if absPath == current {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("cannot remove the current worktree").Build(), usage)
+	}
+
+	worktrees, err := env.ListWorktrees(dEnv.FS)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	for _, wt := range worktrees {
+		if wt.Path != absPath {
+			continue
+		}
+		if wt.Main {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("cannot remove the main worktree").Build(), usage)
+		}
+		if wt.Head != nil && !apr.Contains(cli.ForceFlag) {
+			dirty, err := hasUncommittedChanges(ctx, dEnv.DoltDB(ctx), wt.Head)
+			if err != nil {
+				return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+			}
+			if dirty {
+				return commands.HandleVErrAndExitCode(errhand.BuildDError("'%s' has uncommitted changes, use --force to remove it anyway", apr.Arg(0)).Build(), usage)
+			}
+		}
+	}
+
+	if err = env.RemoveWorktree(dEnv.FS, apr.Arg(0)); err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	return 0
+}
+
+// hasUncommittedChanges returns whether the working set of |head| differs from the commit it points at.
+func hasUncommittedChanges(ctx context.Context, ddb *doltdb.DoltDB, head ref.DoltRef) (bool, error) {
+	wsRef, err := ref.WorkingSetRefForHead(head)
+	if err != nil {
+		return false, err
+	}
+	ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+	if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	cm, err := ddb.ResolveCommitRef(ctx, head)
+	if err != nil {
+		return false, err
+	}
+	headRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return false, err
+	}
+	headHash, err := headRoot.HashOf()
+	if err != nil {
+		return false, err
+	}
+	workingHash, err := ws.WorkingRoot().HashOf()
+	if err != nil {
+		return false, err
+	}
+	stagedHash, err := ws.StagedRoot().HashOf()
+	if err != nil {
+		return false, err
+	}
+	return workingHash != headHash || stagedHash != headHash, nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/tidwall/gjson"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
@@ -307,6 +308,7 @@ func runMain() int {
 						)),
 					)
 					otel.SetTracerProvider(tp)
+					otel.SetTextMapPropagator(propagation.TraceContext{})
 					defer tp.Shutdown(context.Background())
 					args = args[1:]
 				}
@@ -280,6 +280,209 @@ func (*DropDatabaseResponse) Descriptor() ([]byte, []int) {
 	return file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescGZIP(), []int{5}
 }
 
+type HeartbeatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True if every database the primary replicates to this standby was caught
+	// up when the heartbeat was sent. Only a standby which was caught up as of
+	// the last heartbeat it received may stand for election.
+	CaughtUp bool `protobuf:"varint,1,opt,name=caught_up,json=caughtUp,proto3" json:"caught_up,omitempty"`
+	// Increases with every heartbeat the primary sends at its epoch. A standby
+	// records the epoch and position of the last heartbeat at which it was
+	// caught up, and only votes for candidates at least as far along.
+	Position      int64 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HeartbeatRequest) GetCaughtUp() bool {
+	if x != nil {
+		return x.CaughtUp
+	}
+	return false
+}
+
+func (x *HeartbeatRequest) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescGZIP(), []int{7}
+}
+
+type RequestVoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The epoch the candidate will become primary at if it wins the election.
+	Epoch int64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// The epoch and position of the last heartbeat at which the candidate was
+	// caught up. A member only votes for a candidate which is at least as far
+	// along as itself.
+	CaughtUpEpoch    int64 `protobuf:"varint,2,opt,name=caught_up_epoch,json=caughtUpEpoch,proto3" json:"caught_up_epoch,omitempty"`
+	CaughtUpPosition int64 `protobuf:"varint,3,opt,name=caught_up_position,json=caughtUpPosition,proto3" json:"caught_up_position,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RequestVoteRequest) Reset() {
+	*x = RequestVoteRequest{}
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVoteRequest) ProtoMessage() {}
+
+func (x *RequestVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVoteRequest.ProtoReflect.Descriptor instead.
+func (*RequestVoteRequest) Descriptor() ([]byte, []int) {
+	return file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RequestVoteRequest) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *RequestVoteRequest) GetCaughtUpEpoch() int64 {
+	if x != nil {
+		return x.CaughtUpEpoch
+	}
+	return 0
+}
+
+func (x *RequestVoteRequest) GetCaughtUpPosition() int64 {
+	if x != nil {
+		return x.CaughtUpPosition
+	}
+	return 0
+}
+
+type RequestVoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True if the member voted for the candidate at the requested epoch.
+	VoteGranted   bool `protobuf:"varint,1,opt,name=vote_granted,json=voteGranted,proto3" json:"vote_granted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestVoteResponse) Reset() {
+	*x = RequestVoteResponse{}
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVoteResponse) ProtoMessage() {}
+
+func (x *RequestVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVoteResponse.ProtoReflect.Descriptor instead.
+func (*RequestVoteResponse) Descriptor() ([]byte, []int) {
+	return file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RequestVoteResponse) GetVoteGranted() bool {
+	if x != nil {
+		return x.VoteGranted
+	}
+	return false
+}
+
 var File_dolt_services_replicationapi_v1alpha1_replication_proto protoreflect.FileDescriptor
 
 const file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDesc = "" +
@@ -293,11 +496,23 @@ const file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDesc = ""
 	"\x1bUpdateBranchControlResponse\")\n" +
 	"\x13DropDatabaseRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"\x16\n" +
-	"\x14DropDatabaseResponse2\xdf\x03\n" +
+	"\x14DropDatabaseResponse\"K\n" +
+	"\x10HeartbeatRequest\x12\x1b\n" +
+	"\tcaught_up\x18\x01 \x01(\bR\bcaughtUp\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x03R\bposition\"\x13\n" +
+	"\x11HeartbeatResponse\"\x80\x01\n" +
+	"\x12RequestVoteRequest\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12&\n" +
+	"\x0fcaught_up_epoch\x18\x02 \x01(\x03R\rcaughtUpEpoch\x12,\n" +
+	"\x12caught_up_position\x18\x03 \x01(\x03R\x10caughtUpPosition\"8\n" +
+	"\x13RequestVoteResponse\x12!\n" +
+	"\fvote_granted\x18\x01 \x01(\bR\vvoteGranted2\xe6\x05\n" +
 	"\x12ReplicationService\x12\x9f\x01\n" +
 	"\x14UpdateUsersAndGrants\x12B.dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsRequest\x1aC.dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsResponse\x12\x9c\x01\n" +
 	"\x13UpdateBranchControl\x12A.dolt.services.replicationapi.v1alpha1.UpdateBranchControlRequest\x1aB.dolt.services.replicationapi.v1alpha1.UpdateBranchControlResponse\x12\x87\x01\n" +
-	"\fDropDatabase\x12:.dolt.services.replicationapi.v1alpha1.DropDatabaseRequest\x1a;.dolt.services.replicationapi.v1alpha1.DropDatabaseResponseB[ZYgithub.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1;replicationapib\x06proto3"
+	"\fDropDatabase\x12:.dolt.services.replicationapi.v1alpha1.DropDatabaseRequest\x1a;.dolt.services.replicationapi.v1alpha1.DropDatabaseResponse\x12~\n" +
+	"\tHeartbeat\x127.dolt.services.replicationapi.v1alpha1.HeartbeatRequest\x1a8.dolt.services.replicationapi.v1alpha1.HeartbeatResponse\x12\x84\x01\n" +
+	"\vRequestVote\x129.dolt.services.replicationapi.v1alpha1.RequestVoteRequest\x1a:.dolt.services.replicationapi.v1alpha1.RequestVoteResponseB[ZYgithub.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1;replicationapib\x06proto3"
 
 var (
 	file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescOnce sync.Once
@@ -311,7 +526,7 @@ func file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescGZIP()
 	return file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDescData
 }
 
-var file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_dolt_services_replicationapi_v1alpha1_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_dolt_services_replicationapi_v1alpha1_replication_proto_goTypes = []any{
 	(*UpdateUsersAndGrantsRequest)(nil),  // 0: dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsRequest
 	(*UpdateUsersAndGrantsResponse)(nil), // 1: dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsResponse
@@ -319,16 +534,24 @@ var file_dolt_services_replicationapi_v1alpha1_replication_proto_goTypes = []any
 	(*UpdateBranchControlResponse)(nil),  // 3: dolt.services.replicationapi.v1alpha1.UpdateBranchControlResponse
 	(*DropDatabaseRequest)(nil),          // 4: dolt.services.replicationapi.v1alpha1.DropDatabaseRequest
 	(*DropDatabaseResponse)(nil),         // 5: dolt.services.replicationapi.v1alpha1.DropDatabaseResponse
+	(*HeartbeatRequest)(nil),             // 6: dolt.services.replicationapi.v1alpha1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),            // 7: dolt.services.replicationapi.v1alpha1.HeartbeatResponse
+	(*RequestVoteRequest)(nil),           // 8: dolt.services.replicationapi.v1alpha1.RequestVoteRequest
+	(*RequestVoteResponse)(nil),          // 9: dolt.services.replicationapi.v1alpha1.RequestVoteResponse
 }
 var file_dolt_services_replicationapi_v1alpha1_replication_proto_depIdxs = []int32{
 	0, // 0: dolt.services.replicationapi.v1alpha1.ReplicationService.UpdateUsersAndGrants:input_type -> dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsRequest
 	2, // 1: dolt.services.replicationapi.v1alpha1.ReplicationService.UpdateBranchControl:input_type -> dolt.services.replicationapi.v1alpha1.UpdateBranchControlRequest
 	4, // 2: dolt.services.replicationapi.v1alpha1.ReplicationService.DropDatabase:input_type -> dolt.services.replicationapi.v1alpha1.DropDatabaseRequest
-	1, // 3: dolt.services.replicationapi.v1alpha1.ReplicationService.UpdateUsersAndGrants:output_type -> dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsResponse
-	3, // 4: dolt.services.replicationapi.v1alpha1.ReplicationService.UpdateBranchControl:output_type -> dolt.services.replicationapi.v1alpha1.UpdateBranchControlResponse
-	5, // 5: dolt.services.replicationapi.v1alpha1.ReplicationService.DropDatabase:output_type -> dolt.services.replicationapi.v1alpha1.DropDatabaseResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
+	6, // 3: dolt.services.replicationapi.v1alpha1.ReplicationService.Heartbeat:input_type -> dolt.services.replicationapi.v1alpha1.HeartbeatRequest
+	8, // 4: dolt.services.replicationapi.v1alpha1.ReplicationService.RequestVote:input_type -> dolt.services.replicationapi.v1alpha1.RequestVoteRequest
+	1, // 5: dolt.services.replicationapi.v1alpha1.ReplicationService.UpdateUsersAndGrants:output_type -> dolt.services.replicationapi.v1alpha1.UpdateUsersAndGrantsResponse
+	3, // 6: dolt.services.replicationapi.v1alpha1.ReplicationService.UpdateBranchControl:output_type -> dolt.services.replicationapi.v1alpha1.UpdateBranchControlResponse
+	5, // 7: dolt.services.replicationapi.v1alpha1.ReplicationService.DropDatabase:output_type -> dolt.services.replicationapi.v1alpha1.DropDatabaseResponse
+	7, // 8: dolt.services.replicationapi.v1alpha1.ReplicationService.Heartbeat:output_type -> dolt.services.replicationapi.v1alpha1.HeartbeatResponse
+	9, // 9: dolt.services.replicationapi.v1alpha1.ReplicationService.RequestVote:output_type -> dolt.services.replicationapi.v1alpha1.RequestVoteResponse
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -345,7 +568,7 @@ func file_dolt_services_replicationapi_v1alpha1_replication_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDesc), len(file_dolt_services_replicationapi_v1alpha1_replication_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
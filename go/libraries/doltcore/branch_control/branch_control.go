@@ -239,9 +239,6 @@ func CheckAccess(ctx context.Context, flags Permissions) error {
 	if controller == nil {
 		return ErrMissingController.New()
 	}
-	controller.Access.RWMutex.RLock()
-	defer controller.Access.RWMutex.RUnlock()
-
 	user := branchAwareSession.GetUser()
 	host := branchAwareSession.GetHost()
 	database := getDatabaseNameOnly(branchAwareSession.GetCurrentDatabase())
@@ -250,9 +247,19 @@ func CheckAccess(ctx context.Context, flags Permissions) error {
 		return err
 	}
 	// Get the permissions for the branch, user, and host combination
+	controller.Access.RWMutex.RLock()
 	_, perms := controller.Access.Match(database, branch, user, host)
+	controller.Access.RWMutex.RUnlock()
 	// If either the flags match or the user is an admin for this branch, then we allow access
-	if (perms&flags == flags) || (perms&Permissions_Admin == Permissions_Admin) {
+	if ObserveDecision(ctx, Decision{
+		Action:      DecisionAccess,
+		Database:    database,
+		Branch:      branch,
+		User:        user,
+		Host:        host,
+		Permissions: flags,
+		Allowed:     (perms&flags == flags) || (perms&Permissions_Admin == Permissions_Admin),
+	}) {
 		return nil
 	}
 	return ErrIncorrectPermissions.New(user, host, branch)
@@ -273,13 +280,20 @@ func CanCreateBranch(ctx context.Context, branchName string) error {
 	if controller == nil {
 		return ErrMissingController.New()
 	}
-	controller.Namespace.RWMutex.RLock()
-	defer controller.Namespace.RWMutex.RUnlock()
-
 	user := branchAwareSession.GetUser()
 	host := branchAwareSession.GetHost()
 	database := getDatabaseNameOnly(branchAwareSession.GetCurrentDatabase())
-	if controller.Namespace.CanCreate(database, branchName, user, host) {
+	controller.Namespace.RWMutex.RLock()
+	canCreate := controller.Namespace.CanCreate(database, branchName, user, host)
+	controller.Namespace.RWMutex.RUnlock()
+	if ObserveDecision(ctx, Decision{
+		Action:   DecisionCreate,
+		Database: database,
+		Branch:   branchName,
+		User:     user,
+		Host:     host,
+		Allowed:  canCreate,
+	}) {
 		return nil
 	}
 	return ErrCannotCreateBranch.New(user, host, branchName)
@@ -300,16 +314,23 @@ func CanDeleteBranch(ctx context.Context, branchName string) error {
 	if controller == nil {
 		return ErrMissingController.New()
 	}
-	controller.Access.RWMutex.RLock()
-	defer controller.Access.RWMutex.RUnlock()
-
 	user := branchAwareSession.GetUser()
 	host := branchAwareSession.GetHost()
 	database := getDatabaseNameOnly(branchAwareSession.GetCurrentDatabase())
 	// Get the permissions for the branch, user, and host combination
+	controller.Access.RWMutex.RLock()
 	_, perms := controller.Access.Match(database, branchName, user, host)
+	controller.Access.RWMutex.RUnlock()
 	// If the user has the write or admin flags, then we allow access
-	if (perms&Permissions_Write == Permissions_Write) || (perms&Permissions_Admin == Permissions_Admin) {
+	if ObserveDecision(ctx, Decision{
+		Action:      DecisionDelete,
+		Database:    database,
+		Branch:      branchName,
+		User:        user,
+		Host:        host,
+		Permissions: Permissions_Write,
+		Allowed:     (perms&Permissions_Write == Permissions_Write) || (perms&Permissions_Admin == Permissions_Admin),
+	}) {
 		return nil
 	}
 	return ErrCannotDeleteBranch.New(user, host, branchName)
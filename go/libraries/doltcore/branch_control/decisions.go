@@ -0,0 +1,75 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branch_control
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// DecisionAction is the kind of check a Decision was made for.
+type DecisionAction string
+
+const (
+	DecisionAccess DecisionAction = "access"
+	DecisionCreate DecisionAction = "create"
+	DecisionDelete DecisionAction = "delete"
+)
+
+// Decision is the outcome of CheckAccess, CanCreateBranch, CanDeleteBranch or dsess.CheckAccessForDb for a SQL
+// session.
+type Decision struct {
+	Action   DecisionAction
+	Database string
+	Branch   string
+	User     string
+	Host     string
+	// Permissions are the permissions the check required. They are Permissions_None for DecisionCreate.
+	Permissions Permissions
+	Allowed     bool
+}
+
+// DecisionObserver is notified of the branch control decisions made for a SQL session that deny access, and of every
+// branch create and delete decision. Allowed access checks are made for every write, so they are not reported. Checks
+// made without a session, such as those from CLI commands, always pass and are not reported either. sql-server
+// installs one with SetDecisionObserver to write decisions to its audit log.
+type DecisionObserver interface {
+	BranchControlDecision(ctx context.Context, decision Decision)
+}
+
+var decisionObserver atomic.Pointer[DecisionObserver]
+
+// SetDecisionObserver installs |observer| to be notified of branch control decisions. A nil |observer| removes the
+// current one.
+func SetDecisionObserver(observer DecisionObserver) {
+	if observer == nil {
+		decisionObserver.Store(nil)
+		return
+	}
+	decisionObserver.Store(&observer)
+}
+
+// ObserveDecision reports |decision| to the installed DecisionObserver, if any, and returns whether it was allowed.
+// Every branch control check made for a SQL session must report its decision through it, including checks made
+// outside this package. Observers may block, so it must not be called while holding a controller's locks.
+func ObserveDecision(ctx context.Context, decision Decision) bool {
+	if decision.Allowed && decision.Action == DecisionAccess {
+		return true
+	}
+	if observer := decisionObserver.Load(); observer != nil {
+		(*observer).BranchControlDecision(ctx, decision)
+	}
+	return decision.Allowed
+}
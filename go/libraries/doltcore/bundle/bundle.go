@@ -0,0 +1,531 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle reads and writes bundle files, which carry refs and the history needed to complete them from one
+// database to another without a remote. A bundle is a single file holding a header, which lists the refs it carries
+// and the prerequisite commits the receiving database must already have, followed by a table file holding every
+// chunk reachable from the refs that is not reachable from the prerequisites.
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Version is the version of the bundle format written by Create. Bundles with a newer version can't be read.
+const Version = 1
+
+// signature is the first line of every bundle file.
+var signature = []byte("# dolt bundle\n")
+
+var ErrNotABundle = errors.New("not a dolt bundle")
+var ErrEmptyBundle = errors.New("refusing to create an empty bundle")
+var ErrMissingPrerequisites = errors.New("the database is missing prerequisite commits of the bundle")
+var ErrCorruptBundle = errors.New("bundle is corrupt")
+
+// Header describes the contents of a bundle.
+type Header struct {
+	Version int `json:"version"`
+	// Format is the storage format of the database the bundle was created from
+	Format string `json:"format"`
+	Refs   []Ref  `json:"refs"`
+	// Prerequisites are the commits the bundle was created relative to. Chunks reachable from them are not in the
+	// bundle, so they must exist in any database the bundle is applied to.
+	Prerequisites []string   `json:"prerequisites,omitempty"`
+	TableFile     *TableFile `json:"table_file,omitempty"`
+}
+
+// Ref is a ref carried by a bundle, and the address it points to.
+type Ref struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+// DoltRef returns the parsed ref.
+func (r Ref) DoltRef() (ref.DoltRef, error) {
+	return ref.Parse(r.Name)
+}
+
+// TableFile describes the table file which follows the header of a bundle.
+type TableFile struct {
+	ID              string `json:"id"`
+	NumChunks       int    `json:"num_chunks"`
+	ChunkDataLength uint64 `json:"chunk_data_length"`
+	Size            uint64 `json:"size"`
+	MD5             []byte `json:"md5"`
+}
+
+// Create writes a bundle of |refs| to |path|, containing the chunks reachable from them which are not reachable from
+// |prerequisites|. The table file is built in |tempDir|.
+func Create(ctx context.Context, ddb *doltdb.DoltDB, path, tempDir string, refs []ref.DoltRef, prerequisites []hash.Hash) (*Header, error) {
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, fmt.Errorf("bundles are not supported for the %s storage format", ddb.Format().VersionString())
+	}
+
+	header := &Header{Version: Version, Format: ddb.Format().VersionString()}
+	for _, p := range prerequisites {
+		header.Prerequisites = append(header.Prerequisites, p.String())
+	}
+
+	refAddrs, err := resolveRefs(ctx, ddb, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var heads []hash.Hash
+	var roots []hash.Hash
+	for _, r := range refs {
+		addr := refAddrs[r.String()]
+		header.Refs = append(header.Refs, Ref{Name: r.String(), Hash: addr.String()})
+		roots = append(roots, addr)
+		if tr, ok := r.(ref.TagRef); ok {
+			tag, err := ddb.ResolveTag(ctx, tr)
+			if err != nil {
+				return nil, err
+			}
+			addr, err = tag.Commit.HashOf()
+			if err != nil {
+				return nil, err
+			}
+		}
+		heads = append(heads, addr)
+	}
+
+	commits, err := commitwalk.GetDotDotRevisions(ctx, ddb, heads, ddb, prerequisites, -1)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmt := range commits {
+		roots = append(roots, cmt.Addr)
+	}
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	walkAddrs := types.WalkAddrsForNBF(ddb.Format(), nil)
+
+	// The prerequisites' histories are not walked, so chunks only reachable from older commits are included in the
+	// bundle. They are redundant, but keep the bundle correct without walking the entire history.
+	excluded := hash.NewHashSet()
+	err = walkChunks(ctx, cs, walkAddrs, prerequisites, excluded, func(chunks.Chunk) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := nbs.NewCmpChunkTableWriter(tempDir)
+	if err != nil {
+		return nil, err
+	}
+	defer wr.Remove()
+
+	err = walkChunks(ctx, cs, walkAddrs, roots, excluded, func(c chunks.Chunk) error {
+		_, err := wr.AddChunk(nbs.ChunkToCompressedChunk(c))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if wr.ChunkCount() == 0 {
+		return nil, ErrEmptyBundle
+	}
+
+	_, id, err := wr.Finish()
+	if err != nil {
+		return nil, err
+	}
+	chunkDataLength, err := wr.ChunkDataLength()
+	if err != nil {
+		return nil, err
+	}
+	header.TableFile = &TableFile{
+		ID:              id,
+		NumChunks:       wr.ChunkCount(),
+		ChunkDataLength: chunkDataLength,
+		Size:            wr.FullLength(),
+		MD5:             wr.GetMD5(),
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(f)
+	err = writeHeader(bw, header)
+	if err == nil {
+		err = wr.Flush(bw)
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return header, nil
+}
+
+// resolveRefs returns the addresses |refs| point to, keyed by ref string.
+func resolveRefs(ctx context.Context, ddb *doltdb.DoltDB, refs []ref.DoltRef) (map[string]hash.Hash, error) {
+	addrs := make(map[string]hash.Hash)
+	err := ddb.VisitRefsOfType(ctx, ref.HeadRefTypes, func(r ref.DoltRef, addr hash.Hash) error {
+		addrs[r.String()] = addr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range refs {
+		if _, ok := addrs[r.String()]; !ok {
+			return nil, fmt.Errorf("ref %s does not exist", r.String())
+		}
+	}
+	return addrs, nil
+}
+
+// walkChunks calls |cb| for every chunk reachable from |roots| which is not in |visited|, adding it to |visited|. The
+// walk does not follow references to commits other than |roots|, so the history behind them is not walked.
+func walkChunks(ctx context.Context, cs chunks.ChunkStore, walkAddrs func(chunks.Chunk, func(hash.Hash, bool) error) error, roots []hash.Hash, visited hash.HashSet, cb func(chunks.Chunk) error) error {
+	isRoot := hash.NewHashSet(roots...)
+	next := hash.NewHashSet()
+	for _, h := range roots {
+		if !visited.Has(h) {
+			next.Insert(h)
+		}
+	}
+
+	for next.Size() > 0 {
+		// each chunk is passed to |cb| as it is read, so only the addresses of the next level are held
+		batch := next
+		next = hash.NewHashSet()
+		var mu sync.Mutex
+		var cbErr error
+		found := 0
+		err := cs.GetMany(ctx, batch, func(_ context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			found++
+			if cbErr != nil {
+				return
+			}
+			if !isRoot.Has(c.Hash()) && serial.GetFileID(c.Data()) == serial.CommitFileID {
+				// commits other than the roots are history which is not part of the walk
+				return
+			}
+			visited.Insert(c.Hash())
+			if cbErr = cb(*c); cbErr != nil {
+				return
+			}
+			cbErr = walkAddrs(*c, func(h hash.Hash, _ bool) error {
+				if !visited.Has(h) && !batch.Has(h) {
+					next.Insert(h)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+		if cbErr != nil {
+			return cbErr
+		}
+		if found != batch.Size() {
+			return fmt.Errorf("%d chunks are missing from the database", batch.Size()-found)
+		}
+	}
+	return nil
+}
+
+func writeHeader(w io.Writer, header *Header) error {
+	data, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(signature); err != nil {
+		return err
+	}
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(data)))
+	if _, err = w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadHeader reads the header of the bundle at |path|, and returns it with the offset of the table file in the bundle.
+func ReadHeader(path string) (*Header, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	sig := make([]byte, len(signature))
+	if _, err = io.ReadFull(f, sig); err != nil || !bytes.Equal(sig, signature) {
+		return nil, 0, fmt.Errorf("%s: %w", path, ErrNotABundle)
+	}
+	var size [8]byte
+	if _, err = io.ReadFull(f, size[:]); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", path, ErrCorruptBundle)
+	}
+	n := binary.BigEndian.Uint64(size[:])
+	if n > 1<<30 {
+		return nil, 0, fmt.Errorf("%s: %w", path, ErrCorruptBundle)
+	}
+	data := make([]byte, n)
+	if _, err = io.ReadFull(f, data); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", path, ErrCorruptBundle)
+	}
+
+	var header Header
+	if err = json.Unmarshal(data, &header); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w: %s", path, ErrCorruptBundle, err.Error())
+	}
+	if header.Version < 1 || header.Version > Version {
+		return nil, 0, fmt.Errorf("%s: unsupported bundle version %d", path, header.Version)
+	}
+	return &header, int64(len(signature)) + 8 + int64(n), nil
+}
+
+// IsBundle returns whether the file at |path| is a bundle.
+func IsBundle(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	sig := make([]byte, len(signature))
+	_, err = io.ReadFull(f, sig)
+	return err == nil && bytes.Equal(sig, signature)
+}
+
+// Verify checks that the bundle at |path| is intact and can be applied to |ddb|, and returns its header. If
+// prerequisite commits are missing from |ddb|, the error wraps ErrMissingPrerequisites and the header is returned.
+func Verify(ctx context.Context, ddb *doltdb.DoltDB, path string) (*Header, error) {
+	header, offset, err := ReadHeader(path)
+	if err != nil {
+		return nil, err
+	}
+	if header.Format != ddb.Format().VersionString() {
+		return nil, fmt.Errorf("bundle has storage format %s, but the database has storage format %s", header.Format, ddb.Format().VersionString())
+	}
+	if header.TableFile == nil {
+		return nil, fmt.Errorf("%s: %w: no table file", path, ErrCorruptBundle)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sum := md5.New()
+	n, err := io.Copy(sum, io.NewSectionReader(f, offset, int64(header.TableFile.Size)))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(n) != header.TableFile.Size || !bytes.Equal(sum.Sum(nil), header.TableFile.MD5) {
+		return nil, fmt.Errorf("%s: %w: table file checksum mismatch", path, ErrCorruptBundle)
+	}
+
+	if len(header.Prerequisites) > 0 {
+		prereqs := hash.NewHashSet()
+		for _, p := range header.Prerequisites {
+			h, ok := hash.MaybeParse(p)
+			if !ok {
+				return nil, fmt.Errorf("%s: %w: invalid prerequisite %s", path, ErrCorruptBundle, p)
+			}
+			prereqs.Insert(h)
+		}
+		cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+		absent, err := cs.HasMany(ctx, prereqs)
+		if err != nil {
+			return nil, err
+		}
+		if absent.Size() > 0 {
+			missing := make([]string, 0, absent.Size())
+			for _, p := range header.Prerequisites {
+				if absent.Has(hash.Parse(p)) {
+					missing = append(missing, p)
+				}
+			}
+			return header, fmt.Errorf("%w: %v", ErrMissingPrerequisites, missing)
+		}
+	}
+	return header, nil
+}
+
+// Unbundle verifies the bundle at |path| and adds its chunks to |ddb|. Refs are not updated; the caller decides which
+// of the bundle's refs to update.
+func Unbundle(ctx context.Context, ddb *doltdb.DoltDB, path string) (*Header, error) {
+	header, err := Verify(ctx, ddb, path)
+	if err != nil {
+		return nil, err
+	}
+	_, offset, err := ReadHeader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	tfs, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return nil, errors.New("the database does not support adding table files")
+	}
+
+	tf := header.TableFile
+	err = tfs.WriteTableFile(ctx, tf.ID, tf.ChunkDataLength, tf.NumChunks, tf.MD5, func() (io.ReadCloser, uint64, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		return sectionReadCloser{io.NewSectionReader(f, offset, int64(tf.Size)), f}, tf.Size, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	walkAddrs := types.WalkAddrsForNBF(ddb.Format(), nil)
+	getAddrs := func(c chunks.Chunk) chunks.GetAddrsCb {
+		return func(ctx context.Context, addrs hash.HashSet, exists chunks.PendingRefExists) error {
+			return walkAddrs(c, func(h hash.Hash, _ bool) error {
+				if !exists(h) {
+					addrs.Insert(h)
+				}
+				return nil
+			})
+		}
+	}
+	err = tfs.AddTableFilesToManifest(ctx, map[string]int{tf.ID: tf.NumChunks}, getAddrs)
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+type sectionReadCloser struct {
+	*io.SectionReader
+	io.Closer
+}
+
+// DefaultFetchRefSpec maps the branches of a bundle to remote-tracking branches when fetching from it.
+const DefaultFetchRefSpec = "refs/heads/*:refs/remotes/bundle/*"
+
+// ErrCantFF is returned by Fetch when a local branch can't be fast-forwarded to a branch of the bundle.
+var ErrCantFF = errors.New("can't fast forward merge")
+
+// Fetch unbundles the bundle at |path| into |ddb| and updates the refs |refSpecs| map the bundle's branches to.
+// Remote-tracking branches are always updated, while local branches are only fast-forwarded, and are left alone if
+// they are already ahead of the bundle. Tags carried by the
+// bundle are created if they don't already exist. Returns the refs which were updated, in the order of the bundle.
+func Fetch(ctx context.Context, ddb *doltdb.DoltDB, path string, refSpecs []ref.RefSpec) ([]doltdb.RefWithHash, error) {
+	header, err := Unbundle(ctx, ddb, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []doltdb.RefWithHash
+	for _, r := range header.Refs {
+		src, err := r.DoltRef()
+		if err != nil {
+			return nil, err
+		}
+		addr, ok := hash.MaybeParse(r.Hash)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w: invalid hash for ref %s", path, ErrCorruptBundle, r.Name)
+		}
+
+		if tr, ok := src.(ref.TagRef); ok {
+			_, exists, err := ddb.HasTag(ctx, tr.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				if err = ddb.SetHead(ctx, tr, addr); err != nil {
+					return nil, err
+				}
+				updated = append(updated, doltdb.RefWithHash{Ref: tr, Hash: addr})
+			}
+			continue
+		}
+		if src.GetType() != ref.BranchRefType {
+			continue
+		}
+
+		for _, rs := range refSpecs {
+			dest := rs.DestRef(src)
+			if dest == nil {
+				continue
+			}
+			if err = updateRef(ctx, ddb, dest, addr); err != nil {
+				return nil, err
+			}
+			updated = append(updated, doltdb.RefWithHash{Ref: dest, Hash: addr})
+		}
+	}
+	return updated, nil
+}
+
+// updateRef points |dest| at the commit |addr|. Local branches are only fast-forwarded.
+func updateRef(ctx context.Context, ddb *doltdb.DoltDB, dest ref.DoltRef, addr hash.Hash) error {
+	optCmt, err := ddb.ReadCommit(ctx, addr)
+	if err != nil {
+		return err
+	}
+	commit, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+
+	if dest.GetType() != ref.BranchRefType {
+		return ddb.SetHeadToCommit(ctx, dest, commit)
+	}
+
+	_, exists, err := ddb.HasBranch(ctx, dest.GetPath())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ddb.NewBranchAtCommit(ctx, dest, commit, nil)
+	}
+	canFF, err := ddb.CanFastForward(ctx, dest, commit)
+	if errors.Is(err, doltdb.ErrUpToDate) || errors.Is(err, doltdb.ErrIsAhead) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%w: %s", ErrCantFF, err.Error())
+	}
+	if !canFF {
+		return fmt.Errorf("%w: %s", ErrCantFF, dest.GetPath())
+	}
+	return ddb.FastForward(ctx, dest, commit)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	main := ref.NewBranchRef("main")
+
+	src := loadDB(t, filepath.Join(dir, "src"))
+	require.NoError(t, src.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	first := commitTo(t, src, main, "first")
+
+	// a bundle with no prerequisites populates an empty database
+	dst := loadDB(t, filepath.Join(dir, "dst"))
+	full := filepath.Join(dir, "full.bundle")
+	header, err := Create(ctx, src, full, dir, []ref.DoltRef{main}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Ref{{Name: "refs/heads/main", Hash: first.String()}}, header.Refs)
+	assert.True(t, IsBundle(full))
+
+	mirror, err := ref.ParseRefSpec("refs/heads/main:refs/heads/main")
+	require.NoError(t, err)
+	updated, err := Fetch(ctx, dst, full, []ref.RefSpec{mirror})
+	require.NoError(t, err)
+	assert.Equal(t, []doltdb.RefWithHash{{Ref: main, Hash: first}}, updated)
+
+	// a bundle created since |first| only applies to databases which have it
+	second := commitTo(t, src, main, "second")
+	require.NoError(t, src.NewTagAtCommit(ctx, ref.NewTagRef("v2"), readCommit(t, src, second), datas.NewTagMeta("Bill Billerson", "bigbillieb@fake.horse", "")))
+	inc := filepath.Join(dir, "inc.bundle")
+	header, err = Create(ctx, src, inc, dir, []ref.DoltRef{main, ref.NewTagRef("v2")}, []hash.Hash{first})
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.String()}, header.Prerequisites)
+
+	empty := loadDB(t, filepath.Join(dir, "empty"))
+	header, err = Verify(ctx, empty, inc)
+	assert.ErrorIs(t, err, ErrMissingPrerequisites)
+	assert.Len(t, header.Refs, 2)
+	_, err = Unbundle(ctx, empty, inc)
+	assert.ErrorIs(t, err, ErrMissingPrerequisites)
+
+	tracking, err := ref.ParseRefSpecForRemote("bundle", DefaultFetchRefSpec)
+	require.NoError(t, err)
+	updated, err = Fetch(ctx, dst, inc, []ref.RefSpec{mirror, tracking})
+	require.NoError(t, err)
+	assert.Len(t, updated, 3)
+
+	head, err := dst.ResolveCommitRef(ctx, main)
+	require.NoError(t, err)
+	h, err := head.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, second, h)
+	tag, err := dst.ResolveTag(ctx, ref.NewTagRef("v2"))
+	require.NoError(t, err)
+	h, err = tag.Commit.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, second, h)
+
+	// local branches are only fast-forwarded
+	commitTo(t, dst, main, "diverged")
+	_, err = Fetch(ctx, dst, inc, []ref.RefSpec{mirror})
+	require.NoError(t, err)
+	third := commitTo(t, src, main, "third")
+	_, err = Create(ctx, src, inc, dir, []ref.DoltRef{main}, []hash.Hash{first})
+	require.NoError(t, err)
+	_, err = Fetch(ctx, dst, inc, []ref.RefSpec{mirror})
+	assert.ErrorIs(t, err, ErrCantFF)
+	_, err = Fetch(ctx, dst, inc, []ref.RefSpec{tracking})
+	require.NoError(t, err)
+	remote, err := dst.ResolveCommitRef(ctx, ref.NewRemoteRef("bundle", "main"))
+	require.NoError(t, err)
+	h, err = remote.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, third, h)
+
+	_, err = Create(ctx, src, filepath.Join(dir, "empty.bundle"), dir, []ref.DoltRef{main}, []hash.Hash{third})
+	assert.ErrorIs(t, err, ErrEmptyBundle)
+	_, err = Create(ctx, src, filepath.Join(dir, "missing.bundle"), dir, []ref.DoltRef{ref.NewBranchRef("missing")}, nil)
+	assert.Error(t, err)
+	assert.False(t, IsBundle(filepath.Join(dir, "missing.bundle")))
+}
+
+func loadDB(t *testing.T, dir string) *doltdb.DoltDB {
+	require.NoError(t, filesys.LocalFS.MkDirs(dir))
+	ddb, err := doltdb.LoadDoltDB(context.Background(), types.Format_Default, "file://"+dir, filesys.LocalFS)
+	require.NoError(t, err)
+	t.Cleanup(func() { ddb.Close() })
+	return ddb
+}
+
+// commitTo adds a commit with the same root value to the head of |branch|, and returns its address.
+func commitTo(t *testing.T, ddb *doltdb.DoltDB, branch ref.DoltRef, desc string) hash.Hash {
+	ctx := context.Background()
+	head, err := ddb.ResolveCommitRef(ctx, branch)
+	require.NoError(t, err)
+	root, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", desc)
+	require.NoError(t, err)
+	cm, err := ddb.Commit(ctx, valHash, branch, meta)
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	return h
+}
+
+func readCommit(t *testing.T, ddb *doltdb.DoltDB, h hash.Hash) *doltdb.Commit {
+	optCmt, err := ddb.ReadCommit(context.Background(), h)
+	require.NoError(t, err)
+	cm, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	return cm
+}
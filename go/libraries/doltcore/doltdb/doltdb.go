@@ -1978,14 +1978,22 @@ func pullHash(
 	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
 
 	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
+		transferCompleted := observeTransfer(ctx)
 		puller, err := pull.NewPuller(ctx, tempDir, defaultTargetFileSize, srcCS, destCS, waf, targetHashes, statsCh)
 		if err == pull.ErrDBUpToDate {
 			return nil
 		} else if err != nil {
+			if transferCompleted != nil {
+				transferCompleted(pull.Stats{}, err)
+			}
 			return err
 		}
 
-		return puller.Pull(ctx)
+		err = puller.Pull(ctx)
+		if transferCompleted != nil {
+			transferCompleted(puller.Stats(), err)
+		}
+		return err
 	} else {
 		return errors.New("Puller not supported")
 	}
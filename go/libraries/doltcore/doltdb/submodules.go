@@ -0,0 +1,111 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Submodule is a row of dolt_submodules: another database, pinned to one of its commits.
+type Submodule struct {
+	Name   string
+	URL    string
+	Commit hash.Hash
+}
+
+// ValidateSubmoduleName returns an error if |name| can't be used as the name of a submodule.
+func ValidateSubmoduleName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid submodule name '%s'", name)
+	}
+	return nil
+}
+
+// ValidateSubmodule returns an error if |name|, |url| and |commit| don't make a valid row of dolt_submodules.
+func ValidateSubmodule(name, url, commit string) error {
+	if err := ValidateSubmoduleName(name); err != nil {
+		return err
+	}
+	u, err := earl.Parse(url)
+	if err != nil || url == "" {
+		return fmt.Errorf("submodule '%s' has invalid url '%s'", name, url)
+	}
+	if _, ok := dbfactory.DBFactories[strings.ToLower(u.Scheme)]; u.Scheme != "" && !ok {
+		return fmt.Errorf("submodule '%s' has url '%s' with unknown scheme '%s'", name, url, u.Scheme)
+	}
+	if _, ok := hash.MaybeParse(commit); !ok {
+		return fmt.Errorf("submodule '%s' is pinned to invalid commit hash '%s'", name, commit)
+	}
+	return nil
+}
+
+// GetSubmodules returns the submodules listed in the dolt_submodules table of |root|, ordered by name.
+func GetSubmodules(ctx context.Context, root RootValue) ([]Submodule, error) {
+	table, found, err := root.GetTable(ctx, TableName{Name: SubmodulesTableName})
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(index)
+	keyDesc, valueDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var submodules []Submodule
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := keyDesc.GetString(0, k)
+		if !ok {
+			return nil, fmt.Errorf("%s had an unexpected key, this should never happen", SubmodulesTableName)
+		}
+		url, _ := valueDesc.GetString(0, v)
+		commit, _ := valueDesc.GetString(1, v)
+		h, ok := hash.MaybeParse(commit)
+		if !ok {
+			return nil, fmt.Errorf("submodule '%s' is pinned to invalid commit hash '%s'", name, commit)
+		}
+		submodules = append(submodules, Submodule{Name: name, URL: url, Commit: h})
+	}
+	return submodules, nil
+}
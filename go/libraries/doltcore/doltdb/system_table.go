@@ -127,6 +127,7 @@ var getWriteableSystemTables = func() []string {
 		GetRebaseTableName(),
 		GetQueryCatalogTableName(),
 		GetTestsTableName(),
+		SubmodulesTableName,
 
 		// TODO: find way to make these writable by the dolt process
 		// TODO: but not by user
@@ -161,6 +162,9 @@ func GeneratedSystemTableNames() []string {
 		GetBackupsTableName(),
 		GetStashesTableName(),
 		GetBranchActivityTableName(),
+		GetTableStorageTableName(),
+		GetIndexBuildsTableName(),
+		GetStorageMaintenanceLogTableName(),
 	}
 }
 
@@ -231,6 +235,20 @@ const (
 	NonlocalTablesOptionsCol = "options"
 )
 
+const (
+	// SubmodulesTableName is the name of the table pinning other databases to commits
+	SubmodulesTableName = "dolt_submodules"
+
+	// SubmodulesNameCol is the name of the primary key column of the submodules table
+	SubmodulesNameCol = "name"
+
+	// SubmodulesUrlCol is the name of the column containing the remote url of a submodule
+	SubmodulesUrlCol = "url"
+
+	// SubmodulesCommitCol is the name of the column containing the commit a submodule is pinned to
+	SubmodulesCommitCol = "commit_hash"
+)
+
 const (
 	// SchemasTableName is the name of the dolt schema fragment table
 	SchemasTableName = "dolt_schemas"
@@ -402,6 +420,21 @@ var GetBranchActivityTableName = func() string {
 	return BranchActivityTableName
 }
 
+// GetTableStorageTableName returns the table storage system table name
+var GetTableStorageTableName = func() string {
+	return TableStorageTableName
+}
+
+// GetIndexBuildsTableName returns the index builds system table name
+var GetIndexBuildsTableName = func() string {
+	return IndexBuildsTableName
+}
+
+// GetStorageMaintenanceLogTableName returns the storage maintenance log system table name
+var GetStorageMaintenanceLogTableName = func() string {
+	return StorageMaintenanceLogTableName
+}
+
 const (
 	// LogTableName is the log system table name
 	LogTableName = "dolt_log"
@@ -462,6 +495,15 @@ const (
 
 	// BranchActivityTableName is the branch activity system table name
 	BranchActivityTableName = "dolt_branch_activity"
+
+	// TableStorageTableName is the table storage system table name
+	TableStorageTableName = "dolt_table_storage"
+
+	// IndexBuildsTableName is the online index builds system table name
+	IndexBuildsTableName = "dolt_index_builds"
+
+	// StorageMaintenanceLogTableName is the storage maintenance log system table name
+	StorageMaintenanceLogTableName = "dolt_storage_maintenance_log"
 )
 
 // DoltGeneratedTableNames is a list of all the generated dolt system tables that are not specific to a user table.
@@ -0,0 +1,83 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/datas/pull"
+)
+
+// TransferDirection describes which way chunks moved relative to the local database.
+type TransferDirection string
+
+const (
+	TransferPush TransferDirection = "push"
+	TransferPull TransferDirection = "pull"
+)
+
+// TransferLabels identifies the local database, the remote and the direction of a chunk transfer made by PullChunks.
+type TransferLabels struct {
+	Database  string
+	Remote    string
+	Direction TransferDirection
+}
+
+// TransferObserver is notified every time PullChunks finishes a transfer for a context labeled with
+// WithTransferLabels. sql-server installs one with SetTransferObserver to export transfer metrics.
+type TransferObserver interface {
+	TransferCompleted(labels TransferLabels, stats pull.Stats, d time.Duration, err error)
+}
+
+var transferObserver atomic.Pointer[TransferObserver]
+
+// SetTransferObserver installs |observer| to be notified of labeled transfers. A nil |observer| removes the current
+// one.
+func SetTransferObserver(observer TransferObserver) {
+	if observer == nil {
+		transferObserver.Store(nil)
+		return
+	}
+	transferObserver.Store(&observer)
+}
+
+type transferLabelsContextKeyType struct{}
+
+var transferLabelsContextKey = transferLabelsContextKeyType{}
+
+// WithTransferLabels returns a context which reports the transfers made with it to the installed TransferObserver
+// under |labels|. Transfers made with unlabeled contexts are not reported.
+func WithTransferLabels(ctx context.Context, labels TransferLabels) context.Context {
+	return context.WithValue(ctx, transferLabelsContextKey, labels)
+}
+
+// observeTransfer returns a function to call with the outcome of a transfer made with |ctx|, or nil if the transfer
+// should not be reported.
+func observeTransfer(ctx context.Context) func(stats pull.Stats, err error) {
+	labels, ok := ctx.Value(transferLabelsContextKey).(TransferLabels)
+	if !ok {
+		return nil
+	}
+	observer := transferObserver.Load()
+	if observer == nil {
+		return nil
+	}
+	start := time.Now()
+	return func(stats pull.Stats, err error) {
+		(*observer).TransferCompleted(labels, stats, time.Since(start), err)
+	}
+}
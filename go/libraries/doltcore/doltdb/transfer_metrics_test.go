@@ -0,0 +1,64 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/datas/pull"
+)
+
+type recordingTransferObserver struct {
+	labels []TransferLabels
+	stats  []pull.Stats
+	errs   []error
+}
+
+func (o *recordingTransferObserver) TransferCompleted(labels TransferLabels, stats pull.Stats, _ time.Duration, err error) {
+	o.labels = append(o.labels, labels)
+	o.stats = append(o.stats, stats)
+	o.errs = append(o.errs, err)
+}
+
+func TestObserveTransfer(t *testing.T) {
+	ctx := context.Background()
+	labels := TransferLabels{Database: "db", Remote: "origin", Direction: TransferPush}
+
+	// Nothing is reported without an observer.
+	assert.Nil(t, observeTransfer(WithTransferLabels(ctx, labels)))
+
+	observer := &recordingTransferObserver{}
+	SetTransferObserver(observer)
+	defer SetTransferObserver(nil)
+
+	// Nothing is reported for unlabeled contexts.
+	assert.Nil(t, observeTransfer(ctx))
+
+	done := observeTransfer(WithTransferLabels(ctx, labels))
+	require.NotNil(t, done)
+	done(pull.Stats{FetchedSourceChunks: 3, FetchedSourceBytes: 1024}, nil)
+	done(pull.Stats{}, errors.New("remote unavailable"))
+
+	assert.Equal(t, []TransferLabels{labels, labels}, observer.labels)
+	assert.Equal(t, uint64(1024), observer.stats[0].FetchedSourceBytes)
+	assert.NoError(t, observer.errs[0])
+	assert.Error(t, observer.errs[1])
+}
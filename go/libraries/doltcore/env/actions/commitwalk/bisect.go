@@ -0,0 +1,162 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitwalk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrBisectBadIsAncestorOfGood = errors.New("the bad commit is an ancestor of a good commit")
+
+// BisectStep is the outcome of BisectNext. Exactly one of FirstBad, Next and Untestable is set.
+type BisectStep struct {
+	// FirstBad is the first bad commit, once bisection is complete.
+	FirstBad *doltdb.Commit
+	// Next is the commit to test next.
+	Next *doltdb.Commit
+	// Remaining is the number of commits which, at worst, are left to test after Next.
+	Remaining int
+	// Steps is a rough estimate of the number of steps left after Next.
+	Steps int
+	// Untestable is set when every commit left to test has been skipped. The first bad commit is one of them.
+	Untestable []*doltdb.Commit
+}
+
+// BisectNext returns the next step of a bisection of the history of |ddb| between the commits in |good| and |bad|.
+// The candidates for the first bad commit are |bad| and those of its ancestors which are not reachable from any of
+// |good|. The commit chosen to be tested next is the candidate which splits the candidates most evenly between those
+// it can reach and those it cannot, so that either result halves the search. Candidates in |skip| are never chosen.
+func BisectNext(ctx context.Context, ddb *doltdb.DoltDB, bad hash.Hash, good []hash.Hash, skip hash.HashSet) (BisectStep, error) {
+	itr, err := GetDotDotRevisionsIterator[context.Context](ctx, ddb, []hash.Hash{bad}, ddb, good, nil)
+	if err != nil {
+		return BisectStep{}, err
+	}
+
+	var order []hash.Hash
+	candidates := make(map[hash.Hash]*doltdb.Commit)
+	for {
+		h, optCmt, _, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return BisectStep{}, err
+		}
+		cmt, ok := optCmt.ToCommit()
+		if !ok {
+			return BisectStep{}, doltdb.ErrGhostCommitEncountered
+		}
+		order = append(order, h)
+		candidates[h] = cmt
+	}
+
+	if _, ok := candidates[bad]; !ok {
+		return BisectStep{}, ErrBisectBadIsAncestorOfGood
+	}
+	if len(order) == 1 {
+		return BisectStep{FirstBad: candidates[bad]}, nil
+	}
+
+	reach, err := reachCounts(ctx, order, candidates)
+	if err != nil {
+		return BisectStep{}, err
+	}
+
+	total := len(order)
+	var best hash.Hash
+	bestScore, bestReach := -1, 0
+	for _, h := range order {
+		if h == bad || skip.Has(h) {
+			continue
+		}
+		score := min(reach[h], total-reach[h])
+		if score > bestScore {
+			best, bestScore, bestReach = h, score, reach[h]
+		}
+	}
+
+	if bestScore < 0 {
+		untestable := make([]*doltdb.Commit, len(order))
+		for i, h := range order {
+			untestable[i] = candidates[h]
+		}
+		return BisectStep{Untestable: untestable}, nil
+	}
+
+	// If the next commit is bad, it and the candidates it reaches remain. Otherwise, the candidates it does not
+	// reach remain, one of which is the known bad commit.
+	remaining := max(bestReach-1, total-bestReach-1)
+	return BisectStep{
+		Next:      candidates[best],
+		Remaining: remaining,
+		Steps:     bits.Len(uint(remaining)),
+	}, nil
+}
+
+// reachCounts returns, for each of |candidates|, the number of candidates which are reachable from it, including
+// itself. |order| lists the candidates with every commit before its parents. The counts are computed from the oldest
+// candidate forward: a candidate with a single candidate parent reaches one more candidate than its parent, and only
+// merges of candidates need to walk their history.
+func reachCounts(ctx context.Context, order []hash.Hash, candidates map[hash.Hash]*doltdb.Commit) (map[hash.Hash]int, error) {
+	parents := make(map[hash.Hash][]hash.Hash, len(order))
+	for _, h := range order {
+		hashes, err := candidates[h].ParentHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range hashes {
+			if _, ok := candidates[p]; ok {
+				parents[h] = append(parents[h], p)
+			}
+		}
+	}
+
+	reach := make(map[hash.Hash]int, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		h := order[i]
+		switch ps := parents[h]; len(ps) {
+		case 0:
+			reach[h] = 1
+		case 1:
+			reach[h] = reach[ps[0]] + 1
+		default:
+			reach[h] = reachableCandidates(h, parents)
+		}
+	}
+	return reach, nil
+}
+
+// reachableCandidates returns the number of candidates which are reachable from |start|, including |start|, where
+// |parents| maps each candidate to its candidate parents.
+func reachableCandidates(start hash.Hash, parents map[hash.Hash][]hash.Hash) int {
+	seen := hash.NewHashSet(start)
+	toVisit := []hash.Hash{start}
+	for len(toVisit) > 0 {
+		h := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		for _, p := range parents[h] {
+			if !seen.Has(p) {
+				seen.Insert(p)
+				toVisit = append(toVisit, p)
+			}
+		}
+	}
+	return seen.Size()
+}
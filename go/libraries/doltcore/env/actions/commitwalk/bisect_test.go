@@ -0,0 +1,166 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitwalk
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBisectNext(t *testing.T) {
+	dEnv := createUninitializedEnv()
+	ctx := context.Background()
+	err := dEnv.InitRepo(ctx, types.Format_Default, "test user", "test@test.com", "main")
+	require.NoError(t, err)
+	ddb := dEnv.DoltDB(ctx)
+
+	// A linear history of 16 commits, the first of which is the initial commit.
+	headRef := ref.NewBranchRef("main")
+	head, err := ddb.ResolveCommitRef(ctx, headRef)
+	require.NoError(t, err)
+	rv, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, rvh, err := ddb.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+	hashes := []hash.Hash{mustGetHash(t, head)}
+	for i := 1; i < 16; i++ {
+		meta, err := datas.NewCommitMetaWithUserTS("test user", "test@test.com", "commit", time.Unix(int64(i), 0))
+		require.NoError(t, err)
+		cmt, err := ddb.Commit(ctx, rvh, headRef, meta)
+		require.NoError(t, err)
+		hashes = append(hashes, mustGetHash(t, cmt))
+	}
+
+	// Bisect for the first bad commit, which is hashes[firstBad].
+	bisect := func(t *testing.T, firstBad int, skip hash.HashSet) (hash.Hash, int) {
+		bad, good := hashes[15], hashes[0]
+		steps := 0
+		for {
+			step, err := BisectNext(ctx, ddb, bad, []hash.Hash{good}, skip)
+			require.NoError(t, err)
+			if step.FirstBad != nil {
+				return mustGetHash(t, step.FirstBad), steps
+			}
+			require.NotNil(t, step.Next)
+			steps++
+			next := mustGetHash(t, step.Next)
+			idx := indexOf(hashes, next)
+			if idx >= firstBad {
+				bad = next
+			} else {
+				good = next
+			}
+		}
+	}
+
+	t.Run("FindsFirstBad", func(t *testing.T) {
+		for _, firstBad := range []int{1, 7, 15} {
+			found, steps := bisect(t, firstBad, nil)
+			assert.Equal(t, hashes[firstBad], found)
+			assert.LessOrEqual(t, steps, 4)
+		}
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		skip := hash.NewHashSet(hashes[7], hashes[8])
+		found, _ := bisect(t, 5, skip)
+		assert.Equal(t, hashes[5], found)
+	})
+
+	t.Run("OnlySkippedLeft", func(t *testing.T) {
+		step, err := BisectNext(ctx, ddb, hashes[4], []hash.Hash{hashes[1]}, hash.NewHashSet(hashes[2], hashes[3]))
+		require.NoError(t, err)
+		require.Len(t, step.Untestable, 3)
+		assert.Equal(t, hashes[4], mustGetHash(t, step.Untestable[0]))
+	})
+
+	t.Run("BadIsAncestorOfGood", func(t *testing.T) {
+		_, err := BisectNext(ctx, ddb, hashes[2], []hash.Hash{hashes[5]}, nil)
+		assert.ErrorIs(t, err, ErrBisectBadIsAncestorOfGood)
+	})
+}
+
+func TestBisectReachCounts(t *testing.T) {
+	dEnv := createUninitializedEnv()
+	ctx := context.Background()
+	err := dEnv.InitRepo(ctx, types.Format_Default, "test user", "test@test.com", "main")
+	require.NoError(t, err)
+	ddb := dEnv.DoltDB(ctx)
+
+	base, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	rv, err := base.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, rvh, err := ddb.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+	commit := func(i int, parents ...*doltdb.Commit) *doltdb.Commit {
+		meta, err := datas.NewCommitMetaWithUserTS("test user", "test@test.com", "commit", time.Unix(int64(i), 0))
+		require.NoError(t, err)
+		cmt, err := ddb.CommitDanglingWithParentCommits(ctx, rvh, parents, meta)
+		require.NoError(t, err)
+		return cmt
+	}
+
+	// base -> a1 -> a2 -> merge -> tip, and base -> b1 -> b2 -> merge
+	a1 := commit(1, base)
+	a2 := commit(2, a1)
+	b1 := commit(3, base)
+	b2 := commit(4, b1)
+	merge := commit(5, a2, b2)
+	tip := commit(6, merge)
+
+	itr, err := GetDotDotRevisionsIterator[context.Context](ctx, ddb, []hash.Hash{mustGetHash(t, tip)}, ddb, []hash.Hash{mustGetHash(t, base)}, nil)
+	require.NoError(t, err)
+	var order []hash.Hash
+	candidates := make(map[hash.Hash]*doltdb.Commit)
+	for {
+		h, optCmt, _, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		cmt, ok := optCmt.ToCommit()
+		require.True(t, ok)
+		order = append(order, h)
+		candidates[h] = cmt
+	}
+	require.Len(t, order, 6)
+
+	reach, err := reachCounts(ctx, order, candidates)
+	require.NoError(t, err)
+	for cmt, expected := range map[*doltdb.Commit]int{a1: 1, a2: 2, b1: 1, b2: 2, merge: 5, tip: 6} {
+		assert.Equal(t, expected, reach[mustGetHash(t, cmt)])
+	}
+}
+
+func indexOf(hashes []hash.Hash, h hash.Hash) int {
+	for i := range hashes {
+		if hashes[i] == h {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrPruneHistoryMergeActive = errors.New("cannot prune history while a merge or rebase is in progress")
+
+// PruneHistoryResult describes the outcome of PruneHistory.
+type PruneHistoryResult struct {
+	// Kept is the number of commits which remain reachable from the heads of the database.
+	Kept int
+	// Pruned is the number of commits selected for pruning whose children are kept. Their ancestors are pruned along
+	// with them.
+	Pruned int
+	// Ghosts is the number of commits which are ghost commits after pruning, including any which
+	// were ghost commits beforehand.
+	Ghosts int
+}
+
+// PruneBefore returns a predicate for PruneHistory which prunes commits made before |cutoff|.
+func PruneBefore(cutoff time.Time) func(context.Context, *doltdb.Commit) (bool, error) {
+	return func(ctx context.Context, c *doltdb.Commit) (bool, error) {
+		meta, err := c.GetCommitMeta(ctx)
+		if err != nil {
+			return false, err
+		}
+		return meta.Time().Before(cutoff), nil
+	}
+}
+
+// PruneAncestorsOf returns a predicate for PruneHistory which prunes the ancestors of |cutoff|. |cutoff| itself is
+// kept.
+func PruneAncestorsOf(ctx context.Context, cutoff *doltdb.Commit) (func(context.Context, *doltdb.Commit) (bool, error), error) {
+	ancestors := hash.NewHashSet()
+	if cutoff.NumParents() > 0 {
+		closure, err := cutoff.GetCommitClosure(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ancestors, err = closure.AsHashSet(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(ctx context.Context, c *doltdb.Commit) (bool, error) {
+		h, err := c.HashOf()
+		if err != nil {
+			return false, err
+		}
+		return ancestors.Has(h), nil
+	}, nil
+}
+
+// PruneHistory turns the commits of |ddb| for which |prune| returns true, along with all of their ancestors, into
+// ghost commits. This is the same state a shallow clone leaves older history in: the commits remain known by
+// address, so that fetching from and pushing to remotes with full history keeps working, but their contents are no
+// longer stored locally and are removed by the next full GC.
+//
+// The commits at the head of every branch, tag, remote ref, workspace and stash are never pruned, and neither are the
+// commits reachable from them through commits which are kept. If |prune| selects no commits, no ghost commits are
+// persisted.
+func PruneHistory(ctx context.Context, ddb *doltdb.DoltDB, prune func(context.Context, *doltdb.Commit) (bool, error)) (PruneHistoryResult, error) {
+	err := checkNoMergeActive(ctx, ddb)
+	if err != nil {
+		return PruneHistoryResult{}, err
+	}
+
+	heads, err := pruneHistoryHeads(ctx, ddb)
+	if err != nil {
+		return PruneHistoryResult{}, err
+	}
+
+	// Walk back from the heads, stopping at pruned commits and at commits which are already ghosts. Every commit
+	// visited is kept.
+	kept := hash.NewHashSet()
+	ghosts := hash.NewHashSet()
+	var toVisit []*doltdb.Commit
+	for h := range heads {
+		optCmt, err := ddb.ReadCommit(ctx, h)
+		if err != nil {
+			return PruneHistoryResult{}, err
+		}
+		cmt, ok := optCmt.ToCommit()
+		if !ok {
+			return PruneHistoryResult{}, fmt.Errorf("%w: %s", doltdb.ErrGhostCommitRuntimeFailure, h.String())
+		}
+		kept.Insert(h)
+		toVisit = append(toVisit, cmt)
+
+		// Every ancestor of a head which is not kept ends up a ghost.
+		if cmt.NumParents() > 0 {
+			closure, err := cmt.GetCommitClosure(ctx)
+			if err != nil {
+				return PruneHistoryResult{}, err
+			}
+			ancestors, err := closure.AsHashSet(ctx)
+			if err != nil {
+				return PruneHistoryResult{}, err
+			}
+			ghosts.InsertAll(ancestors)
+		}
+	}
+
+	pruned := hash.NewHashSet()
+	for len(toVisit) > 0 {
+		cmt := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+
+		for i := 0; i < cmt.NumParents(); i++ {
+			optParent, err := cmt.GetParent(ctx, i)
+			if err != nil {
+				return PruneHistoryResult{}, err
+			}
+			if kept.Has(optParent.Addr) {
+				continue
+			}
+			ghosts.Insert(optParent.Addr)
+			parent, ok := optParent.ToCommit()
+			if !ok {
+				continue
+			}
+			prunable, err := prune(ctx, parent)
+			if err != nil {
+				return PruneHistoryResult{}, err
+			}
+			if prunable {
+				pruned.Insert(optParent.Addr)
+				continue
+			}
+			kept.Insert(optParent.Addr)
+			toVisit = append(toVisit, parent)
+		}
+	}
+
+	for h := range kept {
+		ghosts.Remove(h)
+	}
+	res := PruneHistoryResult{Kept: kept.Size(), Pruned: pruned.Size(), Ghosts: ghosts.Size()}
+	if pruned.Size() == 0 {
+		return res, nil
+	}
+
+	err = ddb.PersistGhostCommits(ctx, ghosts)
+	if err != nil {
+		return PruneHistoryResult{}, err
+	}
+	return res, nil
+}
+
+// pruneHistoryHeads returns the addresses of the commits which are referenced directly by the refs and stashes of
+// |ddb|.
+func pruneHistoryHeads(ctx context.Context, ddb *doltdb.DoltDB) (hash.HashSet, error) {
+	heads := hash.NewHashSet()
+	refTypes := make(map[ref.RefType]struct{})
+	for t := range ref.HeadRefTypes {
+		// The address of a tag ref is the address of the tag, not of its commit.
+		if t != ref.TagRefType {
+			refTypes[t] = struct{}{}
+		}
+	}
+	err := ddb.VisitRefsOfType(ctx, refTypes, func(_ ref.DoltRef, addr hash.Hash) error {
+		heads.Insert(addr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := ddb.GetTagsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		heads.Insert(t.Hash)
+	}
+
+	stashes, err := ddb.GetStashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stashes {
+		h, ok := hash.MaybeParse(s.CommitHash)
+		if !ok {
+			return nil, fmt.Errorf("invalid commit hash %s for stash %s", s.CommitHash, s.Name)
+		}
+		heads.Insert(h)
+	}
+
+	return heads, nil
+}
+
+// checkNoMergeActive returns an error if the working set of any branch of |ddb| has a merge or rebase in progress,
+// since their state references commits which are not reachable from any ref.
+func checkNoMergeActive(ctx context.Context, ddb *doltdb.DoltDB) error {
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range branches {
+		wsRef, err := ref.WorkingSetRefForHead(b)
+		if err != nil {
+			return err
+		}
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if ws.MergeActive() || ws.RebaseActive() {
+			return fmt.Errorf("%w: %s", ErrPruneHistoryMergeActive, b.GetPath())
+		}
+	}
+	return nil
+}
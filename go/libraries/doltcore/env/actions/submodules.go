@@ -0,0 +1,155 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const submoduleRemoteName = "origin"
+
+// FetchSubmodule clones the database at |url| as submodule |name| of the repository in |dEnv|, or fetches its
+// branches if it has already been cloned, and returns the environment of the clone.
+func FetchSubmodule(ctx context.Context, dEnv *env.DoltEnv, name, url string) (*env.DoltEnv, error) {
+	dir, err := env.SubmoduleDir(name)
+	if err != nil {
+		return nil, err
+	}
+	_, absUrl, err := env.GetAbsRemoteUrl(dEnv.FS, dEnv.Config, url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url for submodule '%s': %w", name, err)
+	}
+	r := env.NewRemote(submoduleRemoteName, absUrl, nil)
+	srcDB, err := r.GetRemoteDB(ctx, types.Format_Default, dEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote db for submodule '%s': %w", name, err)
+	}
+
+	smEnv, err := env.LoadSubmoduleEnv(ctx, dEnv, name)
+	if err != nil {
+		return nil, err
+	}
+	if smEnv == nil {
+		smEnv, err = EnvForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version, env.GetCurrentUserHomeDir)
+		if err != nil {
+			return nil, err
+		}
+		err = CloneRemote(ctx, srcDB, r.Name, "", false, -1, smEnv)
+		if err != nil {
+			smEnv.FS.Delete(".", true)
+			return nil, err
+		}
+		return smEnv, nil
+	}
+
+	// the url of a submodule can change from one commit to the next
+	smEnv.RepoState.Remotes.Set(r.Name, r)
+	if err = smEnv.RepoState.Save(smEnv.FS); err != nil {
+		return nil, err
+	}
+	refSpecs, _, err := env.ParseRefSpecs(nil, smEnv.RepoStateReader(), r)
+	if err != nil {
+		return nil, err
+	}
+	err = FetchRefSpecs(ctx, smEnv.DbData(ctx), srcDB, refSpecs, true, &r, ref.UpdateMode{Force: true}, startNoopProgress, stopNoopProgress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch submodule '%s': %w", name, err)
+	}
+	return smEnv, nil
+}
+
+// ResolveSubmoduleCommit returns the hash of the commit |cSpecStr| refers to in the clone of a submodule.
+func ResolveSubmoduleCommit(ctx context.Context, smEnv *env.DoltEnv, cSpecStr string) (hash.Hash, error) {
+	cs, err := doltdb.NewCommitSpec(cSpecStr)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	headRef, err := smEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	optCmt, err := smEnv.DoltDB(ctx).Resolve(ctx, cs, headRef)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return hash.Hash{}, doltdb.ErrGhostCommitEncountered
+	}
+	return cm.HashOf()
+}
+
+// CheckoutSubmodule points the checked out branch of the clone of a submodule at |commit|, and resets its working
+// set to match.
+func CheckoutSubmodule(ctx context.Context, smEnv *env.DoltEnv, commit hash.Hash) error {
+	ddb := smEnv.DoltDB(ctx)
+	optCmt, err := ddb.ReadCommit(ctx, commit)
+	if err != nil {
+		return fmt.Errorf("commit %s not found in submodule: %w", commit.String(), err)
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+
+	headRef, err := smEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return err
+	}
+	if err = ddb.SetHeadToCommit(ctx, headRef, cm); err != nil {
+		return err
+	}
+	ws, err := smEnv.WorkingSet(ctx)
+	if err != nil {
+		return err
+	}
+	return smEnv.UpdateWorkingSet(ctx, ws.WithWorkingRoot(root).WithStagedRoot(root).ClearMerge().ClearRebase())
+}
+
+// SubmoduleHead returns the hash of the commit checked out in the clone of a submodule.
+func SubmoduleHead(ctx context.Context, smEnv *env.DoltEnv) (hash.Hash, error) {
+	return ResolveSubmoduleCommit(ctx, smEnv, "HEAD")
+}
+
+func startNoopProgress(ctx context.Context) (*sync.WaitGroup, chan pull.Stats) {
+	statsCh := make(chan pull.Stats)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range statsCh {
+		}
+	}()
+	return wg, statsCh
+}
+
+func stopNoopProgress(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh chan pull.Stats) {
+	cancel()
+	close(statsCh)
+	wg.Wait()
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -35,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -215,11 +217,26 @@ func LoadDoltDB(ctx context.Context, fs filesys.Filesys, urlStr string, dEnv *Do
 			return
 		}
 
-		var params map[string]interface{}
+		params := make(map[string]interface{})
 		if mmapArchiveIndexes {
-			params = map[string]interface{}{dbfactory.MMapArchiveIndexesParam: struct{}{}}
+			params[dbfactory.MMapArchiveIndexesParam] = struct{}{}
 		}
-		ddb, dbLoadErr := doltdb.LoadDoltDBWithParams(ctx, types.Format_Default, urlStr, fs, params)
+
+		// a linked worktree uses the database of the repository it was added to
+		dbUrlStr := urlStr
+		if urlStr == doltdb.LocalDirDoltDB {
+			dataDir, linked, err := worktreeDataDir(fs)
+			if err != nil {
+				dEnv.DBLoadError = err
+				return
+			}
+			if linked {
+				dbUrlStr = earl.FileUrlFromPath(filepath.ToSlash(dataDir), os.PathSeparator)
+				params[dbfactory.ChunkJournalParam] = struct{}{}
+			}
+		}
+
+		ddb, dbLoadErr := doltdb.LoadDoltDBWithParams(ctx, types.Format_Default, dbUrlStr, fs, params)
 		dEnv.doltDB = ddb
 		dEnv.DBLoadError = dbLoadErr
 		dEnv.urlStr = urlStr
@@ -365,7 +382,13 @@ func (dEnv *DoltEnv) HasDoltDir() bool {
 }
 
 func (dEnv *DoltEnv) HasDoltDataDir() bool {
-	exists, isDir := dEnv.FS.Exists(dbfactory.DoltDataDir)
+	dataDir, linked, err := worktreeDataDir(dEnv.FS)
+	if err != nil {
+		return false
+	} else if !linked {
+		dataDir = dbfactory.DoltDataDir
+	}
+	exists, isDir := dEnv.FS.Exists(dataDir)
 	return exists && isDir
 }
 
@@ -1145,6 +1168,30 @@ func (dEnv *DoltEnv) UpdateBranch(name string, new BranchConfig) error {
 	return nil
 }
 
+// GetBisectState returns the state of the bisect session in progress, or nil if there is none.
+func (dEnv *DoltEnv) GetBisectState() (*BisectState, error) {
+	if dEnv.RSLoadErr != nil {
+		return nil, dEnv.RSLoadErr
+	}
+
+	return dEnv.RepoState.Bisect, nil
+}
+
+// SetBisectState records |bs| as the state of the bisect session in progress. A nil |bs| ends the session.
+func (dEnv *DoltEnv) SetBisectState(bs *BisectState) error {
+	if dEnv.RSLoadErr != nil {
+		return dEnv.RSLoadErr
+	}
+
+	dEnv.RepoState.Bisect = bs
+
+	err := dEnv.RepoState.Save(dEnv.FS)
+	if err != nil {
+		return ErrFailedToWriteRepoState
+	}
+	return nil
+}
+
 var ErrNotACred = errors.New("not a valid credential key id or public key")
 
 func (dEnv *DoltEnv) FindCreds(credsDir, pubKeyOrId string) (string, error) {
@@ -72,6 +72,7 @@ type RepoState struct {
 	Remotes  *concurrentmap.Map[string, Remote]       `json:"remotes"`
 	Backups  *concurrentmap.Map[string, Remote]       `json:"backups"`
 	Branches *concurrentmap.Map[string, BranchConfig] `json:"branches"`
+	Bisect   *BisectState                             `json:"bisect,omitempty"`
 	// |staged|, |working|, and |merge| are legacy fields left over from when Dolt repos stored this info in the repo
 	// state file, not in the DB directly. They're still here so that we can migrate existing repositories forward to the
 	// new storage format, but they should be used only for this purpose and are no longer written.
@@ -87,6 +88,7 @@ type repoStateLegacy struct {
 	Remotes  *concurrentmap.Map[string, Remote]       `json:"remotes"`
 	Backups  *concurrentmap.Map[string, Remote]       `json:"backups"`
 	Branches *concurrentmap.Map[string, BranchConfig] `json:"branches"`
+	Bisect   *BisectState                             `json:"bisect,omitempty"`
 	Staged   string                                   `json:"staged,omitempty"`
 	Working  string                                   `json:"working,omitempty"`
 	Merge    *mergeState                              `json:"merge,omitempty"`
@@ -99,12 +101,27 @@ func repoStateLegacyFromRepoState(rs *RepoState) *repoStateLegacy {
 		Remotes:  rs.Remotes,
 		Backups:  rs.Backups,
 		Branches: rs.Branches,
+		Bisect:   rs.Bisect,
 		Staged:   rs.staged,
 		Working:  rs.working,
 		Merge:    rs.merge,
 	}
 }
 
+// BisectState is the state of a bisect session in progress. Commits are recorded by their hash.
+type BisectState struct {
+	// Bad is the commit known to be bad, if any.
+	Bad string `json:"bad,omitempty"`
+	// Good are the commits known to be good.
+	Good []string `json:"good,omitempty"`
+	// Skip are the commits which could not be tested.
+	Skip []string `json:"skip,omitempty"`
+	// Current is the commit to test next, if bisection has begun.
+	Current string `json:"current,omitempty"`
+	// Log is a record of the session as the commands which would replay it, with comments.
+	Log []string `json:"log,omitempty"`
+}
+
 type mergeState struct {
 	Commit          string `json:"commit"`
 	PreMergeWorking string `json:"working_pre_merge"`
@@ -116,6 +133,7 @@ func (rs *repoStateLegacy) toRepoState() *RepoState {
 		Remotes:  rs.Remotes,
 		Backups:  rs.Backups,
 		Branches: rs.Branches,
+		Bisect:   rs.Bisect,
 		staged:   rs.Staged,
 		working:  rs.Working,
 		merge:    rs.Merge,
@@ -0,0 +1,66 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// Submodules are other databases listed in the dolt_submodules table, each pinned to one of its commits. Their clones
+// live inside the .dolt directory of the repository using them, so that they are not mistaken for databases of their
+// own, and each has the commit it is pinned to checked out.
+var submodulesDir = filepath.Join(dbfactory.DoltDir, "submodules")
+
+// SubmoduleDir returns the directory, relative to the root of a repository, holding the clone of submodule |name|.
+func SubmoduleDir(name string) (string, error) {
+	if err := doltdb.ValidateSubmoduleName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(submodulesDir, name), nil
+}
+
+// LoadSubmoduleEnv loads the clone of submodule |name| of the repository in |dEnv|. It returns nil if the submodule
+// hasn't been cloned.
+func LoadSubmoduleEnv(ctx context.Context, dEnv *DoltEnv, name string) (*DoltEnv, error) {
+	dir, err := SubmoduleDir(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists, isDir := dEnv.FS.Exists(filepath.Join(dir, dbfactory.DoltDir)); !exists || !isDir {
+		return nil, nil
+	}
+
+	fs, err := dEnv.FS.WithWorkingDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	hdp := dEnv.hdp
+	if hdp == nil {
+		hdp = GetCurrentUserHomeDir
+	}
+	smEnv := Load(ctx, hdp, fs, doltdb.LocalDirDoltDB, dEnv.Version)
+	if smEnv.DBLoadError != nil {
+		return nil, fmt.Errorf("failed to load submodule '%s': %w", name, smEnv.DBLoadError)
+	}
+	if smEnv.RSLoadErr != nil {
+		return nil, fmt.Errorf("failed to load submodule '%s': %w", name, smEnv.RSLoadErr)
+	}
+	return smEnv, nil
+}
@@ -0,0 +1,331 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dolthub/fslock"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// A repository can have several worktrees: directories which each have a branch checked out. The repository's own
+// directory is its main worktree, and `dolt worktree add` creates linked worktrees. A linked worktree has a .dolt
+// directory with its own repo state, and so its own HEAD, but no data directory. Instead, a link file points at the
+// .dolt directory of the main worktree, and the linked worktree uses its database, sharing chunks and refs. Working
+// sets are stored in the database by branch, so each worktree has its own as long as no branch is checked out in more
+// than one of them. The main worktree lists its linked worktrees so that this can be enforced.
+const (
+	worktreeLinkFile  = "worktree.json"
+	worktreesFile     = "worktrees.json"
+	worktreesLockFile = "worktrees.lock"
+)
+
+// worktreesLockTimeout is how long to wait for another process to finish changing the worktree list.
+var worktreesLockTimeout = 10 * time.Second
+
+var ErrNotAWorktree = errors.New("not a worktree")
+
+// Worktree is a directory with a branch of a repository checked out.
+type Worktree struct {
+	// Path is the absolute path of the directory.
+	Path string
+	// Head is the ref checked out in the worktree, or nil if its repo state can't be read.
+	Head ref.DoltRef
+	// Main is set for the repository's own directory.
+	Main bool
+}
+
+type worktreeLink struct {
+	DoltDir string `json:"dolt_dir"`
+}
+
+type worktreeList struct {
+	Worktrees []string `json:"worktrees"`
+}
+
+func getWorktreeLinkFile() string {
+	return filepath.Join(dbfactory.DoltDir, worktreeLinkFile)
+}
+
+// IsLinkedWorktree returns whether |fs| is a worktree created by `dolt worktree add`.
+func IsLinkedWorktree(fs filesys.ReadableFS) bool {
+	exists, isDir := fs.Exists(getWorktreeLinkFile())
+	return exists && !isDir
+}
+
+// mainDoltDir returns the absolute path of the .dolt directory of the main worktree of the repository in |fs|.
+func mainDoltDir(fs filesys.ReadableFS) (string, error) {
+	if !IsLinkedWorktree(fs) {
+		return fs.Abs(dbfactory.DoltDir)
+	}
+
+	data, err := fs.ReadFile(getWorktreeLinkFile())
+	if err != nil {
+		return "", err
+	}
+	var link worktreeLink
+	if err = json.Unmarshal(data, &link); err != nil {
+		return "", fmt.Errorf("invalid worktree link %s: %w", getWorktreeLinkFile(), err)
+	}
+	return link.DoltDir, nil
+}
+
+// worktreeDataDir returns the absolute path of the data directory shared by the linked worktree in |fs|, or false if
+// |fs| isn't a linked worktree.
+func worktreeDataDir(fs filesys.ReadableFS) (string, bool, error) {
+	if !IsLinkedWorktree(fs) {
+		return "", false, nil
+	}
+	doltDir, err := mainDoltDir(fs)
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Join(doltDir, filepath.Base(dbfactory.DoltDataDir)), true, nil
+}
+
+func loadWorktreeList(fs filesys.ReadableFS, doltDir string) (*worktreeList, error) {
+	path := filepath.Join(doltDir, worktreesFile)
+	if exists, _ := fs.Exists(path); !exists {
+		return &worktreeList{}, nil
+	}
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var list worktreeList
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid worktree list %s: %w", path, err)
+	}
+	return &list, nil
+}
+
+func (l *worktreeList) save(fs filesys.WritableFS, doltDir string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(doltDir, worktreesFile), data, os.ModePerm)
+}
+
+// lockWorktreeList locks the worktree list of the repository whose main .dolt directory is |doltDir| against changes by
+// other processes, and returns a function which unlocks it. The list must be locked while it is checked and then
+// changed.
+func lockWorktreeList(fs filesys.Filesys, doltDir string) (func() error, error) {
+	lck := filesys.CreateFilesysLock(fs, filepath.Join(doltDir, worktreesLockFile))
+	start := time.Now()
+	for {
+		locked, err := lck.TryLock()
+		if locked {
+			return lck.Unlock, nil
+		}
+		if err != nil && !errors.Is(err, fslock.ErrLocked) {
+			return nil, err
+		}
+		if time.Since(start) > worktreesLockTimeout {
+			return nil, errors.New("timed out waiting for another process to finish changing the worktrees of this repository")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// ListWorktrees returns the worktrees of the repository in |fs|, starting with its main worktree.
+func ListWorktrees(fs filesys.Filesys) ([]Worktree, error) {
+	doltDir, err := mainDoltDir(fs)
+	if err != nil {
+		return nil, err
+	}
+	list, err := loadWorktreeList(fs, doltDir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := append([]string{filepath.Dir(doltDir)}, list.Worktrees...)
+	worktrees := make([]Worktree, len(paths))
+	for i, path := range paths {
+		worktrees[i] = Worktree{Path: path, Main: i == 0}
+		wfs, err := fs.WithWorkingDir(path)
+		if err != nil {
+			continue
+		}
+		if rs, err := LoadRepoState(wfs); err == nil {
+			worktrees[i].Head = rs.Head.Ref
+		}
+	}
+	return worktrees, nil
+}
+
+// FindWorktreeWithBranch returns the worktree of the repository in |fs|, other than the one in |fs| itself, which has
+// |branch| checked out, or nil if there isn't one.
+func FindWorktreeWithBranch(fs filesys.Filesys, branch ref.DoltRef) (*Worktree, error) {
+	current, err := fs.Abs(".")
+	if err != nil {
+		return nil, err
+	}
+	return findWorktreeWithBranch(fs, branch, current)
+}
+
+func findWorktreeWithBranch(fs filesys.Filesys, branch ref.DoltRef, exclude string) (*Worktree, error) {
+	worktrees, err := ListWorktrees(fs)
+	if err != nil {
+		return nil, err
+	}
+	for _, wt := range worktrees {
+		if wt.Path != exclude && wt.Head != nil && ref.Equals(wt.Head, branch) {
+			return &wt, nil
+		}
+	}
+	return nil, nil
+}
+
+// CheckBranchNotInWorktree returns an error if |branch| is checked out in a worktree of the repository in |fs| other
+// than the one in |fs| itself.
+func CheckBranchNotInWorktree(fs filesys.Filesys, branch ref.DoltRef) error {
+	wt, err := FindWorktreeWithBranch(fs, branch)
+	if err != nil {
+		return err
+	}
+	if wt != nil {
+		return fmt.Errorf("branch '%s' is already checked out at '%s'", branch.GetPath(), wt.Path)
+	}
+	return nil
+}
+
+// AddWorktree creates a linked worktree of the repository in |fs| at |path|, with |branch| checked out. Remotes and
+// branch configuration are copied from |rs|.
+func AddWorktree(fs filesys.Filesys, rs *RepoState, path string, branch ref.BranchRef) (_ string, err error) {
+	doltDir, err := mainDoltDir(fs)
+	if err != nil {
+		return "", err
+	}
+	unlock, err := lockWorktreeList(fs, doltDir)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	absPath, err := fs.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if exists, isDir := fs.Exists(absPath); exists {
+		empty := isDir
+		if isDir {
+			_ = fs.Iter(absPath, false, func(string, int64, bool) bool {
+				empty = false
+				return true
+			})
+		}
+		if !empty {
+			return "", fmt.Errorf("'%s' already exists", path)
+		}
+	}
+
+	wt, err := findWorktreeWithBranch(fs, branch, "")
+	if err != nil {
+		return "", err
+	}
+	if wt != nil {
+		return "", fmt.Errorf("branch '%s' is already checked out at '%s'", branch.GetPath(), wt.Path)
+	}
+
+	list, err := loadWorktreeList(fs, doltDir)
+	if err != nil {
+		return "", err
+	}
+
+	if err = fs.MkDirs(filepath.Join(absPath, dbfactory.DoltDir)); err != nil {
+		return "", err
+	}
+	wfs, err := fs.WithWorkingDir(absPath)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(worktreeLink{DoltDir: doltDir}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err = wfs.WriteFile(getWorktreeLinkFile(), data, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	newRS := &RepoState{
+		Head:     ref.MarshalableRef{Ref: branch},
+		Remotes:  rs.Remotes.DeepCopy(),
+		Backups:  rs.Backups.DeepCopy(),
+		Branches: rs.Branches.DeepCopy(),
+	}
+	if err = newRS.Save(wfs); err != nil {
+		return "", err
+	}
+
+	list.Worktrees = append(list.Worktrees, absPath)
+	if err = list.save(fs, doltDir); err != nil {
+		return "", err
+	}
+	return absPath, nil
+}
+
+// RemoveWorktree deletes the linked worktree at |path| of the repository in |fs| and forgets it. The worktree is
+// forgotten even if its directory has already been deleted.
+func RemoveWorktree(fs filesys.Filesys, path string) (err error) {
+	doltDir, err := mainDoltDir(fs)
+	if err != nil {
+		return err
+	}
+	unlock, err := lockWorktreeList(fs, doltDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	absPath, err := fs.Abs(path)
+	if err != nil {
+		return err
+	}
+	list, err := loadWorktreeList(fs, doltDir)
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	for i, wt := range list.Worktrees {
+		if wt == absPath {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("'%s' is %w", path, ErrNotAWorktree)
+	}
+
+	if exists, _ := fs.Exists(absPath); exists {
+		if err = fs.Delete(absPath, true); err != nil {
+			return err
+		}
+	}
+
+	list.Worktrees = append(list.Worktrees[:idx], list.Worktrees[idx+1:]...)
+	return list.save(fs, doltDir)
+}
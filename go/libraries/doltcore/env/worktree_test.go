@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestWorktrees(t *testing.T) {
+	root := t.TempDir()
+	mainDir := filepath.Join(root, "main")
+	fs, err := filesys.LocalFilesysWithWorkingDir(filepath.ToSlash(root))
+	require.NoError(t, err)
+	require.NoError(t, fs.MkDirs(filepath.Join(mainDir, dbfactory.DoltDataDir)))
+	mainFS, err := fs.WithWorkingDir(mainDir)
+	require.NoError(t, err)
+
+	rs := &RepoState{
+		Head:     ref.MarshalableRef{Ref: ref.NewBranchRef(DefaultInitBranch)},
+		Remotes:  concurrentmap.New[string, Remote](),
+		Backups:  concurrentmap.New[string, Remote](),
+		Branches: concurrentmap.New[string, BranchConfig](),
+	}
+	require.NoError(t, rs.Save(mainFS))
+
+	feat := ref.NewBranchRef("feat")
+	featDir, err := AddWorktree(mainFS, rs, "../feat", feat)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "feat"), featDir)
+
+	featFS, err := fs.WithWorkingDir(featDir)
+	require.NoError(t, err)
+	assert.True(t, IsLinkedWorktree(featFS))
+	assert.False(t, IsLinkedWorktree(mainFS))
+	dataDir, ok, err := worktreeDataDir(featFS)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(mainDir, dbfactory.DoltDataDir), dataDir)
+
+	worktrees, err := ListWorktrees(featFS)
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	assert.Equal(t, Worktree{Path: mainDir, Head: ref.NewBranchRef(DefaultInitBranch), Main: true}, worktrees[0])
+	assert.Equal(t, Worktree{Path: featDir, Head: feat}, worktrees[1])
+
+	// a branch can only be checked out in one worktree
+	_, err = AddWorktree(mainFS, rs, "../other", feat)
+	assert.Error(t, err)
+	_, err = AddWorktree(featFS, rs, "../other", feat)
+	assert.Error(t, err)
+	assert.Error(t, CheckBranchNotInWorktree(mainFS, feat))
+	assert.NoError(t, CheckBranchNotInWorktree(featFS, feat))
+	assert.Error(t, CheckBranchNotInWorktree(featFS, ref.NewBranchRef(DefaultInitBranch)))
+	assert.NoError(t, CheckBranchNotInWorktree(mainFS, ref.NewBranchRef("other")))
+
+	// worktrees can't be created in directories with files in them
+	require.NoError(t, fs.MkDirs(filepath.Join(root, "full")))
+	require.NoError(t, fs.WriteFile(filepath.Join(root, "full", "file"), []byte("data"), 0644))
+	_, err = AddWorktree(mainFS, rs, "../full", ref.NewBranchRef("other"))
+	assert.Error(t, err)
+
+	require.NoError(t, RemoveWorktree(mainFS, "../feat"))
+	exists, _ := fs.Exists(featDir)
+	assert.False(t, exists)
+	worktrees, err = ListWorktrees(mainFS)
+	require.NoError(t, err)
+	assert.Len(t, worktrees, 1)
+	assert.NoError(t, CheckBranchNotInWorktree(mainFS, feat))
+
+	err = RemoveWorktree(mainFS, "../feat")
+	assert.ErrorIs(t, err, ErrNotAWorktree)
+}
+
+func TestWorktreeListLock(t *testing.T) {
+	root := t.TempDir()
+	fs, err := filesys.LocalFilesysWithWorkingDir(filepath.ToSlash(root))
+	require.NoError(t, err)
+	require.NoError(t, fs.MkDirs(filepath.Join(root, dbfactory.DoltDataDir)))
+	rs := &RepoState{
+		Head:     ref.MarshalableRef{Ref: ref.NewBranchRef(DefaultInitBranch)},
+		Remotes:  concurrentmap.New[string, Remote](),
+		Backups:  concurrentmap.New[string, Remote](),
+		Branches: concurrentmap.New[string, BranchConfig](),
+	}
+	require.NoError(t, rs.Save(fs))
+	doltDir, err := mainDoltDir(fs)
+	require.NoError(t, err)
+
+	defer func(timeout time.Duration) {
+		worktreesLockTimeout = timeout
+	}(worktreesLockTimeout)
+	worktreesLockTimeout = 100 * time.Millisecond
+
+	// another process changing the worktree list holds the lock
+	unlock, err := lockWorktreeList(fs, doltDir)
+	require.NoError(t, err)
+	_, err = AddWorktree(fs, rs, "../feat", ref.NewBranchRef("feat"))
+	assert.ErrorContains(t, err, "timed out")
+	require.NoError(t, unlock())
+
+	_, err = AddWorktree(fs, rs, "../feat", ref.NewBranchRef("feat"))
+	require.NoError(t, err)
+	require.NoError(t, RemoveWorktree(fs, "../feat"))
+}
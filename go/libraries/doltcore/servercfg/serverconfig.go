@@ -76,6 +76,46 @@ const (
 	DefaultMaxLoggedQueryLen         = 0
 	DefaultEncodeLoggedQuery         = false
 	DefaultCompressionLevel          = 1
+
+	DefaultClusterHeartbeatInterval = 500 * time.Millisecond
+	DefaultClusterElectionTimeout   = 5 * time.Second
+	DefaultClusterSemiSyncMinAcks   = 1
+	DefaultClusterSemiSyncTimeout   = 10 * time.Second
+
+	DefaultStorageMaintenanceCheckInterval    = 10 * time.Minute
+	DefaultStorageMaintenanceConjoinMinFiles  = 8
+	DefaultStorageMaintenanceConjoinMaxFileMB = 64
+	DefaultStorageMaintenanceArchiveZstdLevel = 3
+	MaxStorageMaintenanceArchiveZstdLevel     = 22
+
+	DefaultPostgresReplicationPort    = 5432
+	DefaultPostgresReplicationSSLMode = PostgresSSLModePrefer
+
+	DefaultTracingServiceName = "dolt-sql-server"
+	DefaultTracingSampleRatio = 1.0
+
+	DefaultAuditLogMaxSizeMB = 100
+	DefaultAuditLogMaxFiles  = 0
+)
+
+const (
+	// PostgresSSLModeDisable connects to Postgres without SSL.
+	PostgresSSLModeDisable = "disable"
+	// PostgresSSLModePrefer uses SSL if the Postgres server supports it, without verifying its certificate.
+	PostgresSSLModePrefer = "prefer"
+	// PostgresSSLModeRequire requires SSL, without verifying the server's certificate.
+	PostgresSSLModeRequire = "require"
+	// PostgresSSLModeVerifyCA requires SSL and a server certificate signed by a trusted certificate authority.
+	PostgresSSLModeVerifyCA = "verify-ca"
+	// PostgresSSLModeVerifyFull requires SSL and a trusted server certificate for the configured host.
+	PostgresSSLModeVerifyFull = "verify-full"
+)
+
+const (
+	// TracingExporterOTLP sends spans over OTLP/HTTP to a collector, usually one running locally.
+	TracingExporterOTLP = "otlp"
+	// TracingExporterFile writes spans to a file as JSON, one span per line.
+	TracingExporterFile = "file"
 )
 
 func ptr[T any](t T) *T {
@@ -109,6 +149,101 @@ type ClusterConfig interface {
 	BootstrapRole() string
 	BootstrapEpoch() int
 	RemotesAPIConfig() ClusterRemotesAPIConfig
+	AutoFailover() ClusterAutoFailoverConfig
+	// ReplicationMode is ClusterReplicationModeAsync or ClusterReplicationModeSemiSync.
+	ReplicationMode() string
+	SemiSync() ClusterSemiSyncConfig
+}
+
+const (
+	// In async replication, a commit on the primary returns as soon as it is written locally and is replicated to
+	// the standbys in the background.
+	ClusterReplicationModeAsync = "async"
+	// In semi_sync replication, a commit on the primary does not return until it has been persisted on at least
+	// ClusterSemiSyncConfig.MinAcks() standbys, or ClusterSemiSyncConfig.Timeout() has passed, in which case it
+	// returns with a warning. dolt_cluster_ack_writes_timeout_secs is ignored in this mode.
+	ClusterReplicationModeSemiSync = "semi_sync"
+)
+
+// ClusterSemiSyncConfig configures the acknowledgements a commit waits for when replication_mode is semi_sync.
+type ClusterSemiSyncConfig interface {
+	// MinAcks is the number of standbys which must persist a commit before it returns success.
+	MinAcks() int
+	// Timeout is how long a commit waits for its acknowledgements before returning with a warning. The commit is
+	// still applied on the primary and continues to replicate in the background.
+	Timeout() time.Duration
+}
+
+// ClusterAutoFailoverConfig configures the leader election a cluster can run for itself. When enabled, the primary
+// sends heartbeats to its standbys, and a caught up standby which stops hearing from the primary stands for
+// election, becoming the primary at a new epoch once a majority of the cluster votes for it.
+type ClusterAutoFailoverConfig interface {
+	Enable() bool
+	// HeartbeatInterval is how often the primary sends heartbeats to its standbys.
+	HeartbeatInterval() time.Duration
+	// ElectionTimeout is the length of the primary's lease. A standby which goes this long, plus two heartbeat
+	// intervals, without a heartbeat stands for election, and a primary which cannot reach a majority of the cluster
+	// for this long, less a heartbeat interval, steps down.
+	ElectionTimeout() time.Duration
+}
+
+// PostgresReplicationConfig configures the sql-server to subscribe to a PostgreSQL publication over logical
+// replication and apply the changes it streams to a Dolt database.
+type PostgresReplicationConfig interface {
+	Host() string
+	Port() int
+	User() string
+	// Password is sent to the server for md5 and SCRAM authentication, and for password authentication only over SSL.
+	Password() string
+	// SSLMode is how the connection to the server is secured, one of the PostgresSSLMode constants. It works like
+	// libpq's sslmode.
+	SSLMode() string
+	// SSLRootCert is the path of a PEM file with the certificate authorities trusted to sign the server's certificate
+	// in the verify-ca and verify-full SSL modes. When it is empty, the system's certificate authorities are trusted.
+	SSLRootCert() string
+	// Database is the PostgreSQL database the publication is in.
+	Database() string
+	// Slot is the name of the logical replication slot to stream changes from.
+	Slot() string
+	// CreateSlot is true if the slot should be created, using the pgoutput plugin, when it does not exist.
+	CreateSlot() bool
+	Publication() string
+	// TargetDatabase is the Dolt database the changes are applied to. Tables which do not exist in it are created
+	// from the publication's relations. Its pg_replication_lsn table records how far each slot has been applied.
+	TargetDatabase() string
+	// CommitInterval is how often the replicated changes are committed with dolt_commit. Zero means that they are
+	// applied to the working set without creating Dolt commits.
+	CommitInterval() time.Duration
+}
+
+// TracingConfig configures the OpenTelemetry spans the sql-server records for queries, storage reads and writes,
+// garbage collection and remote calls, and where it exports them.
+type TracingConfig interface {
+	// Exporter is either TracingExporterOTLP or TracingExporterFile.
+	Exporter() string
+	// Endpoint is the host:port of the OTLP/HTTP collector used by TracingExporterOTLP.
+	Endpoint() string
+	// Insecure is true if the OTLP/HTTP collector is reached over plain HTTP instead of HTTPS.
+	Insecure() bool
+	// File is the path of the file TracingExporterFile appends spans to.
+	File() string
+	// ServiceName is the service.name resource attribute of every span.
+	ServiceName() string
+	// SampleRatio is the fraction of traces, between 0 and 1, which are recorded. Traces continued from a caller
+	// follow the caller's sampling decision.
+	SampleRatio() float64
+}
+
+// AuditLogConfig configures the audit log, a hash chained JSON-lines file recording DDL, DML, privilege changes,
+// version control procedure calls, denied branch control checks and branch create and delete decisions. Passwords set
+// by recorded statements are redacted.
+type AuditLogConfig interface {
+	// File is the path of the audit log. Rotated files are kept next to it.
+	File() string
+	// MaxSizeMB is the size in megabytes after which the audit log is rotated.
+	MaxSizeMB() int
+	// MaxFiles is the number of rotated files to keep. Zero keeps all of them.
+	MaxFiles() int
 }
 
 type ClusterRemotesAPIConfig interface {
@@ -225,6 +360,12 @@ type ServerConfig interface {
 	MCPDatabase() *string
 	// ClusterConfig is the configuration for clustering in this sql-server.
 	ClusterConfig() ClusterConfig
+	// PostgresReplicationConfig is the configuration for replicating from PostgreSQL into this sql-server, or nil.
+	PostgresReplicationConfig() PostgresReplicationConfig
+	// TracingConfig is the configuration for exporting OpenTelemetry traces from this sql-server, or nil.
+	TracingConfig() TracingConfig
+	// AuditLogConfig is the configuration for the audit log of this sql-server, or nil.
+	AuditLogConfig() AuditLogConfig
 	// EventSchedulerStatus is the configuration for enabling or disabling the event scheduler in this server.
 	EventSchedulerStatus() string
 	// ValueSet returns whether the value string provided was explicitly set in the config
@@ -311,6 +452,15 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidatePostgresReplicationConfig(config.PostgresReplicationConfig()); err != nil {
+		return err
+	}
+	if err := ValidateTracingConfig(config.TracingConfig()); err != nil {
+		return err
+	}
+	if err := ValidateAuditLogConfig(config.AuditLogConfig()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
@@ -350,6 +500,9 @@ const (
 	RemotesapiPortKey               = "remotesapi_port"
 	RemotesapiReadOnlyKey           = "remotesapi_read_only"
 	ClusterConfigKey                = "cluster_config"
+	PostgresReplicationConfigKey    = "postgres_replication_config"
+	TracingConfigKey                = "tracing_config"
+	AuditLogConfigKey               = "audit_log_config"
 	EventSchedulerKey               = "event_scheduler"
 )
 
@@ -390,6 +543,84 @@ func ApplySystemVariables(ctx *sql.Context, cfg ServerConfig, sysVarTarget Syste
 	return nil
 }
 
+func ValidatePostgresReplicationConfig(config PostgresReplicationConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.Host() == "" {
+		return fmt.Errorf("postgres_replication: host must be set")
+	}
+	if config.Port() < 1 || config.Port() > 65535 {
+		return fmt.Errorf("postgres_replication: port must be in the range between 1-65535: %d", config.Port())
+	}
+	if config.User() == "" {
+		return fmt.Errorf("postgres_replication: user must be set")
+	}
+	switch config.SSLMode() {
+	case PostgresSSLModeDisable, PostgresSSLModePrefer, PostgresSSLModeRequire, PostgresSSLModeVerifyCA, PostgresSSLModeVerifyFull:
+	default:
+		return fmt.Errorf("postgres_replication: ssl_mode must be one of %s, %s, %s, %s or %s: %s", PostgresSSLModeDisable,
+			PostgresSSLModePrefer, PostgresSSLModeRequire, PostgresSSLModeVerifyCA, PostgresSSLModeVerifyFull, config.SSLMode())
+	}
+	if config.SSLRootCert() != "" && config.SSLMode() != PostgresSSLModeVerifyCA && config.SSLMode() != PostgresSSLModeVerifyFull {
+		return fmt.Errorf("postgres_replication: ssl_root_cert is only used with ssl_mode %s or %s", PostgresSSLModeVerifyCA, PostgresSSLModeVerifyFull)
+	}
+	if config.Database() == "" {
+		return fmt.Errorf("postgres_replication: database must be set")
+	}
+	if config.Slot() == "" {
+		return fmt.Errorf("postgres_replication: slot must be set")
+	}
+	if config.Publication() == "" {
+		return fmt.Errorf("postgres_replication: publication must be set")
+	}
+	if config.TargetDatabase() == "" {
+		return fmt.Errorf("postgres_replication: target_database must be set")
+	}
+	if config.CommitInterval() < 0 {
+		return fmt.Errorf("postgres_replication: commit_interval_millis must be >= 0: %v", config.CommitInterval())
+	}
+	return nil
+}
+
+func ValidateTracingConfig(config TracingConfig) error {
+	if config == nil {
+		return nil
+	}
+	switch config.Exporter() {
+	case TracingExporterOTLP:
+		if config.Endpoint() == "" {
+			return fmt.Errorf("tracing: endpoint must be set for the %s exporter", TracingExporterOTLP)
+		}
+	case TracingExporterFile:
+		if config.File() == "" {
+			return fmt.Errorf("tracing: file must be set for the %s exporter", TracingExporterFile)
+		}
+	default:
+		return fmt.Errorf("tracing: exporter must be one of %s or %s: %q", TracingExporterOTLP, TracingExporterFile, config.Exporter())
+	}
+	if config.SampleRatio() < 0 || config.SampleRatio() > 1 {
+		return fmt.Errorf("tracing: sample_ratio must be between 0 and 1: %v", config.SampleRatio())
+	}
+	return nil
+}
+
+func ValidateAuditLogConfig(config AuditLogConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.File() == "" {
+		return fmt.Errorf("audit_log: file must be set")
+	}
+	if config.MaxSizeMB() <= 0 {
+		return fmt.Errorf("audit_log: max_size_mb must be greater than 0: %d", config.MaxSizeMB())
+	}
+	if config.MaxFiles() < 0 {
+		return fmt.Errorf("audit_log: max_files cannot be negative: %d", config.MaxFiles())
+	}
+	return nil
+}
+
 func ValidateClusterConfig(config ClusterConfig) error {
 	if config == nil {
 		return nil
@@ -421,6 +652,30 @@ func ValidateClusterConfig(config ClusterConfig) error {
 	if config.RemotesAPIConfig().TLSKey() != "" && config.RemotesAPIConfig().TLSCert() == "" {
 		return fmt.Errorf("cluster: remotesapi: tls_cert: must supply a tls_cert if you supply a tls_key")
 	}
+	if failover := config.AutoFailover(); failover.Enable() {
+		if len(remotes) < 2 {
+			return fmt.Errorf("cluster: auto_failover: requires at least two standby_remotes so that a majority of the cluster can elect a new primary")
+		}
+		if failover.HeartbeatInterval() <= 0 {
+			return fmt.Errorf("cluster: auto_failover: heartbeat_interval_millis: must be greater than 0")
+		}
+		if failover.ElectionTimeout() < 2*failover.HeartbeatInterval() {
+			return fmt.Errorf("cluster: auto_failover: election_timeout_millis: is %d but must be at least twice heartbeat_interval_millis", failover.ElectionTimeout().Milliseconds())
+		}
+	}
+	switch config.ReplicationMode() {
+	case ClusterReplicationModeAsync:
+	case ClusterReplicationModeSemiSync:
+		semiSync := config.SemiSync()
+		if semiSync.MinAcks() < 1 || semiSync.MinAcks() > len(remotes) {
+			return fmt.Errorf("cluster: semi_sync: min_acks: is %d but must be between 1 and the number of standby_remotes, %d", semiSync.MinAcks(), len(remotes))
+		}
+		if semiSync.Timeout() <= 0 {
+			return fmt.Errorf("cluster: semi_sync: timeout_millis: must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("cluster: replication_mode: is \"%s\" but must be \"%s\" or \"%s\"", config.ReplicationMode(), ClusterReplicationModeAsync, ClusterReplicationModeSemiSync)
+	}
 	return nil
 }
 
@@ -496,4 +751,21 @@ func CheckForUnixSocket(config ServerConfig) (string, bool, error) {
 type AutoGCBehavior interface {
 	Enable() bool
 	ArchiveLevel() int
+	// StorageMaintenance returns the configuration of the storage maintenance performed alongside auto GC.
+	StorageMaintenance() StorageMaintenanceBehavior
+}
+
+// StorageMaintenanceBehavior defines when the storage files of old generations are conjoined and converted to
+// archives by a running server.
+type StorageMaintenanceBehavior interface {
+	Enable() bool
+	// CheckInterval is how often each database is checked for maintenance work.
+	CheckInterval() time.Duration
+	// ConjoinMinFiles is the number of small storage files which must accumulate before they are conjoined.
+	ConjoinMinFiles() int
+	// ConjoinMaxFileSize is the size in bytes at or below which a storage file is considered small.
+	ConjoinMaxFileSize() uint64
+	// ArchiveZstdLevel is the zstd compression level used when converting table files to archives. Zero disables
+	// the conversion.
+	ArchiveZstdLevel() int
 }
@@ -152,12 +152,15 @@ type YAMLConfig struct {
 	PrivilegeFile     *string                `yaml:"privilege_file,omitempty"`
 	BranchControlFile *string                `yaml:"branch_control_file,omitempty"`
 	// TODO: Rename to UserVars_
-	Vars            []UserSessionVars      `yaml:"user_session_vars"`
-	SystemVars_     map[string]interface{} `yaml:"system_variables,omitempty" minver:"1.11.1"`
-	Jwks            []JwksConfig           `yaml:"jwks"`
-	GoldenMysqlConn *string                `yaml:"golden_mysql_conn,omitempty"`
-	MetricsConfig   MetricsYAMLConfig      `yaml:"metrics,omitempty"`
-	ClusterCfg      *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
+	Vars                   []UserSessionVars              `yaml:"user_session_vars"`
+	SystemVars_            map[string]interface{}         `yaml:"system_variables,omitempty" minver:"1.11.1"`
+	Jwks                   []JwksConfig                   `yaml:"jwks"`
+	GoldenMysqlConn        *string                        `yaml:"golden_mysql_conn,omitempty"`
+	MetricsConfig          MetricsYAMLConfig              `yaml:"metrics,omitempty"`
+	ClusterCfg             *ClusterYAMLConfig             `yaml:"cluster,omitempty"`
+	PostgresReplicationCfg *PostgresReplicationYAMLConfig `yaml:"postgres_replication,omitempty" minver:"TBD"`
+	TracingCfg             *TracingYAMLConfig             `yaml:"tracing,omitempty" minver:"TBD"`
+	AuditLogCfg            *AuditLogYAMLConfig            `yaml:"audit_log,omitempty" minver:"TBD"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -230,12 +233,15 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			Port_:     cfg.RemotesapiPort(),
 			ReadOnly_: cfg.RemotesapiReadOnly(),
 		},
-		ClusterCfg:        clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
-		PrivilegeFile:     ptr(cfg.PrivilegeFilePath()),
-		BranchControlFile: ptr(cfg.BranchControlFilePath()),
-		SystemVars_:       systemVars,
-		Vars:              cfg.UserVars(),
-		Jwks:              cfg.JwksConfig(),
+		ClusterCfg:             clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
+		PostgresReplicationCfg: postgresReplicationConfigAsYAMLConfig(cfg.PostgresReplicationConfig()),
+		TracingCfg:             tracingConfigAsYAMLConfig(cfg.TracingConfig()),
+		AuditLogCfg:            auditLogConfigAsYAMLConfig(cfg.AuditLogConfig()),
+		PrivilegeFile:          ptr(cfg.PrivilegeFilePath()),
+		BranchControlFile:      ptr(cfg.BranchControlFilePath()),
+		SystemVars_:            systemVars,
+		Vars:                   cfg.UserVars(),
+		Jwks:                   cfg.JwksConfig(),
 	}
 }
 
@@ -257,6 +263,9 @@ func clusterConfigAsYAMLConfig(config ClusterConfig) *ClusterYAMLConfig {
 			URLMatches: config.RemotesAPIConfig().ServerNameURLMatches(),
 			DNSMatches: config.RemotesAPIConfig().ServerNameDNSMatches(),
 		},
+		AutoFailover_:    toClusterAutoFailoverYAML(config.AutoFailover()),
+		ReplicationMode_: toClusterReplicationModeYAML(config.ReplicationMode()),
+		SemiSync_:        toClusterSemiSyncYAML(config.ReplicationMode(), config.SemiSync()),
 	}
 }
 
@@ -302,12 +311,15 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			Port_:     zeroIf(cfg.RemotesapiPort(), !cfg.ValueSet(RemotesapiPortKey)),
 			ReadOnly_: zeroIf(cfg.RemotesapiReadOnly(), !cfg.ValueSet(RemotesapiReadOnlyKey)),
 		},
-		ClusterCfg:        zeroIf(clusterConfigAsYAMLConfig(cfg.ClusterConfig()), !cfg.ValueSet(ClusterConfigKey)),
-		PrivilegeFile:     zeroIf(ptr(cfg.PrivilegeFilePath()), !cfg.ValueSet(PrivilegeFilePathKey)),
-		BranchControlFile: zeroIf(ptr(cfg.BranchControlFilePath()), !cfg.ValueSet(BranchControlFilePathKey)),
-		SystemVars_:       zeroIf(systemVars, !cfg.ValueSet(SystemVarsKey)),
-		Vars:              zeroIf(cfg.UserVars(), !cfg.ValueSet(UserVarsKey)),
-		Jwks:              zeroIf(cfg.JwksConfig(), !cfg.ValueSet(JwksConfigKey)),
+		ClusterCfg:             zeroIf(clusterConfigAsYAMLConfig(cfg.ClusterConfig()), !cfg.ValueSet(ClusterConfigKey)),
+		PostgresReplicationCfg: zeroIf(postgresReplicationConfigAsYAMLConfig(cfg.PostgresReplicationConfig()), !cfg.ValueSet(PostgresReplicationConfigKey)),
+		TracingCfg:             zeroIf(tracingConfigAsYAMLConfig(cfg.TracingConfig()), !cfg.ValueSet(TracingConfigKey)),
+		AuditLogCfg:            zeroIf(auditLogConfigAsYAMLConfig(cfg.AuditLogConfig()), !cfg.ValueSet(AuditLogConfigKey)),
+		PrivilegeFile:          zeroIf(ptr(cfg.PrivilegeFilePath()), !cfg.ValueSet(PrivilegeFilePathKey)),
+		BranchControlFile:      zeroIf(ptr(cfg.BranchControlFilePath()), !cfg.ValueSet(BranchControlFilePathKey)),
+		SystemVars_:            zeroIf(systemVars, !cfg.ValueSet(SystemVarsKey)),
+		Vars:                   zeroIf(cfg.UserVars(), !cfg.ValueSet(UserVarsKey)),
+		Jwks:                   zeroIf(cfg.JwksConfig(), !cfg.ValueSet(JwksConfigKey)),
 	}
 }
 
@@ -918,6 +930,27 @@ func (cfg YAMLConfig) ClusterConfig() ClusterConfig {
 	return cfg.ClusterCfg
 }
 
+func (cfg YAMLConfig) PostgresReplicationConfig() PostgresReplicationConfig {
+	if cfg.PostgresReplicationCfg == nil {
+		return nil
+	}
+	return cfg.PostgresReplicationCfg
+}
+
+func (cfg YAMLConfig) TracingConfig() TracingConfig {
+	if cfg.TracingCfg == nil {
+		return nil
+	}
+	return cfg.TracingCfg
+}
+
+func (cfg YAMLConfig) AuditLogConfig() AuditLogConfig {
+	if cfg.AuditLogCfg == nil {
+		return nil
+	}
+	return cfg.AuditLogCfg
+}
+
 func (cfg YAMLConfig) AutoGCBehavior() AutoGCBehavior {
 	if cfg.BehaviorConfig.AutoGCBehavior == nil {
 		return nil
@@ -940,10 +973,13 @@ func (cfg YAMLConfig) EventSchedulerStatus() string {
 }
 
 type ClusterYAMLConfig struct {
-	StandbyRemotes_ []StandbyRemoteYAMLConfig   `yaml:"standby_remotes"`
-	BootstrapRole_  string                      `yaml:"bootstrap_role"`
-	BootstrapEpoch_ int                         `yaml:"bootstrap_epoch"`
-	RemotesAPI      ClusterRemotesAPIYAMLConfig `yaml:"remotesapi"`
+	StandbyRemotes_  []StandbyRemoteYAMLConfig      `yaml:"standby_remotes"`
+	BootstrapRole_   string                         `yaml:"bootstrap_role"`
+	BootstrapEpoch_  int                            `yaml:"bootstrap_epoch"`
+	RemotesAPI       ClusterRemotesAPIYAMLConfig    `yaml:"remotesapi"`
+	AutoFailover_    *ClusterAutoFailoverYAMLConfig `yaml:"auto_failover,omitempty" minver:"TBD"`
+	ReplicationMode_ *string                        `yaml:"replication_mode,omitempty" minver:"TBD"`
+	SemiSync_        *ClusterSemiSyncYAMLConfig     `yaml:"semi_sync,omitempty" minver:"TBD"`
 }
 
 type StandbyRemoteYAMLConfig struct {
@@ -979,6 +1015,106 @@ func (c *ClusterYAMLConfig) RemotesAPIConfig() ClusterRemotesAPIConfig {
 	return c.RemotesAPI
 }
 
+func (c *ClusterYAMLConfig) AutoFailover() ClusterAutoFailoverConfig {
+	if c.AutoFailover_ == nil {
+		return &ClusterAutoFailoverYAMLConfig{}
+	}
+	return c.AutoFailover_
+}
+
+func (c *ClusterYAMLConfig) ReplicationMode() string {
+	if c.ReplicationMode_ == nil {
+		return ClusterReplicationModeAsync
+	}
+	return *c.ReplicationMode_
+}
+
+func (c *ClusterYAMLConfig) SemiSync() ClusterSemiSyncConfig {
+	if c.SemiSync_ == nil {
+		return &ClusterSemiSyncYAMLConfig{}
+	}
+	return c.SemiSync_
+}
+
+type ClusterAutoFailoverYAMLConfig struct {
+	Enable_                  *bool   `yaml:"enable,omitempty" minver:"TBD"`
+	HeartbeatIntervalMillis_ *uint64 `yaml:"heartbeat_interval_millis,omitempty" minver:"TBD"`
+	ElectionTimeoutMillis_   *uint64 `yaml:"election_timeout_millis,omitempty" minver:"TBD"`
+}
+
+func (c *ClusterAutoFailoverYAMLConfig) Enable() bool {
+	if c.Enable_ == nil {
+		return false
+	}
+	return *c.Enable_
+}
+
+func (c *ClusterAutoFailoverYAMLConfig) HeartbeatInterval() time.Duration {
+	if c.HeartbeatIntervalMillis_ == nil {
+		return DefaultClusterHeartbeatInterval
+	}
+	return time.Duration(*c.HeartbeatIntervalMillis_) * time.Millisecond
+}
+
+func (c *ClusterAutoFailoverYAMLConfig) ElectionTimeout() time.Duration {
+	if c.ElectionTimeoutMillis_ == nil {
+		return DefaultClusterElectionTimeout
+	}
+	return time.Duration(*c.ElectionTimeoutMillis_) * time.Millisecond
+}
+
+// toClusterAutoFailoverYAML returns the YAML config for |c|, or nil if auto failover is disabled, in which case the
+// block is omitted.
+func toClusterAutoFailoverYAML(c ClusterAutoFailoverConfig) *ClusterAutoFailoverYAMLConfig {
+	if c == nil || !c.Enable() {
+		return nil
+	}
+	return &ClusterAutoFailoverYAMLConfig{
+		Enable_:                  ptr(true),
+		HeartbeatIntervalMillis_: ptr(uint64(c.HeartbeatInterval().Milliseconds())),
+		ElectionTimeoutMillis_:   ptr(uint64(c.ElectionTimeout().Milliseconds())),
+	}
+}
+
+type ClusterSemiSyncYAMLConfig struct {
+	MinAcks_       *int    `yaml:"min_acks,omitempty" minver:"TBD"`
+	TimeoutMillis_ *uint64 `yaml:"timeout_millis,omitempty" minver:"TBD"`
+}
+
+func (c *ClusterSemiSyncYAMLConfig) MinAcks() int {
+	if c.MinAcks_ == nil {
+		return DefaultClusterSemiSyncMinAcks
+	}
+	return *c.MinAcks_
+}
+
+func (c *ClusterSemiSyncYAMLConfig) Timeout() time.Duration {
+	if c.TimeoutMillis_ == nil {
+		return DefaultClusterSemiSyncTimeout
+	}
+	return time.Duration(*c.TimeoutMillis_) * time.Millisecond
+}
+
+// toClusterReplicationModeYAML returns nil for the default async mode, in which case replication_mode is omitted.
+func toClusterReplicationModeYAML(mode string) *string {
+	if mode == "" || mode == ClusterReplicationModeAsync {
+		return nil
+	}
+	return &mode
+}
+
+// toClusterSemiSyncYAML returns the YAML config for |c|, or nil if |mode| is not semi_sync, in which case the block
+// is omitted.
+func toClusterSemiSyncYAML(mode string, c ClusterSemiSyncConfig) *ClusterSemiSyncYAMLConfig {
+	if mode != ClusterReplicationModeSemiSync || c == nil {
+		return nil
+	}
+	return &ClusterSemiSyncYAMLConfig{
+		MinAcks_:       ptr(c.MinAcks()),
+		TimeoutMillis_: ptr(uint64(c.Timeout().Milliseconds())),
+	}
+}
+
 type ClusterRemotesAPIYAMLConfig struct {
 	Addr_      string   `yaml:"address"`
 	Port_      int      `yaml:"port"`
@@ -1031,13 +1167,20 @@ func (cfg YAMLConfig) ValueSet(value string) bool {
 		return cfg.ListenerConfig.MaxConnectionsTimeoutMs != nil
 	case EventSchedulerKey:
 		return cfg.BehaviorConfig.EventSchedulerStatus != nil
+	case PostgresReplicationConfigKey:
+		return cfg.PostgresReplicationCfg != nil
+	case TracingConfigKey:
+		return cfg.TracingCfg != nil
+	case AuditLogConfigKey:
+		return cfg.AuditLogCfg != nil
 	}
 	return false
 }
 
 type AutoGCBehaviorYAMLConfig struct {
-	Enable_       *bool `yaml:"enable,omitempty" minver:"1.50.0"`
-	ArchiveLevel_ *int  `yaml:"archive_level,omitempty" minver:"1.52.1"`
+	Enable_             *bool                         `yaml:"enable,omitempty" minver:"1.50.0"`
+	ArchiveLevel_       *int                          `yaml:"archive_level,omitempty" minver:"1.52.1"`
+	StorageMaintenance_ *StorageMaintenanceYAMLConfig `yaml:"storage_maintenance,omitempty" minver:"TBD"`
 }
 
 func (a *AutoGCBehaviorYAMLConfig) Enable() bool {
@@ -1054,9 +1197,300 @@ func (a *AutoGCBehaviorYAMLConfig) ArchiveLevel() int {
 	return *a.ArchiveLevel_
 }
 
+func (a *AutoGCBehaviorYAMLConfig) StorageMaintenance() StorageMaintenanceBehavior {
+	if a.StorageMaintenance_ == nil {
+		return &StorageMaintenanceYAMLConfig{}
+	}
+	return a.StorageMaintenance_
+}
+
 func toAutoGCBehaviorYAML(a AutoGCBehavior) *AutoGCBehaviorYAMLConfig {
 	return &AutoGCBehaviorYAMLConfig{
-		Enable_:       ptr(a.Enable()),
-		ArchiveLevel_: ptr(a.ArchiveLevel()),
+		Enable_:             ptr(a.Enable()),
+		ArchiveLevel_:       ptr(a.ArchiveLevel()),
+		StorageMaintenance_: toStorageMaintenanceYAML(a.StorageMaintenance()),
+	}
+}
+
+type StorageMaintenanceYAMLConfig struct {
+	Enable_            *bool   `yaml:"enable,omitempty" minver:"TBD"`
+	CheckIntervalSecs_ *uint64 `yaml:"check_interval_secs,omitempty" minver:"TBD"`
+	ConjoinMinFiles_   *int    `yaml:"conjoin_min_files,omitempty" minver:"TBD"`
+	ConjoinMaxFileMB_  *uint64 `yaml:"conjoin_max_file_mb,omitempty" minver:"TBD"`
+	ArchiveZstdLevel_  *int    `yaml:"archive_zstd_level,omitempty" minver:"TBD"`
+}
+
+func (s *StorageMaintenanceYAMLConfig) Enable() bool {
+	if s.Enable_ == nil {
+		return false
+	}
+	return *s.Enable_
+}
+
+func (s *StorageMaintenanceYAMLConfig) CheckInterval() time.Duration {
+	if s.CheckIntervalSecs_ == nil {
+		return DefaultStorageMaintenanceCheckInterval
+	}
+	return time.Duration(*s.CheckIntervalSecs_) * time.Second
+}
+
+func (s *StorageMaintenanceYAMLConfig) ConjoinMinFiles() int {
+	if s.ConjoinMinFiles_ == nil {
+		return DefaultStorageMaintenanceConjoinMinFiles
+	}
+	return *s.ConjoinMinFiles_
+}
+
+func (s *StorageMaintenanceYAMLConfig) ConjoinMaxFileSize() uint64 {
+	if s.ConjoinMaxFileMB_ == nil {
+		return DefaultStorageMaintenanceConjoinMaxFileMB << 20
+	}
+	return *s.ConjoinMaxFileMB_ << 20
+}
+
+func (s *StorageMaintenanceYAMLConfig) ArchiveZstdLevel() int {
+	if s.ArchiveZstdLevel_ == nil {
+		return DefaultStorageMaintenanceArchiveZstdLevel
+	}
+	return *s.ArchiveZstdLevel_
+}
+
+// toStorageMaintenanceYAML returns the YAML config for |s|, or nil if storage maintenance is disabled, in which case
+// the block is omitted.
+func toStorageMaintenanceYAML(s StorageMaintenanceBehavior) *StorageMaintenanceYAMLConfig {
+	if s == nil || !s.Enable() {
+		return nil
+	}
+	return &StorageMaintenanceYAMLConfig{
+		Enable_:            ptr(s.Enable()),
+		CheckIntervalSecs_: ptr(uint64(s.CheckInterval() / time.Second)),
+		ConjoinMinFiles_:   ptr(s.ConjoinMinFiles()),
+		ConjoinMaxFileMB_:  ptr(s.ConjoinMaxFileSize() >> 20),
+		ArchiveZstdLevel_:  ptr(s.ArchiveZstdLevel()),
+	}
+}
+
+type PostgresReplicationYAMLConfig struct {
+	Host_                 *string `yaml:"host,omitempty" minver:"TBD"`
+	Port_                 *int    `yaml:"port,omitempty" minver:"TBD"`
+	User_                 *string `yaml:"user,omitempty" minver:"TBD"`
+	Password_             *string `yaml:"password,omitempty" minver:"TBD"`
+	SSLMode_              *string `yaml:"ssl_mode,omitempty" minver:"TBD"`
+	SSLRootCert_          *string `yaml:"ssl_root_cert,omitempty" minver:"TBD"`
+	Database_             *string `yaml:"database,omitempty" minver:"TBD"`
+	Slot_                 *string `yaml:"slot,omitempty" minver:"TBD"`
+	CreateSlot_           *bool   `yaml:"create_slot,omitempty" minver:"TBD"`
+	Publication_          *string `yaml:"publication,omitempty" minver:"TBD"`
+	TargetDatabase_       *string `yaml:"target_database,omitempty" minver:"TBD"`
+	CommitIntervalMillis_ *uint64 `yaml:"commit_interval_millis,omitempty" minver:"TBD"`
+}
+
+func (c *PostgresReplicationYAMLConfig) Host() string {
+	if c.Host_ == nil {
+		return ""
+	}
+	return *c.Host_
+}
+
+func (c *PostgresReplicationYAMLConfig) Port() int {
+	if c.Port_ == nil {
+		return DefaultPostgresReplicationPort
+	}
+	return *c.Port_
+}
+
+func (c *PostgresReplicationYAMLConfig) User() string {
+	if c.User_ == nil {
+		return ""
+	}
+	return *c.User_
+}
+
+func (c *PostgresReplicationYAMLConfig) Password() string {
+	if c.Password_ == nil {
+		return ""
+	}
+	return *c.Password_
+}
+
+func (c *PostgresReplicationYAMLConfig) SSLMode() string {
+	if c.SSLMode_ == nil {
+		return DefaultPostgresReplicationSSLMode
+	}
+	return *c.SSLMode_
+}
+
+func (c *PostgresReplicationYAMLConfig) SSLRootCert() string {
+	if c.SSLRootCert_ == nil {
+		return ""
+	}
+	return *c.SSLRootCert_
+}
+
+func (c *PostgresReplicationYAMLConfig) Database() string {
+	if c.Database_ == nil {
+		return ""
+	}
+	return *c.Database_
+}
+
+func (c *PostgresReplicationYAMLConfig) Slot() string {
+	if c.Slot_ == nil {
+		return ""
+	}
+	return *c.Slot_
+}
+
+func (c *PostgresReplicationYAMLConfig) CreateSlot() bool {
+	if c.CreateSlot_ == nil {
+		return false
+	}
+	return *c.CreateSlot_
+}
+
+func (c *PostgresReplicationYAMLConfig) Publication() string {
+	if c.Publication_ == nil {
+		return ""
+	}
+	return *c.Publication_
+}
+
+func (c *PostgresReplicationYAMLConfig) TargetDatabase() string {
+	if c.TargetDatabase_ == nil {
+		return ""
+	}
+	return *c.TargetDatabase_
+}
+
+func (c *PostgresReplicationYAMLConfig) CommitInterval() time.Duration {
+	if c.CommitIntervalMillis_ == nil {
+		return 0
+	}
+	return time.Duration(*c.CommitIntervalMillis_) * time.Millisecond
+}
+
+func postgresReplicationConfigAsYAMLConfig(config PostgresReplicationConfig) *PostgresReplicationYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	yc := &PostgresReplicationYAMLConfig{
+		Host_:           nillableStrPtr(config.Host()),
+		Port_:           ptr(config.Port()),
+		User_:           nillableStrPtr(config.User()),
+		Password_:       nillableStrPtr(config.Password()),
+		SSLMode_:        ptr(config.SSLMode()),
+		SSLRootCert_:    nillableStrPtr(config.SSLRootCert()),
+		Database_:       nillableStrPtr(config.Database()),
+		Slot_:           nillableStrPtr(config.Slot()),
+		CreateSlot_:     nillableBoolPtr(config.CreateSlot()),
+		Publication_:    nillableStrPtr(config.Publication()),
+		TargetDatabase_: nillableStrPtr(config.TargetDatabase()),
+	}
+	if config.CommitInterval() != 0 {
+		yc.CommitIntervalMillis_ = ptr(uint64(config.CommitInterval().Milliseconds()))
+	}
+	return yc
+}
+
+type TracingYAMLConfig struct {
+	Exporter_    *string  `yaml:"exporter,omitempty" minver:"TBD"`
+	Endpoint_    *string  `yaml:"endpoint,omitempty" minver:"TBD"`
+	Insecure_    *bool    `yaml:"insecure,omitempty" minver:"TBD"`
+	File_        *string  `yaml:"file,omitempty" minver:"TBD"`
+	ServiceName_ *string  `yaml:"service_name,omitempty" minver:"TBD"`
+	SampleRatio_ *float64 `yaml:"sample_ratio,omitempty" minver:"TBD"`
+}
+
+func (c *TracingYAMLConfig) Exporter() string {
+	if c.Exporter_ == nil {
+		return ""
+	}
+	return *c.Exporter_
+}
+
+func (c *TracingYAMLConfig) Endpoint() string {
+	if c.Endpoint_ == nil {
+		return ""
+	}
+	return *c.Endpoint_
+}
+
+func (c *TracingYAMLConfig) Insecure() bool {
+	if c.Insecure_ == nil {
+		return false
+	}
+	return *c.Insecure_
+}
+
+func (c *TracingYAMLConfig) File() string {
+	if c.File_ == nil {
+		return ""
+	}
+	return *c.File_
+}
+
+func (c *TracingYAMLConfig) ServiceName() string {
+	if c.ServiceName_ == nil {
+		return DefaultTracingServiceName
+	}
+	return *c.ServiceName_
+}
+
+func (c *TracingYAMLConfig) SampleRatio() float64 {
+	if c.SampleRatio_ == nil {
+		return DefaultTracingSampleRatio
+	}
+	return *c.SampleRatio_
+}
+
+func tracingConfigAsYAMLConfig(config TracingConfig) *TracingYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &TracingYAMLConfig{
+		Exporter_:    nillableStrPtr(config.Exporter()),
+		Endpoint_:    nillableStrPtr(config.Endpoint()),
+		Insecure_:    nillableBoolPtr(config.Insecure()),
+		File_:        nillableStrPtr(config.File()),
+		ServiceName_: ptr(config.ServiceName()),
+		SampleRatio_: ptr(config.SampleRatio()),
+	}
+}
+
+type AuditLogYAMLConfig struct {
+	File_      *string `yaml:"file,omitempty" minver:"TBD"`
+	MaxSizeMB_ *int    `yaml:"max_size_mb,omitempty" minver:"TBD"`
+	MaxFiles_  *int    `yaml:"max_files,omitempty" minver:"TBD"`
+}
+
+func (c *AuditLogYAMLConfig) File() string {
+	if c.File_ == nil {
+		return ""
+	}
+	return *c.File_
+}
+
+func (c *AuditLogYAMLConfig) MaxSizeMB() int {
+	if c.MaxSizeMB_ == nil {
+		return DefaultAuditLogMaxSizeMB
+	}
+	return *c.MaxSizeMB_
+}
+
+func (c *AuditLogYAMLConfig) MaxFiles() int {
+	if c.MaxFiles_ == nil {
+		return DefaultAuditLogMaxFiles
+	}
+	return *c.MaxFiles_
+}
+
+func auditLogConfigAsYAMLConfig(config AuditLogConfig) *AuditLogYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &AuditLogYAMLConfig{
+		File_:      nillableStrPtr(config.File()),
+		MaxSizeMB_: ptr(config.MaxSizeMB()),
+		MaxFiles_:  ptr(config.MaxFiles()),
 	}
 }
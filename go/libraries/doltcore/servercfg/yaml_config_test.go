@@ -16,6 +16,7 @@ package servercfg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -158,6 +159,45 @@ remotesapi:
 	require.Equal(t, 8000, *config.RemotesapiPort())
 }
 
+func TestUnmarshallStorageMaintenance(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+behavior:
+  auto_gc_behavior:
+    enable: true
+`))
+	require.NoError(t, err)
+	maintenance := config.AutoGCBehavior().StorageMaintenance()
+	assert.False(t, maintenance.Enable())
+	assert.Equal(t, DefaultStorageMaintenanceCheckInterval, maintenance.CheckInterval())
+	assert.Equal(t, DefaultStorageMaintenanceConjoinMinFiles, maintenance.ConjoinMinFiles())
+	assert.Equal(t, uint64(DefaultStorageMaintenanceConjoinMaxFileMB<<20), maintenance.ConjoinMaxFileSize())
+	assert.Equal(t, DefaultStorageMaintenanceArchiveZstdLevel, maintenance.ArchiveZstdLevel())
+	assert.NotContains(t, config.String(), "storage_maintenance")
+
+	config, err = NewYamlConfig([]byte(`
+behavior:
+  auto_gc_behavior:
+    enable: true
+    storage_maintenance:
+      enable: true
+      check_interval_secs: 60
+      conjoin_min_files: 4
+      conjoin_max_file_mb: 16
+      archive_zstd_level: 0
+`))
+	require.NoError(t, err)
+	maintenance = config.AutoGCBehavior().StorageMaintenance()
+	assert.True(t, maintenance.Enable())
+	assert.Equal(t, time.Minute, maintenance.CheckInterval())
+	assert.Equal(t, 4, maintenance.ConjoinMinFiles())
+	assert.Equal(t, uint64(16<<20), maintenance.ConjoinMaxFileSize())
+	assert.Equal(t, 0, maintenance.ArchiveZstdLevel())
+
+	roundTripped, err := NewYamlConfig([]byte(config.String()))
+	require.NoError(t, err)
+	assert.Equal(t, config.AutoGCBehavior(), roundTripped.AutoGCBehavior())
+}
+
 func TestUnmarshallCluster(t *testing.T) {
 	testStr := `
 cluster:
@@ -179,6 +219,67 @@ cluster:
 	require.Equal(t, 0, config.ClusterConfig().BootstrapEpoch())
 	require.Equal(t, "standby", config.ClusterConfig().StandbyRemotes()[0].Name())
 	require.Equal(t, "http://doltdb-1.doltdb:50051/{database}", config.ClusterConfig().StandbyRemotes()[0].RemoteURLTemplate())
+	require.False(t, config.ClusterConfig().AutoFailover().Enable())
+	require.Equal(t, DefaultClusterHeartbeatInterval, config.ClusterConfig().AutoFailover().HeartbeatInterval())
+	require.Equal(t, DefaultClusterElectionTimeout, config.ClusterConfig().AutoFailover().ElectionTimeout())
+	require.Equal(t, ClusterReplicationModeAsync, config.ClusterConfig().ReplicationMode())
+	require.Equal(t, DefaultClusterSemiSyncMinAcks, config.ClusterConfig().SemiSync().MinAcks())
+	require.Equal(t, DefaultClusterSemiSyncTimeout, config.ClusterConfig().SemiSync().Timeout())
+}
+
+func TestUnmarshallClusterAutoFailover(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+cluster:
+  standby_remotes:
+  - name: standby1
+    remote_url_template: http://doltdb-1.doltdb:50051/{database}
+  - name: standby2
+    remote_url_template: http://doltdb-2.doltdb:50051/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 1
+  remotesapi:
+    port: 50051
+  auto_failover:
+    enable: true
+    heartbeat_interval_millis: 250
+    election_timeout_millis: 2000
+`))
+	require.NoError(t, err)
+	failover := config.ClusterConfig().AutoFailover()
+	assert.True(t, failover.Enable())
+	assert.Equal(t, 250*time.Millisecond, failover.HeartbeatInterval())
+	assert.Equal(t, 2*time.Second, failover.ElectionTimeout())
+	assert.NoError(t, ValidateClusterConfig(config.ClusterConfig()))
+}
+
+func TestUnmarshallClusterSemiSync(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+cluster:
+  standby_remotes:
+  - name: standby1
+    remote_url_template: http://doltdb-1.doltdb:50051/{database}
+  - name: standby2
+    remote_url_template: http://doltdb-2.doltdb:50051/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 1
+  remotesapi:
+    port: 50051
+  replication_mode: semi_sync
+  semi_sync:
+    min_acks: 2
+    timeout_millis: 1500
+`))
+	require.NoError(t, err)
+	cluster := config.ClusterConfig()
+	assert.Equal(t, ClusterReplicationModeSemiSync, cluster.ReplicationMode())
+	assert.Equal(t, 2, cluster.SemiSync().MinAcks())
+	assert.Equal(t, 1500*time.Millisecond, cluster.SemiSync().Timeout())
+	assert.NoError(t, ValidateClusterConfig(cluster))
+
+	roundTripped, err := NewYamlConfig([]byte(config.String()))
+	require.NoError(t, err)
+	assert.Equal(t, cluster.ReplicationMode(), roundTripped.ClusterConfig().ReplicationMode())
+	assert.Equal(t, cluster.SemiSync(), roundTripped.ClusterConfig().SemiSync())
 }
 
 func TestValidateClusterConfig(t *testing.T) {
@@ -259,6 +360,74 @@ cluster:
   bootstrap_epoch: 0
   remotesapi:
     port: 50051
+`,
+			Error: true,
+		},
+		{
+			Name: "auto_failover with one standby",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby
+    remote_url_template: http://localhost:50051/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50051
+  auto_failover:
+    enable: true
+`,
+			Error: true,
+		},
+		{
+			Name: "auto_failover election timeout shorter than heartbeats",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby1
+    remote_url_template: http://localhost:50051/{database}
+  - name: standby2
+    remote_url_template: http://localhost:50052/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50051
+  auto_failover:
+    enable: true
+    heartbeat_interval_millis: 1000
+    election_timeout_millis: 1500
+`,
+			Error: true,
+		},
+		{
+			Name: "bad replication_mode",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby
+    remote_url_template: http://localhost:50051/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50051
+  replication_mode: sync
+`,
+			Error: true,
+		},
+		{
+			Name: "semi_sync min_acks more than standby remotes",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby
+    remote_url_template: http://localhost:50051/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50051
+  replication_mode: semi_sync
+  semi_sync:
+    min_acks: 2
 `,
 			Error: true,
 		},
@@ -288,6 +457,165 @@ cluster:
 	}
 }
 
+func TestUnmarshallPostgresReplication(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+postgres_replication:
+  host: pg.example.com
+  user: replicator
+  password: secret
+  ssl_mode: verify-full
+  ssl_root_cert: /etc/dolt/pg-ca.pem
+  database: app
+  slot: dolt_slot
+  create_slot: true
+  publication: dolt_pub
+  target_database: app_history
+  commit_interval_millis: 30000
+`))
+	require.NoError(t, err)
+	pg := config.PostgresReplicationConfig()
+	require.NotNil(t, pg)
+	assert.Equal(t, "pg.example.com", pg.Host())
+	assert.Equal(t, DefaultPostgresReplicationPort, pg.Port())
+	assert.Equal(t, "replicator", pg.User())
+	assert.Equal(t, "secret", pg.Password())
+	assert.Equal(t, PostgresSSLModeVerifyFull, pg.SSLMode())
+	assert.Equal(t, "/etc/dolt/pg-ca.pem", pg.SSLRootCert())
+	assert.Equal(t, "app", pg.Database())
+	assert.Equal(t, "dolt_slot", pg.Slot())
+	assert.True(t, pg.CreateSlot())
+	assert.Equal(t, "dolt_pub", pg.Publication())
+	assert.Equal(t, "app_history", pg.TargetDatabase())
+	assert.Equal(t, 30*time.Second, pg.CommitInterval())
+	assert.NoError(t, ValidatePostgresReplicationConfig(pg))
+
+	roundTripped, err := NewYamlConfig([]byte(config.String()))
+	require.NoError(t, err)
+	assert.Equal(t, pg, roundTripped.PostgresReplicationConfig())
+
+	config, err = NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.PostgresReplicationConfig())
+}
+
+func TestValidatePostgresReplicationConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+		Error  bool
+	}{
+		{
+			Name:   "no postgres_replication: config",
+			Config: "",
+			Error:  false,
+		},
+		{
+			Name: "complete",
+			Config: `
+postgres_replication:
+  host: localhost
+  port: 5433
+  user: replicator
+  database: app
+  slot: dolt_slot
+  publication: dolt_pub
+  target_database: app
+`,
+			Error: false,
+		},
+		{
+			Name: "no host",
+			Config: `
+postgres_replication:
+  user: replicator
+  database: app
+  slot: dolt_slot
+  publication: dolt_pub
+  target_database: app
+`,
+			Error: true,
+		},
+		{
+			Name: "bad port",
+			Config: `
+postgres_replication:
+  host: localhost
+  port: 70000
+  user: replicator
+  database: app
+  slot: dolt_slot
+  publication: dolt_pub
+  target_database: app
+`,
+			Error: true,
+		},
+		{
+			Name: "no publication",
+			Config: `
+postgres_replication:
+  host: localhost
+  user: replicator
+  database: app
+  slot: dolt_slot
+  target_database: app
+`,
+			Error: true,
+		},
+		{
+			Name: "bad ssl mode",
+			Config: `
+postgres_replication:
+  host: localhost
+  user: replicator
+  ssl_mode: always
+  database: app
+  slot: dolt_slot
+  publication: dolt_pub
+  target_database: app
+`,
+			Error: true,
+		},
+		{
+			Name: "root cert without verification",
+			Config: `
+postgres_replication:
+  host: localhost
+  user: replicator
+  ssl_mode: require
+  ssl_root_cert: ca.pem
+  database: app
+  slot: dolt_slot
+  publication: dolt_pub
+  target_database: app
+`,
+			Error: true,
+		},
+		{
+			Name: "no target database",
+			Config: `
+postgres_replication:
+  host: localhost
+  user: replicator
+  database: app
+  slot: dolt_slot
+  publication: dolt_pub
+`,
+			Error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			if c.Error {
+				require.Error(t, ValidatePostgresReplicationConfig(cfg.PostgresReplicationConfig()))
+			} else {
+				require.NoError(t, ValidatePostgresReplicationConfig(cfg.PostgresReplicationConfig()))
+			}
+		})
+	}
+}
+
 // Tests that a common YAML error (incorrect indentation) throws an error
 func TestUnmarshallError(t *testing.T) {
 	testStr := `
@@ -555,3 +883,131 @@ x:
 
 	assert.Equal(t, expected, commentYAMLDiffs(a, b))
 }
+
+func TestUnmarshallTracing(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+tracing:
+  exporter: otlp
+  endpoint: localhost:4318
+  insecure: true
+  sample_ratio: 0.25
+`))
+	require.NoError(t, err)
+	tracing := config.TracingConfig()
+	require.NotNil(t, tracing)
+	assert.Equal(t, TracingExporterOTLP, tracing.Exporter())
+	assert.Equal(t, "localhost:4318", tracing.Endpoint())
+	assert.True(t, tracing.Insecure())
+	assert.Equal(t, "", tracing.File())
+	assert.Equal(t, DefaultTracingServiceName, tracing.ServiceName())
+	assert.Equal(t, 0.25, tracing.SampleRatio())
+	assert.NoError(t, ValidateTracingConfig(tracing))
+
+	roundTripped, err := NewYamlConfig([]byte(config.String()))
+	require.NoError(t, err)
+	assert.Equal(t, tracing, roundTripped.TracingConfig())
+
+	config, err = NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.TracingConfig())
+}
+
+func TestValidateTracingConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+		Error  bool
+	}{
+		{
+			Name:   "no tracing: config",
+			Config: "",
+			Error:  false,
+		},
+		{
+			Name: "file exporter",
+			Config: `
+tracing:
+  exporter: file
+  file: /var/log/dolt/traces.json
+`,
+			Error: false,
+		},
+		{
+			Name: "unknown exporter",
+			Config: `
+tracing:
+  exporter: zipkin
+  endpoint: localhost:9411
+`,
+			Error: true,
+		},
+		{
+			Name: "otlp exporter without endpoint",
+			Config: `
+tracing:
+  exporter: otlp
+`,
+			Error: true,
+		},
+		{
+			Name: "file exporter without file",
+			Config: `
+tracing:
+  exporter: file
+`,
+			Error: true,
+		},
+		{
+			Name: "sample ratio out of range",
+			Config: `
+tracing:
+  exporter: file
+  file: traces.json
+  sample_ratio: 1.5
+`,
+			Error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			err = ValidateTracingConfig(cfg.TracingConfig())
+			if c.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshallAuditLog(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+audit_log:
+  file: /var/log/dolt/audit.jsonl
+  max_files: 5
+`))
+	require.NoError(t, err)
+	auditLog := config.AuditLogConfig()
+	require.NotNil(t, auditLog)
+	assert.Equal(t, "/var/log/dolt/audit.jsonl", auditLog.File())
+	assert.Equal(t, DefaultAuditLogMaxSizeMB, auditLog.MaxSizeMB())
+	assert.Equal(t, 5, auditLog.MaxFiles())
+	assert.NoError(t, ValidateAuditLogConfig(auditLog))
+
+	roundTripped, err := NewYamlConfig([]byte(config.String()))
+	require.NoError(t, err)
+	assert.Equal(t, auditLog, roundTripped.AuditLogConfig())
+
+	config, err = NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.AuditLogConfig())
+
+	config, err = NewYamlConfig([]byte(`
+audit_log:
+  max_size_mb: 0
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateAuditLogConfig(config.AuditLogConfig()))
+}
@@ -0,0 +1,336 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+)
+
+// handler wraps the sql-server's mysql.Handler and writes a Record to its Log for every DDL, DML, privilege or
+// stored procedure statement it executes. Other statements, and statements which do not parse, are not recorded.
+type handler struct {
+	mysql.Handler
+	log       *Log
+	currentDB func(*mysql.Conn) string
+}
+
+// binlogReplicaHandler is a handler for a mysql.Handler which also serves binlog replicas.
+type binlogReplicaHandler struct {
+	handler
+	mysql.BinlogReplicaHandler
+}
+
+// NewHandler returns a mysql.Handler which executes statements with |h| and records them in |log|. |currentDB|
+// returns the current database of a connection.
+func NewHandler(h mysql.Handler, log *Log, currentDB func(*mysql.Conn) string) mysql.Handler {
+	ah := handler{Handler: h, log: log, currentDB: currentDB}
+	if brh, ok := h.(mysql.BinlogReplicaHandler); ok {
+		return binlogReplicaHandler{ah, brh}
+	}
+	return ah
+}
+
+func (h handler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	r, ok := h.newRecord(ctx, c, query, false)
+	if !ok {
+		return h.Handler.ComQuery(ctx, c, query, callback)
+	}
+	var rows uint64
+	err := h.Handler.ComQuery(ctx, c, query, func(res *sqltypes.Result, more bool) error {
+		rows += res.RowsAffected
+		return callback(res, more)
+	})
+	h.write(r, rows, err)
+	return err
+}
+
+func (h handler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	r, ok := h.newRecord(ctx, c, query, true)
+	if !ok {
+		return h.Handler.ComMultiQuery(ctx, c, query, callback)
+	}
+	var rows uint64
+	remainder, err := h.Handler.ComMultiQuery(ctx, c, query, func(res *sqltypes.Result, more bool) error {
+		rows += res.RowsAffected
+		return callback(res, more)
+	})
+	h.write(r, rows, err)
+	return remainder, err
+}
+
+func (h handler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	r, ok := h.newRecord(ctx, c, prepare.PrepareStmt, false)
+	if !ok {
+		return h.Handler.ComStmtExecute(ctx, c, prepare, callback)
+	}
+	var rows uint64
+	err := h.Handler.ComStmtExecute(ctx, c, prepare, func(res *sqltypes.Result) error {
+		rows += res.RowsAffected
+		return callback(res)
+	})
+	h.write(r, rows, err)
+	return err
+}
+
+// newRecord parses the statement |query| is about to execute and returns the Record describing it, or false if it
+// should not be recorded. If |first| is true, only the first statement in |query| is executed.
+func (h handler) newRecord(ctx context.Context, c *mysql.Conn, query string, first bool) (Record, bool) {
+	opts, err := h.Handler.ParserOptionsForConnection(c)
+	if err != nil {
+		return Record{}, false
+	}
+	var parsed sqlparser.Statement
+	if first {
+		var end int
+		parsed, end, err = sqlparser.ParseOneWithOptions(ctx, query, opts)
+		if end > 0 && end < len(query) {
+			query = query[:end]
+		}
+	} else {
+		parsed, err = sqlparser.ParseWithOptions(ctx, query, opts)
+	}
+	if err != nil {
+		return Record{}, false
+	}
+	r, ok := classify(parsed)
+	if !ok {
+		return Record{}, false
+	}
+	r.User = c.User
+	if c.Conn != nil {
+		r.Host = remoteHost(c.RemoteAddr())
+	}
+	r.ConnectionID = c.ConnectionID
+	r.Database = h.currentDB(c)
+	r.Statement = redactedStatement(parsed, query)
+	return r, true
+}
+
+// redactedSecret replaces the passwords in recorded statements.
+const redactedSecret = "<secret>"
+
+// redactedStatement returns the text of |stmt|, parsed from |query|, to record in the log. Statements that set
+// account passwords are recorded as the formatted |stmt| with the passwords replaced by redactedSecret, since
+// |query| holds them in plain text. Other statements are recorded as they were sent.
+func redactedStatement(stmt sqlparser.Statement, query string) string {
+	if redactPasswords(stmt) {
+		return sqlparser.String(stmt)
+	}
+	return strings.TrimRight(strings.TrimSpace(query), ";")
+}
+
+// redactQuery returns |query| with any account passwords it sets redacted, like redactedStatement.
+func redactQuery(query string) string {
+	lower := strings.ToLower(query)
+	if !strings.Contains(lower, "identified") && !strings.Contains(lower, "password") {
+		return query
+	}
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return query
+	}
+	return redactedStatement(stmt, query)
+}
+
+// redactPasswords replaces the passwords set by the CREATE USER, ALTER USER or SET PASSWORD statement |stmt| with
+// redactedSecret, and returns whether |stmt| is one of those statements.
+func redactPasswords(stmt sqlparser.Statement) bool {
+	switch s := stmt.(type) {
+	case *sqlparser.CreateUser:
+		for i := range s.Users {
+			redactAuthentication(s.Users[i].Auth1)
+			redactAuthentication(s.Users[i].Auth2)
+			redactAuthentication(s.Users[i].Auth3)
+			redactAuthentication(s.Users[i].AuthInitial)
+		}
+		return true
+	case *sqlparser.DDL:
+		if s.Authentication == nil {
+			return false
+		}
+		redactAuthentication(s.Authentication)
+		return true
+	case *sqlparser.Set:
+		if !setsPassword(s) {
+			return false
+		}
+		for _, expr := range s.Exprs {
+			if isPasswordVariable(expr) {
+				expr.Expr = sqlparser.NewStrVal([]byte(redactedSecret))
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func redactAuthentication(auth *sqlparser.Authentication) {
+	if auth == nil {
+		return
+	}
+	if auth.Password != "" {
+		auth.Password = redactedSecret
+	}
+	if auth.Identity != "" {
+		auth.Identity = redactedSecret
+	}
+}
+
+// setsPassword returns whether |set| is a SET PASSWORD statement.
+func setsPassword(set *sqlparser.Set) bool {
+	for _, expr := range set.Exprs {
+		if isPasswordVariable(expr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPasswordVariable(expr *sqlparser.SetVarExpr) bool {
+	return expr.Name != nil && expr.Name.Qualifier.IsEmpty() && expr.Name.Name.EqualString("password")
+}
+
+func (h handler) write(r Record, rows uint64, err error) {
+	r.RowsAffected = &rows
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if err := h.log.Write(r); err != nil {
+		logrus.WithError(err).Error("could not write to the audit log")
+	}
+}
+
+// classify returns a Record with the Kind, Tables and Procedure of |stmt| filled in, or false if statements like
+// |stmt| are not recorded.
+func classify(stmt sqlparser.Statement) (Record, bool) {
+	var r Record
+	switch s := stmt.(type) {
+	case *sqlparser.DDL:
+		r.Kind = KindDDL
+		if s.ViewSpec != nil {
+			r.Tables = appendTable(r.Tables, s.ViewSpec.ViewName)
+		}
+	case *sqlparser.AlterTable, *sqlparser.DBDDL:
+		r.Kind = KindDDL
+	case *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete, *sqlparser.Load:
+		r.Kind = KindDML
+	case *sqlparser.CreateUser, *sqlparser.RenameUser, *sqlparser.DropUser, *sqlparser.CreateRole, *sqlparser.DropRole,
+		*sqlparser.GrantPrivilege, *sqlparser.GrantRole, *sqlparser.GrantProxy,
+		*sqlparser.RevokePrivilege, *sqlparser.RevokeRole, *sqlparser.RevokeProxy:
+		r.Kind = KindPrivilege
+	case *sqlparser.Set:
+		if !setsPassword(s) {
+			return Record{}, false
+		}
+		r.Kind = KindPrivilege
+		return r, true
+	case *sqlparser.Call:
+		r.Procedure = s.ProcName.Name.Lowered()
+		if strings.HasPrefix(r.Procedure, "dolt_") {
+			r.Kind = KindVersionControl
+		} else {
+			r.Kind = KindProcedure
+		}
+		return r, true
+	default:
+		return Record{}, false
+	}
+
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch n := node.(type) {
+		case *sqlparser.ColName:
+			// Column qualifiers may be aliases rather than tables.
+			return false, nil
+		case sqlparser.TableName:
+			r.Tables = appendTable(r.Tables, n)
+		}
+		return true, nil
+	}, stmt)
+	return r, true
+}
+
+func appendTable(tables []string, name sqlparser.TableName) []string {
+	if name.Name.IsEmpty() {
+		return tables
+	}
+	table := name.Name.String()
+	if !name.DbQualifier.IsEmpty() {
+		table = name.DbQualifier.String() + "." + table
+	}
+	for _, t := range tables {
+		if t == table {
+			return tables
+		}
+	}
+	return append(tables, table)
+}
+
+func remoteHost(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+var _ branch_control.DecisionObserver = (*Log)(nil)
+
+// BranchControlDecision implements branch_control.DecisionObserver.
+func (l *Log) BranchControlDecision(ctx context.Context, decision branch_control.Decision) {
+	r := Record{
+		Kind:        KindBranchControl,
+		User:        decision.User,
+		Host:        decision.Host,
+		Database:    decision.Database,
+		Branch:      decision.Branch,
+		Action:      string(decision.Action),
+		Permissions: permissionsName(decision.Permissions),
+		Allowed:     &decision.Allowed,
+	}
+	if sqlCtx, ok := ctx.(*sql.Context); ok && sqlCtx.Session != nil {
+		r.ConnectionID = sqlCtx.Session.ID()
+		r.Statement = redactQuery(sqlCtx.Query())
+	}
+	if err := l.Write(r); err != nil {
+		logrus.WithError(err).Error("could not write to the audit log")
+	}
+}
+
+func permissionsName(perms branch_control.Permissions) string {
+	switch {
+	case perms&branch_control.Permissions_Admin == branch_control.Permissions_Admin:
+		return "admin"
+	case perms&branch_control.Permissions_Write == branch_control.Permissions_Write:
+		return "write"
+	case perms&branch_control.Permissions_Read == branch_control.Permissions_Read:
+		return "read"
+	default:
+		return ""
+	}
+}
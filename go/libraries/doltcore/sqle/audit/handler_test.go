@@ -0,0 +1,206 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		query     string
+		audited   bool
+		kind      Kind
+		tables    []string
+		procedure string
+	}{
+		{query: "select * from t", audited: false},
+		{query: "show tables", audited: false},
+		{query: "set @a = 1", audited: false},
+		{query: "create table t (pk int primary key)", audited: true, kind: KindDDL, tables: []string{"t"}},
+		{query: "alter table t add column c int", audited: true, kind: KindDDL, tables: []string{"t"}},
+		{query: "create view v as select * from t", audited: true, kind: KindDDL, tables: []string{"v", "t"}},
+		{query: "drop database db", audited: true, kind: KindDDL},
+		{query: "insert into db.t values (1)", audited: true, kind: KindDML, tables: []string{"db.t"}},
+		{query: "update t as x join u on x.a = u.a set x.b = 1", audited: true, kind: KindDML, tables: []string{"t", "u"}},
+		{query: "delete from t where a in (select a from s)", audited: true, kind: KindDML, tables: []string{"t", "s"}},
+		{query: "grant select on db.* to 'u'@'%'", audited: true, kind: KindPrivilege},
+		{query: "revoke select on db.* from 'u'@'%'", audited: true, kind: KindPrivilege},
+		{query: "create user 'u'@'%'", audited: true, kind: KindPrivilege},
+		{query: "set password = 'hunter2'", audited: true, kind: KindPrivilege},
+		{query: "set autocommit = 1", audited: false},
+		{query: "call dolt_merge('feature')", audited: true, kind: KindVersionControl, procedure: "dolt_merge"},
+		{query: "call db.DOLT_BRANCH('-d', 'feature')", audited: true, kind: KindVersionControl, procedure: "dolt_branch"},
+		{query: "call my_proc()", audited: true, kind: KindProcedure, procedure: "my_proc"},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := sqlparser.Parse(test.query)
+			require.NoError(t, err)
+			r, ok := classify(stmt)
+			require.Equal(t, test.audited, ok)
+			assert.Equal(t, test.kind, r.Kind)
+			assert.Equal(t, test.tables, r.Tables)
+			assert.Equal(t, test.procedure, r.Procedure)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(path, 1<<20, 0)
+	require.NoError(t, err)
+
+	inner := &fakeHandler{}
+	h := NewHandler(inner, l, func(*mysql.Conn) string { return "mydb" })
+	c := &mysql.Conn{User: "alice", ConnectionID: 7}
+	callback := func(*sqltypes.Result, bool) error { return nil }
+
+	ctx := context.Background()
+	inner.rowsAffected = 3
+	require.NoError(t, h.ComQuery(ctx, c, "insert into t values (1), (2), (3)", callback))
+	require.NoError(t, h.ComQuery(ctx, c, "select * from t", callback))
+	inner.err = errors.New("branch is protected")
+	require.Error(t, h.ComQuery(ctx, c, "call dolt_reset('--hard')", callback))
+	inner.err = nil
+	remainder, err := h.ComMultiQuery(ctx, c, "drop table t; select 1", callback)
+	require.NoError(t, err)
+	assert.Equal(t, " select 1", remainder)
+	l.BranchControlDecision(ctx, branch_control.Decision{
+		Action:      branch_control.DecisionDelete,
+		Database:    "mydb",
+		Branch:      "main",
+		User:        "alice",
+		Host:        "%",
+		Permissions: branch_control.Permissions_Write,
+		Allowed:     false,
+	})
+	require.NoError(t, l.Close())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, KindDML, records[0].Kind)
+	assert.Equal(t, "alice", records[0].User)
+	assert.Equal(t, uint32(7), records[0].ConnectionID)
+	assert.Equal(t, "mydb", records[0].Database)
+	assert.Equal(t, []string{"t"}, records[0].Tables)
+	require.NotNil(t, records[0].RowsAffected)
+	assert.Equal(t, uint64(3), *records[0].RowsAffected)
+
+	assert.Equal(t, KindVersionControl, records[1].Kind)
+	assert.Equal(t, "dolt_reset", records[1].Procedure)
+	assert.Equal(t, "branch is protected", records[1].Error)
+
+	assert.Equal(t, KindDDL, records[2].Kind)
+	assert.Equal(t, "drop table t", records[2].Statement)
+
+	assert.Equal(t, KindBranchControl, records[3].Kind)
+	assert.Equal(t, "delete", records[3].Action)
+	assert.Equal(t, "write", records[3].Permissions)
+	require.NotNil(t, records[3].Allowed)
+	assert.False(t, *records[3].Allowed)
+
+	assert.NoError(t, Verify(path))
+}
+
+func TestRedactedStatement(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			query:    "CREATE USER 'u'@'%' IDENTIFIED BY 'hunter2';",
+			expected: "create user `u`@`%` identified by '<secret>'",
+		},
+		{
+			query:    "create user if not exists u identified with mysql_native_password by 'p1', v identified with caching_sha2_password as 'hash'",
+			expected: "create user if not exists `u`@`%` identified with mysql_native_password by '<secret>', `v`@`%` identified with caching_sha2_password as '<secret>'",
+		},
+		{
+			query:    "alter user 'u'@'localhost' identified by 'hunter2'",
+			expected: "alter user `u`@`localhost` identified by '<secret>'",
+		},
+		{
+			query:    "SET PASSWORD = 'hunter2'",
+			expected: "set PASSWORD = '<secret>'",
+		},
+		{
+			query:    "create user u identified by random password",
+			expected: "create user `u`@`%` identified by random password",
+		},
+		{
+			query:    "insert into t values ('password', 'identified by')",
+			expected: "insert into t values ('password', 'identified by')",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := sqlparser.Parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, redactedStatement(stmt, test.query))
+			assert.Equal(t, test.expected, redactQuery(test.query))
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(path, 1<<20, 0)
+	require.NoError(t, err)
+	h := NewHandler(&fakeHandler{}, l, func(*mysql.Conn) string { return "mydb" })
+	callback := func(*sqltypes.Result, bool) error { return nil }
+	require.NoError(t, h.ComQuery(context.Background(), &mysql.Conn{User: "root"}, "create user 'u'@'%' identified by 'hunter2'", callback))
+	require.NoError(t, l.Close())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 1)
+	assert.Equal(t, KindPrivilege, records[0].Kind)
+	assert.NotContains(t, records[0].Statement, "hunter2")
+}
+
+type fakeHandler struct {
+	mysql.Handler
+	rowsAffected uint64
+	err          error
+}
+
+func (h *fakeHandler) ComQuery(_ context.Context, _ *mysql.Conn, _ string, callback mysql.ResultSpoolFn) error {
+	if h.err != nil {
+		return h.err
+	}
+	return callback(&sqltypes.Result{RowsAffected: h.rowsAffected}, false)
+}
+
+func (h *fakeHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	_, end, err := sqlparser.ParseOne(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	return query[end:], h.ComQuery(ctx, c, query[:end], callback)
+}
+
+func (h *fakeHandler) ParserOptionsForConnection(*mysql.Conn) (sqlparser.ParserOptions, error) {
+	return sqlparser.ParserOptions{}, nil
+}
@@ -0,0 +1,335 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind is the category of operation an audit Record describes.
+type Kind string
+
+const (
+	KindDDL            Kind = "ddl"
+	KindDML            Kind = "dml"
+	KindPrivilege      Kind = "privilege"
+	KindVersionControl Kind = "version_control"
+	KindProcedure      Kind = "procedure"
+	KindBranchControl  Kind = "branch_control"
+)
+
+// Record is a single line of the audit log.
+type Record struct {
+	Seq          uint64    `json:"seq"`
+	Time         time.Time `json:"time"`
+	Kind         Kind      `json:"kind"`
+	User         string    `json:"user,omitempty"`
+	Host         string    `json:"host,omitempty"`
+	ConnectionID uint32    `json:"connection_id,omitempty"`
+	Database     string    `json:"database,omitempty"`
+	Statement    string    `json:"statement,omitempty"`
+	Tables       []string  `json:"tables,omitempty"`
+	Procedure    string    `json:"procedure,omitempty"`
+	RowsAffected *uint64   `json:"rows_affected,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	Branch       string    `json:"branch,omitempty"`
+	Action       string    `json:"action,omitempty"`
+	Permissions  string    `json:"permissions,omitempty"`
+	Allowed      *bool     `json:"allowed,omitempty"`
+	// PrevHash is the hex encoded SHA-256 of the previous line of the log, which may be in the previous file. Removing
+	// or editing a line breaks the chain at the line which follows it.
+	PrevHash string `json:"prev_hash"`
+}
+
+const rotatedTimeFormat = "20060102T150405.000000000"
+
+// Log is an append only JSON-lines audit log. Every record is chained to the one before it by its PrevHash, and the
+// log is rotated to a timestamped file next to it once it grows past its maximum size. The chain continues across
+// rotated files. Log is safe for concurrent use.
+type Log struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+
+	f        *os.File
+	size     int64
+	seq      uint64
+	prevHash string
+}
+
+// Open opens the audit log at |path|, creating it if it does not exist, and continues the hash chain from its last
+// record. The log is rotated once it is larger than |maxSize| bytes, and only the newest |maxFiles| rotated files are
+// kept, or all of them if |maxFiles| is 0.
+func Open(path string, maxSize int64, maxFiles int) (*Log, error) {
+	l := &Log{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	last, size, err := lastLine(f)
+	if err == nil && last == nil {
+		// The current file is empty, so the chain continues from the most recently rotated file.
+		last, err = lastRotatedLine(path)
+	}
+	if err == nil && size > 0 && !bytes.HasSuffix(last, []byte("\n")) {
+		// A partial line left by a crash. Terminate it so the next record starts on its own line; the partial line
+		// itself will fail verification.
+		var n int
+		n, err = f.Write([]byte("\n"))
+		size += int64(n)
+	}
+	if err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	l.f = f
+	l.size = size
+	if last != nil {
+		last = bytes.TrimSuffix(last, []byte("\n"))
+		l.prevHash = hashLine(last)
+		var r Record
+		if json.Unmarshal(last, &r) == nil {
+			l.seq = r.Seq
+		}
+	}
+	return l, nil
+}
+
+// Write appends |r| to the log, filling in its Seq, PrevHash and, if it is unset, its Time.
+func (l *Log) Write(r Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return errors.New("audit log is closed")
+	}
+
+	r.Seq = l.seq + 1
+	r.PrevHash = l.prevHash
+	if r.Time.IsZero() {
+		r.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if l.size > 0 && l.size+int64(len(line))+1 > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(append(line, '\n'))
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	l.seq = r.Seq
+	l.prevHash = hashLine(line)
+	return nil
+}
+
+// Close syncs and closes the log.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := errors.Join(l.f.Sync(), l.f.Close())
+	l.f = nil
+	return err
+}
+
+func (l *Log) rotate() error {
+	if err := errors.Join(l.f.Sync(), l.f.Close()); err != nil {
+		return err
+	}
+	l.f = nil
+	stem, ext := splitExt(l.path)
+	ts := time.Now().UTC()
+	rotated := stem + "-" + ts.Format(rotatedTimeFormat) + ext
+	// Never overwrite a rotated file, even if the clock is too coarse to tell two rotations apart.
+	for _, err := os.Stat(rotated); err == nil; _, err = os.Stat(rotated) {
+		ts = ts.Add(time.Nanosecond)
+		rotated = stem + "-" + ts.Format(rotatedTimeFormat) + ext
+	}
+	if err := os.Rename(l.path, rotated); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	l.f = f
+	l.size = 0
+
+	if l.maxFiles > 0 {
+		files, err := rotatedFiles(l.path)
+		if err != nil {
+			return err
+		}
+		for len(files) > l.maxFiles {
+			if err := os.Remove(files[0]); err != nil {
+				return err
+			}
+			files = files[1:]
+		}
+	}
+	return nil
+}
+
+// Files returns the rotated files of the audit log at |path|, oldest first, followed by |path| itself.
+func Files(path string) ([]string, error) {
+	files, err := rotatedFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, path), nil
+}
+
+// Verify reads the audit log |files| in order and checks that the sequence numbers are consecutive and that every
+// record's PrevHash matches the line before it. The first record of the first file is trusted, since older files may
+// have been removed by rotation. It returns an error describing the first break in the chain.
+func Verify(files ...string) error {
+	var prevHash string
+	var seq uint64
+	first := true
+	for _, file := range files {
+		err := func() error {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			scanner.Buffer(nil, 64*1024*1024)
+			for lineNum := 1; scanner.Scan(); lineNum++ {
+				line := scanner.Bytes()
+				var r Record
+				if err := json.Unmarshal(line, &r); err != nil {
+					return fmt.Errorf("%s:%d: invalid record: %w", file, lineNum, err)
+				}
+				if !first {
+					if r.PrevHash != prevHash {
+						return fmt.Errorf("%s:%d: hash chain broken before record %d", file, lineNum, r.Seq)
+					}
+					if r.Seq != seq+1 {
+						return fmt.Errorf("%s:%d: expected record %d, found record %d", file, lineNum, seq+1, r.Seq)
+					}
+				}
+				first = false
+				prevHash = hashLine(line)
+				seq = r.Seq
+			}
+			return scanner.Err()
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hashLine(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
+
+func splitExt(path string) (string, string) {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext), ext
+}
+
+// rotatedFiles returns the rotated files of the audit log at |path|, oldest first.
+func rotatedFiles(path string) ([]string, error) {
+	stem, ext := splitExt(path)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	prefix := filepath.Base(stem) + "-"
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		if _, err := time.Parse(rotatedTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)); err != nil {
+			continue
+		}
+		files = append(files, filepath.Join(filepath.Dir(path), name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func lastRotatedLine(path string) ([]byte, error) {
+	files, err := rotatedFiles(path)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	f, err := os.Open(files[len(files)-1])
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	last, _, err := lastLine(f)
+	return last, err
+}
+
+// lastLine returns the last line of |f|, including its trailing newline if it has one, and the size of |f|. It
+// returns a nil line if |f| is empty.
+func lastLine(f *os.File) ([]byte, int64, error) {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil || size == 0 {
+		return nil, size, err
+	}
+	const chunkSize = 4096
+	var tail []byte
+	for off := size; off > 0; {
+		n := int64(chunkSize)
+		if off < n {
+			n = off
+		}
+		off -= n
+		chunk := make([]byte, n)
+		if _, err := f.ReadAt(chunk, off); err != nil {
+			return nil, size, err
+		}
+		tail = append(chunk, tail...)
+		// Skip the newline ending the last line when looking for the one which starts it.
+		if i := bytes.LastIndexByte(tail[:len(tail)-1], '\n'); i >= 0 {
+			return tail[i+1:], size, nil
+		}
+	}
+	return tail, size, nil
+}
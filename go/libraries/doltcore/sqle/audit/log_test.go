@@ -0,0 +1,129 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	t.Run("ChainsRecords", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		l, err := Open(path, 1<<20, 0)
+		require.NoError(t, err)
+		for _, stmt := range []string{"create table t (pk int primary key)", "insert into t values (1)", "call dolt_commit('-am', 'x')"} {
+			require.NoError(t, l.Write(Record{Kind: KindDDL, User: "root", Statement: stmt}))
+		}
+		require.NoError(t, l.Close())
+
+		records := readRecords(t, path)
+		require.Len(t, records, 3)
+		assert.Equal(t, "", records[0].PrevHash)
+		for i, r := range records {
+			assert.Equal(t, uint64(i+1), r.Seq)
+			assert.False(t, r.Time.IsZero())
+		}
+		assert.NoError(t, Verify(path))
+	})
+
+	t.Run("ContinuesChainWhenReopened", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		l, err := Open(path, 1<<20, 0)
+		require.NoError(t, err)
+		require.NoError(t, l.Write(Record{Kind: KindDML, Statement: "insert into t values (1)"}))
+		require.NoError(t, l.Close())
+
+		l, err = Open(path, 1<<20, 0)
+		require.NoError(t, err)
+		require.NoError(t, l.Write(Record{Kind: KindDML, Statement: "insert into t values (2)"}))
+		require.NoError(t, l.Close())
+
+		records := readRecords(t, path)
+		require.Len(t, records, 2)
+		assert.Equal(t, uint64(2), records[1].Seq)
+		assert.NotEmpty(t, records[1].PrevHash)
+		assert.NoError(t, Verify(path))
+	})
+
+	t.Run("DetectsRemovedAndEditedRecords", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		l, err := Open(path, 1<<20, 0)
+		require.NoError(t, err)
+		for _, stmt := range []string{"delete from t", "drop table t", "drop database db"} {
+			require.NoError(t, l.Write(Record{Kind: KindDDL, Statement: stmt}))
+		}
+		require.NoError(t, l.Close())
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := bytes.SplitAfter(contents, []byte("\n"))
+
+		removed := filepath.Join(t.TempDir(), "removed.jsonl")
+		require.NoError(t, os.WriteFile(removed, append(append([]byte{}, lines[0]...), lines[2]...), 0600))
+		assert.Error(t, Verify(removed))
+
+		edited := filepath.Join(t.TempDir(), "edited.jsonl")
+		require.NoError(t, os.WriteFile(edited, bytes.Replace(contents, []byte("delete from t"), []byte("select 1 from t"), 1), 0600))
+		assert.Error(t, Verify(edited))
+	})
+
+	t.Run("RotatesAndPrunes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		l, err := Open(path, 256, 2)
+		require.NoError(t, err)
+		for i := 0; i < 20; i++ {
+			require.NoError(t, l.Write(Record{Kind: KindDML, Statement: "insert into t values (1)"}))
+		}
+		require.NoError(t, l.Close())
+
+		rotated, err := rotatedFiles(path)
+		require.NoError(t, err)
+		assert.Len(t, rotated, 2)
+		files, err := Files(path)
+		require.NoError(t, err)
+		assert.Equal(t, path, files[len(files)-1])
+		assert.NoError(t, Verify(files...))
+
+		records := readRecords(t, path)
+		require.NotEmpty(t, records)
+		assert.Equal(t, uint64(20), records[len(records)-1].Seq)
+
+		// The chain continues from the most recently rotated file when the current one is empty.
+		require.NoError(t, os.Remove(path))
+		l, err = Open(path, 256, 2)
+		require.NoError(t, err)
+		require.NoError(t, l.Write(Record{Kind: KindDML}))
+		require.NoError(t, l.Close())
+		assert.NoError(t, Verify(rotated[len(rotated)-1], path))
+	})
+}
+
+func readRecords(t *testing.T, path string) []Record {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var records []Record
+	for _, line := range bytes.Split(bytes.TrimSpace(contents), []byte("\n")) {
+		var r Record
+		require.NoError(t, json.Unmarshal(line, &r))
+		records = append(records, r)
+	}
+	return records
+}
@@ -27,8 +27,10 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -43,6 +45,10 @@ import (
 // it is time to perform a GC for that particular database. If it is,
 // they forward a request the background thread to register the
 // database as wanting a GC.
+//
+// If storage maintenance is enabled, the same hooks and background
+// thread also conjoin and archive the storage files of the old
+// generation. See storage_maintenance.go.
 
 type AutoGCController struct {
 	workCh   chan autoGCWork
@@ -52,6 +58,9 @@ type AutoGCController struct {
 	threads  *sql.BackgroundThreads
 	arcLevel chunks.GCArchiveLevel
 	mu       sync.Mutex
+
+	maintenance *StorageMaintenanceConfig
+	log         *StorageMaintenanceLog
 }
 
 func NewAutoGCController(arcLevel chunks.GCArchiveLevel, lgr *logrus.Logger) *AutoGCController {
@@ -63,14 +72,31 @@ func NewAutoGCController(arcLevel chunks.GCArchiveLevel, lgr *logrus.Logger) *Au
 	}
 }
 
+// EnableStorageMaintenance configures the controller to perform
+// storage maintenance in addition to auto GC. Must be called before
+// the commit hooks are applied.
+func (c *AutoGCController) EnableStorageMaintenance(cfg StorageMaintenanceConfig) {
+	c.maintenance = &cfg
+}
+
+// SetStorageMaintenanceLog sets the log in which the controller
+// records the GC and maintenance operations it performs.
+func (c *AutoGCController) SetStorageMaintenanceLog(log *StorageMaintenanceLog) {
+	c.log = log
+}
+
 // Passed by a commit hook to the auto-GC thread, requesting the
-// thread to dolt_gc |db|. When the GC is finished, |done| will be
-// closed. Signalling completion allows the commit hook to only
-// submit one dolt_gc request at a time.
+// thread to dolt_gc |db|, or to perform the maintenance operation
+// |op| on it. When the work is finished, |done| will be closed.
+// Signalling completion allows the commit hook to only submit one
+// request at a time.
 type autoGCWork struct {
-	db   *doltdb.DoltDB
-	done chan *gcWorkReport
-	name string // only for logging.
+	db     *doltdb.DoltDB
+	done   chan *gcWorkReport
+	name   string // only for logging.
+	op     maintenanceOp
+	files  []hash.Hash
+	reason string
 }
 
 // During engine initialization, this should be called to ensure the
@@ -144,13 +170,28 @@ func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF fun
 	defer close(work.done)
 	var err error
 	start := time.Now()
+	event := c.beginEvent(ctx, work, start)
 	defer func() {
 		work.done <- &gcWorkReport{
+			op:    work.op,
 			start: start,
 			end:   time.Now(),
 			err:   err,
 		}
 	}()
+	if work.op != maintenanceOpGC {
+		err = c.runMaintenance(ctx, work)
+		c.finishEvent(ctx, work, event, err)
+		if errors.Is(err, errNothingToArchive) {
+			c.lgr.Infof("sqle/auto_gc: Skipped archive of storage files of database %s: %v", work.name, err)
+			return
+		} else if err != nil {
+			c.lgr.Warnf("sqle/auto_gc: Attempt to %s storage files of database %s failed with error: %v", work.op, work.name, err)
+			return
+		}
+		c.lgr.Infof("sqle/auto_gc: Successfully completed %s of storage files of database %s in %v", work.op, work.name, time.Since(start))
+		return
+	}
 	sqlCtx, err := ctxF(ctx)
 	if err != nil {
 		c.lgr.Warnf("sqle/auto_gc: Could not create session to GC %s: %v", work.name, err)
@@ -161,6 +202,7 @@ func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF fun
 	sql.SessionCommandBegin(sqlCtx.Session)
 	defer sql.SessionCommandEnd(sqlCtx.Session)
 	err = dprocedures.RunDoltGC(sqlCtx, work.db, types.GCModeDefault, c.arcLevel, work.name)
+	c.finishEvent(ctx, work, event, err)
 	if err != nil {
 		if !errors.Is(err, chunks.ErrNothingToCollect) {
 			c.lgr.Warnf("sqle/auto_gc: Attempt to auto GC database %s failed with error: %v", work.name, err)
@@ -171,20 +213,58 @@ func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF fun
 	c.lgr.Infof("sqle/auto_gc: Successfully completed auto GC of database %s in %v", work.name, time.Since(start))
 }
 
+// beginEvent returns the log event for |work|, recording the state of
+// the storage before the work begins. Returns nil if there is no log.
+func (c *AutoGCController) beginEvent(ctx context.Context, work autoGCWork, start time.Time) *dtables.StorageMaintenanceEvent {
+	if c.log == nil {
+		return nil
+	}
+	files, bytes := storageStats(ctx, work.db)
+	return &dtables.StorageMaintenanceEvent{
+		Database:    work.name,
+		Operation:   string(work.op),
+		Reason:      work.reason,
+		StartedAt:   start,
+		FilesBefore: files,
+		BytesBefore: bytes,
+	}
+}
+
+// finishEvent records |event| in the log with the outcome |err| of its
+// work.
+func (c *AutoGCController) finishEvent(ctx context.Context, work autoGCWork, event *dtables.StorageMaintenanceEvent, err error) {
+	if event == nil {
+		return
+	}
+	event.FinishedAt = time.Now()
+	event.FilesAfter, event.BytesAfter = storageStats(ctx, work.db)
+	switch {
+	case err == nil:
+		event.Status = maintenanceStatusCompleted
+	case errors.Is(err, chunks.ErrNothingToCollect), errors.Is(err, errNothingToArchive):
+		event.Status = maintenanceStatusSkipped
+	default:
+		event.Status = maintenanceStatusFailed
+		event.Error = err.Error()
+	}
+	c.log.Record(*event)
+}
+
 func (c *AutoGCController) newCommitHook(name string, db *doltdb.DoltDB) *autoGCCommitHook {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	closed := make(chan *gcWorkReport)
 	close(closed)
 	ret := &autoGCCommitHook{
-		c:         c,
-		name:      name,
-		done:      closed,
-		next:      make(chan *gcWorkReport, 1),
-		db:        db,
-		tickCh:    make(chan struct{}),
-		stopCh:    make(chan struct{}),
-		stoppedCh: make(chan struct{}),
+		c:                    c,
+		name:                 name,
+		done:                 closed,
+		next:                 make(chan *gcWorkReport, 1),
+		db:                   db,
+		tickCh:               make(chan struct{}),
+		stopCh:               make(chan struct{}),
+		stoppedCh:            make(chan struct{}),
+		lastMaintenanceCheck: time.Now(),
 	}
 	c.hooks[name] = ret
 	if c.threads != nil {
@@ -196,6 +276,7 @@ func (c *AutoGCController) newCommitHook(name string, db *doltdb.DoltDB) *autoGC
 }
 
 type gcWorkReport struct {
+	op    maintenanceOp
 	start time.Time
 	end   time.Time
 	err   error
@@ -226,6 +307,17 @@ type autoGCCommitHook struct {
 	// This records stats about the last run of Auto GC. It starts
 	// |nil| and will only be populated after a successful run.
 	lastGcWorkReport *gcWorkReport
+	// The last time the database was checked for storage
+	// maintenance work. Only used when storage maintenance is
+	// enabled.
+	lastMaintenanceCheck time.Time
+	// The table files which remained in the old generation after
+	// the last archive operation. They could not be archived, and
+	// will not be tried again.
+	unarchivable hash.HashSet
+	// Set when an archive operation finishes, until |unarchivable|
+	// has been read from the store again.
+	unarchivableStale bool
 
 	db *doltdb.DoltDB
 	// Closed when the thread should shutdown because the database
@@ -290,9 +382,14 @@ func (h *autoGCCommitHook) Execute(ctx context.Context, _ datas.Dataset, _ *dolt
 	}
 }
 
-func (h *autoGCCommitHook) requestGC(ctx context.Context) error {
+func (h *autoGCCommitHook) requestGC(ctx context.Context, reason string) error {
+	return h.requestWork(ctx, autoGCWork{db: h.db, name: h.name, op: maintenanceOpGC, reason: reason})
+}
+
+func (h *autoGCCommitHook) requestWork(ctx context.Context, work autoGCWork) error {
+	work.done = h.next
 	select {
-	case h.c.workCh <- autoGCWork{h.db, h.next, h.name}:
+	case h.c.workCh <- work:
 		h.done = h.next
 		h.next = make(chan *gcWorkReport, 1)
 		h.lastSz = nil
@@ -334,8 +431,10 @@ func shouldRequestGC(currSz, lastSz doltdb.StoreSizes, lastGcReport *gcWorkRepor
 func (h *autoGCCommitHook) checkForGC(ctx context.Context) error {
 	select {
 	case report, ok := <-h.done:
-		if ok {
+		if ok && report.op == maintenanceOpGC {
 			h.lastGcWorkReport = report
+		} else if ok && report.op == maintenanceOpArchive {
+			h.unarchivableStale = true
 		}
 		sz, err := h.db.StoreSizes(ctx)
 		if err != nil {
@@ -345,8 +444,27 @@ func (h *autoGCCommitHook) checkForGC(ctx context.Context) error {
 		if h.lastSz == nil {
 			h.lastSz = &sz
 		}
-		if shouldRequestGC(sz, *h.lastSz, h.lastGcWorkReport, time.Now()) {
-			return h.requestGC(ctx)
+		now := time.Now()
+		if shouldRequestGC(sz, *h.lastSz, h.lastGcWorkReport, now) {
+			return h.requestGC(ctx, "store growth")
+		}
+		if h.c.maintenance != nil && now.Sub(h.lastMaintenanceCheck) >= h.c.maintenance.CheckInterval {
+			h.lastMaintenanceCheck = now
+			if h.unarchivableStale {
+				unarchivable, err := tableFiles(h.db)
+				if err != nil {
+					// Planning without the files the archive left behind would try them again, so
+					// skip maintenance until they can be read.
+					h.c.lgr.Warnf("sqle/auto_gc: Could not list the storage files of database %s, skipping maintenance: %v", h.name, err)
+					return err
+				}
+				h.unarchivable, h.unarchivableStale = unarchivable, false
+			}
+			plan, ok, err := h.c.planMaintenance(h.db, h.unarchivable)
+			if err != nil || !ok {
+				return err
+			}
+			return h.requestWork(ctx, autoGCWork{db: h.db, name: h.name, op: plan.op, files: plan.files, reason: plan.reason})
 		}
 	default:
 		// A GC is already running or pending. No need to check.
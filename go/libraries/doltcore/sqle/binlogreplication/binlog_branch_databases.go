@@ -0,0 +1,232 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const branchDatabasesFilename = "binlog-branch-databases"
+
+// BranchDatabases lists the branches, other than BinlogBranch, that generate binlog events, and the database names
+// their changes are replicated under. It is configured with the @@log_bin_branch_databases system variable, which is
+// only read when the server starts, so it can't be changed at runtime and is set in the system_variables section of
+// the server's config file. The first change to a newly mapped branch replicates a snapshot of the whole branch,
+// replacing anything replicas already have under its replicated name.
+var BranchDatabases []BranchDatabase
+
+// BranchDatabase maps the branch |Branch| of the Dolt database |Database| to the database named |ReplicatedName| in
+// the binlog, so that replicas can follow a branch other than BinlogBranch.
+type BranchDatabase struct {
+	Database       string
+	Branch         string
+	ReplicatedName string
+}
+
+// String returns the @@log_bin_branch_databases entry for |bd|, with the database names lower cased, since database
+// names are case-insensitive.
+func (bd BranchDatabase) String() string {
+	return fmt.Sprintf("%s/%s=%s", strings.ToLower(bd.Database), bd.Branch, strings.ToLower(bd.ReplicatedName))
+}
+
+// ParseBranchDatabases parses a @@log_bin_branch_databases value, a comma separated list of entries in the form
+// <database>/<branch>[=<replicated name>]. When the replicated name is omitted, the branch is replicated under the
+// database's own name, in place of BinlogBranch. For example, "mydb/release=mydb_release" replicates the release
+// branch of mydb to the database mydb_release, and "otherdb/v2" replicates otherdb from its v2 branch.
+func ParseBranchDatabases(value string) ([]BranchDatabase, error) {
+	var branchDatabases []BranchDatabase
+	replicatedNames := make(map[string]struct{})
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		dbAndBranch, replicatedName, renamed := strings.Cut(entry, "=")
+		database, branch, ok := strings.Cut(strings.TrimSpace(dbAndBranch), "/")
+		if !ok || database == "" || branch == "" {
+			return nil, fmt.Errorf("invalid entry %q: expected <database>/<branch>[=<replicated name>]", entry)
+		}
+		replicatedName = strings.TrimSpace(replicatedName)
+		if !renamed {
+			replicatedName = database
+		} else if replicatedName == "" {
+			return nil, fmt.Errorf("invalid entry %q: replicated database name must not be empty", entry)
+		}
+
+		if _, ok := replicatedNames[strings.ToLower(replicatedName)]; ok {
+			return nil, fmt.Errorf("database %s is replicated from more than one branch", replicatedName)
+		}
+		replicatedNames[strings.ToLower(replicatedName)] = struct{}{}
+
+		branchDatabases = append(branchDatabases, BranchDatabase{
+			Database:       database,
+			Branch:         branch,
+			ReplicatedName: replicatedName,
+		})
+	}
+
+	return branchDatabases, nil
+}
+
+// replicatedDatabaseNames returns the names that changes to the branch |branchName| of the database |databaseName|
+// are replicated under. The database's own name is replicated from BinlogBranch, unless an entry in BranchDatabases
+// replicates another branch under that name.
+func replicatedDatabaseNames(databaseName, branchName string) []string {
+	var names []string
+	if branchName == BinlogBranch && !isReplicatedNameMapped(databaseName) {
+		names = append(names, databaseName)
+	}
+	for _, branchDatabase := range BranchDatabases {
+		if strings.EqualFold(branchDatabase.Database, databaseName) && branchDatabase.Branch == branchName {
+			names = append(names, branchDatabase.ReplicatedName)
+		}
+	}
+	return names
+}
+
+// branchDatabaseFor returns the entry in BranchDatabases that replicates the branch |branchName| of the database
+// |databaseName| under the name |replicatedName|, if there is one.
+func branchDatabaseFor(databaseName, branchName, replicatedName string) (BranchDatabase, bool) {
+	for _, branchDatabase := range BranchDatabases {
+		if strings.EqualFold(branchDatabase.Database, databaseName) && branchDatabase.Branch == branchName &&
+			strings.EqualFold(branchDatabase.ReplicatedName, replicatedName) {
+			return branchDatabase, true
+		}
+	}
+	return BranchDatabase{}, false
+}
+
+// replicatedBranches returns the branches of the database |databaseName| that generate binlog events.
+func replicatedBranches(databaseName string) []string {
+	var branches []string
+	if !isReplicatedNameMapped(databaseName) {
+		branches = append(branches, BinlogBranch)
+	}
+	for _, branchDatabase := range BranchDatabases {
+		if strings.EqualFold(branchDatabase.Database, databaseName) && !slices.Contains(branches, branchDatabase.Branch) {
+			branches = append(branches, branchDatabase.Branch)
+		}
+	}
+	return branches
+}
+
+// isReplicatedNameMapped returns true if an entry in BranchDatabases replicates a branch under the name |name|.
+func isReplicatedNameMapped(name string) bool {
+	for _, branchDatabase := range BranchDatabases {
+		if strings.EqualFold(branchDatabase.ReplicatedName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// branchDatabaseSnapshots tracks the entries in BranchDatabases whose replicated database has been populated in the
+// binlog with a snapshot of its branch. The set is stored in the .doltcfg/binlog-branch-databases file at the root of
+// the server's filesystem, one entry per line, so that a restarted server doesn't send the snapshot again, and so
+// that a branch mapped while the server was stopped is still snapshotted when it is first replicated.
+type branchDatabaseSnapshots struct {
+	mu      sync.Mutex
+	fs      filesys.Filesys
+	entries map[string]struct{}
+}
+
+// loadBranchDatabaseSnapshots loads the set of snapshotted entries from the .doltcfg/binlog-branch-databases file at
+// the root of |fs|. If the file doesn't exist, the set is empty.
+func loadBranchDatabaseSnapshots(fs filesys.Filesys) (*branchDatabaseSnapshots, error) {
+	snapshots := &branchDatabaseSnapshots{
+		fs:      fs,
+		entries: make(map[string]struct{}),
+	}
+
+	path := filepath.Join(binlogPositionDirectory, branchDatabasesFilename)
+	if exists, _ := fs.Exists(path); !exists {
+		return snapshots, nil
+	}
+	bytes, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(bytes), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			snapshots.entries[line] = struct{}{}
+		}
+	}
+	return snapshots, nil
+}
+
+// claim returns true if the replicated database for |bd| has not been snapshotted yet, and marks it as snapshotted
+// in memory, so that concurrent updates to the branch don't each send a snapshot. Callers must call save once the
+// snapshot has been written to the binlog, or release if writing it failed.
+func (s *branchDatabaseSnapshots) claim(bd BranchDatabase) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[bd.String()]; ok {
+		return false
+	}
+	s.entries[bd.String()] = struct{}{}
+	return true
+}
+
+// release forgets that the replicated database for |bd| has been snapshotted.
+func (s *branchDatabaseSnapshots) release(bd BranchDatabase) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, bd.String())
+}
+
+// databaseDropped forgets the snapshots of all branches of the database |databaseName|, since their replicated
+// databases are dropped along with it, and saves the set.
+func (s *branchDatabaseSnapshots) databaseDropped(databaseName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix := strings.ToLower(databaseName) + "/"
+	for entry := range s.entries {
+		if strings.HasPrefix(entry, prefix) {
+			delete(s.entries, entry)
+		}
+	}
+	return s.saveLocked()
+}
+
+// save writes the set of snapshotted entries to the .doltcfg/binlog-branch-databases file.
+func (s *branchDatabaseSnapshots) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.saveLocked()
+}
+
+func (s *branchDatabaseSnapshots) saveLocked() error {
+	if err := createDoltCfgDir(s.fs); err != nil {
+		return err
+	}
+
+	entries := make([]string, 0, len(s.entries))
+	for entry := range s.entries {
+		entries = append(entries, entry+"\n")
+	}
+	slices.Sort(entries)
+	return s.fs.WriteFile(filepath.Join(binlogPositionDirectory, branchDatabasesFilename), []byte(strings.Join(entries, "")), 0666)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestParseBranchDatabases(t *testing.T) {
+	branchDatabases, err := ParseBranchDatabases("")
+	require.NoError(t, err)
+	assert.Empty(t, branchDatabases)
+
+	branchDatabases, err = ParseBranchDatabases(" mydb/release=mydb_release, otherdb/feature/v2 ,")
+	require.NoError(t, err)
+	assert.Equal(t, []BranchDatabase{
+		{Database: "mydb", Branch: "release", ReplicatedName: "mydb_release"},
+		{Database: "otherdb", Branch: "feature/v2", ReplicatedName: "otherdb"},
+	}, branchDatabases)
+
+	for _, value := range []string{"mydb", "/release", "mydb/", "mydb/release=", "a/b=x,c/d=X"} {
+		_, err = ParseBranchDatabases(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestReplicatedDatabaseNames(t *testing.T) {
+	defer func() { BranchDatabases = nil }()
+	var err error
+	BranchDatabases, err = ParseBranchDatabases("mydb/release=mydb_release,otherdb/v2")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"mydb"}, replicatedDatabaseNames("mydb", BinlogBranch))
+	assert.Equal(t, []string{"mydb_release"}, replicatedDatabaseNames("mydb", "release"))
+	assert.Empty(t, replicatedDatabaseNames("mydb", "feature"))
+	assert.Empty(t, replicatedDatabaseNames("otherdb", BinlogBranch))
+	assert.Equal(t, []string{"otherdb"}, replicatedDatabaseNames("otherdb", "v2"))
+
+	assert.Equal(t, []string{BinlogBranch, "release"}, replicatedBranches("mydb"))
+	assert.Equal(t, []string{"v2"}, replicatedBranches("otherdb"))
+	assert.Equal(t, []string{BinlogBranch}, replicatedBranches("thirddb"))
+}
+
+func TestBranchDatabaseSnapshots(t *testing.T) {
+	fs := filesys.EmptyInMemFS("/")
+	release := BranchDatabase{Database: "MyDB", Branch: "release", ReplicatedName: "mydb_release"}
+	v2 := BranchDatabase{Database: "otherdb", Branch: "v2", ReplicatedName: "otherdb"}
+
+	snapshots, err := loadBranchDatabaseSnapshots(fs)
+	require.NoError(t, err)
+	assert.True(t, snapshots.claim(release))
+	assert.False(t, snapshots.claim(release))
+	assert.True(t, snapshots.claim(v2))
+	snapshots.release(v2)
+	require.NoError(t, snapshots.save())
+
+	// Snapshots survive a restart, and remapping a branch to another name needs a new snapshot
+	snapshots, err = loadBranchDatabaseSnapshots(fs)
+	require.NoError(t, err)
+	assert.False(t, snapshots.claim(BranchDatabase{Database: "mydb", Branch: "release", ReplicatedName: "MYDB_RELEASE"}))
+	assert.True(t, snapshots.claim(BranchDatabase{Database: "mydb", Branch: "release", ReplicatedName: "mydb_rc"}))
+	assert.True(t, snapshots.claim(v2))
+
+	// Dropping a database forgets the snapshots of its branches
+	require.NoError(t, snapshots.databaseDropped("mydb"))
+	snapshots, err = loadBranchDatabaseSnapshots(fs)
+	require.NoError(t, err)
+	assert.True(t, snapshots.claim(release))
+	assert.False(t, snapshots.claim(v2))
+}
@@ -54,6 +54,9 @@ type binlogProducer struct {
 	logManager      *logManager
 	gtidSequence    int64
 	binlogEventMeta mysql.BinlogEventMetadata
+
+	// snapshots records the entries in BranchDatabases whose replicated databases have been populated in the binlog
+	snapshots *branchDatabaseSnapshots
 }
 
 var _ doltdb.DatabaseUpdateListener = (*binlogProducer)(nil)
@@ -78,6 +81,10 @@ func NewBinlogProducer(ctx *sql.Context, fs filesys.Filesys) (*binlogProducer, e
 		return nil, err
 	}
 
+	if b.snapshots, err = loadBranchDatabaseSnapshots(fs); err != nil {
+		return nil, err
+	}
+
 	return b, nil
 }
 
@@ -93,8 +100,9 @@ func (b *binlogProducer) LogManager(logManager *logManager) {
 // need to change this so that it writes to a binary log file as the intermediate, and the readers watch that
 // log to stream events back to the connected replicas.
 func (b *binlogProducer) WorkingRootUpdated(ctx *sql.Context, databaseName string, branchName string, before doltdb.RootValue, after doltdb.RootValue) error {
-	// We only support updates to a single branch for binlog events, so ignore all other updates
-	if branchName != BinlogBranch {
+	// Only BinlogBranch and the branches listed in BranchDatabases generate binlog events, so ignore all other updates
+	replicatedNames := replicatedDatabaseNames(databaseName, branchName)
+	if len(replicatedNames) == 0 {
 		return nil
 	}
 
@@ -102,41 +110,113 @@ func (b *binlogProducer) WorkingRootUpdated(ctx *sql.Context, databaseName strin
 		return nil
 	}
 
-	var binlogEvents []mysql.BinlogEvent
 	tableDeltas, err := diff.GetTableDeltas(ctx, before, after)
 	if err != nil {
 		return err
 	}
 
+	// The first update to a branch listed in BranchDatabases replaces its replicated database with a snapshot of
+	// the branch, since replicas only have the contents of another branch, or nothing at all, under that name.
+	var snapshotted []BranchDatabase
+	defer func() {
+		for _, branchDatabase := range snapshotted {
+			b.snapshots.release(branchDatabase)
+		}
+	}()
+
+	var binlogEvents []mysql.BinlogEvent
+	for _, replicatedName := range replicatedNames {
+		var events []mysql.BinlogEvent
+		branchDatabase, mapped := branchDatabaseFor(databaseName, branchName, replicatedName)
+		if mapped && b.snapshots.claim(branchDatabase) {
+			snapshotted = append(snapshotted, branchDatabase)
+			events, err = b.createSnapshotEvents(ctx, replicatedName, after)
+		} else {
+			events, err = b.createWorkingRootEvents(ctx, replicatedName, tableDeltas, after)
+		}
+		if err != nil {
+			return err
+		}
+		binlogEvents = append(binlogEvents, events...)
+	}
+
+	if err = b.logManager.WriteEvents(ctx, binlogEvents...); err != nil {
+		return err
+	}
+	if len(snapshotted) > 0 {
+		snapshotted = nil
+		return b.snapshots.save()
+	}
+	return nil
+}
+
+// createSnapshotEvents returns the binlog events that replace the database |replicatedName| with the schema and data
+// of the root value |root|, by dropping and recreating the database and then replicating every table in |root| as if
+// it had just been created.
+func (b *binlogProducer) createSnapshotEvents(ctx *sql.Context, replicatedName string, root doltdb.RootValue) ([]mysql.BinlogEvent, error) {
+	emptyRoot, err := doltdb.EmptyRootValue(ctx, root.VRW(), root.NodeStore())
+	if err != nil {
+		return nil, err
+	}
+	tableDeltas, err := diff.GetTableDeltas(ctx, emptyRoot, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var binlogEvents []mysql.BinlogEvent
+	for _, statement := range []string{
+		fmt.Sprintf("drop database if exists `%s`;", replicatedName),
+		fmt.Sprintf("create database `%s`;", replicatedName),
+	} {
+		binlogEvent, err := b.createGtidEvent(ctx)
+		if err != nil {
+			return nil, err
+		}
+		binlogEvents = append(binlogEvents, binlogEvent, b.newQueryEvent(replicatedName, statement))
+	}
+
+	events, err := b.createWorkingRootEvents(ctx, replicatedName, tableDeltas, root)
+	if err != nil {
+		return nil, err
+	}
+	return append(binlogEvents, events...), nil
+}
+
+// createWorkingRootEvents returns the binlog events that replicate the changes in |tableDeltas| to the database
+// |replicatedName|.
+func (b *binlogProducer) createWorkingRootEvents(ctx *sql.Context, replicatedName string, tableDeltas []diff.TableDelta, after doltdb.RootValue) ([]mysql.BinlogEvent, error) {
+	var binlogEvents []mysql.BinlogEvent
+
 	// Process schema changes first
-	binlogEvents, hasDataChanges, err := b.createSchemaChangeQueryEvents(ctx, databaseName, tableDeltas, after)
+	schemaEvents, hasDataChanges, err := b.createSchemaChangeQueryEvents(ctx, replicatedName, tableDeltas, after)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	binlogEvents = append(binlogEvents, schemaEvents...)
 
 	// Process data changes...
 	if hasDataChanges {
 		// GTID
 		binlogEvent, err := b.createGtidEvent(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		binlogEvents = append(binlogEvents, binlogEvent)
 
 		// Send a Query BEGIN event to start the new transaction
-		binlogEvents = append(binlogEvents, b.newQueryEvent(databaseName, "BEGIN"))
+		binlogEvents = append(binlogEvents, b.newQueryEvent(replicatedName, "BEGIN"))
 
 		// Create TableMap events describing the schemas of the tables being updated
-		tableMapEvents, tablesToId, err := b.createTableMapEvents(ctx, databaseName, tableDeltas)
+		tableMapEvents, tablesToId, err := b.createTableMapEvents(ctx, replicatedName, tableDeltas)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		binlogEvents = append(binlogEvents, tableMapEvents...)
 
 		// Loop over the tableDeltas to pull out their diff contents
 		rowEvents, err := b.createRowEvents(ctx, tableDeltas, tablesToId)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		binlogEvents = append(binlogEvents, rowEvents...)
 
@@ -144,7 +224,7 @@ func (b *binlogProducer) WorkingRootUpdated(ctx *sql.Context, databaseName strin
 		binlogEvents = append(binlogEvents, b.newXIDEvent())
 	}
 
-	return b.logManager.WriteEvents(ctx, binlogEvents...)
+	return binlogEvents, nil
 }
 
 // DatabaseCreated implements the doltdb.DatabaseUpdateListener interface.
@@ -182,7 +262,26 @@ func (b *binlogProducer) DatabaseDropped(ctx *sql.Context, databaseName string)
 	dropDatabaseStatement := fmt.Sprintf("drop database `%s`;", databaseName)
 	binlogEvents = append(binlogEvents, b.newQueryEvent(databaseName, dropDatabaseStatement))
 
-	return b.logManager.WriteEvents(ctx, binlogEvents...)
+	// Drop the databases that other branches of this database are replicated under, too
+	for _, branchDatabase := range BranchDatabases {
+		replicatedName := branchDatabase.ReplicatedName
+		if !strings.EqualFold(branchDatabase.Database, databaseName) || replicatedName == databaseName {
+			continue
+		}
+		binlogEvent, err := b.createGtidEvent(ctx)
+		if err != nil {
+			return err
+		}
+		binlogEvents = append(binlogEvents, binlogEvent)
+
+		dropDatabaseStatement := fmt.Sprintf("drop database if exists `%s`;", replicatedName)
+		binlogEvents = append(binlogEvents, b.newQueryEvent(replicatedName, dropDatabaseStatement))
+	}
+
+	if err = b.logManager.WriteEvents(ctx, binlogEvents...); err != nil {
+		return err
+	}
+	return b.snapshots.databaseDropped(databaseName)
 }
 
 // initializeGtidPosition loads the persisted GTID position from disk and initializes it
@@ -73,10 +73,21 @@ type binlogReplicaApplier struct {
 	format        *mysql.BinlogFormat
 	tableMapsById map[uint64]*mysql.TableMap
 
+	// skipTransaction is true while the events of a source transaction filtered out by
+	// @@dolt_binlog_replica_ignore_gtids are being received.
+	skipTransaction bool
+
 	dbsWithUncommittedChanges map[string]struct{}
-	replicationSourceUuid     string
-	handlerWg                 sync.WaitGroup
-	running                   atomic.Bool
+	// The source transactions applied since the last Dolt commit, which the dolt_binlog_replica_commit_mode
+	// system variable controls the timing of.
+	pendingTransactions int64
+	firstPendingGtid    mysql.GTID
+	firstPendingTime    time.Time
+	// The databases with Dolt commits that have not been tagged at a heartbeat yet.
+	dbsWithUntaggedCommits map[string]struct{}
+	replicationSourceUuid  string
+	handlerWg              sync.WaitGroup
+	running                atomic.Bool
 }
 
 func newBinlogReplicaApplier(filters *filterConfiguration) *binlogReplicaApplier {
@@ -307,6 +318,14 @@ func (a *binlogReplicaApplier) startReplicationEventStream(ctx *sql.Context, con
 		return err
 	}
 
+	// Ask the source to send a heartbeat when it has had no events to send for a while, so that batched Dolt
+	// commits and heartbeat tags are created while the source is idle. The period is in nanoseconds.
+	heartbeatPeriod := loadDoltCommitPolicy().heartbeatPeriod()
+	_, err = conn.ExecuteFetch(fmt.Sprintf("set @master_heartbeat_period=%d;", heartbeatPeriod.Nanoseconds()), 0, false)
+	if err != nil {
+		return err
+	}
+
 	return conn.SendBinlogDumpCommand(serverId, *position)
 }
 
@@ -381,6 +400,7 @@ func (a *binlogReplicaApplier) replicaBinlogEventHandler(ctx *sql.Context) error
 // processing it. Session command management is handled by the caller.
 func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.Engine, event mysql.BinlogEvent) error {
 	createCommit := false
+	heartbeat := false
 
 	// We don't support checksum validation, so we MUST strip off any checksum bytes if present, otherwise it gets
 	// interpreted as part of the payload and corrupts the data. Future checksum sizes, are not guaranteed to be the
@@ -461,8 +481,10 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			ctx.SetSessionVariable(ctx, "unique_checks", 1)
 		}
 
-		ctx.SetCurrentDatabase(query.Database)
-		executeQueryWithEngine(ctx, engine, query.SQL)
+		if !a.skipTransaction {
+			ctx.SetCurrentDatabase(query.Database)
+			executeQueryWithEngine(ctx, engine, query.SQL)
+		}
 		createCommit = !strings.EqualFold(query.SQL, "begin")
 
 	case event.IsRotate():
@@ -515,6 +537,11 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			"isBegin": isBegin,
 		}).Trace("Received binlog event: GTID")
 		a.currentGtid = gtid
+		if err := a.filters.loadIgnoreGtids(); err != nil {
+			ctx.GetLogger().Errorf("unable to load GTID filter, using the previous filter: %s", err.Error())
+			DoltBinlogReplicaController.setSqlError(mysql.ERUnknownError, err.Error())
+		}
+		a.skipTransaction = a.filters.isGtidFilteredOut(ctx, gtid)
 		// if the source's UUID hasn't been set yet, set it and persist it
 		if a.replicationSourceUuid == "" {
 			uuid := fmt.Sprintf("%v", gtid.SourceServer())
@@ -568,6 +595,10 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 	case event.IsDeleteRows(), event.IsWriteRows(), event.IsUpdateRows():
 		// A ROWS_EVENT is written for row based replication if data is inserted, deleted or updated.
 		// For more details, see: https://mariadb.com/kb/en/rows_event_v1v2-rows_compressed_event_v1/
+		if a.skipTransaction {
+			ctx.GetLogger().Tracef("skipping row event for filtered out transaction %s", a.currentGtid)
+			break
+		}
 		err := a.processRowEvent(ctx, event, engine)
 		if err != nil {
 			return err
@@ -591,6 +622,7 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			// when the primary has no binlog events to send to replica servers.
 			// For more details, see: https://mariadb.com/kb/en/heartbeat_log_event/
 			ctx.GetLogger().Trace("Received binlog event: Heartbeat")
+			heartbeat = true
 		} else {
 			return fmt.Errorf("received unknown event: %v", event)
 		}
@@ -603,7 +635,8 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			return err
 		}
 
-		// Record the last GTID processed after the commit
+		// Record the last GTID processed after the commit. Filtered out transactions are recorded too, so that the
+		// source doesn't send them again when the replica reconnects.
 		a.currentPosition.GTIDSet = a.currentPosition.GTIDSet.AddGTID(a.currentGtid)
 		err := sql.SystemVariables.AssignValues(map[string]interface{}{"gtid_executed": a.currentPosition.GTIDSet.String()})
 		if err != nil {
@@ -613,24 +646,70 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 		if err != nil {
 			return fmt.Errorf("unable to store GTID executed metadata to disk: %s", err.Error())
 		}
+		DoltBinlogReplicaController.setPosition(a.currentGtid, event.Timestamp())
 
-		// For now, create a Dolt commit from every data update. Eventually, we'll want to make this configurable.
-		// We commit to every database that we saw had a dirty session – these identify the databases where we have
-		// run DML commands through the engine. We also commit to every database that was modified through a RowEvent,
-		// which is all tracked through the applier's databasesWithUncommitedChanges property – these don't show up
-		// as dirty in our session, since we used TableWriter to update them.
-		a.addDatabasesWithUncommittedChanges(databasesToCommit...)
-		for _, database := range a.databasesWithUncommittedChanges() {
-			executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
-			executeQueryWithEngine(ctx, engine,
-				fmt.Sprintf("call dolt_commit('-Am', 'Dolt binlog replica commit: GTID %s');", a.currentGtid))
+		if a.skipTransaction {
+			// Nothing was applied for a filtered out transaction, so there is nothing to create a Dolt commit for
+			a.skipTransaction = false
+		} else {
+			// We commit to every database that we saw had a dirty session – these identify the databases where we have
+			// run DML commands through the engine. We also commit to every database that was modified through a RowEvent,
+			// which is all tracked through the applier's databasesWithUncommitedChanges property – these don't show up
+			// as dirty in our session, since we used TableWriter to update them.
+			a.addDatabasesWithUncommittedChanges(databasesToCommit...)
+			if a.pendingTransactions == 0 {
+				a.firstPendingGtid = a.currentGtid
+				a.firstPendingTime = time.Now()
+			}
+			a.pendingTransactions++
 		}
-		a.dbsWithUncommittedChanges = nil
+	}
+
+	policy := loadDoltCommitPolicy()
+	if policy.shouldCommit(a.pendingTransactions, a.firstPendingTime, time.Now(), heartbeat) {
+		a.createDoltCommits(ctx, engine)
+	}
+	if heartbeat && policy.heartbeatTagPfx != "" {
+		a.createHeartbeatTags(ctx, engine, policy.heartbeatTagPfx)
 	}
 
 	return nil
 }
 
+// createDoltCommits creates a Dolt commit in every database changed by the source transactions applied since the
+// last Dolt commit. The commit message records the GTIDs of those transactions.
+func (a *binlogReplicaApplier) createDoltCommits(ctx *sql.Context, engine *gms.Engine) {
+	message := doltCommitMessage(a.pendingTransactions, a.firstPendingGtid, a.currentGtid)
+	for _, database := range a.databasesWithUncommittedChanges() {
+		executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
+		executeQueryWithEngine(ctx, engine, fmt.Sprintf("call dolt_commit('-Am', %s);", quoteSqlString(message)))
+		if a.dbsWithUntaggedCommits == nil {
+			a.dbsWithUntaggedCommits = make(map[string]struct{})
+		}
+		a.dbsWithUntaggedCommits[database] = struct{}{}
+	}
+	a.dbsWithUncommittedChanges = nil
+	a.pendingTransactions = 0
+	a.firstPendingGtid = nil
+}
+
+// createHeartbeatTags tags the head of every database that has had Dolt commits created since the last heartbeat,
+// naming each tag with |prefix| followed by the current time. A heartbeat means that the source has no more events
+// to send, so each tag marks a point where the replica had caught up with the source.
+func (a *binlogReplicaApplier) createHeartbeatTags(ctx *sql.Context, engine *gms.Engine, prefix string) {
+	if len(a.dbsWithUntaggedCommits) == 0 {
+		return
+	}
+	tagName := heartbeatTagName(prefix, time.Now())
+	message := fmt.Sprintf("Dolt binlog replica heartbeat: executed GTID set %s", a.currentPosition.GTIDSet)
+	for _, database := range keys(a.dbsWithUntaggedCommits) {
+		executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
+		executeQueryWithEngine(ctx, engine,
+			fmt.Sprintf("call dolt_tag('-m', %s, %s);", quoteSqlString(message), quoteSqlString(tagName)))
+	}
+	a.dbsWithUntaggedCommits = nil
+}
+
 // addDatabasesWithUncommittedChanges marks the specifeid |dbNames| as databases with uncommitted changes so that
 // the replica applier knows which databases need to have Dolt commits created.
 func (a *binlogReplicaApplier) addDatabasesWithUncommittedChanges(dbNames ...string) {
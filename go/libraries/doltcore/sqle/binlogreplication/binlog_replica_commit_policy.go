@@ -0,0 +1,116 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// defaultHeartbeatPeriod is the period the replica asks the source to send heartbeats at when it has no events to
+// send. It matches the default MySQL replicas use, half of the default @@replica_net_timeout.
+const defaultHeartbeatPeriod = 30 * time.Second
+
+// doltCommitPolicy controls when the binlog replica applier turns the source transactions it has applied into Dolt
+// commits, and whether it tags them when the source is idle. It is loaded from the dolt_binlog_replica_* system
+// variables, which can be changed while replication is running.
+type doltCommitPolicy struct {
+	mode            string
+	intervalSecs    int64
+	intervalTxns    int64
+	heartbeatTagPfx string
+}
+
+// loadDoltCommitPolicy loads the current doltCommitPolicy from the global system variables.
+func loadDoltCommitPolicy() doltCommitPolicy {
+	policy := doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeTransaction}
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaCommitMode); ok {
+		if mode, ok := val.(string); ok {
+			policy.mode = strings.ToLower(mode)
+		}
+	}
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaCommitIntervalSecs); ok {
+		policy.intervalSecs, _ = val.(int64)
+	}
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaCommitIntervalTxns); ok {
+		policy.intervalTxns, _ = val.(int64)
+	}
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaHeartbeatTagPrefix); ok {
+		policy.heartbeatTagPfx, _ = val.(string)
+	}
+	return policy
+}
+
+// shouldCommit returns true if the |pending| source transactions applied since the last Dolt commit, the first of
+// which was applied at |batchStart|, should be committed at |now|. |heartbeat| is true when the source has just sent a
+// heartbeat, meaning that it has no more events to send.
+func (p doltCommitPolicy) shouldCommit(pending int64, batchStart, now time.Time, heartbeat bool) bool {
+	if pending == 0 {
+		return false
+	}
+	switch p.mode {
+	case dsess.BinlogReplicaCommitModeOff:
+		return false
+	case dsess.BinlogReplicaCommitModeBatch:
+		if heartbeat {
+			return true
+		}
+		if p.intervalTxns > 0 && pending >= p.intervalTxns {
+			return true
+		}
+		return p.intervalSecs > 0 && now.Sub(batchStart) >= time.Duration(p.intervalSecs)*time.Second
+	default:
+		return true
+	}
+}
+
+// heartbeatPeriod returns the period the replica asks the source to send heartbeats at. Batches committed every
+// dolt_binlog_replica_commit_interval_secs seconds need a heartbeat at least that often, so that they are committed
+// on time when the source is idle.
+func (p doltCommitPolicy) heartbeatPeriod() time.Duration {
+	period := defaultHeartbeatPeriod
+	if p.mode == dsess.BinlogReplicaCommitModeBatch && p.intervalSecs > 0 {
+		if interval := time.Duration(p.intervalSecs) * time.Second; interval < period {
+			period = interval
+		}
+	}
+	return period
+}
+
+// doltCommitMessage returns the message of the Dolt commit of the |count| source transactions from |first| through
+// |last|. The message records the GTIDs of the source transactions the commit includes.
+func doltCommitMessage(count int64, first, last mysql.GTID) string {
+	if count == 1 {
+		return fmt.Sprintf("Dolt binlog replica commit: GTID %s", last)
+	}
+	return fmt.Sprintf("Dolt binlog replica commit: %d transactions, GTIDs %s through %s", count, first, last)
+}
+
+// heartbeatTagName returns the name of the tag created with |prefix| for a heartbeat received at |now|.
+func heartbeatTagName(prefix string, now time.Time) string {
+	return prefix + now.UTC().Format("20060102T150405Z")
+}
+
+// quoteSqlString returns |s| as a single-quoted SQL string literal.
+func quoteSqlString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
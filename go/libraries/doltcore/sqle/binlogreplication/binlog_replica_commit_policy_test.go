@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+func TestDoltCommitPolicyShouldCommit(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	transaction := doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeTransaction}
+	off := doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeOff}
+	batch := doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeBatch, intervalSecs: 10, intervalTxns: 100}
+	idleBatch := doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeBatch}
+
+	tests := []struct {
+		name      string
+		policy    doltCommitPolicy
+		pending   int64
+		elapsed   time.Duration
+		heartbeat bool
+		expected  bool
+	}{
+		{"transaction, nothing pending", transaction, 0, 0, false, false},
+		{"transaction, one pending", transaction, 1, 0, false, true},
+		{"off", off, 5, time.Hour, true, false},
+		{"batch, below both intervals", batch, 99, 9 * time.Second, false, false},
+		{"batch, transaction interval reached", batch, 100, 0, false, true},
+		{"batch, time interval reached", batch, 1, 10 * time.Second, false, true},
+		{"batch, heartbeat", batch, 1, 0, true, true},
+		{"batch, heartbeat with nothing pending", batch, 0, 0, true, false},
+		{"batch without intervals", idleBatch, 1000, time.Hour, false, false},
+		{"batch without intervals, heartbeat", idleBatch, 1000, time.Hour, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.policy.shouldCommit(tt.pending, start, start.Add(tt.elapsed), tt.heartbeat))
+		})
+	}
+}
+
+func TestDoltCommitPolicyHeartbeatPeriod(t *testing.T) {
+	require.Equal(t, defaultHeartbeatPeriod, doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeTransaction}.heartbeatPeriod())
+	require.Equal(t, defaultHeartbeatPeriod, doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeBatch, intervalSecs: 120}.heartbeatPeriod())
+	require.Equal(t, 5*time.Second, doltCommitPolicy{mode: dsess.BinlogReplicaCommitModeBatch, intervalSecs: 5}.heartbeatPeriod())
+}
+
+func TestDoltCommitMessage(t *testing.T) {
+	first, err := mysql.ParseGTID(mysqlFlavor, "3e11fa47-71ca-11e1-9e33-c80aa9429562:23")
+	require.NoError(t, err)
+	last, err := mysql.ParseGTID(mysqlFlavor, "3e11fa47-71ca-11e1-9e33-c80aa9429562:27")
+	require.NoError(t, err)
+	require.Equal(t, "Dolt binlog replica commit: GTID 3e11fa47-71ca-11e1-9e33-c80aa9429562:27",
+		doltCommitMessage(1, last, last))
+	require.Equal(t, "Dolt binlog replica commit: 5 transactions, GTIDs 3e11fa47-71ca-11e1-9e33-c80aa9429562:23 "+
+		"through 3e11fa47-71ca-11e1-9e33-c80aa9429562:27", doltCommitMessage(5, first, last))
+	require.Equal(t, "replica-20250102T030405Z",
+		heartbeatTagName("replica-", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/binlogreplication"
 	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/vitess/go/mysql"
 )
 
 var DoltBinlogReplicaController = newDoltBinlogReplicaController()
@@ -63,6 +64,19 @@ type doltBinlogReplicaController struct {
 	operationMutex *sync.Mutex
 	engine         *sqle.Engine
 	status         binlogreplication.ReplicaStatus
+	position       ReplicaPosition
+}
+
+// ReplicaPosition describes the last source transaction the replica applied.
+type ReplicaPosition struct {
+	// The UUID of the source server that originally committed the transaction.
+	SourceUuid string
+	// The sequence number of the transaction's GTID.
+	Sequence int64
+	// When the source wrote the event that committed the transaction.
+	SourceTimestamp time.Time
+	// Whether the replica's applier is currently running.
+	Running bool
 }
 
 var _ binlogreplication.BinlogReplicaController = (*doltBinlogReplicaController)(nil)
@@ -124,6 +138,10 @@ func (d *doltBinlogReplicaController) StartReplica(ctx *sql.Context) error {
 		return ErrEmptyUsername
 	}
 
+	if err := d.filters.loadIgnoreGtids(); err != nil {
+		return fmt.Errorf("unable to start replication: %s", err.Error())
+	}
+
 	if d.ctx == nil {
 		return fmt.Errorf("no execution context set for the replica controller")
 	}
@@ -347,6 +365,30 @@ func (d *doltBinlogReplicaController) GetReplicaStatus(ctx *sql.Context) (*binlo
 	return &copy, nil
 }
 
+// Position returns the last source transaction applied since the server started. Its SourceUuid is empty if no
+// transaction has been applied yet.
+func (d *doltBinlogReplicaController) Position() ReplicaPosition {
+	d.statusMutex.Lock()
+	defer d.statusMutex.Unlock()
+	position := d.position
+	position.Running = d.status.ReplicaSqlRunning == binlogreplication.ReplicaSqlRunning
+	return position
+}
+
+// setPosition records |gtid|, committed by the source at |timestamp|, as the last source transaction applied.
+func (d *doltBinlogReplicaController) setPosition(gtid mysql.GTID, timestamp uint32) {
+	gtid56, ok := gtid.(mysql.Mysql56GTID)
+	if !ok {
+		return
+	}
+
+	d.statusMutex.Lock()
+	defer d.statusMutex.Unlock()
+	d.position.SourceUuid = gtid56.Server.String()
+	d.position.Sequence = gtid56.Sequence
+	d.position.SourceTimestamp = time.Unix(int64(timestamp), 0)
+}
+
 // ResetReplica implements the BinlogReplicaController interface
 func (d *doltBinlogReplicaController) ResetReplica(ctx *sql.Context, resetAll bool) error {
 	d.operationMutex.Lock()
@@ -21,6 +21,8 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
 // filterConfiguration defines the binlog filtering rules applied on the replica.
@@ -29,6 +31,14 @@ type filterConfiguration struct {
 	doTables map[string]map[string]struct{}
 	// ignoreTables holds a map of database name to map of table names, indicating tables that should NOT be replicated.
 	ignoreTables map[string]map[string]struct{}
+	// ignoreGtids holds the source transactions that should NOT be replicated, as set by the
+	// @@dolt_binlog_replica_ignore_gtids system variable.
+	ignoreGtids mysql.Mysql56GTIDSet
+	// ignoreSources holds the UUIDs of source servers whose transactions should NOT be replicated.
+	ignoreSources map[mysql.SID]struct{}
+	// ignoreGtidsValue is the @@dolt_binlog_replica_ignore_gtids value that ignoreGtids and ignoreSources were
+	// parsed from.
+	ignoreGtidsValue string
 	// mu guards against concurrent access to the filter configuration data.
 	mu *sync.Mutex
 }
@@ -135,6 +145,89 @@ func (fc *filterConfiguration) isTableFilteredOut(ctx *sql.Context, tableMap *my
 	return false
 }
 
+// loadIgnoreGtids reads the @@dolt_binlog_replica_ignore_gtids system variable and, if its value has changed since
+// it was last loaded, sets the GTIDs and source servers to filter out of replication. If the value is not valid, an
+// error is returned and the previously loaded filter remains in place.
+func (fc *filterConfiguration) loadIgnoreGtids() error {
+	if fc == nil {
+		return nil
+	}
+
+	value := ""
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaIgnoreGtids); ok {
+		value, _ = val.(string)
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if value == fc.ignoreGtidsValue {
+		return nil
+	}
+
+	gtids, sources, err := parseIgnoreGtids(value)
+	if err != nil {
+		return err
+	}
+	fc.ignoreGtids = gtids
+	fc.ignoreSources = sources
+	fc.ignoreGtidsValue = value
+	return nil
+}
+
+// parseIgnoreGtids parses |value|, a comma separated list of MySQL GTID sets, e.g.
+// "3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5:11", and source server UUIDs. A source server UUID without any
+// intervals matches every transaction from that source.
+func parseIgnoreGtids(value string) (mysql.Mysql56GTIDSet, map[mysql.SID]struct{}, error) {
+	var gtidSets []string
+	sources := make(map[mysql.SID]struct{})
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, ":") {
+			gtidSets = append(gtidSets, entry)
+			continue
+		}
+		sid, err := mysql.ParseSID(entry)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid @@%s value %q: %s", dsess.BinlogReplicaIgnoreGtids, entry, err.Error())
+		}
+		sources[sid] = struct{}{}
+	}
+
+	gtidSet, err := mysql.ParseMysql56GTIDSet(strings.Join(gtidSets, ","))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid @@%s value: %s", dsess.BinlogReplicaIgnoreGtids, err.Error())
+	}
+	return gtidSet.(mysql.Mysql56GTIDSet), sources, nil
+}
+
+// isGtidFilteredOut returns true if the source transaction identified by |gtid| has been filtered out on this
+// replica and should not have any of its events applied.
+func (fc *filterConfiguration) isGtidFilteredOut(ctx *sql.Context, gtid mysql.GTID) bool {
+	if fc == nil {
+		return false
+	}
+	gtid56, ok := gtid.(mysql.Mysql56GTID)
+	if !ok {
+		return false
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if _, ok := fc.ignoreSources[gtid56.Server]; ok {
+		ctx.GetLogger().Tracef("skipping transaction %s (source in ignoreGtids)", gtid56)
+		return true
+	}
+	if fc.ignoreGtids.ContainsGTID(gtid56) {
+		ctx.GetLogger().Tracef("skipping transaction %s (in ignoreGtids)", gtid56)
+		return true
+	}
+	return false
+}
+
 // getDoTables returns a slice of qualified table names that are configured to be replicated.
 func (fc *filterConfiguration) getDoTables() []string {
 	fc.mu.Lock()
@@ -0,0 +1,102 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	sourceUuid1 = "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+	sourceUuid2 = "4f22ab58-82db-22f2-af44-d91bba53a673"
+)
+
+func TestIsGtidFilteredOut(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	gtid := func(s string) mysql.GTID {
+		g, err := mysql.ParseGTID(mysqlFlavor, s)
+		require.NoError(t, err)
+		return g
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		gtid     string
+		expected bool
+	}{
+		{"no filter", "", sourceUuid1 + ":1", false},
+		{"in interval", sourceUuid1 + ":1-5:11", sourceUuid1 + ":3", true},
+		{"in second interval", sourceUuid1 + ":1-5:11", sourceUuid1 + ":11", true},
+		{"between intervals", sourceUuid1 + ":1-5:11", sourceUuid1 + ":6", false},
+		{"other source", sourceUuid1 + ":1-5", sourceUuid2 + ":3", false},
+		{"whole source", sourceUuid2, sourceUuid2 + ":1000", true},
+		{"whole source, other source", sourceUuid2, sourceUuid1 + ":1", false},
+		{"mixed", sourceUuid1 + ":7, " + strings.ToUpper(sourceUuid2), sourceUuid2 + ":1", true},
+		{"mixed, interval", sourceUuid1 + ":7, " + sourceUuid2, sourceUuid1 + ":7", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := newFilterConfiguration()
+			gtids, sources, err := parseIgnoreGtids(tt.value)
+			require.NoError(t, err)
+			fc.ignoreGtids, fc.ignoreSources = gtids, sources
+			require.Equal(t, tt.expected, fc.isGtidFilteredOut(ctx, gtid(tt.gtid)))
+		})
+	}
+
+	var fc *filterConfiguration
+	require.False(t, fc.isGtidFilteredOut(ctx, gtid(sourceUuid1+":1")))
+}
+
+func TestParseIgnoreGtidsErrors(t *testing.T) {
+	for _, value := range []string{"not-a-uuid", sourceUuid1 + ":x", sourceUuid1 + ":1-5,bogus"} {
+		_, _, err := parseIgnoreGtids(value)
+		require.Error(t, err, value)
+		require.Contains(t, err.Error(), "invalid @@dolt_binlog_replica_ignore_gtids value")
+	}
+}
+
+func TestLoadIgnoreGtids(t *testing.T) {
+	sqle.AddDoltSystemVariables()
+	ctx := sql.NewEmptyContext()
+	t.Cleanup(func() {
+		require.NoError(t, sql.SystemVariables.SetGlobal(ctx, dsess.BinlogReplicaIgnoreGtids, ""))
+	})
+
+	fc := newFilterConfiguration()
+	require.NoError(t, sql.SystemVariables.SetGlobal(ctx, dsess.BinlogReplicaIgnoreGtids, sourceUuid1))
+	require.NoError(t, fc.loadIgnoreGtids())
+	gtid, err := mysql.ParseGTID(mysqlFlavor, sourceUuid1+":1")
+	require.NoError(t, err)
+	require.True(t, fc.isGtidFilteredOut(ctx, gtid))
+
+	// An invalid value leaves the previous filter in place
+	require.NoError(t, sql.SystemVariables.SetGlobal(ctx, dsess.BinlogReplicaIgnoreGtids, "bogus"))
+	require.Error(t, fc.loadIgnoreGtids())
+	require.True(t, fc.isGtidFilteredOut(ctx, gtid))
+
+	require.NoError(t, sql.SystemVariables.SetGlobal(ctx, dsess.BinlogReplicaIgnoreGtids, ""))
+	require.NoError(t, fc.loadIgnoreGtids())
+	require.False(t, fc.isGtidFilteredOut(ctx, gtid))
+}
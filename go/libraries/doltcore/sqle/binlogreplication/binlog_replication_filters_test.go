@@ -16,6 +16,8 @@ package binlogreplication
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -175,6 +177,43 @@ func TestBinlogReplicationFilters_doTablesAndIgnoreTables(t *testing.T) {
 	require.NoError(t, rows.Close())
 }
 
+// TestBinlogReplicationFilters_ignoreGtids tests that source transactions listed in the
+// @@dolt_binlog_replica_ignore_gtids system variable are not applied, but are still recorded as executed.
+func TestBinlogReplicationFilters_ignoreGtids(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	h.primaryDatabase.MustExec("CREATE TABLE db01.t1 (pk INT PRIMARY KEY);")
+	h.waitForReplicaToCatchUp()
+
+	// Ignore the second and third of the next five transactions
+	gtid := queryGtid(t, h.primaryDatabase)
+	sourceUuid := strings.Split(gtid, ":")[0]
+	lastSequence, err := strconv.Atoi(gtid[strings.LastIndex(gtid, "-")+1:])
+	require.NoError(t, err)
+	h.replicaDatabase.MustExec(fmt.Sprintf("SET @@GLOBAL.dolt_binlog_replica_ignore_gtids = '%s:%d-%d';",
+		sourceUuid, lastSequence+2, lastSequence+3))
+	for i := 1; i <= 5; i++ {
+		h.primaryDatabase.MustExec(fmt.Sprintf("INSERT INTO db01.t1 VALUES (%d);", i))
+	}
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("SELECT pk FROM db01.t1 ORDER BY pk;", [][]any{{"1"}, {"4"}, {"5"}})
+
+	// A source UUID without intervals ignores every transaction from that source
+	h.replicaDatabase.MustExec(fmt.Sprintf("SET @@GLOBAL.dolt_binlog_replica_ignore_gtids = '%s';", sourceUuid))
+	h.primaryDatabase.MustExec("INSERT INTO db01.t1 VALUES (6);")
+	h.primaryDatabase.MustExec("CREATE TABLE db01.t2 (pk INT PRIMARY KEY);")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("SELECT pk FROM db01.t1 ORDER BY pk;", [][]any{{"1"}, {"4"}, {"5"}})
+	h.requireReplicaResults("SHOW TABLES FROM db01;", [][]any{{"t1"}})
+
+	h.replicaDatabase.MustExec("SET @@GLOBAL.dolt_binlog_replica_ignore_gtids = '';")
+	h.primaryDatabase.MustExec("INSERT INTO db01.t1 VALUES (7);")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("SELECT pk FROM db01.t1 ORDER BY pk;", [][]any{{"1"}, {"4"}, {"5"}, {"7"}})
+}
+
 // TestBinlogReplicationFilters_errorCases test returned errors for various error cases.
 func TestBinlogReplicationFilters_errorCases(t *testing.T) {
 	h := newHarness(t)
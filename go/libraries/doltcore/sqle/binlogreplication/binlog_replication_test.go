@@ -608,6 +608,89 @@ func TestDoltCommits(t *testing.T) {
 	require.Equal(t, 5, len(allRows)) // 4 transactions + 1 initial commit
 }
 
+// TestDoltCommitsInBatches tests that a replica in the batch commit mode creates one Dolt commit for every
+// @@dolt_binlog_replica_commit_interval_transactions source transactions.
+func TestDoltCommitsInBatches(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+	h.replicaDatabase.MustExec("set @@GLOBAL.dolt_binlog_replica_commit_mode = 'batch';")
+	h.replicaDatabase.MustExec("set @@GLOBAL.dolt_binlog_replica_commit_interval_transactions = 3;")
+
+	// Three transactions make up the first batch
+	h.primaryDatabase.MustExec("create table t1 (pk int primary key);")
+	h.primaryDatabase.MustExec("insert into t1 values (1);")
+	h.primaryDatabase.MustExec("insert into t1 values (2);")
+	h.waitForReplicaToCatchUp()
+	requireDoltCommitCount(t, h.replicaDatabase, "db01", 2)
+	rows, err := h.replicaDatabase.Queryx("select message from db01.dolt_log limit 1;")
+	require.NoError(t, err)
+	row := convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Regexp(t, "^Dolt binlog replica commit: 3 transactions, GTIDs \\S+:2 through \\S+:4$", row["message"])
+	require.NoError(t, rows.Close())
+
+	// Two transactions don't fill a batch, so they stay in the working set
+	h.primaryDatabase.MustExec("insert into t1 values (3);")
+	h.primaryDatabase.MustExec("insert into t1 values (4);")
+	h.waitForReplicaToCatchUp()
+	requireDoltCommitCount(t, h.replicaDatabase, "db01", 2)
+	rows, err = h.replicaDatabase.Queryx("select count(*) as count from db01.t1;")
+	require.NoError(t, err)
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "4", row["count"])
+	require.NoError(t, rows.Close())
+
+	// Switching back to a Dolt commit per transaction commits them with the next transaction
+	h.replicaDatabase.MustExec("set @@GLOBAL.dolt_binlog_replica_commit_mode = 'transaction';")
+	h.primaryDatabase.MustExec("insert into t1 values (5);")
+	h.waitForReplicaToCatchUp()
+	requireDoltCommitCount(t, h.replicaDatabase, "db01", 3)
+	rows, err = h.replicaDatabase.Queryx("select message from db01.dolt_log limit 1;")
+	require.NoError(t, err)
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Regexp(t, "^Dolt binlog replica commit: 3 transactions, GTIDs \\S+:5 through \\S+:7$", row["message"])
+	require.NoError(t, rows.Close())
+}
+
+// TestDoltCommitsAtHeartbeats tests that a replica in the batch commit mode commits the transactions it has applied
+// when the source sends a heartbeat, and tags the commits when @@dolt_binlog_replica_heartbeat_tag_prefix is set.
+func TestDoltCommitsAtHeartbeats(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(map[string]string{
+		"server_id":                                "42",
+		"dolt_binlog_replica_commit_mode":          "batch",
+		"dolt_binlog_replica_commit_interval_secs": "1",
+		"dolt_binlog_replica_heartbeat_tag_prefix": "caught_up_",
+	})
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	h.primaryDatabase.MustExec("create table t1 (pk int primary key);")
+	h.primaryDatabase.MustExec("insert into t1 values (1), (2);")
+	h.waitForReplicaToCatchUp()
+
+	requireDoltCommitCount(t, h.replicaDatabase, "db01", 2)
+	require.Eventually(t, func() bool {
+		rows, err := h.replicaDatabase.Queryx("select count(*) as count from db01.dolt_tags where tag_name like 'caught_up_%';")
+		require.NoError(t, err)
+		row := convertMapScanResultToStrings(readNextRow(t, rows))
+		require.NoError(t, rows.Close())
+		return row["count"] == "1"
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+// requireDoltCommitCount waits for |database| to have |count| Dolt commits on its current branch.
+func requireDoltCommitCount(t *testing.T, db *sqlx.DB, database string, count int) {
+	var actual string
+	require.Eventuallyf(t, func() bool {
+		rows, err := db.Queryx("select count(*) as count from " + database + ".dolt_log;")
+		require.NoError(t, err)
+		row := convertMapScanResultToStrings(readNextRow(t, rows))
+		require.NoError(t, rows.Close())
+		actual = row["count"].(string)
+		return actual == strconv.Itoa(count)
+	}, 5*time.Second, 100*time.Millisecond, "expected %d commits in %s, found %s", count, database, actual)
+}
+
 // TestForeignKeyChecks tests that foreign key constraints replicate correctly when foreign key checks are
 // enabled and disabled.
 func TestForeignKeyChecks(t *testing.T) {
@@ -0,0 +1,399 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+// The persistent config key under which the latest epoch this server has voted in is stored, so that it cannot
+// vote twice in the same election across a restart.
+const votedEpochConfigKey = "voted_epoch"
+
+// autoFailover holds the state of the leader election a cluster runs when
+// auto_failover is enabled. It works like a Raft election in which the
+// cluster's epoch is the term:
+//
+// * The primary sends a heartbeat to every standby each heartbeat interval.
+// A heartbeat renews the primary's lease on the standby, and tells the standby
+// whether it is caught up on every replicated database. Each heartbeat carries
+// a position, which increases with every heartbeat at the epoch, and a standby
+// remembers the epoch and position of the last heartbeat at which it was
+// caught up. This plays the part of the last log entry in Raft.
+//
+// * A standby which goes an election timeout plus two heartbeat intervals,
+// plus some jitter, without a heartbeat stands for election if it was caught
+// up as of the last heartbeat it received. It asks every other member of the
+// cluster for its vote at the next epoch, and becomes primary at that epoch
+// if a majority of the cluster, counting itself, votes for it.
+//
+// * A member votes at most once per epoch, only for epochs newer than its own,
+// and never while it is the primary or still holds a lease from the primary.
+// It only votes for a candidate which was caught up at a heartbeat at least
+// as late as the last one at which the member itself was caught up, so that
+// a candidate missing writes the member has cannot win with its vote.
+//
+// * A primary which cannot get a majority of the cluster to acknowledge its
+// heartbeats for an election timeout, less a heartbeat interval, steps down to
+// standby. Standbys hold the lease for an election timeout plus two heartbeat
+// intervals, so that a primary cut off from the rest of the cluster stops
+// accepting writes before the rest of the cluster can elect its replacement.
+// See standbyLeaseTimeout.
+//
+// Stale primaries are fenced by epoch. Cluster members refuse replication
+// traffic from a primary at an older epoch than their own, and a primary
+// which sees a newer epoch in a response becomes a standby at that epoch.
+type autoFailover struct {
+	heartbeatInterval time.Duration
+	electionTimeout   time.Duration
+
+	lgr *logrus.Entry
+
+	mu sync.Mutex
+	// As a standby, the last time we heard from the primary. Its lease is
+	// good until a standbyLeaseTimeout after this.
+	lastHeartbeat time.Time
+	// As a standby, when we stand for election if we have not heard from
+	// the primary.
+	electionDeadline time.Time
+	// As a primary, the last time a majority of the cluster acknowledged
+	// our heartbeats.
+	lastQuorum time.Time
+	// Whether the last heartbeat from the primary said we were caught up.
+	caughtUp bool
+	// The epoch and position of the last heartbeat at which we were caught
+	// up. As a primary, |position| is that of the last heartbeat we sent.
+	caughtUpEpoch    int
+	caughtUpPosition int64
+	position         int64
+	votedEpoch       int
+
+	done chan struct{}
+}
+
+func newAutoFailover(lgr *logrus.Logger, cfg servercfg.ClusterAutoFailoverConfig, votedEpoch int) *autoFailover {
+	af := &autoFailover{
+		heartbeatInterval: cfg.HeartbeatInterval(),
+		electionTimeout:   cfg.ElectionTimeout(),
+		lgr:               lgr.WithField(logFieldThread, "Cluster Auto Failover"),
+		votedEpoch:        votedEpoch,
+		done:              make(chan struct{}),
+	}
+	now := time.Now()
+	af.lastQuorum = now
+	af.lastHeartbeat = now
+	af.resetElectionDeadline(now)
+	return af
+}
+
+// primaryLeaseTimeout is how long a primary keeps accepting writes without a
+// majority of the cluster acknowledging its heartbeats.
+func (af *autoFailover) primaryLeaseTimeout() time.Duration {
+	return af.electionTimeout - af.heartbeatInterval
+}
+
+// standbyLeaseTimeout is how long a standby treats the primary's lease as held
+// after it last heard from the primary. It must outlast the primary's own view
+// of its lease by long enough for the primary to notice the lease has lapsed
+// and step down. The primary only checks its lease once a round of heartbeats
+// completes, and a round starts up to a heartbeat interval after the last one
+// and can take up to another heartbeat interval, so the primary starts
+// stepping down at most two heartbeat intervals after its lease lapses. Since
+// the primary measures its lease from before the standby hears from it,
+// holding the lease for an election timeout plus two heartbeat intervals
+// leaves the primary a heartbeat interval to finish stepping down before a
+// standby will vote for its replacement.
+func (af *autoFailover) standbyLeaseTimeout() time.Duration {
+	return af.electionTimeout + 2*af.heartbeatInterval
+}
+
+// called with af.mu held.
+func (af *autoFailover) leaseLapsed(now time.Time) bool {
+	return now.Sub(af.lastQuorum) > af.primaryLeaseTimeout()
+}
+
+// called with af.mu held.
+func (af *autoFailover) leaseHeld(now time.Time) bool {
+	return now.Sub(af.lastHeartbeat) < af.standbyLeaseTimeout()
+}
+
+// called with af.mu held.
+func (af *autoFailover) resetElectionDeadline(now time.Time) {
+	jitter := time.Duration(rand.Int63n(int64(af.electionTimeout)))
+	af.electionDeadline = now.Add(af.standbyLeaseTimeout() + jitter)
+}
+
+// roleChanged is called by the Controller, with c.mu held, whenever this
+// server changes role, which it now holds at |epoch|. |steppedDown| is true if a
+// primary is becoming a standby because it lost its lease, in which case it
+// still has every write it accepted and may stand in the next election.
+func (af *autoFailover) roleChanged(epoch int, steppedDown bool) {
+	af.mu.Lock()
+	defer af.mu.Unlock()
+	now := time.Now()
+	af.lastQuorum = now
+	af.lastHeartbeat = now
+	af.resetElectionDeadline(now)
+	af.caughtUp = steppedDown
+	if steppedDown {
+		af.caughtUpEpoch, af.caughtUpPosition = epoch, af.position
+	}
+	af.position = 0
+}
+
+// heartbeatReceived is called on a standby when the primary's heartbeat for
+// |position| at |epoch| arrives.
+func (af *autoFailover) heartbeatReceived(epoch int, caughtUp bool, position int64) {
+	af.mu.Lock()
+	defer af.mu.Unlock()
+	now := time.Now()
+	af.lastHeartbeat = now
+	af.resetElectionDeadline(now)
+	af.caughtUp = caughtUp
+	if caughtUp && positionAtLeast(epoch, position, af.caughtUpEpoch, af.caughtUpPosition) {
+		af.caughtUpEpoch, af.caughtUpPosition = epoch, position
+	}
+}
+
+// positionAtLeast returns true if |position| at |epoch| is no earlier than
+// |otherPosition| at |otherEpoch|.
+func positionAtLeast(epoch int, position int64, otherEpoch int, otherPosition int64) bool {
+	if epoch != otherEpoch {
+		return epoch > otherEpoch
+	}
+	return position >= otherPosition
+}
+
+func (af *autoFailover) stop() {
+	close(af.done)
+}
+
+func (c *Controller) runAutoFailover() {
+	af := c.autoFailover
+	ticker := time.NewTicker(af.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-af.done:
+			return
+		case <-ticker.C:
+		}
+		role, epoch := c.roleAndEpoch()
+		switch role {
+		case RolePrimary:
+			if c.sendHeartbeats(epoch) {
+				c.stepDown(epoch)
+			}
+		case RoleStandby:
+			c.standForElection()
+		}
+	}
+}
+
+// quorum returns the number of votes needed to win an election.
+func (c *Controller) quorum() int {
+	return (len(c.replicationClients)+1)/2 + 1
+}
+
+// sendHeartbeats sends a heartbeat to every standby, renewing our lease if a
+// majority of the cluster acknowledges them. It returns true if the lease has
+// lapsed, in which case we must step down to standby.
+func (c *Controller) sendHeartbeats(epoch int) bool {
+	af := c.autoFailover
+	// The standbys' leases are renewed when they receive the heartbeat,
+	// so ours is measured from when we send it.
+	start := time.Now()
+	af.mu.Lock()
+	af.position++
+	position := af.position
+	af.mu.Unlock()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	acks := 1
+	for _, client := range c.replicationClients {
+		wg.Add(1)
+		go func(client *replicationServiceClient) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), af.heartbeatInterval)
+			defer cancel()
+			_, err := client.client.Heartbeat(ctx, &replicationapi.HeartbeatRequest{
+				CaughtUp: c.standbyCaughtUp(client.remote),
+				Position: position,
+			})
+			if err != nil {
+				af.lgr.Tracef("cluster/autofailover: heartbeat to %s failed: %v", client.remote, err)
+				return
+			}
+			mu.Lock()
+			acks++
+			mu.Unlock()
+		}(client)
+	}
+	wg.Wait()
+
+	af.mu.Lock()
+	if acks >= c.quorum() {
+		af.lastQuorum = start
+	}
+	lapsed := af.leaseLapsed(time.Now())
+	af.mu.Unlock()
+	return lapsed
+}
+
+// stepDown makes this primary a standby at |epoch| after its lease lapses.
+func (c *Controller) stepDown(epoch int) {
+	af := c.autoFailover
+	af.lgr.Warnf("cluster/autofailover: a majority of the cluster has not acknowledged heartbeats for %v. stepping down to standby at epoch %d.", af.primaryLeaseTimeout(), epoch)
+	c.setRoleAndEpoch(string(RoleStandby), epoch, roleTransitionOptions{
+		graceful:    false,
+		steppedDown: true,
+	})
+}
+
+// standbyCaughtUp returns true if every database is caught up on the standby
+// remote named |remote|.
+func (c *Controller) standbyCaughtUp(remote string) bool {
+	c.mu.Lock()
+	commithooks := make([]*commithook, len(c.commithooks))
+	copy(commithooks, c.commithooks)
+	c.mu.Unlock()
+	for _, h := range commithooks {
+		if h.remotename == remote && !h.caughtUp() {
+			return false
+		}
+	}
+	return true
+}
+
+// standForElection runs an election for the next epoch if this standby has
+// gone too long without hearing from the primary and is caught up.
+func (c *Controller) standForElection() {
+	af := c.autoFailover
+	c.mu.Lock()
+	af.mu.Lock()
+	now := time.Now()
+	if c.role != RoleStandby || now.Before(af.electionDeadline) {
+		af.mu.Unlock()
+		c.mu.Unlock()
+		return
+	}
+	af.resetElectionDeadline(now)
+	if !af.caughtUp {
+		af.mu.Unlock()
+		c.mu.Unlock()
+		af.lgr.Warnf("cluster/autofailover: have not heard from the primary for %v, but not standing for election because this standby is not known to be caught up.", af.standbyLeaseTimeout())
+		return
+	}
+	epoch := c.epoch + 1
+	if af.votedEpoch >= epoch {
+		epoch = af.votedEpoch + 1
+	}
+	af.votedEpoch = epoch
+	caughtUpEpoch, caughtUpPosition := af.caughtUpEpoch, af.caughtUpPosition
+	af.mu.Unlock()
+	err := c.persistentCfg.SetStrings(map[string]string{votedEpochConfigKey: strconv.Itoa(epoch)})
+	c.mu.Unlock()
+	if err != nil {
+		af.lgr.Errorf("cluster/autofailover: could not persist vote for epoch %d; not standing for election: %v", epoch, err)
+		return
+	}
+
+	af.lgr.Infof("cluster/autofailover: have not heard from the primary for %v; standing for election at epoch %d.", af.standbyLeaseTimeout(), epoch)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	votes := 1
+	for _, client := range c.replicationClients {
+		wg.Add(1)
+		go func(client *replicationServiceClient) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), af.electionTimeout)
+			defer cancel()
+			resp, err := client.client.RequestVote(ctx, &replicationapi.RequestVoteRequest{
+				Epoch:            int64(epoch),
+				CaughtUpEpoch:    int64(caughtUpEpoch),
+				CaughtUpPosition: caughtUpPosition,
+			})
+			if err != nil {
+				af.lgr.Tracef("cluster/autofailover: vote request to %s failed: %v", client.remote, err)
+				return
+			}
+			if resp.VoteGranted {
+				mu.Lock()
+				votes++
+				mu.Unlock()
+			}
+		}(client)
+	}
+	wg.Wait()
+
+	if votes < c.quorum() {
+		af.lgr.Infof("cluster/autofailover: lost the election at epoch %d with %d of the %d votes needed.", epoch, votes, c.quorum())
+		return
+	}
+	af.lgr.Infof("cluster/autofailover: won the election at epoch %d with %d votes. becoming primary.", epoch, votes)
+	_, err = c.setRoleAndEpoch(string(RolePrimary), epoch, roleTransitionOptions{
+		graceful: false,
+	})
+	if err != nil {
+		// Another primary made itself known while we were collecting votes.
+		af.lgr.Warnf("cluster/autofailover: could not become primary after winning the election at epoch %d: %v", epoch, err)
+	}
+}
+
+// grantVote decides whether this server votes for a candidate asking to
+// become primary at |epoch|, which was last caught up at |caughtUpPosition|
+// at |caughtUpEpoch|.
+func (c *Controller) grantVote(epoch int, caughtUpEpoch int, caughtUpPosition int64) bool {
+	af := c.autoFailover
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	af.mu.Lock()
+	if c.role != RoleStandby || epoch <= c.epoch || epoch <= af.votedEpoch {
+		af.mu.Unlock()
+		return false
+	}
+	if af.leaseHeld(time.Now()) {
+		// We have heard from the primary recently enough that its
+		// lease is still good.
+		af.mu.Unlock()
+		return false
+	}
+	if !positionAtLeast(caughtUpEpoch, caughtUpPosition, af.caughtUpEpoch, af.caughtUpPosition) {
+		// The candidate may be missing writes we have.
+		af.lgr.Infof("cluster/autofailover: not voting for a candidate at epoch %d which was last caught up at position %d of epoch %d, behind our position %d of epoch %d.",
+			epoch, caughtUpPosition, caughtUpEpoch, af.caughtUpPosition, af.caughtUpEpoch)
+		af.mu.Unlock()
+		return false
+	}
+	af.votedEpoch = epoch
+	// Give the candidate a chance to win before we stand ourselves.
+	af.resetElectionDeadline(time.Now())
+	af.mu.Unlock()
+	if err := c.persistentCfg.SetStrings(map[string]string{votedEpochConfigKey: strconv.Itoa(epoch)}); err != nil {
+		af.lgr.Errorf("cluster/autofailover: could not persist vote for epoch %d; not voting: %v", epoch, err)
+		return false
+	}
+	af.lgr.Infof("cluster/autofailover: voted for a candidate at epoch %d.", epoch)
+	return true
+}
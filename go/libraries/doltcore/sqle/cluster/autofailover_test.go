@@ -0,0 +1,216 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+func newTestAutoFailoverController(role Role, epoch int) (*Controller, *config.MapConfig) {
+	pCfg := config.NewMapConfig(map[string]string{})
+	enable := true
+	af := newAutoFailover(logrus.StandardLogger(), &servercfg.ClusterAutoFailoverYAMLConfig{Enable_: &enable}, 0)
+	return &Controller{
+		role:          role,
+		epoch:         epoch,
+		persistentCfg: pCfg,
+		autoFailover:  af,
+	}, pCfg
+}
+
+// expireLease makes the standby's view of the primary's lease look like it
+// was last renewed more than an election timeout ago.
+func expireLease(af *autoFailover) {
+	af.mu.Lock()
+	defer af.mu.Unlock()
+	af.lastHeartbeat = time.Now().Add(-2 * af.electionTimeout)
+	af.electionDeadline = time.Now().Add(-time.Second)
+}
+
+func TestAutoFailoverGrantVote(t *testing.T) {
+	t.Run("WhileLeaseHeld", func(t *testing.T) {
+		c, _ := newTestAutoFailoverController(RoleStandby, 10)
+		assert.False(t, c.grantVote(11, 0, 0))
+	})
+	t.Run("AfterLeaseExpires", func(t *testing.T) {
+		c, pCfg := newTestAutoFailoverController(RoleStandby, 10)
+		expireLease(c.autoFailover)
+		assert.True(t, c.grantVote(11, 0, 0))
+		assert.Equal(t, "11", pCfg.GetStringOrDefault(votedEpochConfigKey, ""))
+		// Only one vote per epoch.
+		assert.False(t, c.grantVote(11, 0, 0))
+		assert.True(t, c.grantVote(12, 0, 0))
+	})
+	t.Run("StaleEpoch", func(t *testing.T) {
+		c, _ := newTestAutoFailoverController(RoleStandby, 10)
+		expireLease(c.autoFailover)
+		assert.False(t, c.grantVote(10, 0, 0))
+		assert.False(t, c.grantVote(9, 0, 0))
+	})
+	t.Run("AsPrimary", func(t *testing.T) {
+		c, _ := newTestAutoFailoverController(RolePrimary, 10)
+		expireLease(c.autoFailover)
+		assert.False(t, c.grantVote(11, 0, 0))
+	})
+	t.Run("HeartbeatRenewsLease", func(t *testing.T) {
+		c, _ := newTestAutoFailoverController(RoleStandby, 10)
+		expireLease(c.autoFailover)
+		c.autoFailover.heartbeatReceived(10, true, 1)
+		assert.False(t, c.grantVote(11, 0, 0))
+	})
+	t.Run("CandidateBehind", func(t *testing.T) {
+		c, _ := newTestAutoFailoverController(RoleStandby, 10)
+		c.autoFailover.heartbeatReceived(10, true, 5)
+		c.autoFailover.heartbeatReceived(10, false, 6)
+		expireLease(c.autoFailover)
+		assert.False(t, c.grantVote(11, 10, 4))
+		assert.False(t, c.grantVote(11, 9, 100))
+		assert.True(t, c.grantVote(11, 10, 5))
+		assert.True(t, c.grantVote(12, 11, 1))
+	})
+	t.Run("SteppedDownPrimary", func(t *testing.T) {
+		c, _ := newTestAutoFailoverController(RolePrimary, 10)
+		c.autoFailover.position = 7
+		c.autoFailover.roleChanged(10, true)
+		c.role = RoleStandby
+		expireLease(c.autoFailover)
+		assert.False(t, c.grantVote(11, 10, 6))
+		assert.True(t, c.grantVote(11, 10, 7))
+	})
+}
+
+func TestAutoFailoverQuorum(t *testing.T) {
+	c := &Controller{}
+	assert.Equal(t, 1, c.quorum())
+	c.replicationClients = make([]*replicationServiceClient, 2)
+	assert.Equal(t, 2, c.quorum())
+	c.replicationClients = make([]*replicationServiceClient, 3)
+	assert.Equal(t, 3, c.quorum())
+	c.replicationClients = make([]*replicationServiceClient, 4)
+	assert.Equal(t, 3, c.quorum())
+}
+
+func TestAutoFailoverDoesNotStandForElectionWhenBehind(t *testing.T) {
+	c, pCfg := newTestAutoFailoverController(RoleStandby, 10)
+	expireLease(c.autoFailover)
+	c.autoFailover.mu.Lock()
+	c.autoFailover.caughtUp = false
+	c.autoFailover.mu.Unlock()
+	c.standForElection()
+	assert.Equal(t, RoleStandby, c.role)
+	assert.Equal(t, 10, c.epoch)
+	assert.Equal(t, "", pCfg.GetStringOrDefault(votedEpochConfigKey, ""))
+}
+
+// partitionableClient delivers heartbeats to |standby| until it is
+// partitioned, after which heartbeats hang until they time out, which is the
+// longest a round of heartbeats can take.
+type partitionableClient struct {
+	replicationapi.ReplicationServiceClient
+	standby     *Controller
+	epoch       int
+	partitioned atomic.Bool
+}
+
+func (c *partitionableClient) Heartbeat(ctx context.Context, req *replicationapi.HeartbeatRequest, _ ...grpc.CallOption) (*replicationapi.HeartbeatResponse, error) {
+	if c.partitioned.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	c.standby.autoFailover.heartbeatReceived(c.epoch, req.CaughtUp, req.Position)
+	return &replicationapi.HeartbeatResponse{}, nil
+}
+
+func TestAutoFailoverPrimaryStepsDownBeforeStandbyVotes(t *testing.T) {
+	const epoch = 10
+	// The shortest election timeout allowed for the heartbeat interval, which
+	// leaves the least room between the primary's lease and the standby's.
+	heartbeatMillis, electionMillis := uint64(50), uint64(100)
+	cfg := &servercfg.ClusterAutoFailoverYAMLConfig{
+		HeartbeatIntervalMillis_: &heartbeatMillis,
+		ElectionTimeoutMillis_:   &electionMillis,
+	}
+
+	for _, partitionAfter := range []int{1, 2, 3} {
+		standby := &Controller{
+			role:          RoleStandby,
+			epoch:         epoch,
+			persistentCfg: config.NewMapConfig(map[string]string{}),
+			autoFailover:  newAutoFailover(logrus.StandardLogger(), cfg, 0),
+		}
+		client := &partitionableClient{standby: standby, epoch: epoch}
+		primary := &Controller{
+			role:               RolePrimary,
+			epoch:              epoch,
+			replicationClients: []*replicationServiceClient{{client: client, remote: "standby"}},
+			autoFailover:       newAutoFailover(logrus.StandardLogger(), cfg, 0),
+		}
+		af := primary.autoFailover
+
+		// The standby asks for a vote as soon as it would be granted.
+		votedAt := make(chan time.Time, 1)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case <-time.After(time.Millisecond):
+				}
+				if standby.grantVote(epoch+1, epoch, math.MaxInt64) {
+					votedAt <- time.Now()
+					return
+				}
+			}
+		}()
+
+		// Send heartbeats the way runAutoFailover does, partitioning the
+		// primary from the standby after |partitionAfter| rounds.
+		var steppedDownAt time.Time
+		ticker := time.NewTicker(af.heartbeatInterval)
+		for round := 1; steppedDownAt.IsZero(); round++ {
+			<-ticker.C
+			if round > partitionAfter {
+				client.partitioned.Store(true)
+			}
+			if primary.sendHeartbeats(epoch) {
+				steppedDownAt = time.Now()
+			}
+		}
+		ticker.Stop()
+
+		select {
+		case voted := <-votedAt:
+			assert.True(t, steppedDownAt.Before(voted), "the primary stepped down at %v, after the standby voted at %v", steppedDownAt, voted)
+			assert.GreaterOrEqual(t, voted.Sub(steppedDownAt), af.heartbeatInterval/2, "the primary has little time to finish stepping down")
+		case <-time.After(2 * af.standbyLeaseTimeout()):
+			require.Fail(t, "the standby never voted for a new primary")
+		}
+	}
+}
@@ -223,7 +223,7 @@ func (h *commithook) shouldReplicate() bool {
 	return (h.nextPushAttempt == (time.Time{}) || time.Now().After(h.nextPushAttempt))
 }
 
-// called with h.mu locked. Returns true if the standby is true-d up, false
+// called with h.mu locked. Returns true if the standby is caught up, false
 // otherwise. Different from shouldReplicate() in that it does not care about
 // nextPushAttempt, for example. Used in Controller.waitForReplicate.
 func (h *commithook) isCaughtUp() bool {
@@ -236,6 +236,14 @@ func (h *commithook) isCaughtUp() bool {
 	return h.nextHead == h.lastPushedHead
 }
 
+// Returns true if the standby is caught up. Used by auto failover to tell a
+// standby whether it may stand for election.
+func (h *commithook) caughtUp() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.isCaughtUp()
+}
+
 // called with h.mu locked.
 func (h *commithook) primaryNeedsInit() bool {
 	return h.role == RolePrimary && h.nextHead == (hash.Hash{})
@@ -333,7 +341,8 @@ func (h *commithook) attemptReplicate(ctx context.Context) {
 	}
 
 	lgr.Tracef("cluster/commithook: pushing chunks for root hash %v to destDB", toPush.String())
-	err = destDB.PullChunks(sqlCtx, h.tempDir, h.srcDB, []hash.Hash{toPush}, nil, nil)
+	transferCtx := doltdb.WithTransferLabels(sqlCtx, doltdb.TransferLabels{Database: h.dbname, Remote: h.remotename, Direction: doltdb.TransferPush})
+	err = destDB.PullChunks(transferCtx, h.tempDir, h.srcDB, []hash.Hash{toPush}, nil, nil)
 	if err == nil {
 		lgr.Tracef("cluster/commithook: successfully pushed chunks, setting root")
 		datasDB := doltdb.HackDatasDatabaseFromDoltDB(destDB)
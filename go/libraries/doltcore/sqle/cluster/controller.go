@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -119,6 +120,9 @@ type Controller struct {
 	sinterceptor serverinterceptor
 	cinterceptor clientinterceptor
 
+	// non-nil if auto_failover is enabled.
+	autoFailover *autoFailover
+
 	epoch int
 	mu    sync.Mutex
 }
@@ -171,6 +175,16 @@ func NewController(lgr *logrus.Logger, cfg servercfg.ClusterConfig, pCfg config.
 	ret.cinterceptor.setRole(role, epoch)
 	ret.cinterceptor.roleSetter = roleSetter
 
+	if cfg.AutoFailover().Enable() {
+		votedEpoch, err := strconv.Atoi(pCfg.GetStringOrDefault(votedEpochConfigKey, "0"))
+		if err != nil {
+			return nil, fmt.Errorf("persisted voted epoch %s.%s must be an integer: %w", PersistentConfigPrefix, votedEpochConfigKey, err)
+		}
+		ret.autoFailover = newAutoFailover(lgr, cfg.AutoFailover(), votedEpoch)
+		ret.sinterceptor.fenceStaleEpochs = true
+		ret.cinterceptor.fenceStaleEpochs = true
+	}
+
 	ret.tlsCfg, err = ret.outboundTlsConfig()
 	if err != nil {
 		return nil, err
@@ -235,6 +249,13 @@ func (c *Controller) Run() {
 		defer wg.Done()
 		c.bcReplication.Run()
 	}()
+	if c.autoFailover != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.runAutoFailover()
+		}()
+	}
 	wg.Wait()
 	for _, client := range c.replicationClients {
 		client.closer()
@@ -245,6 +266,9 @@ func (c *Controller) GracefulStop() error {
 	c.jwks.GracefulStop()
 	c.mysqlDbPersister.GracefulStop()
 	c.bcReplication.GracefulStop()
+	if c.autoFailover != nil {
+		c.autoFailover.stop()
+	}
 	return nil
 }
 
@@ -497,6 +521,27 @@ func (c *Controller) refreshSystemVars() {
 			Type:    gmstypes.NewSystemIntType(dsess.DoltClusterRoleEpochVariable, 0, 9223372036854775807, false),
 			Default: epoch,
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltClusterReplicationMode,
+			Dynamic: false,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    gmstypes.NewSystemStringType(dsess.DoltClusterReplicationMode),
+			Default: c.cfg.ReplicationMode(),
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltClusterSemiSyncMinAcks,
+			Dynamic: false,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    gmstypes.NewSystemIntType(dsess.DoltClusterSemiSyncMinAcks, 0, math.MaxInt32, false),
+			Default: int64(c.cfg.SemiSync().MinAcks()),
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltClusterSemiSyncTimeoutMillis,
+			Dynamic: false,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    gmstypes.NewSystemIntType(dsess.DoltClusterSemiSyncTimeoutMillis, 0, math.MaxInt64, false),
+			Default: c.cfg.SemiSync().Timeout().Milliseconds(),
+		},
 	}
 	c.systemVars.AddSystemVariables(vars)
 }
@@ -564,6 +609,9 @@ type roleTransitionOptions struct {
 	// If true, all standby replicas must be caught up in order to
 	// transition from primary to standby.
 	graceful bool
+	// True if auto failover is moving a primary which lost its lease to
+	// standby.
+	steppedDown bool
 }
 
 type roleTransitionResult struct {
@@ -643,6 +691,9 @@ func (c *Controller) setRoleAndEpoch(role string, epoch int, opts roleTransition
 		}
 		c.mysqlDbPersister.setRole(c.role)
 		c.bcReplication.setRole(c.role)
+		if c.autoFailover != nil {
+			c.autoFailover.roleChanged(c.epoch, opts.steppedDown)
+		}
 	}
 	_ = c.persistVariables()
 	return roleTransitionResult{
@@ -672,17 +723,19 @@ func (c *Controller) GetClusterStatus() []clusterdb.ReplicaStatus {
 	commithooks := make([]*commithook, len(c.commithooks))
 	copy(commithooks, c.commithooks)
 	c.mu.Unlock()
+	mode := c.cfg.ReplicationMode()
 	ret := make([]clusterdb.ReplicaStatus, len(commithooks))
 	for i, c := range commithooks {
 		lag, lastUpdate, currentErrorStr := c.status()
 		ret[i] = clusterdb.ReplicaStatus{
-			Database:       c.dbname,
-			Remote:         c.remotename,
-			Role:           string(role),
-			Epoch:          epoch,
-			ReplicationLag: lag,
-			LastUpdate:     lastUpdate,
-			CurrentError:   currentErrorStr,
+			Database:        c.dbname,
+			Remote:          c.remotename,
+			Role:            string(role),
+			Epoch:           epoch,
+			ReplicationMode: mode,
+			ReplicationLag:  lag,
+			LastUpdate:      lastUpdate,
+			CurrentError:    currentErrorStr,
 		}
 	}
 	return ret
@@ -785,6 +838,7 @@ func (c *Controller) RegisterGrpcServices(ctxFactory func(context.Context) (*sql
 		branchControl:        c.branchControlController,
 		branchControlFilesys: c.branchControlFilesys,
 		dropDatabase:         c.dropDatabase,
+		controller:           c,
 		lgr:                  c.lgr.WithFields(logrus.Fields{}),
 	})
 }
@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc/status"
 	"gopkg.in/go-jose/go-jose.v2/jwt"
 
+	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/utils/jwtauth"
 )
 
@@ -71,12 +72,18 @@ func isLikelyServerResponse(err error) bool {
 // response header asserts that the standby replica is a primary at a higher
 // epoch than this server, this incterceptor coordinates with the Controller to
 // immediately transition to standby and to stop replicating to the standby.
+//
+// When auto failover is enabled, |fenceStaleEpochs| is set. Vote requests are
+// then let through while this server is a standby, and this server also
+// transitions to standby when any cluster member responds with a higher epoch
+// than its own, since that member has seen a newer election.
 type clientinterceptor struct {
-	lgr        *logrus.Entry
-	roleSetter func(role string, epoch int)
-	role       Role
-	epoch      int
-	mu         sync.Mutex
+	lgr              *logrus.Entry
+	roleSetter       func(role string, epoch int)
+	role             Role
+	epoch            int
+	fenceStaleEpochs bool
+	mu               sync.Mutex
 }
 
 func (ci *clientinterceptor) setRole(role Role, epoch int) {
@@ -96,7 +103,7 @@ func (ci *clientinterceptor) Stream() grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 		role, epoch := ci.getRole()
 		ci.lgr.Tracef("cluster: clientinterceptor: processing request to %s, role %s", method, string(role))
-		if role == RoleStandby {
+		if role == RoleStandby && !ci.allowedAsStandby(method) {
 			return nil, status.Error(codes.FailedPrecondition, "cluster: clientinterceptor: this server is a standby and is not currently replicating to its standby")
 		}
 		if role == RoleDetectedBrokenConfig {
@@ -114,7 +121,7 @@ func (ci *clientinterceptor) Unary() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		role, epoch := ci.getRole()
 		ci.lgr.Tracef("cluster: clientinterceptor: processing request to %s, role %s", method, string(role))
-		if role == RoleStandby {
+		if role == RoleStandby && !ci.allowedAsStandby(method) {
 			return status.Error(codes.FailedPrecondition, "cluster: clientinterceptor: this server is a standby and is not currently replicating to its standby")
 		}
 		if role == RoleDetectedBrokenConfig {
@@ -128,6 +135,12 @@ func (ci *clientinterceptor) Unary() grpc.UnaryClientInterceptor {
 	}
 }
 
+// Returns true if a standby may make a request to |method|. Standbys only
+// make requests when standing for election.
+func (ci *clientinterceptor) allowedAsStandby(method string) bool {
+	return ci.fenceStaleEpochs && method == replicationapi.ReplicationService_RequestVote_FullMethodName
+}
+
 func (ci *clientinterceptor) handleResponseHeaders(header metadata.MD, err error) {
 	role, epoch := ci.getRole()
 	if role != RolePrimary {
@@ -152,6 +165,10 @@ func (ci *clientinterceptor) handleResponseHeaders(header metadata.MD, err error
 			} else if respRole == string(RoleDetectedBrokenConfig) && respEpoch >= epoch {
 				ci.lgr.Errorf("cluster: clientinterceptor: this server learned from its standby that the standby is in detected_broken_config at the same or higher epoch. force transitioning to detected_broken_config.")
 				ci.roleSetter(string(RoleDetectedBrokenConfig), respEpoch)
+			} else if ci.fenceStaleEpochs && respEpoch > epoch {
+				// A newer primary has been elected without us.
+				ci.lgr.Warnf("cluster: clientinterceptor: this server is primary at epoch %d. a server it attempted to replicate to is %s at epoch %d. force transitioning to standby.", epoch, respRole, respEpoch)
+				ci.roleSetter(string(RoleStandby), respEpoch)
 			}
 		} else {
 			ci.lgr.Errorf("cluster: clientinterceptor: failed to parse epoch in response header; something is wrong: %v", err)
@@ -187,6 +204,11 @@ func (ci *clientinterceptor) Options() []grpc.DialOption {
 // requests with codes.Unauthenticated. Eventually, it will allow read-only
 // traffic through which is authenticated and authorized.
 //
+// When auto failover is enabled, |fenceStaleEpochs| is set, and this
+// interceptor also fails requests from a primary at an older epoch than ours
+// with codes.FailedPrecondition. Such a primary was replaced in an election it
+// did not see, and the response headers tell it so.
+//
 // The serverinterceptor is responsible for authenticating incoming requests
 // from standby replicas. It is instantiated with a jwtauth.KeyProvider and
 // some jwt.Expected. Incoming requests must have a valid, unexpired, signed
@@ -195,11 +217,12 @@ type serverinterceptor struct {
 	keyProvider jwtauth.KeyProvider
 	jwtExpected jwt.Expected
 
-	lgr        *logrus.Entry
-	roleSetter func(role string, epoch int)
-	role       Role
-	epoch      int
-	mu         sync.Mutex
+	lgr              *logrus.Entry
+	roleSetter       func(role string, epoch int)
+	role             Role
+	epoch            int
+	fenceStaleEpochs bool
+	mu               sync.Mutex
 }
 
 func (si *serverinterceptor) Stream() grpc.StreamServerInterceptor {
@@ -225,6 +248,9 @@ func (si *serverinterceptor) Stream() grpc.StreamServerInterceptor {
 				// In detected_brokne_config we do not accept replication requests.
 				return status.Error(codes.FailedPrecondition, "this server is currently in detected_broken_config and is not currently accepting replication")
 			}
+			if si.isFromStalePrimary(incomingMetadata(ss.Context()), epoch) {
+				return status.Error(codes.FailedPrecondition, "this server has seen a newer primary and is not accepting replication from an older epoch")
+			}
 			return handler(srv, ss)
 		} else if isWrite := writeEndpoints[info.FullMethod]; isWrite {
 			return status.Error(codes.Unimplemented, "unimplemented")
@@ -257,6 +283,9 @@ func (si *serverinterceptor) Unary() grpc.UnaryServerInterceptor {
 				// In detected_broken_config we do not accept replication requests.
 				return nil, status.Error(codes.FailedPrecondition, "this server is currently in detected_broken_config and is not currently accepting replication")
 			}
+			if si.isFromStalePrimary(incomingMetadata(ctx), epoch) {
+				return nil, status.Error(codes.FailedPrecondition, "this server has seen a newer primary and is not accepting replication from an older epoch")
+			}
 			return handler(ctx, req)
 		} else if isWrite := writeEndpoints[info.FullMethod]; isWrite {
 			return nil, status.Error(codes.Unimplemented, "unimplemented")
@@ -298,6 +327,30 @@ func (si *serverinterceptor) handleRequestHeaders(header metadata.MD) bool {
 	return false
 }
 
+// Returns true if stale epochs are fenced and |header| is from a primary at
+// an older epoch than |epoch|.
+func (si *serverinterceptor) isFromStalePrimary(header metadata.MD, epoch int) bool {
+	if !si.fenceStaleEpochs {
+		return false
+	}
+	roles := header.Get(clusterRoleHeader)
+	epochs := header.Get(clusterRoleEpochHeader)
+	if len(roles) == 0 || len(epochs) == 0 || roles[0] != string(RolePrimary) {
+		return false
+	}
+	reqepoch, err := strconv.Atoi(epochs[0])
+	if err != nil || reqepoch >= epoch {
+		return false
+	}
+	si.lgr.Warnf("cluster: serverinterceptor: this server is at epoch %d. rejecting replication from a primary at epoch %d.", epoch, reqepoch)
+	return true
+}
+
+func incomingMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return md
+}
+
 func (si *serverinterceptor) Options() []grpc.ServerOption {
 	return []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(si.Unary()),
@@ -35,6 +35,7 @@ import (
 	"gopkg.in/go-jose/go-jose.v2"
 	"gopkg.in/go-jose/go-jose.v2/jwt"
 
+	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/utils/jwtauth"
 )
 
@@ -304,3 +305,75 @@ func TestClientInterceptorAsStandbyDoesNotSendRequest(t *testing.T) {
 		assert.Equal(t, "10", srv.md.Get(clusterRoleEpochHeader)[0])
 	}
 }
+
+func TestServerInterceptorFencesStalePrimary(t *testing.T) {
+	var si serverinterceptor
+	si.setRole(RoleStandby, 10)
+	si.roleSetter = noopSetRole
+	si.lgr = lgr
+	si.keyProvider = kp
+	t.Run("Disabled", func(t *testing.T) {
+		withClient(t, func(t *testing.T, client grpc_health_v1.HealthClient) {
+			_, err := client.Check(outboundCtx(RolePrimary, 9), &grpc_health_v1.HealthCheckRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		}, si.Options(), nil)
+	})
+	si.fenceStaleEpochs = true
+	t.Run("Enabled", func(t *testing.T) {
+		srv := withClient(t, func(t *testing.T, client grpc_health_v1.HealthClient) {
+			var md metadata.MD
+			_, err := client.Check(outboundCtx(RolePrimary, 9), &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&md))
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			if assert.Len(t, md.Get(clusterRoleEpochHeader), 1) {
+				assert.Equal(t, "10", md.Get(clusterRoleEpochHeader)[0])
+			}
+			ss, err := client.Watch(outboundCtx(RolePrimary, 9), &grpc_health_v1.HealthCheckRequest{})
+			require.NoError(t, err)
+			_, err = ss.Recv()
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		}, si.Options(), nil)
+		assert.Nil(t, srv.md)
+		srv = withClient(t, func(t *testing.T, client grpc_health_v1.HealthClient) {
+			_, err := client.Check(outboundCtx(RolePrimary, 10), &grpc_health_v1.HealthCheckRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		}, si.Options(), nil)
+		assert.NotNil(t, srv.md)
+	})
+}
+
+func TestClientInterceptorFencedByNewerEpoch(t *testing.T) {
+	var si serverinterceptor
+	si.setRole(RoleStandby, 11)
+	si.roleSetter = noopSetRole
+	si.lgr = lgr
+	si.keyProvider = kp
+	si.fenceStaleEpochs = true
+
+	var ci clientinterceptor
+	ci.setRole(RolePrimary, 10)
+	ci.lgr = lgr
+	ci.fenceStaleEpochs = true
+	var newRole string
+	var newEpoch int
+	ci.roleSetter = func(role string, epoch int) {
+		newRole, newEpoch = role, epoch
+	}
+	withClient(t, func(t *testing.T, client grpc_health_v1.HealthClient) {
+		_, err := client.Check(outboundCtx(), &grpc_health_v1.HealthCheckRequest{})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	}, si.Options(), ci.Options())
+	assert.Equal(t, string(RoleStandby), newRole)
+	assert.Equal(t, 11, newEpoch)
+}
+
+func TestClientInterceptorAsStandbyAllowsVoteRequests(t *testing.T) {
+	var ci clientinterceptor
+	ci.setRole(RoleStandby, 10)
+	ci.roleSetter = noopSetRole
+	ci.lgr = lgr
+	assert.False(t, ci.allowedAsStandby(replicationapi.ReplicationService_RequestVote_FullMethodName))
+	ci.fenceStaleEpochs = true
+	assert.True(t, ci.allowedAsStandby(replicationapi.ReplicationService_RequestVote_FullMethodName))
+	assert.False(t, ci.allowedAsStandby(replicationapi.ReplicationService_Heartbeat_FullMethodName))
+	assert.False(t, ci.allowedAsStandby(replicationapi.ReplicationService_UpdateUsersAndGrants_FullMethodName))
+}
@@ -44,6 +44,8 @@ type replicationServiceServer struct {
 	branchControlFilesys filesys.Filesys
 
 	dropDatabase func(*sql.Context, string) error
+
+	controller *Controller
 }
 
 func (s *replicationServiceServer) UpdateUsersAndGrants(ctx context.Context, req *replicationapi.UpdateUsersAndGrantsRequest) (*replicationapi.UpdateUsersAndGrantsResponse, error) {
@@ -99,3 +101,21 @@ func (s *replicationServiceServer) DropDatabase(ctx context.Context, req *replic
 	}
 	return &replicationapi.DropDatabaseResponse{}, nil
 }
+
+func (s *replicationServiceServer) Heartbeat(ctx context.Context, req *replicationapi.HeartbeatRequest) (*replicationapi.HeartbeatResponse, error) {
+	if s.controller == nil || s.controller.autoFailover == nil {
+		return nil, status.Error(codes.Unimplemented, "unimplemented")
+	}
+	_, epoch := s.controller.roleAndEpoch()
+	s.controller.autoFailover.heartbeatReceived(epoch, req.CaughtUp, req.Position)
+	return &replicationapi.HeartbeatResponse{}, nil
+}
+
+func (s *replicationServiceServer) RequestVote(ctx context.Context, req *replicationapi.RequestVoteRequest) (*replicationapi.RequestVoteResponse, error) {
+	if s.controller == nil || s.controller.autoFailover == nil {
+		return nil, status.Error(codes.Unimplemented, "unimplemented")
+	}
+	return &replicationapi.RequestVoteResponse{
+		VoteGranted: s.controller.grantVote(int(req.Epoch), int(req.CaughtUpEpoch), req.CaughtUpPosition),
+	}, nil
+}
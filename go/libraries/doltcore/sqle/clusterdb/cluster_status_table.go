@@ -38,6 +38,8 @@ type ReplicaStatus struct {
 	Remote string
 	// The epoch of this server's current role.
 	Epoch int
+	// The cluster's replication mode, "async" or "semi_sync".
+	ReplicationMode string
 }
 
 type ClusterStatusProvider interface {
@@ -93,7 +95,7 @@ func replicaStatusesToRows(rss []ReplicaStatus) []sql.Row {
 }
 
 func replicaStatusToRow(rs ReplicaStatus) sql.Row {
-	ret := make(sql.Row, 7)
+	ret := make(sql.Row, 8)
 	ret[0] = rs.Database
 	ret[1] = rs.Remote
 	ret[2] = rs.Role
@@ -107,6 +109,7 @@ func replicaStatusToRow(rs ReplicaStatus) sql.Row {
 	if rs.CurrentError != nil {
 		ret[6] = *rs.CurrentError
 	}
+	ret[7] = rs.ReplicationMode
 	return ret
 }
 
@@ -119,5 +122,6 @@ func (t ClusterStatusTable) Schema() sql.Schema {
 		{Name: "replication_lag_millis", Type: types.Int64, Source: StatusTableName, PrimaryKey: false, Nullable: true},
 		{Name: "last_update", Type: types.Datetime, Source: StatusTableName, PrimaryKey: false, Nullable: true},
 		{Name: "current_error", Type: types.Text, Source: StatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "replication_mode", Type: types.Text, Source: StatusTableName, PrimaryKey: false, Nullable: false},
 	}
 }
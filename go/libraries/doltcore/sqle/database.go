@@ -707,6 +707,38 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewBranchActivityTable(ctx, db), true
 		}
+	case doltdb.IndexBuildsTableName, doltdb.GetIndexBuildsTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewIndexBuildsTable(ctx, db, lwrName), true
+		}
+	case doltdb.StorageMaintenanceLogTableName, doltdb.GetStorageMaintenanceLogTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewStorageMaintenanceLogTable(ctx, db, lwrName), true
+		}
+	case doltdb.TableStorageTableName, doltdb.GetTableStorageTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			if head == nil {
+				var err error
+				head, err = ds.GetHeadCommit(ctx, db.RevisionQualifiedName())
+				if err != nil {
+					return nil, false, err
+				}
+			}
+
+			dt, found = dtables.NewTableStorageTable(ctx, lwrName, db.ddb, head, root), true
+		}
 	case doltdb.RemoteBranchesTableName, doltdb.GetRemoteBranchesTableName():
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -967,6 +999,17 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewTestsTable(ctx, versionableTable), true
 		}
+	case doltdb.SubmodulesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.SubmodulesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptySubmodulesTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewSubmodulesTable(ctx, versionableTable), true
+		}
 	}
 
 	if found {
@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
@@ -37,6 +38,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/indexbuild"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -61,6 +64,8 @@ type DoltDatabaseProvider struct {
 	isStandby              *bool
 	mu                     *sync.RWMutex
 	droppedDatabaseManager *droppedDatabaseManager
+	indexBuilds            *indexbuild.Controller
+	maintenanceLog         *StorageMaintenanceLog
 
 	defaultBranch     string
 	dbFactoryUrl      string
@@ -75,6 +80,8 @@ var _ sql.CollatedDatabaseProvider = (*DoltDatabaseProvider)(nil)
 var _ sql.ExternalStoredProcedureProvider = (*DoltDatabaseProvider)(nil)
 var _ sql.TableFunctionProvider = (*DoltDatabaseProvider)(nil)
 var _ dsess.DoltDatabaseProvider = (*DoltDatabaseProvider)(nil)
+var _ indexbuild.ControllerProvider = (*DoltDatabaseProvider)(nil)
+var _ dtables.StorageMaintenanceLogProvider = (*DoltDatabaseProvider)(nil)
 
 func (p *DoltDatabaseProvider) DefaultBranch() string {
 	return p.defaultBranch
@@ -159,6 +166,8 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		dbFactoryUrl:           dbFactoryUrl,
 		isStandby:              new(bool),
 		droppedDatabaseManager: newDroppedDatabaseManager(fs),
+		indexBuilds:            indexbuild.NewController(logrus.StandardLogger()),
+		maintenanceLog:         NewStorageMaintenanceLog(),
 	}, nil
 }
 
@@ -228,6 +237,21 @@ func (p *DoltDatabaseProvider) InstallReplicationInitDatabaseHook(bThreads *sql.
 	p.AddInitDatabaseHook(NewConfigureReplicationDatabaseHook(bThreads, ctxF))
 }
 
+// IndexBuildController returns the controller which runs online index builds for the databases of this provider.
+func (p *DoltDatabaseProvider) IndexBuildController() *indexbuild.Controller {
+	return p.indexBuilds
+}
+
+// StorageMaintenanceLog returns the log of the storage maintenance performed on the databases of this provider.
+func (p *DoltDatabaseProvider) StorageMaintenanceLog() *StorageMaintenanceLog {
+	return p.maintenanceLog
+}
+
+// StorageMaintenanceEvents implements dtables.StorageMaintenanceLogProvider.
+func (p *DoltDatabaseProvider) StorageMaintenanceEvents() []dtables.StorageMaintenanceEvent {
+	return p.maintenanceLog.Events()
+}
+
 // SetIsStandby sets whether this provider is set to standby |true|. Standbys return every dolt database as a read only
 // database. Set back to |false| to get read-write behavior from dolt databases again.
 func (p *DoltDatabaseProvider) SetIsStandby(standby bool) {
@@ -128,6 +128,9 @@ func renameBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argpar
 	if err := branch_control.CanCreateBranch(ctx, newBranchName); err != nil {
 		return err
 	}
+	if err := checkBranchNotInWorktree(ctx, dbName, oldBranchName); err != nil {
+		return err
+	}
 	force := apr.Contains(cli.ForceFlag)
 
 	if !force {
@@ -239,6 +242,9 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argp
 		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
 			return err
 		}
+		if err = checkBranchNotInWorktree(ctx, dbName, branchName); err != nil {
+			return err
+		}
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -0,0 +1,68 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/indexbuild"
+)
+
+// doltCancelIndexBuild is the stored procedure version for the CLI function `dolt_cancel_index_build(id)`. It
+// cancels an online index build of the current database.
+func doltCancelIndexBuild(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("dolt_cancel_index_build expects exactly one argument, the id of the index build")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index build id: %s", args[0])
+	}
+
+	controller := indexbuild.GetController(ctx)
+	if controller == nil {
+		return nil, indexbuild.ErrControllerNotRunning
+	}
+	// only builds of the current database may be cancelled
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	found := false
+	for _, job := range controller.Jobs() {
+		jobBaseName, _ := doltdb.SplitRevisionDbName(job.Database)
+		if job.ID == id && strings.EqualFold(baseName, jobBaseName) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, indexbuild.ErrJobNotFound
+	}
+	if err = controller.Cancel(id); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
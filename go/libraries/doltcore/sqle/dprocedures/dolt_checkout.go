@@ -437,6 +437,12 @@ func checkoutNewBranch(
 	if optionBBranch != "" {
 		newBranchName = optionBBranch
 	}
+	if createBranchForcibly {
+		// -B resets the branch if it already exists
+		if err = checkBranchNotInWorktree(ctx, dbName, newBranchName); err != nil {
+			return "", "", err
+		}
+	}
 
 	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, createBranchForcibly, rsc)
 	if err != nil {
@@ -545,6 +551,9 @@ func checkoutExistingBranchWithWorkingSetFallback(
 	if !ok {
 		return fmt.Errorf("could not load database %s", dbName)
 	}
+	if err := checkBranchNotInWorktree(ctx, dbName, branchName); err != nil {
+		return err
+	}
 
 	err := checkoutExistingBranch(ctx, dbName, branchName, apr)
 	if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
@@ -191,6 +191,18 @@ func transferWorkingChanges(
 	return nil
 }
 
+// checkBranchNotInWorktree returns an error if |branchName| is checked out in a worktree of the repository |dbName| is
+// stored in, other than the directory |dbName| was loaded from. A branch checked out in another worktree has its
+// working set changed by that worktree, so sessions must not check it out, move it or delete it.
+func checkBranchNotInWorktree(ctx *sql.Context, dbName, branchName string) error {
+	fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(dbName)
+	if err != nil {
+		// the database isn't stored in a directory of its own, so it has no worktrees
+		return nil
+	}
+	return env.CheckBranchNotInWorktree(fs, ref.NewBranchRef(branchName))
+}
+
 // willModifyDb determines whether or not this operation is a no-op and can return early with a helpful message.
 func willModifyDb(ctx *sql.Context, dSess *dsess.DoltSession, data env.DbData[*sql.Context], dbName, branchName string, updateHead bool) (bool, error) {
 	headRef, err := data.Rsr.CWBHeadRef(ctx)
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
@@ -92,7 +93,7 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 
 	prune := apr.Contains(cli.PruneFlag)
 	mode := ref.UpdateMode{Force: true, Prune: prune}
-	err = actions.FetchRefSpecs(ctx, dbData, srcDB, refSpecs, defaultRefSpec, &remote, mode, runProgFuncs, stopProgFuncs)
+	err = actions.FetchRefSpecs(transferContext(ctx, dbName, remote.Name, doltdb.TransferPull), dbData, srcDB, refSpecs, defaultRefSpec, &remote, mode, runProgFuncs, stopProgFuncs)
 	if err != nil {
 		return cmdFailure, fmt.Errorf("fetch failed: %w", err)
 	}
@@ -108,3 +109,14 @@ func validateFetchArgs(apr *argparser.ArgParseResults, refSpecArgs []string) err
 
 	return nil
 }
+
+// transferContext returns |ctx| labeled so that the chunks it transfers to or from |remoteName| are reported to the
+// transfer metrics of the database |dbName|.
+func transferContext(ctx *sql.Context, dbName, remoteName string, direction doltdb.TransferDirection) *sql.Context {
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	return ctx.WithContext(doltdb.WithTransferLabels(ctx, doltdb.TransferLabels{
+		Database:  baseName,
+		Remote:    remoteName,
+		Direction: direction,
+	}))
+}
@@ -159,7 +159,8 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, string, error) {
 	}
 	prune := apr.Contains(cli.PruneFlag)
 	mode := ref.UpdateMode{Force: true, Prune: prune}
-	err = actions.FetchRefSpecs(ctx, dbData, srcDB, pullSpec.RefSpecs, false, &pullSpec.Remote, mode, runProgFuncs, stopProgFuncs)
+	transferCtx := transferContext(ctx, dbName, pullSpec.Remote.Name, doltdb.TransferPull)
+	err = actions.FetchRefSpecs(transferCtx, dbData, srcDB, pullSpec.RefSpecs, false, &pullSpec.Remote, mode, runProgFuncs, stopProgFuncs)
 	if err != nil {
 		return noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("fetch failed: %w", err)
 	}
@@ -243,7 +244,7 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, string, error) {
 	if err != nil {
 		return noConflictsOrViolations, threeWayMerge, "", err
 	}
-	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, runProgFuncs, stopProgFuncs)
+	err = actions.FetchFollowTags(transferCtx, tmpDir, srcDB, dbData.Ddb, runProgFuncs, stopProgFuncs)
 	if err != nil {
 		return conflicts, fastForward, "", err
 	}
@@ -115,7 +115,7 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 		DestDb:  remoteDB,
 		TmpDir:  tmpDir,
 	}
-	returnMsg, err = actions.DoPush(ctx, po, runProgFuncs, stopProgFuncs)
+	returnMsg, err = actions.DoPush(transferContext(ctx, dbName, remote.Name, doltdb.TransferPush), po, runProgFuncs, stopProgFuncs)
 	if err != nil {
 		switch err {
 		case doltdb.ErrUpToDate:
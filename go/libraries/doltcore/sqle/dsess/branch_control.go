@@ -37,9 +37,6 @@ func CheckAccessForDb(ctx context.Context, db SqlDatabase, flags branch_control.
 		return branch_control.ErrMissingController.New()
 	}
 
-	controller.Access.RWMutex.RLock()
-	defer controller.Access.RWMutex.RUnlock()
-
 	user := branchAwareSession.GetUser()
 	host := branchAwareSession.GetHost()
 
@@ -51,9 +48,19 @@ func CheckAccessForDb(ctx context.Context, db SqlDatabase, flags branch_control.
 	dbName, branch := doltdb.SplitRevisionDbName(db.RevisionQualifiedName())
 
 	// Get the permissions for the branch, user, and host combination
+	controller.Access.RWMutex.RLock()
 	_, perms := controller.Access.Match(dbName, branch, user, host)
+	controller.Access.RWMutex.RUnlock()
 	// If either the flags match or the user is an admin for this branch, then we allow access
-	if (perms&flags == flags) || (perms&branch_control.Permissions_Admin == branch_control.Permissions_Admin) {
+	if branch_control.ObserveDecision(ctx, branch_control.Decision{
+		Action:      branch_control.DecisionAccess,
+		Database:    dbName,
+		Branch:      branch,
+		User:        user,
+		Host:        host,
+		Permissions: flags,
+		Allowed:     (perms&flags == flags) || (perms&branch_control.Permissions_Admin == branch_control.Permissions_Admin),
+	}) {
 		return nil
 	}
 	return branch_control.ErrIncorrectPermissions.New(user, host, branch)
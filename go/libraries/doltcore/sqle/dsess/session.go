@@ -359,6 +359,21 @@ func (d *DoltSession) ClearNotices() {
 	d.notices = nil
 }
 
+// readReplicaNeedsPull returns whether |rrd| has to pull from its remote before a transaction can begin. It does
+// unless it pulled within @@dolt_read_replica_max_staleness_millis, which the background puller keeps it doing, and
+// the session has not asked for strong consistency.
+func readReplicaNeedsPull(ctx *sql.Context, rrd RemoteReadReplicaDatabase) (bool, error) {
+	maxStaleness := ReadReplicaMaxStaleness()
+	if maxStaleness == 0 {
+		return true, nil
+	}
+	strong, err := ReadReplicaStrongConsistency(ctx)
+	if err != nil {
+		return false, err
+	}
+	return strong || !rrd.PulledWithin(maxStaleness), nil
+}
+
 // StartTransaction refreshes the state of this session and starts a new transaction.
 func (d *DoltSession) StartTransaction(ctx *sql.Context, tCharacteristic sql.TransactionCharacteristic) (sql.Transaction, error) {
 	// TODO: this is only necessary to support filter-branch, which needs to set a root directly and not have the
@@ -380,7 +395,13 @@ func (d *DoltSession) StartTransaction(ctx *sql.Context, tCharacteristic sql.Tra
 		if ddb != nil {
 			rrd, ok := db.(RemoteReadReplicaDatabase)
 			if ok && rrd.ValidReplicaState(ctx) {
-				err := rrd.PullFromRemote(ctx)
+				pull, err := readReplicaNeedsPull(ctx, rrd)
+				if err != nil {
+					return nil, err
+				}
+				if pull {
+					err = rrd.PullFromRemote(ctx)
+				}
 				if err != nil && !IgnoreReplicationErrors() {
 					return nil, fmt.Errorf("replication error: %w", err)
 				} else if err != nil {
@@ -16,6 +16,7 @@ package dsess
 
 import (
 	"context"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -68,6 +69,8 @@ type RemoteReadReplicaDatabase interface {
 	ValidReplicaState(ctx *sql.Context) bool
 	// PullFromRemote performs a pull from the remote and returns any error encountered
 	PullFromRemote(ctx *sql.Context) error
+	// PulledWithin returns whether this read replica finished a pull from the remote within the last |d|
+	PulledWithin(d time.Duration) bool
 }
 
 type DoltDatabaseProvider interface {
@@ -270,8 +270,11 @@ func doltCommit(ctx *sql.Context,
 
 	var rsc doltdb.ReplicationStatusController
 	newCommit, err := doltDb.CommitWithWorkingSet(ctx, headRef, workingSet.Ref(), &pending, workingSet, currHash, tx.WorkingSetMeta(ctx), &rsc)
-	WaitForReplicationController(ctx, rsc)
-	return workingSet, newCommit, err
+	if err != nil {
+		return workingSet, newCommit, err
+	}
+	waitForCommitReplication(ctx, rsc)
+	return workingSet, newCommit, nil
 }
 
 // txCommit is a transactionWrite function that updates the working set
@@ -286,8 +289,11 @@ func txCommit(ctx *sql.Context,
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
 	var rsc doltdb.ReplicationStatusController
 	err := doltDb.UpdateWorkingSet(ctx, workingSet.Ref(), workingSet, hash, tx.WorkingSetMeta(ctx), &rsc)
-	WaitForReplicationController(ctx, rsc)
-	return workingSet, nil, err
+	if err != nil {
+		return workingSet, nil, err
+	}
+	waitForCommitReplication(ctx, rsc)
+	return workingSet, nil, nil
 }
 
 // DoltCommit commits the working set and creates a new DoltCommit as specified, in one atomic write
@@ -300,6 +306,31 @@ func (tx *DoltTransaction) DoltCommit(
 	return tx.doCommit(ctx, workingSet, commit, doltCommit, dbName)
 }
 
+// ErrSemiSyncAckTimeout is returned by waitForSemiSyncAcks when a write is not persisted on enough standbys before
+// the semi_sync timeout. The write has already been applied, so it is reported to the client as a warning.
+var ErrSemiSyncAckTimeout = errors.New("semi_sync replication: the write was not acknowledged by enough standbys. it was applied on this server and will continue to replicate in the background")
+
+// waitForCommitReplication waits on the replication of a transaction commit. The commit has already been applied
+// when this is called, so a commit which fails to replicate in time only results in a warning. In semi_sync cluster
+// replication, the wait is governed by dolt_cluster_semi_sync_min_acks and dolt_cluster_semi_sync_timeout_millis,
+// and dolt_cluster_ack_writes_timeout_secs is ignored.
+func waitForCommitReplication(ctx *sql.Context, rsc doltdb.ReplicationStatusController) {
+	if len(rsc.Wait) == 0 {
+		return
+	}
+	if minAcks, timeout, ok := semiSyncReplication(); ok {
+		if err := waitForSemiSyncAcks(ctx, rsc, minAcks, timeout); err != nil {
+			ctx.Session.Warn(&sql.Warning{
+				Level:   "Warning",
+				Code:    mysql.ERQueryTimeout,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+	WaitForReplicationController(ctx, rsc)
+}
+
 func WaitForReplicationController(ctx *sql.Context, rsc doltdb.ReplicationStatusController) {
 	if len(rsc.Wait) == 0 {
 		return
@@ -367,6 +398,71 @@ func WaitForReplicationController(ctx *sql.Context, rsc doltdb.ReplicationStatus
 	}
 }
 
+// semiSyncReplication returns the number of acknowledgements a write must wait for and how long to wait for them
+// when this server is running cluster replication in semi_sync mode.
+func semiSyncReplication() (int, time.Duration, bool) {
+	_, mode, ok := sql.SystemVariables.GetGlobal(DoltClusterReplicationMode)
+	if !ok || mode != DoltClusterReplicationModeSemiSync {
+		return 0, 0, false
+	}
+	_, minAcks, ok := sql.SystemVariables.GetGlobal(DoltClusterSemiSyncMinAcks)
+	if !ok {
+		return 0, 0, false
+	}
+	_, timeout, ok := sql.SystemVariables.GetGlobal(DoltClusterSemiSyncTimeoutMillis)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(minAcks.(int64)), time.Duration(timeout.(int64)) * time.Millisecond, true
+}
+
+// waitForSemiSyncAcks returns once |minAcks| of the waits in |rsc| succeed, and returns ErrSemiSyncAckTimeout if
+// that does not happen within |timeout|, including when there are fewer than |minAcks| waits. The waits which are
+// still outstanding when we return are canceled, but replication to those standbys carries on.
+func waitForSemiSyncAcks(ctx *sql.Context, rsc doltdb.ReplicationStatusController, minAcks int, timeout time.Duration) error {
+	cCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(context.Canceled)
+
+	type result struct {
+		i   int
+		err error
+	}
+	results := make(chan result, len(rsc.Wait))
+	for i, f := range rsc.Wait {
+		go func() {
+			results <- result{i, f(cCtx)}
+		}()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	acked := make([]bool, len(rsc.Wait))
+	acks, received := 0, 0
+	for acks < minAcks && received < len(rsc.Wait) {
+		select {
+		case r := <-results:
+			received++
+			if r.err == nil {
+				acked[r.i] = true
+				acks++
+			}
+		case <-timer.C:
+			received = len(rsc.Wait)
+		}
+	}
+	if acks >= minAcks {
+		return nil
+	}
+
+	cancel(doltdb.ErrReplicationWaitFailed)
+	for i := range rsc.NotifyWaitFailed {
+		if !acked[i] {
+			rsc.NotifyWaitFailed[i]()
+		}
+	}
+	return fmt.Errorf("%w: received %d of the %d required acknowledgements within %v", ErrSemiSyncAckTimeout, acks, minAcks, timeout)
+}
+
 // doCommit commits this transaction with the write function provided. It takes the same params as DoltCommit
 func (tx *DoltTransaction) doCommit(
 	ctx *sql.Context,
@@ -0,0 +1,81 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func TestWaitForSemiSyncAcks(t *testing.T) {
+	acked := func(context.Context) error {
+		return nil
+	}
+	blocked := func(ctx context.Context) error {
+		<-ctx.Done()
+		return context.Cause(ctx)
+	}
+	failed := func(context.Context) error {
+		return errors.New("circuit breaker is open")
+	}
+	newRsc := func(waits ...func(context.Context) error) (doltdb.ReplicationStatusController, *atomic.Int32) {
+		var notified atomic.Int32
+		rsc := doltdb.ReplicationStatusController{Wait: waits}
+		for range waits {
+			rsc.NotifyWaitFailed = append(rsc.NotifyWaitFailed, func() {
+				notified.Add(1)
+			})
+		}
+		return rsc, &notified
+	}
+	ctx := sql.NewEmptyContext()
+
+	t.Run("MinAcksReached", func(t *testing.T) {
+		rsc, notified := newRsc(acked, blocked)
+		start := time.Now()
+		err := waitForSemiSyncAcks(ctx, rsc, 1, time.Minute)
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), time.Minute)
+		assert.Equal(t, int32(0), notified.Load())
+	})
+	t.Run("TimesOut", func(t *testing.T) {
+		rsc, notified := newRsc(acked, blocked)
+		err := waitForSemiSyncAcks(ctx, rsc, 2, 50*time.Millisecond)
+		assert.ErrorIs(t, err, ErrSemiSyncAckTimeout)
+		assert.Equal(t, int32(1), notified.Load())
+	})
+	t.Run("FailsWithoutWaitingWhenAcksAreImpossible", func(t *testing.T) {
+		rsc, notified := newRsc(acked, failed)
+		start := time.Now()
+		err := waitForSemiSyncAcks(ctx, rsc, 2, time.Minute)
+		assert.ErrorIs(t, err, ErrSemiSyncAckTimeout)
+		assert.Less(t, time.Since(start), time.Minute)
+		assert.Equal(t, int32(1), notified.Load())
+	})
+	t.Run("MinAcksMoreThanWaits", func(t *testing.T) {
+		rsc, notified := newRsc(acked)
+		err := waitForSemiSyncAcks(ctx, rsc, 2, time.Minute)
+		assert.ErrorIs(t, err, ErrSemiSyncAckTimeout)
+		assert.Equal(t, int32(0), notified.Load())
+	})
+}
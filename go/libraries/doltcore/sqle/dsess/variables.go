@@ -17,6 +17,7 @@ package dsess
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
@@ -49,7 +50,14 @@ const (
 	SkipReplicationErrors                = "dolt_skip_replication_errors"
 	ReplicateHeads                       = "dolt_replicate_heads"
 	ReplicateAllHeads                    = "dolt_replicate_all_heads"
+	ReadReplicaMaxStalenessMillis        = "dolt_read_replica_max_staleness_millis"
+	ReadReplicaConsistency               = "dolt_read_replica_consistency"
 	AsyncReplication                     = "dolt_async_replication"
+	BinlogReplicaCommitMode              = "dolt_binlog_replica_commit_mode"
+	BinlogReplicaCommitIntervalSecs      = "dolt_binlog_replica_commit_interval_secs"
+	BinlogReplicaCommitIntervalTxns      = "dolt_binlog_replica_commit_interval_transactions"
+	BinlogReplicaHeartbeatTagPrefix      = "dolt_binlog_replica_heartbeat_tag_prefix"
+	BinlogReplicaIgnoreGtids             = "dolt_binlog_replica_ignore_gtids"
 	AwsCredsFile                         = "aws_credentials_file"
 	AwsCredsProfile                      = "aws_credentials_profile"
 	AwsCredsRegion                       = "aws_credentials_region"
@@ -58,9 +66,14 @@ const (
 	ShowSystemTables                     = "dolt_show_system_tables"
 	AllowCICreation                      = "dolt_allow_ci_creation"
 
-	DoltClusterRoleVariable         = "dolt_cluster_role"
-	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
-	DoltClusterAckWritesTimeoutSecs = "dolt_cluster_ack_writes_timeout_secs"
+	DoltClusterRoleVariable          = "dolt_cluster_role"
+	DoltClusterRoleEpochVariable     = "dolt_cluster_role_epoch"
+	DoltClusterAckWritesTimeoutSecs  = "dolt_cluster_ack_writes_timeout_secs"
+	DoltClusterReplicationMode       = "dolt_cluster_replication_mode"
+	DoltClusterSemiSyncMinAcks       = "dolt_cluster_semi_sync_min_acks"
+	DoltClusterSemiSyncTimeoutMillis = "dolt_cluster_semi_sync_timeout_millis"
+
+	DoltClusterReplicationModeSemiSync = "semi_sync"
 
 	DoltStatsEnabled     = "dolt_stats_enabled"
 	DoltStatsPaused      = "dolt_stats_paused"
@@ -71,6 +84,26 @@ const (
 	DoltStatsGCEnabled   = "dolt_stats_gc_enabled"
 
 	DoltAutoGCEnabled = "dolt_auto_gc_enabled"
+
+	DoltOnlineIndexBuilds = "dolt_online_index_builds"
+)
+
+// Values of the dolt_read_replica_consistency system variable
+const (
+	ReadReplicaConsistencyEventual = "eventual"
+	ReadReplicaConsistencyStrong   = "strong"
+)
+
+// Values of the dolt_binlog_replica_commit_mode system variable
+const (
+	// BinlogReplicaCommitModeTransaction creates a Dolt commit for every source transaction a binlog replica applies.
+	BinlogReplicaCommitModeTransaction = "transaction"
+	// BinlogReplicaCommitModeBatch creates a Dolt commit for the source transactions applied every
+	// dolt_binlog_replica_commit_interval_secs seconds or dolt_binlog_replica_commit_interval_transactions
+	// transactions, and whenever the source is idle.
+	BinlogReplicaCommitModeBatch = "batch"
+	// BinlogReplicaCommitModeOff leaves the changes a binlog replica applies in the working set.
+	BinlogReplicaCommitModeOff = "off"
 )
 
 const URLTemplateDatabasePlaceholder = "{database}"
@@ -203,6 +236,35 @@ func IgnoreReplicationErrors() bool {
 	return skip == SysVarTrue
 }
 
+// ReadReplicaMaxStaleness returns the value of the dolt_read_replica_max_staleness_millis system variable, which is how
+// old the local state of a read replica can be when a transaction begins without it pulling from its remote. Zero
+// means that every transaction pulls.
+func ReadReplicaMaxStaleness() time.Duration {
+	_, millis, ok := sql.SystemVariables.GetGlobal(ReadReplicaMaxStalenessMillis)
+	if !ok {
+		panic("dolt system variables not loaded")
+	}
+	ms, ok := millis.(int64)
+	if !ok {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// ReadReplicaStrongConsistency returns true if the session's dolt_read_replica_consistency is strong, in which case
+// every transaction pulls from the remote regardless of dolt_read_replica_max_staleness_millis.
+func ReadReplicaStrongConsistency(ctx *sql.Context) (bool, error) {
+	val, err := ctx.GetSessionVariable(ctx, ReadReplicaConsistency)
+	if err != nil {
+		return false, err
+	}
+	consistency, ok := val.(string)
+	if !ok {
+		return false, fmt.Errorf("unexpected type for variable %s: %T", ReadReplicaConsistency, val)
+	}
+	return strings.EqualFold(consistency, ReadReplicaConsistencyStrong), nil
+}
+
 // WarnReplicationError logs a warning for the replication error given
 func WarnReplicationError(ctx *sql.Context, err error) {
 	ctx.GetLogger().Warn(fmt.Errorf("replication failure: %w", err))
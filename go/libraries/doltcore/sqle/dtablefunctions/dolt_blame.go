@@ -0,0 +1,618 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const blameTableDefaultRowCount = 1000
+
+var errUnblameableTable = errors.New("unable to generate blame for table without primary key")
+
+var _ sql.TableFunction = (*BlameTableFunction)(nil)
+var _ sql.ExecSourceRel = (*BlameTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*BlameTableFunction)(nil)
+
+// BlameTableFunction implements the DOLT_BLAME table function, which shows the commit which last modified each
+// column of each row of a table. Unlike the dolt_blame_$tablename system views, which blame whole rows, a row whose
+// price column changed yesterday and whose name column changed a year ago is blamed on both commits.
+//
+// Blame is computed by walking the first parents of the starting revision. For each pair of adjacent commits, the
+// rows of the table are diffed, and each column not yet blamed whose value differs is blamed on the newer commit.
+// Rows and columns which don't exist in the older commit, or whose primary key changed, are blamed on the newer commit.
+type BlameTableFunction struct {
+	ctx           *sql.Context
+	database      sql.Database
+	argumentExprs []sql.Expression
+
+	tableName string
+	revision  string
+	columns   []string
+	since     *time.Time
+	until     *time.Time
+
+	sqlSch sql.Schema
+}
+
+// blameColumn is a column of the table being blamed.
+type blameColumn struct {
+	name string
+	tag  uint64
+	isPk bool
+}
+
+// blameRow is a row of the table being blamed, and the commits its columns were blamed on so far.
+type blameRow struct {
+	key       val.Tuple
+	commits   []*blameCommit
+	remaining int
+}
+
+// blameTarget is the table being blamed, at the commit blame starts from.
+type blameTarget struct {
+	start     *doltdb.Commit
+	tableName doltdb.TableName
+	tbl       *doltdb.Table
+	sch       schema.Schema
+	cols      []blameColumn
+}
+
+type blameCommit struct {
+	hash string
+	meta *datas.CommitMeta
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (btf *BlameTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &BlameTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (btf *BlameTableFunction) Name() string {
+	return "dolt_blame"
+}
+
+// Database implements the sql.Databaser interface
+func (btf *BlameTableFunction) Database() sql.Database {
+	return btf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (btf *BlameTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nbtf := *btf
+	nbtf.database = database
+	return &nbtf, nil
+}
+
+// DataLength estimates total data size for query planning.
+func (btf *BlameTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(btf.Schema())
+	numRows, _, err := btf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+// RowCount returns estimated row count for query planning.
+func (btf *BlameTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return blameTableDefaultRowCount, false, nil
+}
+
+// Resolved implements the sql.Resolvable interface
+func (btf *BlameTableFunction) Resolved() bool {
+	for _, expr := range btf.argumentExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsReadOnly implements the sql.Node interface
+func (btf *BlameTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (btf *BlameTableFunction) String() string {
+	args := make([]string, len(btf.argumentExprs))
+	for i, expr := range btf.argumentExprs {
+		args[i] = expr.String()
+	}
+	return fmt.Sprintf("DOLT_BLAME(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface
+func (btf *BlameTableFunction) Schema() sql.Schema {
+	if !btf.Resolved() {
+		return nil
+	}
+
+	if btf.sqlSch == nil {
+		panic("schema hasn't been generated yet")
+	}
+
+	return btf.sqlSch
+}
+
+// Children implements the sql.Node interface
+func (btf *BlameTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (btf *BlameTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return btf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (btf *BlameTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: btf.database.Name(), Table: btf.tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface
+func (btf *BlameTableFunction) Expressions() []sql.Expression {
+	return btf.argumentExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (btf *BlameTableFunction) WithExpressions(expressions ...sql.Expression) (sql.Node, error) {
+	newBtf := *btf
+	newBtf.argumentExprs = expressions
+
+	// The schema depends on the primary key of the table, so only literal arguments are supported, as they are
+	// for DOLT_DIFF.
+	var exprStrs []string
+	for _, expr := range expressions {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(btf.Name(), expr.String())
+		}
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(btf.Name(), expr.String())
+		}
+		lit, ok := expr.(*expression.Literal)
+		if !ok || !gmstypes.IsText(lit.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(btf.Name(), expr.String())
+		}
+		exprStrs = append(exprStrs, fmt.Sprintf("%v", lit.Value()))
+	}
+
+	apr, err := cli.CreateBlameArgParser(true).Parse(exprStrs)
+	if err != nil {
+		return nil, sql.ErrInvalidArgumentDetails.New(btf.Name(), err.Error())
+	}
+
+	switch apr.NArg() {
+	case 1:
+		newBtf.revision = "HEAD"
+		newBtf.tableName = apr.Arg(0)
+	case 2:
+		newBtf.revision = apr.Arg(0)
+		newBtf.tableName = apr.Arg(1)
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New(btf.Name(), "1 to 2", apr.NArg())
+	}
+
+	if cols, ok := apr.GetValue(cli.ColumnsParam); ok {
+		newBtf.columns = strings.Split(cols, ",")
+	}
+	if newBtf.since, err = parseDateParam(btf.ctx, apr, cli.SinceParam); err != nil {
+		return nil, err
+	}
+	if newBtf.until, err = parseDateParam(btf.ctx, apr, cli.UntilParam); err != nil {
+		return nil, err
+	}
+
+	target, err := newBtf.resolveTarget(btf.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newBtf.sqlSch = nil
+	for _, col := range target.sch.GetPKCols().GetColumns() {
+		newBtf.sqlSch = append(newBtf.sqlSch, &sql.Column{Name: col.Name, Type: col.TypeInfo.ToSqlType(), PrimaryKey: true})
+	}
+	newBtf.sqlSch = append(newBtf.sqlSch,
+		&sql.Column{Name: "column_name", Type: gmstypes.Text},
+		&sql.Column{Name: "commit", Type: gmstypes.Text},
+		&sql.Column{Name: "commit_date", Type: gmstypes.Datetime},
+		&sql.Column{Name: "committer", Type: gmstypes.Text},
+		&sql.Column{Name: "email", Type: gmstypes.Text},
+		&sql.Column{Name: "message", Type: gmstypes.Text},
+	)
+
+	return &newBtf, nil
+}
+
+// parseDateParam returns the date given for |param|, or nil if it wasn't given.
+func parseDateParam(ctx *sql.Context, apr *argparser.ArgParseResults, param string) (*time.Time, error) {
+	str, ok := apr.GetValue(param)
+	if !ok {
+		return nil, nil
+	}
+	t, err := gmstypes.DatetimeMaxPrecision.ConvertWithoutRangeCheck(ctx, str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date for --%s: %s", param, str)
+	}
+	return &t, nil
+}
+
+// resolveTarget returns the commit blame starts from, the table at that commit and the columns to blame.
+func (btf *BlameTableFunction) resolveTarget(ctx *sql.Context) (*blameTarget, error) {
+	sqledb, ok := btf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", btf.database)
+	}
+	ddb := sqledb.DbData().Ddb
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, sqledb.RevisionQualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	start, err := resolveCommit(ctx, ddb, headRef, btf.revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// changes made after --until are ignored by starting from the newest commit made before it
+	for btf.until != nil {
+		meta, err := start.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !meta.Time().After(*btf.until) {
+			break
+		}
+		if start.NumParents() == 0 {
+			return nil, fmt.Errorf("no commits were made on or before %s", btf.until.Format(time.DateTime))
+		}
+		optCmt, err := start.GetParent(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		if start, ok = optCmt.ToCommit(); !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+	}
+
+	root, err := start.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tableName, tbl, ok, err := resolve.Table(ctx, root, btf.tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(btf.tableName)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, errUnblameableTable
+	}
+
+	var cols []blameColumn
+	if len(btf.columns) == 0 {
+		for _, col := range sch.GetNonPKCols().GetColumns() {
+			if !col.Virtual {
+				cols = append(cols, blameColumn{name: col.Name, tag: col.Tag})
+			}
+		}
+	} else {
+		for _, name := range btf.columns {
+			col, ok := sch.GetAllCols().GetByNameCaseInsensitive(strings.TrimSpace(name))
+			if !ok {
+				return nil, sql.ErrTableColumnNotFound.New(tableName.Name, name)
+			}
+			if col.Virtual {
+				return nil, fmt.Errorf("cannot blame virtual column %s", col.Name)
+			}
+			cols = append(cols, blameColumn{name: col.Name, tag: col.Tag, isPk: col.IsPartOfPK})
+		}
+	}
+
+	return &blameTarget{start: start, tableName: tableName, tbl: tbl, sch: sch, cols: cols}, nil
+}
+
+// RowIter implements the sql.Node interface
+func (btf *BlameTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	target, err := btf.resolveTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := btf.blame(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := target.tbl.NodeStore()
+	keyDesc := target.sch.GetKeyDescriptor(ns)
+	var sqlRows []sql.Row
+	for _, r := range rows {
+		var pks sql.Row
+		for i := 0; i < keyDesc.Count(); i++ {
+			v, err := tree.GetField(ctx, keyDesc, i, r.key, ns)
+			if err != nil {
+				return nil, err
+			}
+			pks = append(pks, v)
+		}
+		for i, cm := range r.commits {
+			// columns last modified before --since aren't blamed
+			if cm == nil {
+				continue
+			}
+			row := append(pks.Copy(), target.cols[i].name, cm.hash, cm.meta.Time(), cm.meta.Name, cm.meta.Email, cm.meta.Description)
+			sqlRows = append(sqlRows, row)
+		}
+	}
+
+	return sql.RowsToRowIter(sqlRows...), nil
+}
+
+// blame returns every row of the table being blamed, with the commits which last modified each of its columns.
+func (btf *BlameTableFunction) blame(ctx *sql.Context, target *blameTarget) ([]*blameRow, error) {
+	cols := target.cols
+	childMap, err := prollyMapForTable(ctx, target.tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*blameRow
+	pending := make(map[string]*blameRow)
+	iter, err := childMap.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		k, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		r := &blameRow{key: k, commits: make([]*blameCommit, len(cols)), remaining: len(cols)}
+		rows = append(rows, r)
+		pending[string(k)] = r
+	}
+
+	blameAll := func(cm *blameCommit, r *blameRow) {
+		for i := range r.commits {
+			if r.commits[i] == nil {
+				r.commits[i] = cm
+			}
+		}
+		r.remaining = 0
+		delete(pending, string(r.key))
+	}
+	blameCol := func(cm *blameCommit, r *blameRow, i int) {
+		if r.commits[i] == nil {
+			r.commits[i] = cm
+			r.remaining--
+			if r.remaining == 0 {
+				delete(pending, string(r.key))
+			}
+		}
+	}
+
+	child, childTbl, childSch := target.start, target.tbl, target.sch
+	for len(pending) > 0 {
+		childCm, err := newBlameCommit(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+		if btf.since != nil && childCm.meta.Time().Before(*btf.since) {
+			break
+		}
+
+		parentTbl, parentSch, parent, err := blameParent(ctx, child, target.tableName)
+		if err != nil {
+			return nil, err
+		}
+		if parentTbl == nil || !blameKeysMatch(childSch, parentSch, childTbl.NodeStore()) {
+			// the rows all appeared in |child|
+			for _, r := range pending {
+				blameAll(childCm, r)
+			}
+			break
+		}
+
+		childHash, err := childTbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		parentHash, err := parentTbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if childHash == parentHash {
+			child, childTbl, childSch = parent, parentTbl, parentSch
+			continue
+		}
+
+		// map the columns being blamed to their positions in the value tuples of each schema
+		childIdx := make([]int, len(cols))
+		parentIdx := make([]int, len(cols))
+		for i, col := range cols {
+			childIdx[i], parentIdx[i] = -1, -1
+			if col.isPk {
+				continue
+			}
+			if idx, ok := childSch.GetNonPKCols().StoredIndexByTag(col.tag); ok {
+				childIdx[i] = idx
+			}
+			if idx, ok := parentSch.GetNonPKCols().StoredIndexByTag(col.tag); ok {
+				parentIdx[i] = idx
+			}
+			if parentIdx[i] < 0 {
+				// the column was added in |child|
+				for _, r := range pending {
+					blameCol(childCm, r, i)
+				}
+			}
+		}
+
+		childMap, err := prollyMapForTable(ctx, childTbl)
+		if err != nil {
+			return nil, err
+		}
+		parentMap, err := prollyMapForTable(ctx, parentTbl)
+		if err != nil {
+			return nil, err
+		}
+		childValDesc := childSch.GetValueDescriptor(childTbl.NodeStore())
+		parentValDesc := parentSch.GetValueDescriptor(parentTbl.NodeStore())
+
+		err = prolly.DiffMaps(ctx, parentMap, childMap, false, func(_ context.Context, d tree.Diff) error {
+			r, ok := pending[string(d.Key)]
+			if !ok {
+				return nil
+			}
+			switch d.Type {
+			case tree.AddedDiff:
+				blameAll(childCm, r)
+			case tree.ModifiedDiff:
+				for i := range cols {
+					if r.commits[i] != nil || childIdx[i] < 0 || parentIdx[i] < 0 {
+						continue
+					}
+					childVal := childValDesc.GetField(childIdx[i], val.Tuple(d.To))
+					parentVal := parentValDesc.GetField(parentIdx[i], val.Tuple(d.From))
+					if !bytes.Equal(childVal, parentVal) {
+						blameCol(childCm, r, i)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		child, childTbl, childSch = parent, parentTbl, parentSch
+	}
+
+	return rows, nil
+}
+
+// blameParent returns the first parent of |child|, and the table named |tableName| in it with its schema. The table
+// is nil if |child| has no parents, its parent isn't available in a shallow clone, or the table doesn't exist in it.
+func blameParent(ctx *sql.Context, child *doltdb.Commit, tableName doltdb.TableName) (*doltdb.Table, schema.Schema, *doltdb.Commit, error) {
+	if child.NumParents() == 0 {
+		return nil, nil, nil, nil
+	}
+	optCmt, err := child.GetParent(ctx, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, nil, nil, nil
+	}
+	root, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tbl, ok, err := root.GetTable(ctx, tableName)
+	if err != nil || !ok {
+		return nil, nil, nil, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil, nil, nil
+	}
+	return tbl, sch, parent, nil
+}
+
+// blameKeysMatch returns whether the rows of |child| and |parent| have the same primary keys, so that their rows can
+// be compared.
+func blameKeysMatch(child, parent schema.Schema, ns tree.NodeStore) bool {
+	childTags, parentTags := child.GetPKCols().Tags, parent.GetPKCols().Tags
+	if len(childTags) != len(parentTags) {
+		return false
+	}
+	for i := range childTags {
+		if childTags[i] != parentTags[i] {
+			return false
+		}
+	}
+	return child.GetKeyDescriptor(ns).Equals(parent.GetKeyDescriptor(ns))
+}
+
+func newBlameCommit(ctx context.Context, cm *doltdb.Commit) (*blameCommit, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &blameCommit{hash: h.String(), meta: meta}, nil
+}
+
+func prollyMapForTable(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
@@ -47,6 +47,7 @@ type LogTableFunction struct {
 	revisionStrs    []string
 	notRevisionStrs []string
 	tableNames      []string
+	filter          *logFilter
 	argumentExprs   []sql.Expression
 	minParents      int
 	showParents     bool
@@ -163,6 +164,10 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, "--tables", strings.Join(ltf.tableNames, ","))
 	}
 
+	if ltf.filter != nil {
+		options = append(options, ltf.filter.options...)
+	}
+
 	return strings.Join(options, ", ")
 }
 
@@ -272,6 +277,11 @@ func (ltf *LogTableFunction) addOptions(expressions []sql.Expression) error {
 		ltf.tableNames = append(ltf.tableNames, tableNames...)
 	}
 
+	ltf.filter, err = newLogFilter(ltf.ctx, apr)
+	if err != nil {
+		return ltf.invalidArgDetailsErr(err.Error())
+	}
+
 	minParents := apr.GetIntOrDefault(cli.MinParentsFlag, 0)
 	if apr.Contains(cli.MergesFlag) {
 		minParents = 2
@@ -421,6 +431,9 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 			return nil, err
 		}
 	}
+	if ltf.filter == nil {
+		ltf.filter = &logFilter{}
+	}
 
 	revisionValStrs, notRevisionValStrs, threeDot := ltf.evaluateArguments()
 	notRevisionValStrs = append(notRevisionValStrs, ltf.notRevisionStrs...)
@@ -526,14 +539,14 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 
 		notCommits = append(notCommits, mergeCommit)
 
-		return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.tableNames)
+		return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.filter.tables)
 	}
 
 	if len(revisionValStrs) <= 1 && len(notRevisionValStrs) == 0 {
-		return ltf.NewLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits[0], matchFunc, cHashToRefs, ltf.tableNames)
+		return ltf.NewLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits[0], matchFunc, cHashToRefs, ltf.filter.tables)
 	}
 
-	return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.tableNames)
+	return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.filter.tables)
 }
 
 // evaluateArguments handles range syntax for revisions and returns processed strings.
@@ -613,6 +626,7 @@ var _ sql.RowIter = (*logTableFunctionRowIter)(nil)
 // logTableFunctionRowIter is a sql.RowIter implementation which iterates over each commit as if it's a row in the table.
 type logTableFunctionRowIter struct {
 	child         doltdb.CommitItr[*sql.Context]
+	filter        *logFilter
 	cHashToRefs   map[hash.Hash][]string
 	decoration    string
 	tableNames    []string
@@ -637,6 +651,7 @@ func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *d
 		child:         child,
 		showParents:   ltf.showParents,
 		showSignature: ltf.showSignature,
+		filter:        ltf.filter,
 		decoration:    ltf.decoration,
 		cHashToRefs:   cHashToRefs,
 		headHash:      h,
@@ -679,6 +694,7 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 		child:         child,
 		showParents:   ltf.showParents,
 		showSignature: ltf.showSignature,
+		filter:        ltf.filter,
 		decoration:    ltf.decoration,
 		cHashToRefs:   cHashToRefs,
 		headHash:      headHash,
@@ -706,73 +722,41 @@ func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
 			return nil, doltdb.ErrGhostCommitEncountered
 		}
 
-		if itr.tableNames != nil {
-			if commit.NumParents() == 0 {
-				// if we're at the root commit, we continue without checking if any tables changed
-				// we expect EOF to be returned on the next call to Next(), but continue in case there are more commits
-				continue
-			}
-			optCmt, err := commit.GetParent(ctx, 0)
+		if meta == nil {
+			meta, err = commit.GetCommitMeta(ctx)
 			if err != nil {
 				return nil, err
 			}
-			parent0Cm, ok := optCmt.ToCommit()
-			if !ok {
-				return nil, doltdb.ErrGhostCommitEncountered
-			}
-
-			var parent1Cm *doltdb.Commit
-			if commit.NumParents() > 1 {
-				optCmt, err = commit.GetParent(ctx, 1)
-				if err != nil {
-					return nil, err
-				}
-				parent1Cm, ok = optCmt.ToCommit()
-				if !ok {
-					return nil, doltdb.ErrGhostCommitEncountered
-				}
-			}
+		}
+		if !itr.filter.matchesMeta(meta) {
+			continue
+		}
 
-			parent0RV, err := parent0Cm.GetRootValue(ctx)
-			if err != nil {
-				return nil, err
-			}
-			var parent1RV doltdb.RootValue
-			if parent1Cm != nil {
-				parent1RV, err = parent1Cm.GetRootValue(ctx)
-				if err != nil {
-					return nil, err
-				}
-			}
-			childRV, err := commit.GetRootValue(ctx)
-			if err != nil {
-				return nil, err
+		didChange := false
+		if itr.filter.hasPathspecs() {
+			if commit.NumParents() == 0 {
+				// if we're at the root commit, we continue without checking if any tables changed
+				// we expect EOF to be returned on the next call to Next(), but continue in case there are more commits
+				continue
 			}
-
-			didChange := false
-			for _, tableName := range itr.tableNames {
-				didChange, err = didTableChangeBetweenRootValues(ctx, childRV, parent0RV, parent1RV, tableName)
+			if itr.tableNames != nil {
+				didChange, err = didTablesChange(ctx, commit, itr.tableNames)
 				if err != nil {
 					return nil, err
 				}
-				if didChange {
-					break
-				}
 			}
-
-			if didChange {
-				break
+			if !didChange && len(itr.filter.columns) == 0 {
+				continue
 			}
-		} else {
-			break
 		}
-	}
 
-	if meta == nil {
-		meta, err = commit.GetCommitMeta(ctx)
+		ok, err = itr.filter.matchesRows(ctx, commit, didChange)
 		if err != nil {
 			return nil, err
 		}
+		if ok {
+			break
+		}
 	}
 
 	// TODO: will retrieve height again if it's 0
@@ -857,6 +841,57 @@ func shouldDecorateWithRefs(decoration string) bool {
 	return decoration == "full" || decoration == "short"
 }
 
+// didTablesChange checks if any of the given tables changed between |commit| and its parents.
+func didTablesChange(ctx *sql.Context, commit *doltdb.Commit, tableNames []string) (bool, error) {
+	optCmt, err := commit.GetParent(ctx, 0)
+	if err != nil {
+		return false, err
+	}
+	parent0Cm, ok := optCmt.ToCommit()
+	if !ok {
+		return false, doltdb.ErrGhostCommitEncountered
+	}
+
+	var parent1Cm *doltdb.Commit
+	if commit.NumParents() > 1 {
+		optCmt, err = commit.GetParent(ctx, 1)
+		if err != nil {
+			return false, err
+		}
+		parent1Cm, ok = optCmt.ToCommit()
+		if !ok {
+			return false, doltdb.ErrGhostCommitEncountered
+		}
+	}
+
+	parent0RV, err := parent0Cm.GetRootValue(ctx)
+	if err != nil {
+		return false, err
+	}
+	var parent1RV doltdb.RootValue
+	if parent1Cm != nil {
+		parent1RV, err = parent1Cm.GetRootValue(ctx)
+		if err != nil {
+			return false, err
+		}
+	}
+	childRV, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, tableName := range tableNames {
+		didChange, err := didTableChangeBetweenRootValues(ctx, childRV, parent0RV, parent1RV, tableName)
+		if err != nil {
+			return false, err
+		}
+		if didChange {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // didTableChangeBetweenRootValues checks if the given table changed between the two given root values.
 func didTableChangeBetweenRootValues(ctx *sql.Context, child, parent0, parent1 doltdb.RootValue, tableName string) (bool, error) {
 	// TODO: schema
@@ -0,0 +1,495 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// validDiffFilters are the row operations accepted by --diff-filter: added, modified and deleted.
+const validDiffFilters = "AMD"
+
+// logFilter selects the commits listed by dolt_log by their metadata, and by the rows they changed compared to their
+// first parent.
+type logFilter struct {
+	author     *regexp.Regexp
+	grep       *regexp.Regexp
+	since      *time.Time
+	until      *time.Time
+	pickaxe    *string
+	diffFilter string
+	// tables are the <table> pathspecs. A commit matches them if it changed any of the tables.
+	tables []string
+	// columns are the <table>.<column> pathspecs, by table. A commit matches them if it changed a value in any of the
+	// columns.
+	columns map[string][]string
+	// options are the filter options as they were given, for display.
+	options []string
+}
+
+// newLogFilter returns the filter for the options in |apr|.
+func newLogFilter(ctx *sql.Context, apr *argparser.ArgParseResults) (*logFilter, error) {
+	f := &logFilter{}
+	for _, param := range []string{cli.AuthorParam, cli.GrepParam, cli.SinceParam, cli.UntilParam, cli.PickaxeParam, cli.DiffFilterParam} {
+		if value, ok := apr.GetValue(param); ok {
+			dashes := "--"
+			if len(param) == 1 {
+				dashes = "-"
+			}
+			f.options = append(f.options, fmt.Sprintf("%s%s %s", dashes, param, value))
+		}
+	}
+
+	var err error
+	if f.author, err = parseLogRegexp(apr, cli.AuthorParam); err != nil {
+		return nil, err
+	}
+	if f.grep, err = parseLogRegexp(apr, cli.GrepParam); err != nil {
+		return nil, err
+	}
+	if f.since, err = parseDateParam(ctx, apr, cli.SinceParam); err != nil {
+		return nil, err
+	}
+	if f.until, err = parseDateParam(ctx, apr, cli.UntilParam); err != nil {
+		return nil, err
+	}
+	if pickaxe, ok := apr.GetValue(cli.PickaxeParam); ok {
+		f.pickaxe = &pickaxe
+	}
+	if diffFilter, ok := apr.GetValue(cli.DiffFilterParam); ok {
+		diffFilter = strings.ToUpper(diffFilter)
+		for _, c := range diffFilter {
+			if !strings.ContainsRune(validDiffFilters, c) {
+				return nil, fmt.Errorf("invalid --%s option: %s", cli.DiffFilterParam, diffFilter)
+			}
+		}
+		f.diffFilter = diffFilter
+	}
+
+	if tableNames, ok := apr.GetValueList(cli.TablesFlag); ok {
+		for _, pathspec := range tableNames {
+			table, column, ok := strings.Cut(pathspec, ".")
+			if !ok {
+				f.tables = append(f.tables, table)
+				continue
+			}
+			if f.columns == nil {
+				f.columns = make(map[string][]string)
+			}
+			f.columns[table] = append(f.columns[table], column)
+		}
+	}
+
+	return f, nil
+}
+
+func parseLogRegexp(apr *argparser.ArgParseResults, param string) (*regexp.Regexp, error) {
+	pattern, ok := apr.GetValue(param)
+	if !ok {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for --%s: %s", param, pattern)
+	}
+	return re, nil
+}
+
+// hasPathspecs returns whether commits are restricted to those which changed the given tables or columns.
+func (f *logFilter) hasPathspecs() bool {
+	return len(f.tables) > 0 || len(f.columns) > 0
+}
+
+// matchesMeta returns whether the commit with |meta| matches the --author, --grep, --since and --until options.
+func (f *logFilter) matchesMeta(meta *datas.CommitMeta) bool {
+	if f.author != nil && !f.author.MatchString(fmt.Sprintf("%s <%s>", meta.Name, meta.Email)) {
+		return false
+	}
+	if f.grep != nil && !f.grep.MatchString(meta.Description) {
+		return false
+	}
+	if f.since != nil && meta.Time().Before(*f.since) {
+		return false
+	}
+	if f.until != nil && meta.Time().After(*f.until) {
+		return false
+	}
+	return true
+}
+
+// matchesRows returns whether the changes made by |commit| to its first parent match the column pathspecs, the -S
+// and the --diff-filter options. Column pathspecs are already matched if |tablesChanged| is set, because one of the
+// table pathspecs was. Root commits never match.
+func (f *logFilter) matchesRows(ctx *sql.Context, commit *doltdb.Commit, tablesChanged bool) (bool, error) {
+	checkColumns := len(f.columns) > 0 && !tablesChanged
+	if !checkColumns && f.pickaxe == nil && f.diffFilter == "" {
+		return true, nil
+	}
+	if commit.NumParents() == 0 {
+		return false, nil
+	}
+	optCmt, err := commit.GetParent(ctx, 0)
+	if err != nil {
+		return false, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return false, doltdb.ErrGhostCommitEncountered
+	}
+	parentRoot, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return false, err
+	}
+	childRoot, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	changes, err := f.rowChanges(ctx, parentRoot, childRoot)
+	if err != nil {
+		return false, err
+	}
+
+	if checkColumns && !changes.columnChanged {
+		return false, nil
+	}
+	if f.pickaxe != nil && changes.pickaxeBefore == changes.pickaxeAfter {
+		return false, nil
+	}
+	for _, c := range f.diffFilter {
+		if changes.ops[c] {
+			return true, nil
+		}
+	}
+	return f.diffFilter == "", nil
+}
+
+// logRowChanges summarizes the row changes made by a commit within the tables and columns a logFilter is limited to.
+type logRowChanges struct {
+	// ops holds the row operations which were made, keyed by their --diff-filter letter.
+	ops map[rune]bool
+	// columnChanged is set if a value in a column pathspec changed.
+	columnChanged bool
+	// pickaxeBefore and pickaxeAfter count the values equal to the -S option before and after the commit.
+	pickaxeBefore int
+	pickaxeAfter  int
+}
+
+// scope returns the columns of |table| the filter is limited to, nil for all of them, or false if the table is out
+// of scope.
+func (f *logFilter) scope(table string) ([]string, bool) {
+	if !f.hasPathspecs() {
+		return nil, true
+	}
+	for _, t := range f.tables {
+		if strings.EqualFold(t, table) {
+			return nil, true
+		}
+	}
+	for t, columns := range f.columns {
+		if strings.EqualFold(t, table) {
+			return columns, true
+		}
+	}
+	return nil, false
+}
+
+func (f *logFilter) rowChanges(ctx *sql.Context, fromRoot, toRoot doltdb.RootValue) (*logRowChanges, error) {
+	changes := &logRowChanges{ops: make(map[rune]bool)}
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, delta := range deltas {
+		name := delta.ToName
+		if delta.ToTable == nil {
+			name = delta.FromName
+		}
+		if !f.hasPathspecs() && doltdb.IsSystemTable(name) {
+			continue
+		}
+		columns, ok := f.scope(name.Name)
+		if !ok {
+			continue
+		}
+		if delta.FromTable != nil && delta.ToTable != nil {
+			fromHash, err := delta.FromTable.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			toHash, err := delta.ToTable.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			if fromHash == toHash {
+				continue
+			}
+		}
+
+		var from, to *logTable
+		if delta.FromTable != nil {
+			if from, err = newLogTable(ctx, delta.FromTable, columns); err != nil {
+				return nil, err
+			}
+		}
+		if delta.ToTable != nil {
+			if to, err = newLogTable(ctx, delta.ToTable, columns); err != nil {
+				return nil, err
+			}
+		}
+
+		if from != nil && to != nil && from.keyDesc.Equals(to.keyDesc) && !schema.IsKeyless(from.sch) == !schema.IsKeyless(to.sch) {
+			if err = f.diffRows(ctx, from, to, changes); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// the tables can't be diffed row by row, so every row was removed and added
+		if from != nil {
+			if err = f.addAll(ctx, from, 'D', changes); err != nil {
+				return nil, err
+			}
+		}
+		if to != nil {
+			if err = f.addAll(ctx, to, 'A', changes); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// diffRows adds the changes between the rows of |from| and |to| to |changes|.
+func (f *logFilter) diffRows(ctx *sql.Context, from, to *logTable, changes *logRowChanges) error {
+	err := prolly.DiffMaps(ctx, from.rows, to.rows, false, func(_ context.Context, d tree.Diff) error {
+		switch d.Type {
+		case tree.AddedDiff:
+			return f.addRow(ctx, to, val.Tuple(d.Key), val.Tuple(d.To), 'A', changes)
+		case tree.RemovedDiff:
+			return f.addRow(ctx, from, val.Tuple(d.Key), val.Tuple(d.From), 'D', changes)
+		}
+
+		before, err := from.cells(ctx, val.Tuple(d.Key), val.Tuple(d.From))
+		if err != nil {
+			return err
+		}
+		after, err := to.cells(ctx, val.Tuple(d.Key), val.Tuple(d.To))
+		if err != nil {
+			return err
+		}
+		f.countPickaxe(before, rowCount(from, val.Tuple(d.From)), &changes.pickaxeBefore)
+		f.countPickaxe(after, rowCount(to, val.Tuple(d.To)), &changes.pickaxeAfter)
+
+		if schema.IsKeyless(to.sch) {
+			// keyless rows only change by being duplicated or removed
+			if val.ReadKeylessCardinality(val.Tuple(d.To)) > val.ReadKeylessCardinality(val.Tuple(d.From)) {
+				changes.ops['A'] = true
+			} else {
+				changes.ops['D'] = true
+			}
+			changes.columnChanged = true
+			return nil
+		}
+		if !cellsEqual(before, after) {
+			changes.ops['M'] = true
+			changes.columnChanged = true
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// addAll adds every row of |t| to |changes| as operation |op|.
+func (f *logFilter) addAll(ctx *sql.Context, t *logTable, op rune, changes *logRowChanges) error {
+	iter, err := t.rows.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = f.addRow(ctx, t, k, v, op, changes); err != nil {
+			return err
+		}
+	}
+}
+
+// addRow adds the row |k|, |v| of |t|, which was added or deleted, to |changes|.
+func (f *logFilter) addRow(ctx *sql.Context, t *logTable, k, v val.Tuple, op rune, changes *logRowChanges) error {
+	changes.ops[op] = true
+	changes.columnChanged = true
+	if f.pickaxe == nil {
+		return nil
+	}
+	cells, err := t.cells(ctx, k, v)
+	if err != nil {
+		return err
+	}
+	if op == 'A' {
+		f.countPickaxe(cells, rowCount(t, v), &changes.pickaxeAfter)
+	} else {
+		f.countPickaxe(cells, rowCount(t, v), &changes.pickaxeBefore)
+	}
+	return nil
+}
+
+// countPickaxe adds the number of values equal to the -S option in |cells|, which are the cells of |rows| identical
+// rows, to |count|.
+func (f *logFilter) countPickaxe(cells map[string]*string, rows int, count *int) {
+	if f.pickaxe == nil {
+		return
+	}
+	for _, cell := range cells {
+		if cell != nil && *cell == *f.pickaxe {
+			*count += rows
+		}
+	}
+}
+
+// rowCount returns the number of rows the stored row with value |v| of |t| stands for. Only a keyless table stores
+// identical rows once, along with their cardinality.
+func rowCount(t *logTable, v val.Tuple) int {
+	if !schema.IsKeyless(t.sch) {
+		return 1
+	}
+	return int(val.ReadKeylessCardinality(v))
+}
+
+func cellsEqual(a, b map[string]*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for col, x := range a {
+		y, ok := b[col]
+		if !ok || (x == nil) != (y == nil) || (x != nil && *x != *y) {
+			return false
+		}
+	}
+	return true
+}
+
+// logTable is a version of a table whose rows are compared by a logFilter.
+type logTable struct {
+	sch     schema.Schema
+	ns      tree.NodeStore
+	rows    prolly.Map
+	keyDesc *val.TupleDesc
+	valDesc *val.TupleDesc
+	// columns are the lower-cased names of the columns which are compared, or nil for all of them
+	columns map[string]bool
+}
+
+func newLogTable(ctx context.Context, tbl *doltdb.Table, columns []string) (*logTable, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := prollyMapForTable(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+	t := &logTable{
+		sch:     sch,
+		ns:      tbl.NodeStore(),
+		rows:    rows,
+		keyDesc: sch.GetKeyDescriptor(tbl.NodeStore()),
+		valDesc: sch.GetValueDescriptor(tbl.NodeStore()),
+	}
+	if columns != nil {
+		t.columns = make(map[string]bool, len(columns))
+		for _, col := range columns {
+			t.columns[strings.ToLower(col)] = true
+		}
+	}
+	return t, nil
+}
+
+// cells returns the values of the compared columns of the row |k|, |v|, formatted as strings, keyed by the lower-cased
+// column name. NULL values are nil.
+func (t *logTable) cells(ctx *sql.Context, k, v val.Tuple) (map[string]*string, error) {
+	cells := make(map[string]*string)
+	add := func(col schema.Column, desc *val.TupleDesc, i int, tup val.Tuple) error {
+		name := strings.ToLower(col.Name)
+		if t.columns != nil && !t.columns[name] {
+			return nil
+		}
+		f, err := tree.GetField(ctx, desc, i, tup, t.ns)
+		if err != nil {
+			return err
+		}
+		if f == nil {
+			cells[name] = nil
+			return nil
+		}
+		s, err := sqlutil.SqlColToStr(ctx, col.TypeInfo.ToSqlType(), f)
+		if err != nil {
+			return err
+		}
+		cells[name] = &s
+		return nil
+	}
+
+	keyless := schema.IsKeyless(t.sch)
+	if !keyless {
+		for i, col := range t.sch.GetPKCols().GetColumns() {
+			if err := add(col, t.keyDesc, i, k); err != nil {
+				return nil, err
+			}
+		}
+	}
+	nonPks := t.sch.GetNonPKCols()
+	for _, col := range nonPks.GetColumns() {
+		idx, ok := nonPks.StoredIndexByTag(col.Tag)
+		if !ok {
+			continue
+		}
+		if keyless {
+			// the first field of a keyless row is its cardinality
+			idx++
+		}
+		if err := add(col, t.valDesc, idx, v); err != nil {
+			return nil, err
+		}
+	}
+	return cells, nil
+}
@@ -0,0 +1,627 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	rowHistoryAdded      = "added"
+	rowHistoryModified   = "modified"
+	rowHistoryKeyChanged = "key_changed"
+	rowHistoryRemoved    = "removed"
+)
+
+var rowHistoryPool = pool.NewBuffPool()
+
+var _ sql.TableFunction = (*RowHistoryTableFunction)(nil)
+var _ sql.ExecSourceRel = (*RowHistoryTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*RowHistoryTableFunction)(nil)
+
+// RowHistoryTableFunction implements the DOLT_ROW_HISTORY table function, which lists every change to a single row,
+// identified by its table and primary key at HEAD. Unlike the dolt_history_$tablename system tables, the row is
+// followed through renames of its table and changes to its primary key.
+//
+// History is walked through the first parents of HEAD. Renames are found by diffing the schemas of adjacent commits.
+// A primary key change can't be told apart from deleting one row and inserting another, so the previous version of a
+// row which appears in a commit is guessed from the rows the commit deleted; see findPreviousKey. The lineage this
+// gives is a heuristic: rows which are unrelated may be linked, and a row whose key changed may be reported as added.
+type RowHistoryTableFunction struct {
+	ctx           *sql.Context
+	database      sql.Database
+	argumentExprs []sql.Expression
+}
+
+var rowHistoryTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: types.Text},
+	&sql.Column{Name: "commit_hash", Type: types.Text},
+	&sql.Column{Name: "committer", Type: types.Text},
+	&sql.Column{Name: "email", Type: types.Text},
+	&sql.Column{Name: "commit_date", Type: types.Datetime},
+	&sql.Column{Name: "message", Type: types.Text},
+	&sql.Column{Name: "diff_type", Type: types.Text},
+	&sql.Column{Name: "changed_columns", Type: types.Text},
+	&sql.Column{Name: "row", Type: types.JSON, Nullable: true},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (rhtf *RowHistoryTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &RowHistoryTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (rhtf *RowHistoryTableFunction) Name() string {
+	return "dolt_row_history"
+}
+
+// Database implements the sql.Databaser interface
+func (rhtf *RowHistoryTableFunction) Database() sql.Database {
+	return rhtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (rhtf *RowHistoryTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nrhtf := *rhtf
+	nrhtf.database = database
+	return &nrhtf, nil
+}
+
+// Schema implements the sql.Node interface
+func (rhtf *RowHistoryTableFunction) Schema() sql.Schema {
+	return rowHistoryTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (rhtf *RowHistoryTableFunction) Resolved() bool {
+	for _, expr := range rhtf.argumentExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsReadOnly implements the sql.Node interface
+func (rhtf *RowHistoryTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (rhtf *RowHistoryTableFunction) String() string {
+	var args []string
+	for _, expr := range rhtf.argumentExprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_ROW_HISTORY(%s)", strings.Join(args, ", "))
+}
+
+// Children implements the sql.Node interface
+func (rhtf *RowHistoryTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (rhtf *RowHistoryTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return rhtf, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (rhtf *RowHistoryTableFunction) Expressions() []sql.Expression {
+	return rhtf.argumentExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (rhtf *RowHistoryTableFunction) WithExpressions(expressions ...sql.Expression) (sql.Node, error) {
+	if len(expressions) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(rhtf.Name(), "at least 2", len(expressions))
+	}
+
+	nrhtf := *rhtf
+	nrhtf.argumentExprs = expressions
+	return &nrhtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (rhtf *RowHistoryTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	if ExpressionIsDeferred(rhtf.argumentExprs[0]) {
+		return true
+	}
+	tableName, err := rhtf.argumentExprs[0].Eval(ctx, nil)
+	if err != nil {
+		return false
+	}
+	tableNameStr, ok := tableName.(string)
+	if !ok {
+		return false
+	}
+
+	subject := sql.PrivilegeCheckSubject{Database: rhtf.database.Name(), Table: tableNameStr}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// RowIter implements the sql.Node interface
+func (rhtf *RowHistoryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := rhtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", rhtf.database)
+	}
+
+	tableNameVal, err := rhtf.argumentExprs[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return nil, ErrInvalidTableName.New(rhtf.argumentExprs[0].String())
+	}
+	var key []interface{}
+	for _, expr := range rhtf.argumentExprs[1:] {
+		v, err := expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, v)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, sqledb.RevisionQualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	child, err := resolveCommit(ctx, sqledb.DbData().Ddb, headRef, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	root, err := child.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvedName, tbl, ok, err := resolve.Table(ctx, root, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(tableName)
+	}
+	childTbl, err := newRowHistoryTable(ctx, resolvedName, tbl)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(childTbl.sch) {
+		return nil, fmt.Errorf("%s requires a table with a primary key", rhtf.Name())
+	}
+	if len(key) != childTbl.sch.GetPKCols().Size() {
+		return nil, fmt.Errorf("table %s has %d primary key columns, but %d values were given", resolvedName.Name, childTbl.sch.GetPKCols().Size(), len(key))
+	}
+
+	rows, err := rowHistory(ctx, child, childTbl, key)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// rowHistory walks the first parents of |child| and returns a row for each commit which changed the row with primary
+// key |key| in |childTbl|, newest first.
+func rowHistory(ctx *sql.Context, child *doltdb.Commit, childTbl *rowHistoryTable, key []interface{}) ([]sql.Row, error) {
+	childRow, err := childTbl.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	emit := func(cm *doltdb.Commit, tbl *rowHistoryTable, diffType string, changed []string, r *rowHistoryRow) error {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return err
+		}
+		var doc interface{}
+		if r != nil {
+			if doc, err = r.json(ctx); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, sql.Row{tbl.name.Name, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description, diffType, strings.Join(changed, ","), doc})
+		return nil
+	}
+
+	for {
+		parent, parentTbl, err := rowHistoryParent(ctx, child, childTbl)
+		if err != nil {
+			return nil, err
+		}
+		if parentTbl == nil || !rowHistoryKeysMatch(childTbl.sch, parentTbl.sch) {
+			// the table, or its current primary key, was created in |child|
+			if childRow != nil {
+				if err = emit(child, childTbl, rowHistoryAdded, childRow.cols, childRow); err != nil {
+					return nil, err
+				}
+			}
+			return rows, nil
+		}
+
+		childHash, err := childTbl.tbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		parentHash, err := parentTbl.tbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if childHash == parentHash {
+			child, childTbl = parent, parentTbl
+			continue
+		}
+
+		parentRow, err := parentTbl.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case childRow != nil && parentRow != nil:
+			if changed := childRow.changedColumns(ctx, parentRow); len(changed) > 0 {
+				err = emit(child, childTbl, rowHistoryModified, changed, childRow)
+			}
+		case childRow != nil:
+			parentRow, err = parentTbl.findPreviousKey(ctx, childTbl, childRow)
+			if err != nil {
+				return nil, err
+			}
+			if parentRow != nil {
+				key = parentRow.key
+				err = emit(child, childTbl, rowHistoryKeyChanged, childRow.changedColumns(ctx, parentRow), childRow)
+			} else {
+				err = emit(child, childTbl, rowHistoryAdded, childRow.cols, childRow)
+			}
+		case parentRow != nil:
+			err = emit(child, childTbl, rowHistoryRemoved, parentRow.cols, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		child, childTbl, childRow = parent, parentTbl, parentRow
+	}
+}
+
+// rowHistoryParent returns the first parent of |child| and the table |childTbl| was in it, following renames. The
+// table is nil if there is no parent, or the table doesn't exist in it.
+func rowHistoryParent(ctx *sql.Context, child *doltdb.Commit, childTbl *rowHistoryTable) (*doltdb.Commit, *rowHistoryTable, error) {
+	if child.NumParents() == 0 {
+		return nil, nil, nil
+	}
+	optCmt, err := child.GetParent(ctx, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		// the rest of the history isn't available in a shallow clone
+		return nil, nil, nil
+	}
+	root, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := childTbl.name
+	tbl, ok, err := root.GetTable(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		childRoot, err := child.GetRootValue(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		deltas, err := diff.GetTableDeltas(ctx, root, childRoot)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, delta := range deltas {
+			if delta.IsRename() && delta.ToName == childTbl.name {
+				name, tbl = delta.FromName, delta.FromTable
+				break
+			}
+		}
+		if tbl == nil {
+			return parent, nil, nil
+		}
+	}
+
+	parentTbl, err := newRowHistoryTable(ctx, name, tbl)
+	if err != nil {
+		return nil, nil, err
+	}
+	if schema.IsKeyless(parentTbl.sch) {
+		return parent, nil, nil
+	}
+	return parent, parentTbl, nil
+}
+
+// rowHistoryKeysMatch returns whether |child| and |parent| have the same primary key columns.
+func rowHistoryKeysMatch(child, parent schema.Schema) bool {
+	childTags, parentTags := child.GetPKCols().Tags, parent.GetPKCols().Tags
+	if len(childTags) != len(parentTags) {
+		return false
+	}
+	for i := range childTags {
+		if childTags[i] != parentTags[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// rowHistoryTable is a version of the table a row is followed through.
+type rowHistoryTable struct {
+	name    doltdb.TableName
+	tbl     *doltdb.Table
+	sch     schema.Schema
+	rows    prolly.Map
+	keyDesc *val.TupleDesc
+	valDesc *val.TupleDesc
+}
+
+func newRowHistoryTable(ctx context.Context, name doltdb.TableName, tbl *doltdb.Table) (*rowHistoryTable, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := prollyMapForTable(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+	ns := tbl.NodeStore()
+	return &rowHistoryTable{
+		name:    name,
+		tbl:     tbl,
+		sch:     sch,
+		rows:    rows,
+		keyDesc: sch.GetKeyDescriptor(ns),
+		valDesc: sch.GetValueDescriptor(ns),
+	}, nil
+}
+
+// get returns the row with primary key |key|, or nil if there isn't one.
+func (t *rowHistoryTable) get(ctx *sql.Context, key []interface{}) (*rowHistoryRow, error) {
+	ns := t.tbl.NodeStore()
+	tb := val.NewTupleBuilder(t.keyDesc, ns)
+	for i, col := range t.sch.GetPKCols().GetColumns() {
+		v, _, err := col.TypeInfo.ToSqlType().Convert(ctx, key[i])
+		if err != nil {
+			// the key can't be stored in this version of the table
+			return nil, nil
+		}
+		if err = tree.PutField(ctx, ns, tb, i, v); err != nil {
+			return nil, err
+		}
+	}
+	k, err := tb.Build(rowHistoryPool)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *rowHistoryRow
+	err = t.rows.Get(ctx, k, func(k, v val.Tuple) error {
+		if k == nil {
+			return nil
+		}
+		r, err = t.row(ctx, k, v)
+		return err
+	})
+	return r, err
+}
+
+// findPreviousKey returns the row deleted from this table by |child| which is most likely the previous version of
+// |childRow|, a row |child| added, or nil if there is no likely one. In order of preference, that is
+//   - the deleted row, if |child| deleted one row and added one row to the table,
+//   - the deleted row with the most non-key values in common with |childRow|, if no other deleted row has as many and
+//     it has more than half of them in common, or every one if the table has no non-key columns.
+func (t *rowHistoryTable) findPreviousKey(ctx *sql.Context, child *rowHistoryTable, childRow *rowHistoryRow) (*rowHistoryRow, error) {
+	var first, best *rowHistoryRow
+	bestCommon, ties := -1, 0
+	added, removed := 0, 0
+	err := prolly.DiffMaps(ctx, t.rows, child.rows, false, func(_ context.Context, d tree.Diff) error {
+		switch d.Type {
+		case tree.AddedDiff:
+			added++
+			return nil
+		case tree.RemovedDiff:
+		default:
+			return nil
+		}
+		r, err := t.row(ctx, val.Tuple(d.Key), val.Tuple(d.From))
+		if err != nil {
+			return err
+		}
+		removed++
+		if first == nil {
+			first = r
+		}
+		if common := childRow.commonValues(ctx, r); common > bestCommon {
+			best, bestCommon, ties = r, common, 0
+		} else if common == bestCommon {
+			ties++
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if added == 1 && removed == 1 {
+		return first, nil
+	}
+	nonPks := len(childRow.cols) - len(childRow.key)
+	if best == nil || ties > 0 || (bestCommon < nonPks && 2*bestCommon <= nonPks) {
+		return nil, nil
+	}
+	return best, nil
+}
+
+// row returns the row stored as |k| and |v|.
+func (t *rowHistoryTable) row(ctx context.Context, k, v val.Tuple) (*rowHistoryRow, error) {
+	ns := t.tbl.NodeStore()
+	r := &rowHistoryRow{}
+	for i, col := range t.sch.GetPKCols().GetColumns() {
+		f, err := tree.GetField(ctx, t.keyDesc, i, k, ns)
+		if err != nil {
+			return nil, err
+		}
+		r.key = append(r.key, f)
+		r.add(col, f)
+	}
+	nonPks := t.sch.GetNonPKCols()
+	for _, col := range nonPks.GetColumns() {
+		idx, ok := nonPks.StoredIndexByTag(col.Tag)
+		if !ok {
+			continue
+		}
+		f, err := tree.GetField(ctx, t.valDesc, idx, v, ns)
+		if err != nil {
+			return nil, err
+		}
+		r.add(col, f)
+	}
+	return r, nil
+}
+
+// rowHistoryRow is a version of the row being followed. Its primary key columns come first.
+type rowHistoryRow struct {
+	key   []interface{}
+	cols  []string
+	tags  []uint64
+	types []sql.Type
+	vals  []interface{}
+}
+
+func (r *rowHistoryRow) add(col schema.Column, v interface{}) {
+	r.cols = append(r.cols, col.Name)
+	r.tags = append(r.tags, col.Tag)
+	r.types = append(r.types, col.TypeInfo.ToSqlType())
+	r.vals = append(r.vals, v)
+}
+
+// str returns the value of column |i| formatted as a string, or nil if it is NULL.
+func (r *rowHistoryRow) str(ctx *sql.Context, i int) *string {
+	if r.vals[i] == nil {
+		return nil
+	}
+	s, err := sqlutil.SqlColToStr(ctx, r.types[i], r.vals[i])
+	if err != nil {
+		s = fmt.Sprintf("%v", r.vals[i])
+	}
+	return &s
+}
+
+// changedColumns returns the columns whose values differ between |r| and |prev|, including columns which were added or
+// dropped. Columns are matched by tag, so a renamed column is only changed if its value is, and is named as it is in
+// |r|.
+func (r *rowHistoryRow) changedColumns(ctx *sql.Context, prev *rowHistoryRow) []string {
+	prevIdx := make(map[uint64]int, len(prev.tags))
+	for i, tag := range prev.tags {
+		prevIdx[tag] = i
+	}
+
+	var changed []string
+	for i, tag := range r.tags {
+		j, ok := prevIdx[tag]
+		if !ok {
+			changed = append(changed, r.cols[i])
+			continue
+		}
+		delete(prevIdx, tag)
+		if !r.sameValue(ctx, i, prev, j) {
+			changed = append(changed, r.cols[i])
+		}
+	}
+	for j, tag := range prev.tags {
+		if _, ok := prevIdx[tag]; ok {
+			changed = append(changed, prev.cols[j])
+		}
+	}
+	return changed
+}
+
+// commonValues returns the number of non-key columns of |r| which have the same value in |other|.
+func (r *rowHistoryRow) commonValues(ctx *sql.Context, other *rowHistoryRow) int {
+	otherIdx := make(map[uint64]int, len(other.tags))
+	for j := len(other.key); j < len(other.tags); j++ {
+		otherIdx[other.tags[j]] = j
+	}
+	common := 0
+	for i := len(r.key); i < len(r.tags); i++ {
+		if j, ok := otherIdx[r.tags[i]]; ok && r.sameValue(ctx, i, other, j) {
+			common++
+		}
+	}
+	return common
+}
+
+// sameValue returns whether column |i| of |r| and column |j| of |other| have the same value.
+func (r *rowHistoryRow) sameValue(ctx *sql.Context, i int, other *rowHistoryRow, j int) bool {
+	a, b := r.str(ctx, i), other.str(ctx, j)
+	return (a == nil) == (b == nil) && (a == nil || *a == *b)
+}
+
+// json returns the row as a JSON object of its column values.
+func (r *rowHistoryRow) json(ctx *sql.Context) (types.JSONDocument, error) {
+	obj := make(map[string]interface{}, len(r.cols))
+	for i, col := range r.cols {
+		if s := r.str(ctx, i); s != nil {
+			obj[col] = *s
+		} else {
+			obj[col] = nil
+		}
+	}
+	return types.JSONDocument{Val: obj}, nil
+}
@@ -21,12 +21,14 @@ var DoltTableFunctions = []sql.TableFunction{
 	&DiffStatTableFunction{},
 	&DiffSummaryTableFunction{},
 	&BranchStatusTableFunction{},
+	&BlameTableFunction{},
 	&LogTableFunction{},
 	&PatchTableFunction{},
 	&PreviewMergeConflictsSummaryTableFunction{},
 	&PreviewMergeConflictsTableFunction{},
 	&SchemaDiffTableFunction{},
 	&ReflogTableFunction{},
+	&RowHistoryTableFunction{},
 	&QueryDiffTableFunction{},
 	&TestsRunTableFunction{},
 }
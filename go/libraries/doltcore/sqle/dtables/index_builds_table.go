@@ -0,0 +1,111 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/indexbuild"
+)
+
+var _ sql.Table = (*IndexBuildsTable)(nil)
+
+// IndexBuildsTable is a read-only system table that shows the online index builds of a database
+type IndexBuildsTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewIndexBuildsTable(_ *sql.Context, db dsess.SqlDatabase, tableName string) sql.Table {
+	return &IndexBuildsTable{db: db, tableName: tableName}
+}
+
+func (ibt *IndexBuildsTable) Name() string {
+	return ibt.tableName
+}
+
+func (ibt *IndexBuildsTable) String() string {
+	return ibt.tableName
+}
+
+func (ibt *IndexBuildsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "id", Type: types.Uint64, Source: ibt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "database", Type: types.Text, Source: ibt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "table_name", Type: types.Text, Source: ibt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "index_name", Type: types.Text, Source: ibt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "state", Type: types.Text, Source: ibt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "rows_total", Type: types.Uint64, Source: ibt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "rows_processed", Type: types.Uint64, Source: ibt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ibt.db.Name()},
+		{Name: "started_at", Type: types.Datetime, Source: ibt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: ibt.db.Name()},
+		{Name: "finished_at", Type: types.Datetime, Source: ibt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: ibt.db.Name()},
+		{Name: "error", Type: types.Text, Source: ibt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: ibt.db.Name()},
+	}
+}
+
+func (ibt *IndexBuildsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (ibt *IndexBuildsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows returns a row for every index build of any revision of this table's database.
+func (ibt *IndexBuildsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	controller := indexbuild.GetController(ctx)
+	if controller == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	baseName, _ := doltdb.SplitRevisionDbName(ibt.db.Name())
+	var rows []sql.Row
+	for _, job := range controller.Jobs() {
+		jobBaseName, _ := doltdb.SplitRevisionDbName(job.Database)
+		if !strings.EqualFold(baseName, jobBaseName) {
+			continue
+		}
+
+		var startedAt, finishedAt, errMsg interface{}
+		if job.StartedAt != nil {
+			startedAt = *job.StartedAt
+		}
+		if job.FinishedAt != nil {
+			finishedAt = *job.FinishedAt
+		}
+		if job.Error != "" {
+			errMsg = job.Error
+		}
+		rows = append(rows, sql.NewRow(
+			job.ID,
+			job.Database,
+			job.Table.Name,
+			job.IndexName,
+			string(job.State),
+			job.RowsTotal,
+			job.RowsProcessed,
+			startedAt,
+			finishedAt,
+			errMsg,
+		))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// StorageMaintenanceEvent records one GC, conjoin or archive operation performed by the storage maintenance of a
+// running server.
+type StorageMaintenanceEvent struct {
+	ID          uint64
+	Database    string
+	Operation   string
+	Reason      string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	FilesBefore int
+	FilesAfter  int
+	BytesBefore uint64
+	BytesAfter  uint64
+	Status      string
+	Error       string
+}
+
+// StorageMaintenanceLogProvider is implemented by database providers which perform storage maintenance.
+type StorageMaintenanceLogProvider interface {
+	StorageMaintenanceEvents() []StorageMaintenanceEvent
+}
+
+var _ sql.Table = (*StorageMaintenanceLogTable)(nil)
+
+// StorageMaintenanceLogTable is a read-only system table that shows the recent storage maintenance of a database
+type StorageMaintenanceLogTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewStorageMaintenanceLogTable(_ *sql.Context, db dsess.SqlDatabase, tableName string) sql.Table {
+	return &StorageMaintenanceLogTable{db: db, tableName: tableName}
+}
+
+func (smt *StorageMaintenanceLogTable) Name() string {
+	return smt.tableName
+}
+
+func (smt *StorageMaintenanceLogTable) String() string {
+	return smt.tableName
+}
+
+func (smt *StorageMaintenanceLogTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "id", Type: types.Uint64, Source: smt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "database", Type: types.Text, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "operation", Type: types.Text, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "reason", Type: types.Text, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "started_at", Type: types.Datetime, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "finished_at", Type: types.Datetime, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "duration_ms", Type: types.Int64, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "files_before", Type: types.Int64, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "files_after", Type: types.Int64, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "bytes_before", Type: types.Uint64, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "bytes_after", Type: types.Uint64, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "status", Type: types.Text, Source: smt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: smt.db.Name()},
+		{Name: "error", Type: types.Text, Source: smt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: smt.db.Name()},
+	}
+}
+
+func (smt *StorageMaintenanceLogTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (smt *StorageMaintenanceLogTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows returns a row for every logged maintenance operation of this table's database.
+func (smt *StorageMaintenanceLogTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok {
+		return sql.RowsToRowIter(), nil
+	}
+	lp, ok := sess.Provider().(StorageMaintenanceLogProvider)
+	if !ok {
+		return sql.RowsToRowIter(), nil
+	}
+
+	baseName, _ := doltdb.SplitRevisionDbName(smt.db.Name())
+	var rows []sql.Row
+	for _, event := range lp.StorageMaintenanceEvents() {
+		if !strings.EqualFold(baseName, event.Database) {
+			continue
+		}
+
+		var errMsg interface{}
+		if event.Error != "" {
+			errMsg = event.Error
+		}
+		rows = append(rows, sql.NewRow(
+			event.ID,
+			event.Database,
+			event.Operation,
+			event.Reason,
+			event.StartedAt,
+			event.FinishedAt,
+			event.FinishedAt.Sub(event.StartedAt).Milliseconds(),
+			int64(event.FilesBefore),
+			int64(event.FilesAfter),
+			event.BytesBefore,
+			event.BytesAfter,
+			event.Status,
+			errMsg,
+		))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
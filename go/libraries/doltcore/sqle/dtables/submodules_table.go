@@ -0,0 +1,68 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func doltSubmodulesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.SubmodulesNameCol, Type: sqlTypes.VarChar, Source: doltdb.SubmodulesTableName, PrimaryKey: true},
+		{Name: doltdb.SubmodulesUrlCol, Type: sqlTypes.VarChar, Source: doltdb.SubmodulesTableName, Nullable: false},
+		{Name: doltdb.SubmodulesCommitCol, Type: sqlTypes.VarChar, Source: doltdb.SubmodulesTableName, Nullable: false},
+	}
+}
+
+// GetDoltSubmodulesSchema returns the schema of the dolt_submodules system table.
+var GetDoltSubmodulesSchema = doltSubmodulesSchema
+
+// NewSubmodulesTable creates a dolt_submodules table
+func NewSubmodulesTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    doltdb.TableName{Name: doltdb.SubmodulesTableName},
+		schema:       GetDoltSubmodulesSchema(),
+		validateRow:  validateSubmoduleRow,
+	}
+}
+
+// NewEmptySubmodulesTable creates an empty dolt_submodules table
+func NewEmptySubmodulesTable(_ *sql.Context) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName:   doltdb.TableName{Name: doltdb.SubmodulesTableName},
+		schema:      GetDoltSubmodulesSchema(),
+		validateRow: validateSubmoduleRow,
+	}
+}
+
+// validateSubmoduleRow rejects rows of dolt_submodules whose name, url or commit hash can't be used to load the
+// submodule.
+func validateSubmoduleRow(row sql.Row) error {
+	var fields [3]string
+	for i := range fields {
+		s, ok := row[i].(string)
+		if !ok {
+			return fmt.Errorf("%s.%s must be a string", doltdb.SubmodulesTableName, doltSubmodulesSchema()[i].Name)
+		}
+		fields[i] = s
+	}
+	return doltdb.ValidateSubmodule(fields[0], fields[1], fields[2])
+}
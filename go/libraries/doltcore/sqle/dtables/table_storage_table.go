@@ -0,0 +1,229 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+)
+
+// TableStorageTable is a sql.Table implementation that implements a system table which shows the shape and size of
+// the prolly trees backing every table and secondary index at a revision.
+type TableStorageTable struct {
+	ddb       *doltdb.DoltDB
+	head      *doltdb.Commit
+	root      doltdb.RootValue
+	tableName string
+}
+
+var _ sql.Table = (*TableStorageTable)(nil)
+
+// NewTableStorageTable creates a TableStorageTable. Chunks are reported as shared with the parent commit when they are
+// also reachable from the root of |head|'s first parent, or from |head| itself when |root| is a working root with
+// changes on top of |head|.
+func NewTableStorageTable(_ *sql.Context, tableName string, ddb *doltdb.DoltDB, head *doltdb.Commit, root doltdb.RootValue) sql.Table {
+	return &TableStorageTable{ddb: ddb, head: head, root: root, tableName: tableName}
+}
+
+// Name implements the interface sql.Table.
+func (tst *TableStorageTable) Name() string {
+	return tst.tableName
+}
+
+// String implements the interface sql.Table.
+func (tst *TableStorageTable) String() string {
+	return tst.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (tst *TableStorageTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: tst.tableName, PrimaryKey: true},
+		{Name: "index_name", Type: types.Text, Source: tst.tableName, PrimaryKey: true},
+		{Name: "tree_height", Type: types.Uint32, Source: tst.tableName, PrimaryKey: false},
+		{Name: "node_count", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false},
+		{Name: "total_bytes", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false},
+		{Name: "unique_bytes", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false},
+		{Name: "average_fill", Type: types.Float64, Source: tst.tableName, PrimaryKey: false},
+		{Name: "shared_with_parent_bytes", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (tst *TableStorageTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (tst *TableStorageTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	tblNames, err := doltdb.UnionTableNames(ctx, tst.root)
+	if err != nil {
+		return nil, err
+	}
+	tblPartitions := make([]tableOfTablesPartition, len(tblNames))
+	for i := range tblNames {
+		tblPartitions[i] = tableOfTablesPartition(tblNames[i])
+	}
+	return &tableOfTablesPartitionIter{
+		idx:      0,
+		tblNames: tblPartitions,
+	}, nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (tst *TableStorageTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	tblName := decodeTableName(part.Key())
+	tbl, ok, err := tst.root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || !storetypes.IsFormat_DOLT(tbl.Format()) {
+		return sql.RowsToRowIter(), nil
+	}
+
+	var baseTbl *doltdb.Table
+	baseRoot, err := tst.baseRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseRoot != nil {
+		baseTbl, _, err = baseRoot.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []sql.Row
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var baseRowData durable.Index
+	if baseTbl != nil {
+		if baseRowData, err = baseTbl.GetRowData(ctx); err != nil {
+			return nil, err
+		}
+	}
+	row, err := tableStorageRow(ctx, tblName.Name, "", rowData, baseRowData)
+	if err != nil {
+		return nil, err
+	}
+	rows = append(rows, row)
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var baseIndexes durable.IndexSet
+	if baseTbl != nil {
+		if baseIndexes, err = baseTbl.GetIndexSet(ctx); err != nil {
+			return nil, err
+		}
+	}
+	err = durable.IterAllIndexes(ctx, sch, indexes, func(name string, idx durable.Index) error {
+		var baseIdx durable.Index
+		if baseIndexes != nil {
+			ok, err := baseIndexes.HasIndex(ctx, name)
+			if err != nil {
+				return err
+			}
+			if ok {
+				if baseIdx, err = baseIndexes.GetIndex(ctx, sch, nil, name); err != nil {
+					return err
+				}
+			}
+		}
+		row, err := tableStorageRow(ctx, tblName.Name, name, idx, baseIdx)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// baseRoot returns the root that chunks are compared against to compute shared bytes, or nil if there is none.
+func (tst *TableStorageTable) baseRoot(ctx context.Context) (doltdb.RootValue, error) {
+	if tst.head == nil {
+		return nil, nil
+	}
+	headRoot, err := tst.head.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rootHash, err := tst.root.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := headRoot.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if rootHash != headHash {
+		return headRoot, nil
+	}
+
+	if tst.head.NumParents() == 0 {
+		return nil, nil
+	}
+	optCmt, err := tst.ddb.ResolveParent(ctx, tst.head, 0)
+	if err != nil {
+		return nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		// the parent is a ghost commit, so there is nothing to share chunks with
+		return nil, nil
+	}
+	return parent.GetRootValue(ctx)
+}
+
+func tableStorageRow(ctx context.Context, tblName, idxName string, idx, baseIdx durable.Index) (sql.Row, error) {
+	m := durable.MapFromIndex(idx)
+	var base *tree.Node
+	if baseIdx != nil {
+		base = durable.MapFromIndex(baseIdx).Node()
+	}
+	stats, err := tree.GetStorageStats(ctx, m.NodeStore(), m.Node(), base)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Row{
+		tblName,
+		idxName,
+		uint32(stats.Height),
+		stats.NodeCount,
+		stats.TotalBytes,
+		stats.UniqueBytes,
+		stats.AverageFill(),
+		stats.SharedBytes,
+	}, nil
+}
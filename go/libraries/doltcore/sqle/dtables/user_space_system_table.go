@@ -40,6 +40,8 @@ type UserSpaceSystemTable struct {
 	backingTable VersionableTable
 	tableName    doltdb.TableName
 	schema       sql.Schema
+	// validateRow, if set, is called with each row inserted or updated, and rejects the write if it returns an error.
+	validateRow func(row sql.Row) error
 }
 
 func (bst *UserSpaceSystemTable) Name() string {
@@ -145,6 +147,9 @@ func (bstw *backedSystemTableWriter) Insert(ctx *sql.Context, r sql.Row) error {
 	if err := bstw.errDuringStatementBegin; err != nil {
 		return err
 	}
+	if err := bstw.validate(r); err != nil {
+		return err
+	}
 	return bstw.tableWriter.Insert(ctx, r)
 }
 
@@ -153,9 +158,19 @@ func (bstw *backedSystemTableWriter) Update(ctx *sql.Context, old sql.Row, new s
 	if err := bstw.errDuringStatementBegin; err != nil {
 		return err
 	}
+	if err := bstw.validate(new); err != nil {
+		return err
+	}
 	return bstw.tableWriter.Update(ctx, old, new)
 }
 
+func (bstw *backedSystemTableWriter) validate(r sql.Row) error {
+	if bstw.bst.validateRow == nil {
+		return nil
+	}
+	return bstw.bst.validateRow(r)
+}
+
 // Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
 // each row to process for the delete operation, which may involve many rows. After all rows have been processed,
 // Close is called.
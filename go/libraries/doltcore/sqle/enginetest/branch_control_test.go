@@ -15,6 +15,8 @@
 package enginetest
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/dolthub/go-mysql-server/enginetest"
@@ -1555,3 +1557,85 @@ func TestBranchControlBlocks(t *testing.T) {
 		})
 	}
 }
+
+// decisionRecorder is a branch_control.DecisionObserver that records every decision it is notified of.
+type decisionRecorder struct {
+	mu        sync.Mutex
+	decisions []branch_control.Decision
+}
+
+func (r *decisionRecorder) BranchControlDecision(_ context.Context, decision branch_control.Decision) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decisions = append(r.decisions, decision)
+}
+
+func (r *decisionRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decisions = nil
+}
+
+func (r *decisionRecorder) writeDecisions(branch string) []branch_control.Decision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var decisions []branch_control.Decision
+	for _, decision := range r.decisions {
+		if decision.Branch == branch && decision.User == "testuser" && decision.Permissions == branch_control.Permissions_Write {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions
+}
+
+// TestBranchControlDecisions asserts that denied writes to the tables of branch databases, which are checked by
+// dsess.CheckAccessForDb rather than branch_control.CheckAccess, report their decisions to the DecisionObserver, and
+// that allowed writes do not.
+func TestBranchControlDecisions(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+
+	engine, err := harness.NewEngine(t)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	rootCtx := enginetest.NewContext(harness)
+	rootCtx.NewCtxWithClient(sql.Client{
+		User:    "root",
+		Address: "localhost",
+	})
+	engine.EngineAnalyzer().Catalog.MySQLDb.AddRootAccount()
+	engine.EngineAnalyzer().Catalog.MySQLDb.SetPersister(&mysql_db.NoopPersister{})
+
+	for _, statement := range TestUserSetUpScripts {
+		enginetest.RunQueryWithContext(t, engine, harness, rootCtx, statement)
+	}
+
+	recorder := &decisionRecorder{}
+	branch_control.SetDecisionObserver(recorder)
+	defer branch_control.SetDecisionObserver(nil)
+
+	userCtx := enginetest.NewContextWithClient(harness, sql.Client{
+		User:    "testuser",
+		Address: "localhost",
+	})
+	query := "INSERT INTO `mydb/other`.test VALUES (2, 2);"
+	enginetest.AssertErrWithCtx(t, engine, harness, userCtx, query, nil, branch_control.ErrIncorrectPermissions)
+	decisions := recorder.writeDecisions("other")
+	require.NotEmpty(t, decisions)
+	for _, decision := range decisions {
+		assert.Equal(t, branch_control.DecisionAccess, decision.Action)
+		assert.Equal(t, "mydb", decision.Database)
+		assert.False(t, decision.Allowed)
+	}
+
+	enginetest.RunQueryWithContext(t, engine, harness, rootCtx,
+		"INSERT INTO dolt_branch_control VALUES ('%', 'other', 'testuser', 'localhost', 'write');")
+	recorder.reset()
+	_, iter, _, err := engine.Query(userCtx, query)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(userCtx, iter)
+	require.NoError(t, err)
+	// Allowed writes are checked for every row, so they are not reported
+	require.Empty(t, recorder.writeDecisions("other"))
+}
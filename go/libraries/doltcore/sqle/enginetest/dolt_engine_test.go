@@ -1487,6 +1487,24 @@ func TestBackupsSystemTable(t *testing.T) {
 	enginetest.TestScript(t, h, BackupsSystemTableQueries)
 }
 
+func TestTableStorageSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, TableStorageSystemTableQueries)
+}
+
+func TestIndexBuildsSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, IndexBuildsSystemTableQueries)
+}
+
+func TestStorageMaintenanceLogSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, StorageMaintenanceLogSystemTableQueries)
+}
+
 func TestHistorySystemTable(t *testing.T) {
 	harness := newDoltEnginetestHarness(t).WithParallelism(2)
 	RunHistorySystemTableTests(t, harness)
@@ -1585,6 +1603,21 @@ func TestPatchTableFunctionPrepared(t *testing.T) {
 	RunDoltPatchTableFunctionTestsPrepared(t, harness)
 }
 
+func TestBlameTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunBlameTableFunctionTests(t, harness)
+}
+
+func TestSubmodulesSystemTable(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunSubmodulesSystemTableTests(t, harness)
+}
+
+func TestRowHistoryTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunRowHistoryTableFunctionTests(t, harness)
+}
+
 func TestLogTableFunction(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunLogTableFunctionTests(t, harness)
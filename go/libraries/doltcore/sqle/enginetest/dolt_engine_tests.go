@@ -1300,6 +1300,41 @@ func RunDoltPatchTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetest
 	}
 }
 
+func RunBlameTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range BlameTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			harness.SkipSetupCommit()
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunSubmodulesSystemTableTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range SubmodulesSystemTableScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunRowHistoryTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range RowHistoryTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			harness.SkipSetupCommit()
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
 func RunLogTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range LogTableFunctionScriptTests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -2920,6 +2920,97 @@ var BrokenHistorySystemTableScriptTests = []queries.ScriptTest{
 	},
 }
 
+var SubmodulesSystemTableScriptTests = []queries.ScriptTest{
+	{
+		Name:        "dolt_submodules is empty until a submodule is added",
+		SetUpScript: []string{},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_submodules;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "describe dolt_submodules;",
+				Expected: []sql.Row{
+					{"name", "varchar(65535)", "NO", "PRI", nil, ""},
+					{"url", "varchar(65535)", "NO", "", nil, ""},
+					{"commit_hash", "varchar(65535)", "NO", "", nil, ""},
+				},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "dolt_submodules is versioned",
+		SetUpScript: []string{
+			"insert into dolt_submodules values ('refdata', 'file:///refdata', 'a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0');",
+			"call dolt_commit('-Am', 'add refdata');",
+			"update dolt_submodules set commit_hash = 'hb3a7ie3m5o5b9mg5n3u7g0rm3mr2dr4';",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_submodules;",
+				Expected: []sql.Row{{"refdata", "file:///refdata", "hb3a7ie3m5o5b9mg5n3u7g0rm3mr2dr4"}},
+			},
+			{
+				Query:    "select * from dolt_submodules as of 'HEAD';",
+				Expected: []sql.Row{{"refdata", "file:///refdata", "a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0"}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status;",
+				Expected: []sql.Row{{"dolt_submodules", false, "modified"}},
+			},
+			{
+				Query:    "select from_commit_hash, to_commit_hash, diff_type from dolt_diff('HEAD', 'WORKING', 'dolt_submodules');",
+				Expected: []sql.Row{{"a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0", "hb3a7ie3m5o5b9mg5n3u7g0rm3mr2dr4", "modified"}},
+			},
+			{
+				Query:    "call dolt_checkout('dolt_submodules');",
+				Expected: []sql.Row{{0, ""}},
+			},
+			{
+				Query:    "select commit_hash from dolt_submodules;",
+				Expected: []sql.Row{{"a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_submodules rejects rows that can't be loaded",
+		SetUpScript: []string{
+			"insert into dolt_submodules values ('refdata', 'file:///refdata', 'a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "insert into dolt_submodules values ('x', 'file:///nowhere', 'garbage');",
+				ExpectedErrStr: "submodule 'x' is pinned to invalid commit hash 'garbage'",
+			},
+			{
+				Query:          "update dolt_submodules set commit_hash = 'garbage';",
+				ExpectedErrStr: "submodule 'refdata' is pinned to invalid commit hash 'garbage'",
+			},
+			{
+				Query:          "insert into dolt_submodules values ('x', 'nope://nowhere', 'a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0');",
+				ExpectedErrStr: "submodule 'x' has url 'nope://nowhere' with unknown scheme 'nope'",
+			},
+			{
+				Query:          "insert into dolt_submodules values ('x', '', 'a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0');",
+				ExpectedErrStr: "submodule 'x' has invalid url ''",
+			},
+			{
+				Query:          "insert into dolt_submodules values ('../x', 'file:///x', 'a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0');",
+				ExpectedErrStr: "invalid submodule name '../x'",
+			},
+			{
+				Query:    "select * from dolt_submodules;",
+				Expected: []sql.Row{{"refdata", "file:///refdata", "a0q6m5nl9gbe5jddrhjk7g1ggcoobkc0"}},
+			},
+		},
+	},
+}
+
 var BranchesSystemTableTests = []queries.ScriptTest{
 	{
 		Name: "dolt_branches basic usage",
@@ -4542,6 +4633,300 @@ var DoltGC = []queries.ScriptTest{
 	},
 }
 
+var BlameTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "blame each column",
+		SetUpScript: []string{
+			"create table t (pk int primary key, name varchar(20), price int);",
+			"insert into t values (1, 'one', 10), (2, 'two', 20);",
+			"call dolt_commit('-Am', 'first', '--date', '2024-01-01T00:00:00');",
+			"update t set price = 11 where pk = 1;",
+			"call dolt_commit('-am', 'second', '--date', '2024-02-01T00:00:00');",
+			"alter table t add column qty int;",
+			"update t set name = 'TWO' where pk = 2;",
+			"insert into t values (3, 'three', 30, 3);",
+			"call dolt_commit('-am', 'third', '--date', '2024-03-01T00:00:00');",
+			"update t set price = 12 where pk = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select pk, column_name, message from dolt_blame('t');",
+				Expected: []sql.Row{
+					{1, "name", "first"},
+					{1, "price", "second"},
+					{1, "qty", "third"},
+					{2, "name", "third"},
+					{2, "price", "first"},
+					{2, "qty", "third"},
+					{3, "name", "third"},
+					{3, "price", "third"},
+					{3, "qty", "third"},
+				},
+			},
+			{
+				Query: "select pk, column_name, message from dolt_blame('HEAD~1', 't', '--columns', 'price,name');",
+				Expected: []sql.Row{
+					{1, "price", "second"},
+					{1, "name", "first"},
+					{2, "price", "first"},
+					{2, "name", "first"},
+				},
+			},
+			{
+				Query: "select pk, message from dolt_blame('t', '--columns', 'price', '--since', '2024-01-15');",
+				Expected: []sql.Row{
+					{1, "second"},
+					{3, "third"},
+				},
+			},
+			{
+				Query: "select pk, column_name, message from dolt_blame('t', '--until', '2024-01-15');",
+				Expected: []sql.Row{
+					{1, "name", "first"},
+					{1, "price", "first"},
+					{2, "name", "first"},
+					{2, "price", "first"},
+				},
+			},
+			{
+				Query: "select pk, date_format(commit_date, '%Y-%m-%d') from dolt_blame('t', '--columns', 'pk') where pk = 3;",
+				Expected: []sql.Row{
+					{3, "2024-03-01"},
+				},
+			},
+		},
+	},
+	{
+		Name: "blame across a primary key change",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'first');",
+			"alter table t modify column pk bigint;",
+			"call dolt_commit('-am', 'second');",
+			"update t set c1 = 2;",
+			"call dolt_commit('-am', 'third');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select pk, column_name, message from dolt_blame('t');",
+				Expected: []sql.Row{{int64(1), "c1", "third"}},
+			},
+			{
+				Query:    "select pk, column_name, message from dolt_blame('HEAD~1', 't');",
+				Expected: []sql.Row{{int64(1), "c1", "second"}},
+			},
+		},
+	},
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"create table keyless (c1 int);",
+			"call dolt_commit('-Am', 'first');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "select * from dolt_blame();",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_blame('HEAD', 't', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_blame(123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_blame('missing');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "select * from dolt_blame('t', '--columns', 'missing');",
+				ExpectedErr: sql.ErrTableColumnNotFound,
+			},
+			{
+				Query:          "select * from dolt_blame('keyless');",
+				ExpectedErrStr: "unable to generate blame for table without primary key",
+			},
+			{
+				Query:          "select * from dolt_blame('t', '--since', 'yesterday');",
+				ExpectedErrStr: "invalid date for --since: yesterday",
+			},
+			{
+				Query:          "select * from dolt_blame('t', '--until', '2000-01-01');",
+				ExpectedErrStr: "no commits were made on or before 2000-01-01 00:00:00",
+			},
+		},
+	},
+}
+
+var RowHistoryTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "row history across modifications",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20));",
+			"insert into t values (1, 1, 'a'), (2, 2, 'b');",
+			"call dolt_commit('-Am', 'first', '--date', '2024-01-01T00:00:00');",
+			"update t set c1 = 10 where pk = 1;",
+			"update t set c2 = 'bb' where pk = 2;",
+			"call dolt_commit('-am', 'second', '--date', '2024-02-01T00:00:00');",
+			"update t set c2 = 'aa' where pk = 2;",
+			"call dolt_commit('-am', 'third', '--date', '2024-03-01T00:00:00');",
+			"update t set c1 = 11, c2 = 'aaa' where pk = 1;",
+			"call dolt_commit('-am', 'fourth', '--date', '2024-04-01T00:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select table_name, message, diff_type, changed_columns, date_format(commit_date, '%Y-%m-%d') from dolt_row_history('t', 1);",
+				Expected: []sql.Row{
+					{"t", "fourth", "modified", "c1,c2", "2024-04-01"},
+					{"t", "second", "modified", "c1", "2024-02-01"},
+					{"t", "first", "added", "pk,c1,c2", "2024-01-01"},
+				},
+			},
+			{
+				Query: "select message, `row` from dolt_row_history('t', 1) where diff_type = 'modified' order by message;",
+				Expected: []sql.Row{
+					{"fourth", types.MustJSON(`{"pk": "1", "c1": "11", "c2": "aaa"}`)},
+					{"second", types.MustJSON(`{"pk": "1", "c1": "10", "c2": "a"}`)},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_row_history('t', 2);",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "row history across a table rename and a key change",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 1), (2, 2);",
+			"call dolt_commit('-Am', 'first');",
+			"rename table t to t2;",
+			"call dolt_commit('-Am', 'rename');",
+			"update t2 set c1 = 10 where pk = 1;",
+			"call dolt_commit('-am', 'update');",
+			"update t2 set pk = 100 where pk = 1;",
+			"call dolt_commit('-am', 'new key');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select table_name, message, diff_type, changed_columns from dolt_row_history('t2', 100);",
+				Expected: []sql.Row{
+					{"t2", "new key", "key_changed", "pk"},
+					{"t2", "update", "modified", "c1"},
+					{"t", "first", "added", "pk,c1"},
+				},
+			},
+			{
+				Query: "select table_name, message, diff_type from dolt_row_history('t2', 2);",
+				Expected: []sql.Row{
+					{"t", "first", "added"},
+				},
+			},
+		},
+	},
+	{
+		Name: "row history across key changes combined with value changes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(10), c3 varchar(10));",
+			"insert into t values (1, 1, 'a', 'x'), (2, 2, 'b', 'y'), (3, 3, 'c', 'z');",
+			"call dolt_commit('-Am', 'first');",
+			"update t set pk = 30, c1 = 33, c2 = 'cc' where pk = 3;",
+			"call dolt_commit('-am', 'only change');",
+			"update t set pk = 10, c1 = 11 where pk = 1;",
+			"delete from t where pk = 2;",
+			"insert into t values (20, 5, 'q', 'w');",
+			"call dolt_commit('-am', 'several changes');",
+			"alter table t rename column c1 to d1;",
+			"call dolt_commit('-am', 'rename column');",
+			"update t set d1 = 12 where pk = 10;",
+			"call dolt_commit('-am', 'update renamed column');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select message, diff_type, changed_columns from dolt_row_history('t', 30);",
+				Expected: []sql.Row{
+					{"only change", "key_changed", "pk,c1,c2"},
+					{"first", "added", "pk,c1,c2,c3"},
+				},
+			},
+			{
+				Query: "select message, diff_type, changed_columns from dolt_row_history('t', 10);",
+				Expected: []sql.Row{
+					{"update renamed column", "modified", "d1"},
+					{"several changes", "key_changed", "pk,c1"},
+					{"first", "added", "pk,c1,c2,c3"},
+				},
+			},
+			{
+				Query: "select message, diff_type from dolt_row_history('t', 20);",
+				Expected: []sql.Row{
+					{"several changes", "added"},
+				},
+			},
+		},
+	},
+	{
+		Name: "row history of a removed and re-added row",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'first');",
+			"delete from t where pk = 1;",
+			"call dolt_commit('-am', 'delete');",
+			"insert into t values (1, 2);",
+			"call dolt_commit('-am', 'insert');",
+			"alter table t add column c2 int;",
+			"call dolt_commit('-am', 'add column');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select message, diff_type, changed_columns, `row` is null from dolt_row_history('t', 1);",
+				Expected: []sql.Row{
+					{"add column", "modified", "c2", false},
+					{"insert", "added", "pk,c1", false},
+					{"delete", "removed", "pk,c1", true},
+					{"first", "added", "pk,c1", false},
+				},
+			},
+		},
+	},
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"create table keyless (c1 int);",
+			"call dolt_commit('-Am', 'first');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "select * from dolt_row_history('t');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_row_history('missing', 1);",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:          "select * from dolt_row_history('keyless', 1);",
+				ExpectedErrStr: "dolt_row_history requires a table with a primary key",
+			},
+			{
+				Query:          "select * from dolt_row_history('t', 1, 2);",
+				ExpectedErrStr: "table t has 1 primary key columns, but 2 values were given",
+			},
+			{
+				Query:    "select * from dolt_row_history('t', 5);",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+}
+
 var LogTableFunctionScriptTests = []queries.ScriptTest{
 	{
 		Name: "invalid arguments",
@@ -5396,6 +5781,104 @@ var LogTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_log filters by author, message, date, value and row operation",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(10));",
+			"create table u (id int primary key);",
+			"insert into t values (1, 1, 'a'), (2, 2, 'b');",
+			"call dolt_commit('-Am', 'first', '--date', '2024-01-01T00:00:00');",
+			"update t set c1 = 10 where pk = 1;",
+			"call dolt_commit('-am', 'bump c1', '--author', 'Bob <bob@example.com>', '--date', '2024-02-01T00:00:00');",
+			"update t set c2 = 'zz' where pk = 2;",
+			"insert into u values (7);",
+			"call dolt_commit('-am', 'set zz', '--date', '2024-03-01T00:00:00');",
+			"delete from t where pk = 2;",
+			"call dolt_commit('-am', 'remove two', '--date', '2024-04-01T00:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select message from dolt_log('--author', 'Bob');",
+				Expected: []sql.Row{{"bump c1"}},
+			},
+			{
+				Query:    "select message from dolt_log('--author', '<bob@example\\\\.com>$');",
+				Expected: []sql.Row{{"bump c1"}},
+			},
+			{
+				Query:    "select message from dolt_log('--grep', '^(set|remove)');",
+				Expected: []sql.Row{{"remove two"}, {"set zz"}},
+			},
+			{
+				Query:    "select message from dolt_log('--since', '2024-02-01', '--until', '2024-03-15');",
+				Expected: []sql.Row{{"set zz"}, {"bump c1"}},
+			},
+			{
+				Query:    "select message from dolt_log('-S', 'zz');",
+				Expected: []sql.Row{{"remove two"}, {"set zz"}},
+			},
+			{
+				Query:    "select message from dolt_log('-S', '10', '--tables', 't.c1');",
+				Expected: []sql.Row{{"bump c1"}},
+			},
+			{
+				Query:    "select message from dolt_log('-S', '10', '--tables', 't.c2');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select message from dolt_log('--diff-filter', 'D');",
+				Expected: []sql.Row{{"remove two"}},
+			},
+			{
+				Query:    "select message from dolt_log('--diff-filter=AM');",
+				Expected: []sql.Row{{"set zz"}, {"bump c1"}, {"first"}},
+			},
+			{
+				Query:    "select message from dolt_log('--diff-filter', 'A', '--tables', 'u');",
+				Expected: []sql.Row{{"set zz"}},
+			},
+			{
+				Query:    "select message from dolt_log('--tables', 't.c2');",
+				Expected: []sql.Row{{"remove two"}, {"set zz"}, {"first"}},
+			},
+			{
+				Query:    "select message from dolt_log('--tables', 't.c1,u');",
+				Expected: []sql.Row{{"remove two"}, {"set zz"}, {"bump c1"}, {"first"}},
+			},
+			{
+				Query:          "select * from dolt_log('--diff-filter', 'X');",
+				ExpectedErrStr: "Invalid argument to dolt_log: invalid --diff-filter option: X",
+			},
+			{
+				Query:          "select * from dolt_log('--grep', '(');",
+				ExpectedErrStr: "Invalid argument to dolt_log: invalid pattern for --grep: (",
+			},
+			{
+				Query:          "select * from dolt_log('--since', 'yesterday');",
+				ExpectedErrStr: "Invalid argument to dolt_log: invalid date for --since: yesterday",
+			},
+		},
+	},
+	{
+		Name: "dolt_log -S counts duplicate rows of keyless tables",
+		SetUpScript: []string{
+			"create table k (c1 varchar(10));",
+			"insert into k values ('x');",
+			"call dolt_commit('-Am', 'one x');",
+			"insert into k values ('x');",
+			"call dolt_commit('-am', 'two x');",
+			"insert into k values ('y');",
+			"call dolt_commit('-am', 'add y');",
+			"delete from k where c1 = 'x' limit 1;",
+			"call dolt_commit('-am', 'one x again');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select message from dolt_log('-S', 'x');",
+				Expected: []sql.Row{{"one x again"}, {"two x"}, {"one x"}},
+			},
+		},
+	},
 }
 
 var BranchStatusTableFunctionScriptTests = []queries.ScriptTest{
@@ -8534,11 +9017,14 @@ var DoltSystemVariables = []queries.ScriptTest{
 					{"dolt_diff_test"},
 					{"dolt_help"},
 					{"dolt_history_test"},
+					{"dolt_index_builds"},
 					{"dolt_log"},
 					{"dolt_remote_branches"},
 					{"dolt_remotes"},
 					{"dolt_stashes"},
 					{"dolt_status"},
+					{"dolt_storage_maintenance_log"},
+					{"dolt_table_storage"},
 					{"dolt_workspace_test"},
 					{"test"},
 				},
@@ -17,6 +17,7 @@ package enginetest
 import (
 	"github.com/dolthub/go-mysql-server/enginetest/queries"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
 )
 
 var BrokenSystemTableQueries = []queries.QueryTest{
@@ -103,3 +104,103 @@ var BackupsSystemTableQueries = queries.ScriptTest{
 		},
 	},
 }
+
+var TableStorageSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_table_storage table",
+	SetUpScript: []string{
+		"create table t (pk int primary key, c1 int, c2 varchar(20), index idx_c1 (c1));",
+		"insert into t values (1, 1, 'one'), (2, 2, 'two'), (3, 3, 'three');",
+		"call dolt_commit('-Am', 'create t');",
+		"create table u (pk int primary key);",
+	},
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			Query: "select table_name, index_name, tree_height, node_count from dolt_table_storage order by 1, 2;",
+			Expected: []sql.Row{
+				{"t", "", uint32(1), uint64(1)},
+				{"t", "idx_c1", uint32(1), uint64(1)},
+				{"u", "", uint32(1), uint64(1)},
+			},
+		},
+		{
+			// the working set has not changed t, so all of its chunks are shared with HEAD
+			Query:    "select count(*) from dolt_table_storage where table_name = 't' and shared_with_parent_bytes = unique_bytes;",
+			Expected: []sql.Row{{2}},
+		},
+		{
+			Query:    "select count(*) from dolt_table_storage where total_bytes < unique_bytes or average_fill <= 0;",
+			Expected: []sql.Row{{0}},
+		},
+		{
+			Query:    "insert into t values (4, 4, 'four');",
+			Expected: []sql.Row{{types.NewOkResult(1)}},
+		},
+		{
+			Query:    "select index_name from dolt_table_storage where table_name = 't' and shared_with_parent_bytes = 0 order by 1;",
+			Expected: []sql.Row{{""}, {"idx_c1"}},
+		},
+		{
+			Query:            "call dolt_commit('-am', 'add a row');",
+			SkipResultsCheck: true,
+		},
+		{
+			// at a commit, chunks are compared against the parent commit
+			Query:    "select index_name from dolt_table_storage as of 'HEAD' where table_name = 't' and shared_with_parent_bytes = 0 order by 1;",
+			Expected: []sql.Row{{""}, {"idx_c1"}},
+		},
+		{
+			Query:    "select table_name, index_name from dolt_table_storage as of 'HEAD~1' order by 1, 2;",
+			Expected: []sql.Row{{"t", ""}, {"t", "idx_c1"}},
+		},
+	},
+}
+
+var IndexBuildsSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_index_builds table without background threads",
+	SetUpScript: []string{
+		"create table t (pk int primary key, c1 int);",
+		"insert into t values (1, 1), (2, 2), (3, 3);",
+		"set @@dolt_online_index_builds = 1;",
+	},
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			// engines which don't run background threads build the index synchronously
+			Query:    "alter table t add index idx_c1 (c1);",
+			Expected: []sql.Row{{types.NewOkResult(0)}},
+		},
+		{
+			Query:    "select count(*) from information_schema.statistics where table_name = 't' and index_name = 'idx_c1';",
+			Expected: []sql.Row{{1}},
+		},
+		{
+			Query:    "select * from dolt_index_builds;",
+			Expected: []sql.Row{},
+		},
+		{
+			Query:          "call dolt_cancel_index_build(1);",
+			ExpectedErrStr: "index build not found",
+		},
+		{
+			Query:          "call dolt_cancel_index_build('abc');",
+			ExpectedErrStr: "invalid index build id: abc",
+		},
+	},
+}
+
+var StorageMaintenanceLogSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_storage_maintenance_log table without auto gc",
+	SetUpScript: []string{
+		"create table t (pk int primary key);",
+		"call dolt_commit('-Am', 'create t');",
+	},
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			Query:    "select * from dolt_storage_maintenance_log;",
+			Expected: []sql.Row{},
+		},
+		{
+			Query:    "select operation, reason, duration_ms, files_before, files_after, status from dolt_storage_maintenance_log where `database` = database();",
+			Expected: []sql.Row{},
+		},
+	},
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/indexbuild"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/prolly"
+)
+
+func TestOnlineIndexBuild(t *testing.T) {
+	setup := func(t *testing.T) (*sqle.Engine, *sql.Context, *indexbuild.Controller) {
+		ctx := context.Background()
+		dEnv := dtestutils.CreateTestEnv()
+		tmpDir, err := dEnv.TempTableFilesDir()
+		require.NoError(t, err)
+		opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+		db, err := NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+		require.NoError(t, err)
+
+		engine, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+		require.NoError(t, err)
+
+		sess := dsess.DSessFromSess(sqlCtx.Session)
+		pro := sess.Provider().(*DoltDatabaseProvider)
+		ctxF := func(ctx context.Context) (*sql.Context, error) {
+			config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
+			sqlCtx := NewTestSQLCtxWithProvider(ctx, pro, config, nil, sess.GCSafepointController())
+			sqlCtx.SetCurrentDatabase(db.Name())
+			return sqlCtx, nil
+		}
+
+		bThreads := sql.NewBackgroundThreads()
+		t.Cleanup(func() {
+			bThreads.Shutdown()
+		})
+		controller := pro.IndexBuildController()
+		require.NoError(t, controller.RunBackgroundThread(bThreads, ctxF))
+
+		var inserts []string
+		for i := 0; i < 100; i++ {
+			inserts = append(inserts, fmt.Sprintf("(%d, %d)", i, i*10))
+		}
+		runQuery(t, sqlCtx, engine, "create table t (pk int primary key, v int)")
+		runQuery(t, sqlCtx, engine, "insert into t values "+strings.Join(inserts, ", "))
+		runQuery(t, sqlCtx, engine, "set @@dolt_online_index_builds = 1")
+		return engine, sqlCtx, controller
+	}
+
+	waitForJob := func(t *testing.T, controller *indexbuild.Controller) indexbuild.JobStatus {
+		jobs := controller.Jobs()
+		require.Len(t, jobs, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		status, err := controller.Wait(ctx, jobs[0].ID)
+		require.NoError(t, err)
+		return status
+	}
+
+	t.Run("BuildsIndex", func(t *testing.T) {
+		engine, sqlCtx, controller := setup(t)
+		runQuery(t, sqlCtx, engine, "alter table t add index idx_v (v)")
+		require.Len(t, sqlCtx.Warnings(), 1)
+
+		status := waitForJob(t, controller)
+		require.Equal(t, indexbuild.StateCompleted, status.State, status.Error)
+		assert.Equal(t, "idx_v", status.IndexName)
+		assert.Equal(t, uint64(100), status.RowsTotal)
+		assert.Equal(t, uint64(100), status.RowsProcessed)
+
+		rows := runQuery(t, sqlCtx, engine, "select id, table_name, index_name, state from dolt_index_builds")
+		assert.Equal(t, []sql.Row{{status.ID, "t", "idx_v", "completed"}}, rows)
+		rows = runQuery(t, sqlCtx, engine, "select count(*) from t where v >= 500")
+		assert.Equal(t, []sql.Row{{int64(50)}}, rows)
+		assertIndexRows(t, sqlCtx, "t", "idx_v", 100)
+	})
+
+	t.Run("UniqueIndexIsSynchronous", func(t *testing.T) {
+		engine, sqlCtx, controller := setup(t)
+		runQuery(t, sqlCtx, engine, "alter table t add unique index idx_v (v)")
+		assert.Empty(t, controller.Jobs())
+		assertIndexRows(t, sqlCtx, "t", "idx_v", 100)
+	})
+
+	t.Run("InvalidDefinition", func(t *testing.T) {
+		engine, sqlCtx, controller := setup(t)
+		_, iter, _, err := engine.Query(sqlCtx, "alter table t add index idx_v (nope)")
+		if err == nil {
+			_, err = sql.RowIterToRows(sqlCtx, iter)
+		}
+		require.Error(t, err)
+		assert.Empty(t, controller.Jobs())
+	})
+
+	t.Run("CatchUp", func(t *testing.T) {
+		engine, sqlCtx, _ := setup(t)
+		snapshot := workingTable(t, sqlCtx, "t")
+		built, err := creation.CreateIndex(sqlCtx, snapshot, "t", "idx_v", []string{"v"}, nil, schema.IndexProperties{IsUserDefined: true}, editor.Options{})
+		require.NoError(t, err)
+
+		runQuery(t, sqlCtx, engine, "insert into t values (100, 1000), (101, 5)")
+		runQuery(t, sqlCtx, engine, "update t set v = v + 1 where pk < 10")
+		runQuery(t, sqlCtx, engine, "delete from t where pk >= 90 and pk < 100")
+		current := workingTable(t, sqlCtx, "t")
+
+		secondary, err := built.NewTable.GetIndexRowData(sqlCtx, "idx_v")
+		require.NoError(t, err)
+		from, err := snapshot.GetRowData(sqlCtx)
+		require.NoError(t, err)
+		to, err := current.GetRowData(sqlCtx)
+		require.NoError(t, err)
+		caughtUp, changed, err := creation.CatchUpSecondaryProllyIndex(sqlCtx, built.Sch, "t", built.NewIndex,
+			durable.MapFromIndex(secondary).(prolly.Map), durable.MapFromIndex(from).(prolly.Map), durable.MapFromIndex(to).(prolly.Map))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(22), changed)
+
+		current, err = current.UpdateSchema(sqlCtx, built.Sch)
+		require.NoError(t, err)
+		expected, err := creation.BuildSecondaryIndex(sqlCtx, current, built.NewIndex, "t", editor.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, durable.MapFromIndex(expected).HashOf(), caughtUp.HashOf())
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		engine, sqlCtx, controller := setup(t)
+		runQuery(t, sqlCtx, engine, "alter table t add index idx_v (v)")
+		jobs := controller.Jobs()
+		require.Len(t, jobs, 1)
+		err := controller.Cancel(jobs[0].ID)
+		if err != nil {
+			// the build may have beaten us to it
+			require.ErrorIs(t, err, indexbuild.ErrJobFinished)
+		}
+
+		status := waitForJob(t, controller)
+		if status.State == indexbuild.StateCancelled {
+			assertIndexRows(t, sqlCtx, "t", "idx_v", -1)
+		} else {
+			require.Equal(t, indexbuild.StateCompleted, status.State, status.Error)
+		}
+	})
+}
+
+func runQuery(t *testing.T, ctx *sql.Context, engine *sqle.Engine, query string) []sql.Row {
+	_, iter, _, err := engine.Query(ctx, query)
+	require.NoError(t, err)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(t, err)
+	return rows
+}
+
+func workingTable(t *testing.T, ctx *sql.Context, tblName string) *doltdb.Table {
+	sess := dsess.DSessFromSess(ctx.Session)
+	_, err := sess.StartTransaction(ctx, sql.ReadWrite)
+	require.NoError(t, err)
+	roots, ok := sess.GetRoots(ctx, ctx.GetCurrentDatabase())
+	require.True(t, ok)
+	tbl, ok, err := roots.Working.GetTable(ctx, doltdb.TableName{Name: tblName})
+	require.NoError(t, err)
+	require.True(t, ok)
+	return tbl
+}
+
+// assertIndexRows asserts that the index |idxName| of |tblName| in the working set has |count| rows, or that the
+// index does not exist when |count| is negative.
+func assertIndexRows(t *testing.T, ctx *sql.Context, tblName, idxName string, count int) {
+	tbl := workingTable(t, ctx, tblName)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	if count < 0 {
+		assert.False(t, sch.Indexes().Contains(idxName))
+		return
+	}
+	require.True(t, sch.Indexes().Contains(idxName))
+	idx, err := tbl.GetIndexRowData(ctx, idxName)
+	require.NoError(t, err)
+	c, err := idx.Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(count), c)
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexbuild
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+)
+
+// build runs |job| to completion in a new session.
+func (c *Controller) build(ctx context.Context, job *Job) error {
+	sqlCtx, err := c.ctxF(ctx)
+	if err != nil {
+		return err
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	for attempt := 0; attempt < maxBuildAttempts; attempt++ {
+		done, err := c.buildOnce(sqlCtx, sess, job)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		c.lgr.Infof("sqle/indexbuild: Schema of %s.%s changed during build of index %s, restarting", job.database, job.table, job.def.Name)
+	}
+	return fmt.Errorf("schema of table `%s` changed during each of %d attempts to build index `%s`", job.table.Name, maxBuildAttempts, job.def.Name)
+}
+
+// buildOnce builds the index of |job| from a snapshot of its table and
+// swaps it into the current version of the table. Returns false if the
+// table's schema changed after the snapshot was taken, in which case the
+// build must be restarted.
+func (c *Controller) buildOnce(ctx *sql.Context, sess *dsess.DoltSession, job *Job) (bool, error) {
+	snapshot, err := c.currentTable(ctx, sess, job)
+	if err != nil {
+		return false, err
+	}
+	// the snapshot is never written back, so this transaction is simply discarded
+	if err = sess.Rollback(ctx, ctx.GetTransaction()); err != nil {
+		return false, err
+	}
+
+	snapshotRows, err := snapshot.GetRowData(ctx)
+	if err != nil {
+		return false, err
+	}
+	total, err := snapshotRows.Count()
+	if err != nil {
+		return false, err
+	}
+	job.setProgress(0, total)
+	job.setState(StateBuilding)
+
+	def := job.def
+	built, err := creation.CreateIndex(ctx, snapshot, job.table.Name, def.Name, def.Columns, def.PrefixLengths, def.Props, editor.Options{
+		RowProgress: func(rows uint64) { job.setProgress(rows, total) },
+	})
+	if err != nil {
+		return false, err
+	}
+	job.setProgress(total, total)
+	if err = ctx.Err(); err != nil {
+		return false, err
+	}
+
+	job.setState(StateCatchingUp)
+	current, err := c.currentTable(ctx, sess, job)
+	if err != nil {
+		return false, err
+	}
+	ok, err := doltdb.SchemaHashesEqual(ctx, snapshot, current)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, sess.Rollback(ctx, ctx.GetTransaction())
+	}
+
+	secondaryIdx, err := built.NewTable.GetIndexRowData(ctx, built.NewIndex.Name())
+	if err != nil {
+		return false, err
+	}
+	secondary, err := durable.ProllyMapFromIndex(secondaryIdx)
+	if err != nil {
+		return false, err
+	}
+	from, err := durable.ProllyMapFromIndex(snapshotRows)
+	if err != nil {
+		return false, err
+	}
+	currentRows, err := current.GetRowData(ctx)
+	if err != nil {
+		return false, err
+	}
+	to, err := durable.ProllyMapFromIndex(currentRows)
+	if err != nil {
+		return false, err
+	}
+	secondary, changed, err := creation.CatchUpSecondaryProllyIndex(ctx, built.Sch, job.table.Name, built.NewIndex, secondary, from, to)
+	if err != nil {
+		return false, err
+	}
+	currentTotal, err := currentRows.Count()
+	if err != nil {
+		return false, err
+	}
+	job.setProgress(total+changed, currentTotal+changed)
+
+	if built.OldIndex != nil && !built.OldIndex.IsUserDefined() {
+		current, err = current.DeleteIndexRowData(ctx, built.OldIndex.Name())
+		if err != nil {
+			return false, err
+		}
+	}
+	current, err = current.UpdateSchema(ctx, built.Sch)
+	if err != nil {
+		return false, err
+	}
+	current, err = current.SetIndexRows(ctx, built.NewIndex.Name(), durable.IndexFromProllyMap(secondary))
+	if err != nil {
+		return false, err
+	}
+
+	roots, ok := sess.GetRoots(ctx, job.database)
+	if !ok {
+		return false, sql.ErrDatabaseNotFound.New(job.database)
+	}
+	root, err := roots.Working.PutTable(ctx, job.table, current)
+	if err != nil {
+		return false, err
+	}
+	if err = sess.SetWorkingRoot(ctx, job.database, root); err != nil {
+		return false, err
+	}
+	if err = ctx.Err(); err != nil {
+		return false, err
+	}
+	return true, sess.CommitTransaction(ctx, ctx.GetTransaction())
+}
+
+// currentTable starts a new transaction and returns the table of |job|
+// in the working set of that transaction.
+func (c *Controller) currentTable(ctx *sql.Context, sess *dsess.DoltSession, job *Job) (*doltdb.Table, error) {
+	if _, err := sess.StartTransaction(ctx, sql.ReadWrite); err != nil {
+		return nil, err
+	}
+	roots, ok := sess.GetRoots(ctx, job.database)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(job.database)
+	}
+	tbl, ok, err := roots.Working.GetTable(ctx, job.table)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+	return tbl, nil
+}
@@ -0,0 +1,413 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexbuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// Online index builds allow `ALTER TABLE ... ADD INDEX` to return before
+// the secondary index has been built. When the session variable
+// @@dolt_online_index_builds is enabled, the DDL statement validates the
+// index definition and submits a Job to the Controller instead of
+// building the index inside the DDL transaction.
+//
+// Each Job runs in its own background session and works as follows:
+//
+//  1. It snapshots the table from the branch's working set and builds
+//     the new secondary index against that snapshot. Writers are not
+//     blocked while this happens.
+//  2. It starts a new transaction and catches the index up by applying
+//     the diff between the snapshot's primary index and the current
+//     primary index.
+//  3. It swaps the index into the table's schema and commits the
+//     transaction. Writes which land between steps 2 and 3 are merged
+//     by the usual transaction commit logic.
+//
+// If the table's schema changes while the index is being built, the
+// build is restarted against a new snapshot. Jobs are visible in the
+// dolt_index_builds system table and can be cancelled with
+// dolt_cancel_index_build().
+
+// State is the lifecycle state of a Job.
+type State string
+
+const (
+	StatePending    State = "pending"
+	StateBuilding   State = "building"
+	StateCatchingUp State = "catching_up"
+	StateCompleted  State = "completed"
+	StateFailed     State = "failed"
+	StateCancelled  State = "cancelled"
+)
+
+// maxBuildAttempts is the number of times a Job restarts its build from
+// a new snapshot when the table's schema changes underneath it.
+const maxBuildAttempts = 3
+
+// maxFinishedJobs is the number of finished Jobs the Controller keeps
+// around so that their outcome can be inspected.
+const maxFinishedJobs = 64
+
+var ErrJobNotFound = errors.New("index build not found")
+var ErrJobFinished = errors.New("index build has already finished")
+var ErrControllerNotRunning = errors.New("online index builds are not running")
+
+// Definition describes the secondary index built by a Job.
+type Definition struct {
+	Name          string
+	Columns       []string
+	PrefixLengths []uint16
+	Props         schema.IndexProperties
+}
+
+// JobStatus is a point-in-time snapshot of a Job.
+type JobStatus struct {
+	ID            uint64
+	Database      string
+	Table         doltdb.TableName
+	IndexName     string
+	State         State
+	RowsTotal     uint64
+	RowsProcessed uint64
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	Error         string
+}
+
+// Job is a single background index build.
+type Job struct {
+	id       uint64
+	database string
+	table    doltdb.TableName
+	def      Definition
+
+	mu            sync.Mutex
+	state         State
+	rowsTotal     uint64
+	rowsProcessed uint64
+	startedAt     time.Time
+	finishedAt    time.Time
+	err           error
+	cancelled     bool
+	cancel        context.CancelFunc
+}
+
+func (j *Job) status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	st := JobStatus{
+		ID:            j.id,
+		Database:      j.database,
+		Table:         j.table,
+		IndexName:     j.def.Name,
+		State:         j.state,
+		RowsTotal:     j.rowsTotal,
+		RowsProcessed: j.rowsProcessed,
+	}
+	if !j.startedAt.IsZero() {
+		t := j.startedAt
+		st.StartedAt = &t
+	}
+	if !j.finishedAt.IsZero() {
+		t := j.finishedAt
+		st.FinishedAt = &t
+	}
+	if j.err != nil {
+		st.Error = j.err.Error()
+	}
+	return st
+}
+
+func (j *Job) finished() bool {
+	return j.state == StateCompleted || j.state == StateFailed || j.state == StateCancelled
+}
+
+func (j *Job) setState(state State) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state = state
+}
+
+func (j *Job) setProgress(processed, total uint64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.rowsProcessed, j.rowsTotal = processed, total
+}
+
+// start records that the job has begun running. Returns false if the
+// job was cancelled before it got a chance to start.
+func (j *Job) start(cancel context.CancelFunc) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.cancelled {
+		return false
+	}
+	j.cancel = cancel
+	j.startedAt = time.Now()
+	return true
+}
+
+func (j *Job) finish(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.finishedAt = time.Now()
+	j.cancel = nil
+	switch {
+	case j.cancelled:
+		j.state = StateCancelled
+	case err != nil:
+		j.state = StateFailed
+		j.err = err
+	default:
+		j.state = StateCompleted
+	}
+}
+
+// Controller runs and tracks the online index builds of a SQL engine.
+type Controller struct {
+	lgr     *logrus.Logger
+	ctxF    func(context.Context) (*sql.Context, error)
+	threads *sql.BackgroundThreads
+
+	mu     sync.Mutex
+	nextID uint64
+	jobs   map[uint64]*Job
+}
+
+func NewController(lgr *logrus.Logger) *Controller {
+	return &Controller{
+		lgr:  lgr,
+		jobs: make(map[uint64]*Job),
+	}
+}
+
+// During engine initialization, this should be called so that submitted
+// jobs have somewhere to run. |ctxF| is used to create the sessions in
+// which jobs run.
+func (c *Controller) RunBackgroundThread(threads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.threads = threads
+	c.ctxF = ctxF
+	return nil
+}
+
+// Running returns whether RunBackgroundThread has been called, and so
+// whether jobs can be submitted.
+func (c *Controller) Running() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.threads != nil
+}
+
+// Submit schedules a build of the index |def| on the table |tbl| in the
+// revision-qualified database |database|. Returns the id of the new Job.
+func (c *Controller) Submit(database string, tbl doltdb.TableName, def Definition) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.threads == nil {
+		return 0, ErrControllerNotRunning
+	}
+	for _, j := range c.jobs {
+		j.mu.Lock()
+		inFlight := !j.finished() && strings.EqualFold(j.database, database) &&
+			j.table.EqualFold(tbl) && strings.EqualFold(j.def.Name, def.Name)
+		j.mu.Unlock()
+		if inFlight {
+			return 0, fmt.Errorf("index `%s` on table `%s` is already being built", def.Name, tbl.Name)
+		}
+	}
+
+	c.nextID++
+	job := &Job{
+		id:       c.nextID,
+		database: database,
+		table:    tbl,
+		def:      def,
+		state:    StatePending,
+	}
+	c.jobs[job.id] = job
+	c.pruneFinishedJobs()
+
+	err := c.threads.Add("index_build["+strconv.FormatUint(job.id, 10)+"]", func(ctx context.Context) {
+		c.run(ctx, job)
+	})
+	if err != nil {
+		delete(c.jobs, job.id)
+		return 0, err
+	}
+	return job.id, nil
+}
+
+// Cancel stops the Job with the given |id|.
+func (c *Controller) Cancel(id uint64) error {
+	c.mu.Lock()
+	job, ok := c.jobs[id]
+	c.mu.Unlock()
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.finished() {
+		return ErrJobFinished
+	}
+	job.cancelled = true
+	if job.cancel != nil {
+		job.cancel()
+	}
+	return nil
+}
+
+// Jobs returns the status of every Job known to the Controller, ordered
+// by id.
+func (c *Controller) Jobs() []JobStatus {
+	c.mu.Lock()
+	jobs := make([]*Job, 0, len(c.jobs))
+	for _, j := range c.jobs {
+		jobs = append(jobs, j)
+	}
+	c.mu.Unlock()
+
+	ret := make([]JobStatus, len(jobs))
+	for i, j := range jobs {
+		ret[i] = j.status()
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret
+}
+
+// Wait blocks until the Job with the given |id| has finished and returns
+// its final status.
+func (c *Controller) Wait(ctx context.Context, id uint64) (JobStatus, error) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		c.mu.Lock()
+		job, ok := c.jobs[id]
+		c.mu.Unlock()
+		if !ok {
+			return JobStatus{}, ErrJobNotFound
+		}
+		if st := job.status(); st.State == StateCompleted || st.State == StateFailed || st.State == StateCancelled {
+			return st, nil
+		}
+		select {
+		case <-ctx.Done():
+			return JobStatus{}, context.Cause(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneFinishedJobs drops the oldest finished jobs once there are more
+// than maxFinishedJobs of them. Callers must hold |c.mu|.
+func (c *Controller) pruneFinishedJobs() {
+	var finished []uint64
+	for id, j := range c.jobs {
+		j.mu.Lock()
+		if j.finished() {
+			finished = append(finished, id)
+		}
+		j.mu.Unlock()
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i] < finished[j]
+	})
+	for _, id := range finished[:len(finished)-maxFinishedJobs] {
+		delete(c.jobs, id)
+	}
+}
+
+func (c *Controller) run(ctx context.Context, job *Job) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if !job.start(cancel) {
+		job.finish(nil)
+		return
+	}
+
+	c.lgr.Infof("sqle/indexbuild: Beginning build of index %s on %s.%s", job.def.Name, job.database, job.table)
+	start := time.Now()
+	err := c.build(ctx, job)
+	job.finish(err)
+
+	if st := job.status(); st.State == StateCompleted {
+		c.lgr.Infof("sqle/indexbuild: Successfully built index %s on %s.%s in %v", job.def.Name, job.database, job.table, time.Since(start))
+	} else if st.State == StateCancelled {
+		c.lgr.Infof("sqle/indexbuild: Build of index %s on %s.%s was cancelled", job.def.Name, job.database, job.table)
+	} else {
+		c.lgr.Warnf("sqle/indexbuild: Build of index %s on %s.%s failed: %v", job.def.Name, job.database, job.table, err)
+	}
+}
+
+// ControllerProvider is implemented by database providers which run
+// online index builds.
+type ControllerProvider interface {
+	IndexBuildController() *Controller
+}
+
+// GetController returns the Controller for the session of |ctx|, or nil
+// if online index builds are not available.
+func GetController(ctx *sql.Context) *Controller {
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok {
+		return nil
+	}
+	cp, ok := sess.Provider().(ControllerProvider)
+	if !ok {
+		return nil
+	}
+	return cp.IndexBuildController()
+}
+
+// Enabled returns whether the session of |ctx| has requested that new
+// indexes be built online.
+func Enabled(ctx *sql.Context) (bool, error) {
+	v, err := ctx.GetSessionVariable(ctx, dsess.DoltOnlineIndexBuilds)
+	if err != nil {
+		return false, err
+	}
+	return v.(int8) == 1, nil
+}
+
+// SupportsOnlineBuild returns whether an index with the properties
+// |props| can be built by a Job. Unique indexes need to be validated
+// against concurrent writes, and full-text, spatial and vector indexes
+// have their own build paths, so they are always built synchronously.
+func SupportsOnlineBuild(props schema.IndexProperties) bool {
+	return !props.IsUnique && !props.IsFullText && !props.IsSpatial && !props.IsVector
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// lsnTable is the table of the target database which stores the end LSN of
+// the last Postgres transaction applied from each replication slot. It is
+// written in the same SQL transaction as the changes themselves, so that a
+// crash can never leave a transaction applied without its LSN recorded, or
+// the other way around.
+const lsnTable = "pg_replication_lsn"
+
+const createLSNTable = "CREATE TABLE IF NOT EXISTS " + lsnTable + " (slot varchar(64) NOT NULL PRIMARY KEY, lsn varchar(32) NOT NULL)"
+
+// loadAppliedLSN loads the end LSN of the last Postgres transaction applied
+// from the subscriber's slot to the current database, creating lsnTable if
+// it does not exist. Returns zero if no transaction has been applied.
+func (s *Subscriber) loadAppliedLSN(ctx *sql.Context) (lsn, error) {
+	if err := s.exec(ctx, createLSNTable, nil); err != nil {
+		return 0, err
+	}
+	rows, err := s.query(ctx, "SELECT lsn FROM "+lsnTable+" WHERE slot = ?", []sqlparser.Expr{
+		sqlparser.NewStrVal([]byte(s.cfg.Slot())),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	str, ok := rows[0][0].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected postgres replication position %v in %s", rows[0][0], lsnTable)
+	}
+	return parseLSN(str)
+}
+
+// saveAppliedLSN records |l| as the end LSN of the last Postgres transaction
+// applied from the subscriber's slot. It must be called in the SQL
+// transaction applying that Postgres transaction. Transactions the server
+// streams again after a restart which end at or before it are skipped.
+func (s *Subscriber) saveAppliedLSN(ctx *sql.Context, l lsn) error {
+	return s.exec(ctx, "REPLACE INTO "+lsnTable+" (slot, lsn) VALUES (?, ?)", []sqlparser.Expr{
+		sqlparser.NewStrVal([]byte(s.cfg.Slot())),
+		sqlparser.NewStrVal([]byte(l.String())),
+	})
+}
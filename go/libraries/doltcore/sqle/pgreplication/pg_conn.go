@@ -0,0 +1,564 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq/scram"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+// The frontend/backend protocol version 3.0.
+const protocolVersion = 196608
+
+// The code of an SSLRequest, which asks the server to secure the connection
+// with SSL before it starts up.
+const sslRequestCode = 80877103
+
+// The SQLSTATE Postgres returns when a replication slot being created already exists.
+const sqlStateDuplicateObject = "42710"
+
+// pgError is an ErrorResponse from the Postgres server.
+type pgError struct {
+	severity string
+	code     string
+	message  string
+}
+
+func (e *pgError) Error() string {
+	return fmt.Sprintf("postgres: %s: %s (SQLSTATE %s)", e.severity, e.message, e.code)
+}
+
+// pgConn is a connection to a Postgres server in logical replication mode. It
+// speaks just enough of the frontend/backend protocol to authenticate, run
+// replication commands, and stream the copy data START_REPLICATION produces.
+//
+// Once streaming, one goroutine may read messages while another sends standby
+// status updates.
+type pgConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	wmu  sync.Mutex
+	// Whether the connection is secured with SSL.
+	tls bool
+}
+
+// connectReplication dials the Postgres server at |addr| and starts a logical
+// replication session for |user| on |database|. The connection is secured
+// according to |sslMode|, one of the servercfg.PostgresSSLMode constants,
+// using |tlsConfig|, which replicationTLSConfig returns.
+func connectReplication(ctx context.Context, addr, sslMode string, tlsConfig *tls.Config, user, password, database string) (*pgConn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	secured := false
+	if sslMode != servercfg.PostgresSSLModeDisable {
+		conn, secured, err = negotiateTLS(ctx, conn, sslMode, tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	c := &pgConn{conn: conn, r: bufio.NewReader(conn), tls: secured}
+	if err := c.startup(user, password, database); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return c, nil
+}
+
+// negotiateTLS sends an SSLRequest on |conn| and, if the server accepts it,
+// returns the connection secured with |tlsConfig|. A server which refuses is
+// only accepted in the prefer SSL mode, in which case |conn| is returned as
+// is.
+func negotiateTLS(ctx context.Context, conn net.Conn, sslMode string, tlsConfig *tls.Config) (net.Conn, bool, error) {
+	msg := binary.BigEndian.AppendUint32(nil, 8)
+	msg = binary.BigEndian.AppendUint32(msg, sslRequestCode)
+	if _, err := conn.Write(msg); err != nil {
+		return conn, false, err
+	}
+	// The response is read straight from the connection, since anything the
+	// server sends after it before the handshake must not be trusted.
+	var resp [1]byte
+	if _, err := io.ReadFull(conn, resp[:]); err != nil {
+		return conn, false, err
+	}
+	switch resp[0] {
+	case 'S':
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return conn, false, fmt.Errorf("postgres: SSL handshake failed: %w", err)
+		}
+		return tlsConn, true, nil
+	case 'N':
+		if sslMode == servercfg.PostgresSSLModePrefer {
+			return conn, false, nil
+		}
+		return conn, false, fmt.Errorf("postgres: the server does not support SSL, which ssl_mode %s requires", sslMode)
+	default:
+		return conn, false, fmt.Errorf("postgres: unexpected response %q to SSLRequest", resp[0])
+	}
+}
+
+// replicationTLSConfig returns the TLS configuration of a connection to the
+// Postgres server |host| in |sslMode|. Certificates are only verified in the
+// verify-ca and verify-full modes, against the certificate authorities in
+// |rootCertFile|, or the system's if it is empty. Only verify-full checks the
+// certificate is for |host|.
+func replicationTLSConfig(sslMode, host, rootCertFile string) (*tls.Config, error) {
+	switch sslMode {
+	case servercfg.PostgresSSLModeDisable:
+		return nil, nil
+	case servercfg.PostgresSSLModePrefer, servercfg.PostgresSSLModeRequire:
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	roots, err := x509.SystemCertPool()
+	if rootCertFile != "" {
+		var pem []byte
+		pem, err = os.ReadFile(rootCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ssl_root_cert: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ssl_root_cert %s has no PEM certificates", rootCertFile)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("could not load the system's certificate authorities: %w", err)
+	}
+
+	if sslMode == servercfg.PostgresSSLModeVerifyFull {
+		return &tls.Config{RootCAs: roots, ServerName: host}, nil
+	}
+	// verify-ca checks the certificate chain, but not the host name, which
+	// the standard verification cannot skip.
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("postgres: the server sent no certificate")
+			}
+			opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}, nil
+}
+
+func (c *pgConn) Close() error {
+	c.send('X', nil)
+	return c.conn.Close()
+}
+
+func (c *pgConn) startup(user, password, database string) error {
+	var buf []byte
+	buf = binary.BigEndian.AppendUint32(buf, protocolVersion)
+	for _, kv := range [][2]string{
+		{"user", user},
+		{"database", database},
+		{"replication", "database"},
+		{"application_name", "dolt"},
+	} {
+		buf = appendCString(buf, kv[0])
+		buf = appendCString(buf, kv[1])
+	}
+	buf = append(buf, 0)
+	msg := binary.BigEndian.AppendUint32(nil, uint32(len(buf)+4))
+	if err := c.write(append(msg, buf...)); err != nil {
+		return err
+	}
+
+	var sc *scram.Client
+	for {
+		typ, body, err := c.receive()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case 'R':
+			r := &reader{buf: body}
+			code := r.uint32()
+			switch code {
+			case 0:
+				// AuthenticationOk
+			case 3:
+				// AuthenticationCleartextPassword
+				if !c.tls {
+					return errors.New("postgres: the server asked for a cleartext password on a connection without SSL; configure md5 or scram-sha-256 authentication for the user, or enable SSL with ssl_mode")
+				}
+				if err := c.send('p', appendCString(nil, password)); err != nil {
+					return err
+				}
+			case 5:
+				// AuthenticationMD5Password
+				salt := r.bytes(4)
+				inner := md5Hex([]byte(password + user))
+				if err := c.send('p', appendCString(nil, "md5"+md5Hex(append([]byte(inner), salt...)))); err != nil {
+					return err
+				}
+			case 10:
+				// AuthenticationSASL
+				var mechanisms []string
+				for r.remaining() > 1 {
+					mechanisms = append(mechanisms, r.cstring())
+				}
+				if !containsString(mechanisms, "SCRAM-SHA-256") {
+					return fmt.Errorf("postgres: unsupported SASL mechanisms: %s", strings.Join(mechanisms, ", "))
+				}
+				sc = scram.NewClient(sha256.New, user, password)
+				sc.Step(nil)
+				if sc.Err() != nil {
+					return sc.Err()
+				}
+				out := sc.Out()
+				msg := appendCString(nil, "SCRAM-SHA-256")
+				msg = binary.BigEndian.AppendUint32(msg, uint32(len(out)))
+				if err := c.send('p', append(msg, out...)); err != nil {
+					return err
+				}
+			case 11, 12:
+				// AuthenticationSASLContinue, AuthenticationSASLFinal
+				if sc == nil {
+					return errors.New("postgres: unexpected SASL message")
+				}
+				sc.Step(r.rest())
+				if sc.Err() != nil {
+					return sc.Err()
+				}
+				if code == 11 {
+					if err := c.send('p', sc.Out()); err != nil {
+						return err
+					}
+				}
+			default:
+				return fmt.Errorf("postgres: unsupported authentication method %d", code)
+			}
+		case 'E':
+			return parseError(body)
+		case 'Z':
+			return nil
+		}
+		// ParameterStatus, BackendKeyData and NoticeResponse are ignored.
+	}
+}
+
+// simpleQuery runs |query| and returns the text values of its result rows.
+func (c *pgConn) simpleQuery(query string) ([][]*string, error) {
+	if err := c.send('Q', appendCString(nil, query)); err != nil {
+		return nil, err
+	}
+	var rows [][]*string
+	var qErr error
+	for {
+		typ, body, err := c.receive()
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case 'D':
+			r := &reader{buf: body}
+			n := int(r.uint16())
+			row := make([]*string, n)
+			for i := range row {
+				l := int32(r.uint32())
+				if l >= 0 {
+					v := string(r.bytes(int(l)))
+					row[i] = &v
+				}
+			}
+			rows = append(rows, row)
+		case 'E':
+			qErr = parseError(body)
+		case 'Z':
+			return rows, qErr
+		}
+	}
+}
+
+// startReplication starts streaming the changes |publication| publishes from
+// the logical replication slot |slot|, beginning after |start|. The server
+// then sends its changes as copy data, read with receiveCopyData.
+func (c *pgConn) startReplication(slot, publication string, start lsn) error {
+	query := fmt.Sprintf("START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names %s)",
+		quoteIdentifier(slot), start, quoteLiteral(quoteIdentifier(publication)))
+	if err := c.send('Q', appendCString(nil, query)); err != nil {
+		return err
+	}
+	for {
+		typ, body, err := c.receive()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case 'W':
+			// CopyBothResponse
+			return nil
+		case 'E':
+			return parseError(body)
+		}
+	}
+}
+
+// createSlot creates the logical replication slot |slot| using the pgoutput
+// plugin. It is not an error for the slot to already exist.
+func (c *pgConn) createSlot(slot string) error {
+	_, err := c.simpleQuery(fmt.Sprintf("CREATE_REPLICATION_SLOT %s LOGICAL pgoutput NOEXPORT_SNAPSHOT", quoteIdentifier(slot)))
+	var pgErr *pgError
+	if errors.As(err, &pgErr) && pgErr.code == sqlStateDuplicateObject {
+		return nil
+	}
+	return err
+}
+
+// receiveCopyData returns the payload of the next CopyData message from the
+// server. It returns io.EOF when the server ends the copy.
+func (c *pgConn) receiveCopyData() ([]byte, error) {
+	for {
+		typ, body, err := c.receive()
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case 'd':
+			return body, nil
+		case 'c':
+			return nil, io.EOF
+		case 'E':
+			return nil, parseError(body)
+		}
+	}
+}
+
+// sendStandbyStatus tells the server that every change up to |flushed| has
+// been durably applied, so that the slot can release the WAL before it.
+func (c *pgConn) sendStandbyStatus(flushed lsn) error {
+	msg := []byte{'r'}
+	for i := 0; i < 3; i++ {
+		msg = binary.BigEndian.AppendUint64(msg, uint64(flushed))
+	}
+	msg = binary.BigEndian.AppendUint64(msg, uint64(toPgTime(time.Now())))
+	msg = append(msg, 0)
+	return c.send('d', msg)
+}
+
+func (c *pgConn) send(typ byte, body []byte) error {
+	msg := make([]byte, 0, len(body)+5)
+	msg = append(msg, typ)
+	msg = binary.BigEndian.AppendUint32(msg, uint32(len(body)+4))
+	return c.write(append(msg, body...))
+}
+
+func (c *pgConn) write(msg []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := c.conn.Write(msg)
+	return err
+}
+
+func (c *pgConn) receive() (byte, []byte, error) {
+	var hdr [5]byte
+	if _, err := io.ReadFull(c.r, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+	l := binary.BigEndian.Uint32(hdr[1:])
+	if l < 4 {
+		return 0, nil, fmt.Errorf("postgres: invalid message length %d", l)
+	}
+	body := make([]byte, l-4)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return 0, nil, err
+	}
+	return hdr[0], body, nil
+}
+
+func parseError(body []byte) error {
+	e := &pgError{}
+	r := &reader{buf: body}
+	for r.remaining() > 0 {
+		field := r.byte()
+		if field == 0 {
+			break
+		}
+		v := r.cstring()
+		switch field {
+		case 'S':
+			e.severity = v
+		case 'C':
+			e.code = v
+		case 'M':
+			e.message = v
+		}
+	}
+	return e
+}
+
+func appendCString(buf []byte, s string) []byte {
+	return append(append(buf, s...), 0)
+}
+
+func md5Hex(b []byte) string {
+	sum := md5.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// lsn is a position in the Postgres write-ahead log.
+type lsn uint64
+
+func (l lsn) String() string {
+	return fmt.Sprintf("%X/%X", uint32(l>>32), uint32(l))
+}
+
+func parseLSN(s string) (lsn, error) {
+	hi, lo, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid LSN %q", s)
+	}
+	h, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", s, err)
+	}
+	l, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", s, err)
+	}
+	return lsn(h<<32 | l), nil
+}
+
+// Postgres timestamps on the wire are microseconds since 2000-01-01 UTC.
+var pgEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func toPgTime(t time.Time) int64 {
+	return t.Sub(pgEpoch).Microseconds()
+}
+
+func fromPgTime(micros int64) time.Time {
+	return pgEpoch.Add(time.Duration(micros) * time.Microsecond)
+}
+
+// reader decodes the big-endian fields of a protocol message. Reading past
+// the end of the message sets |err| and returns zero values.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) remaining() int {
+	return len(r.buf)
+}
+
+func (r *reader) bytes(n int) []byte {
+	if n < 0 || n > len(r.buf) {
+		r.err = io.ErrUnexpectedEOF
+		r.buf = nil
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) rest() []byte {
+	return r.bytes(len(r.buf))
+}
+
+func (r *reader) byte() byte {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *reader) uint16() uint16 {
+	b := r.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (r *reader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *reader) uint64() uint64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *reader) cstring() string {
+	for i, b := range r.buf {
+		if b == 0 {
+			s := string(r.buf[:i])
+			r.buf = r.buf[i+1:]
+			return s
+		}
+	}
+	r.err = io.ErrUnexpectedEOF
+	r.buf = nil
+	return ""
+}
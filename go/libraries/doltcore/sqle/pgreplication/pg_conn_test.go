@@ -0,0 +1,158 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+// newTestCertificate returns a self-signed certificate for |host|, and the
+// path of a PEM file containing it.
+func newTestCertificate(t *testing.T, host string) (tls.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "root.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, path
+}
+
+func TestConnectReplicationSSL(t *testing.T) {
+	cert, rootCert := newTestCertificate(t, "127.0.0.1")
+	_, otherRootCert := newTestCertificate(t, "127.0.0.1")
+	serverTLS := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	tests := []struct {
+		name      string
+		serverTLS bool
+		sslMode   string
+		host      string
+		rootCert  string
+		secured   bool
+		err       bool
+	}{
+		{name: "disabled", serverTLS: true, sslMode: servercfg.PostgresSSLModeDisable},
+		{name: "preferred", serverTLS: true, sslMode: servercfg.PostgresSSLModePrefer, secured: true},
+		{name: "preferred without server support", sslMode: servercfg.PostgresSSLModePrefer},
+		{name: "required", serverTLS: true, sslMode: servercfg.PostgresSSLModeRequire, secured: true},
+		{name: "required without server support", sslMode: servercfg.PostgresSSLModeRequire, err: true},
+		{name: "verify-ca", serverTLS: true, sslMode: servercfg.PostgresSSLModeVerifyCA, rootCert: rootCert, secured: true},
+		{name: "verify-ca with another host's certificate", serverTLS: true, sslMode: servercfg.PostgresSSLModeVerifyCA, host: "pg.example.com", rootCert: rootCert, secured: true},
+		{name: "verify-ca with an untrusted certificate", serverTLS: true, sslMode: servercfg.PostgresSSLModeVerifyCA, rootCert: otherRootCert, err: true},
+		{name: "verify-full", serverTLS: true, sslMode: servercfg.PostgresSSLModeVerifyFull, rootCert: rootCert, secured: true},
+		{name: "verify-full with an untrusted certificate", serverTLS: true, sslMode: servercfg.PostgresSSLModeVerifyFull, rootCert: otherRootCert, err: true},
+		{name: "verify-full with another host's certificate", serverTLS: true, sslMode: servercfg.PostgresSSLModeVerifyFull, host: "pg.example.com", rootCert: rootCert, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := newFakePostgres(t)
+			defer pg.Close()
+			if tt.serverTLS {
+				pg.tlsConfig = serverTLS
+			}
+			host := tt.host
+			if host == "" {
+				host = "127.0.0.1"
+			}
+			tlsConfig, err := replicationTLSConfig(tt.sslMode, host, tt.rootCert)
+			require.NoError(t, err)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			conn, err := connectReplication(ctx, pg.ln.Addr().String(), tt.sslMode, tlsConfig, "replicator", "secret", "app")
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer conn.Close()
+			assert.Equal(t, tt.secured, conn.tls)
+			pg.mu.Lock()
+			defer pg.mu.Unlock()
+			assert.Equal(t, tt.secured, pg.secured)
+		})
+	}
+}
+
+func TestConnectReplicationCleartextPassword(t *testing.T) {
+	cert, _ := newTestCertificate(t, "127.0.0.1")
+	pg := newFakePostgres(t)
+	defer pg.Close()
+	pg.cleartext = true
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The password is not sent in the clear.
+	_, err := connectReplication(ctx, pg.ln.Addr().String(), servercfg.PostgresSSLModePrefer, &tls.Config{InsecureSkipVerify: true}, "replicator", "secret", "app")
+	require.ErrorContains(t, err, "cleartext password")
+	pg.mu.Lock()
+	assert.Empty(t, pg.password)
+	pg.mu.Unlock()
+
+	pg.tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	conn, err := connectReplication(ctx, pg.ln.Addr().String(), servercfg.PostgresSSLModeRequire, &tls.Config{InsecureSkipVerify: true}, "replicator", "secret", "app")
+	require.NoError(t, err)
+	defer conn.Close()
+	pg.mu.Lock()
+	assert.Equal(t, "secret", pg.password)
+	pg.mu.Unlock()
+}
+
+func TestReplicationTLSConfig(t *testing.T) {
+	cfg, err := replicationTLSConfig(servercfg.PostgresSSLModeDisable, "pg.example.com", "")
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	_, err = replicationTLSConfig(servercfg.PostgresSSLModeVerifyFull, "pg.example.com", filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "root.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+	_, err = replicationTLSConfig(servercfg.PostgresSSLModeVerifyCA, "pg.example.com", notPEM)
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/lib/pq/oid"
+
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// errUnsupportedValue is returned for a value which the Dolt column created
+// for it cannot store. Replication stops rather than retrying, since the
+// server would stream the same value again.
+var errUnsupportedValue = errors.New("unsupported value")
+
+// The longest value of a key column whose Postgres type is unbounded, such as
+// text, in characters, or in bytes for bytea. A Dolt primary key cannot
+// include a text or blob column, so the values are stored in a varchar or
+// varbinary column of this length instead.
+const maxKeyLength = 767
+
+// The type of key columns whose Postgres type is unbounded, or whose length
+// is longer than maxKeyLength.
+var (
+	unboundedKeyStringType = fmt.Sprintf("varchar(%d)", maxKeyLength)
+	unboundedKeyBinaryType = fmt.Sprintf("varbinary(%d)", maxKeyLength)
+)
+
+// The number of bytes a row takes for a text, blob or json column, which Dolt
+// stores out of the row, and for other columns which are not strings. These
+// follow schema.MaxRowStorageSize.
+const (
+	outOfRowBytes   = 20
+	decimalRowBytes = 30
+	otherRowBytes   = 16
+)
+
+// columnTypes returns the types of the Dolt columns created for the columns
+// of |rel|. Dolt stores varchar and char columns in the row, and cannot create
+// a table whose row could take more than val.MaxTupleDataSize bytes, so when
+// the columns would, the longest ones which are not part of the key are stored
+// as longtext instead.
+func columnTypes(rel relationMessage) []string {
+	types := make([]string, len(rel.columns))
+	var size int64
+	for i, col := range rel.columns {
+		types[i] = columnType(col)
+		size += rowBytes(types[i])
+	}
+	for size > int64(val.MaxTupleDataSize) {
+		longest := -1
+		for i, col := range rel.columns {
+			if _, inRow := inRowStringBytes(types[i]); inRow && !col.key && (longest < 0 || rowBytes(types[i]) > rowBytes(types[longest])) {
+				longest = i
+			}
+		}
+		if longest < 0 {
+			// Only the key is left, which Dolt will refuse to create.
+			break
+		}
+		size -= rowBytes(types[longest]) - outOfRowBytes
+		types[longest] = "longtext"
+	}
+	return types
+}
+
+// rowBytes returns the most bytes a value of the Dolt type |typ| takes in a
+// row.
+func rowBytes(typ string) int64 {
+	if n, ok := inRowStringBytes(typ); ok {
+		return n
+	}
+	switch {
+	case typ == "longtext" || typ == "longblob" || typ == "json":
+		return outOfRowBytes
+	case strings.HasPrefix(typ, "decimal"):
+		return decimalRowBytes
+	}
+	return otherRowBytes
+}
+
+// inRowStringBytes returns the most bytes a value of the Dolt string type
+// |typ| takes in a row, or false if |typ| is not a string type stored in the
+// row. Strings take up to four bytes a character.
+func inRowStringBytes(typ string) (int64, bool) {
+	for _, t := range []struct {
+		prefix       string
+		bytesPerChar int64
+	}{{"varchar(", 4}, {"char(", 4}, {"varbinary(", 1}} {
+		if strings.HasPrefix(typ, t.prefix) && strings.HasSuffix(typ, ")") {
+			n, err := strconv.ParseInt(typ[len(t.prefix):len(typ)-1], 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			return n * t.bytesPerChar, true
+		}
+	}
+	return 0, false
+}
+
+// columnType returns the type of the Dolt column created for the Postgres
+// column |col|. Types without a close equivalent are stored as their text
+// representation.
+func columnType(col relationColumn) string {
+	switch oid.Oid(col.typeOID) {
+	case oid.T_bool:
+		return "boolean"
+	case oid.T_int2:
+		return "smallint"
+	case oid.T_int4:
+		return "int"
+	case oid.T_int8:
+		return "bigint"
+	case oid.T_oid:
+		return "int unsigned"
+	case oid.T_float4:
+		return "float"
+	case oid.T_float8:
+		return "double"
+	case oid.T_numeric:
+		// The typmod of numeric(p, s) is ((p << 16) | s) + 4.
+		if col.typeMod >= 4 {
+			precision := ((col.typeMod - 4) >> 16) & 0xffff
+			scale := (col.typeMod - 4) & 0xffff
+			if precision <= 65 && scale <= 30 {
+				return fmt.Sprintf("decimal(%d,%d)", precision, scale)
+			}
+		}
+		return "decimal(65,30)"
+	case oid.T_varchar:
+		// The typmod of varchar(n) and char(n) is n + 4.
+		if col.typeMod >= 4 && (!col.key || col.typeMod-4 <= maxKeyLength) {
+			return fmt.Sprintf("varchar(%d)", col.typeMod-4)
+		}
+	case oid.T_bpchar:
+		if col.typeMod >= 4 && col.typeMod-4 <= 255 {
+			return fmt.Sprintf("char(%d)", col.typeMod-4)
+		}
+	case oid.T_char:
+		return "char(1)"
+	case oid.T_name:
+		return "varchar(64)"
+	case oid.T_uuid:
+		return "char(36)"
+	case oid.T_date:
+		return "date"
+	case oid.T_time:
+		return "time(6)"
+	case oid.T_timestamp, oid.T_timestamptz:
+		// timestamptz values are stored in UTC.
+		return "datetime(6)"
+	case oid.T_json, oid.T_jsonb:
+		return "json"
+	case oid.T_bytea:
+		if col.key {
+			return unboundedKeyBinaryType
+		}
+		return "longblob"
+	}
+	if col.key {
+		return unboundedKeyStringType
+	}
+	return "longtext"
+}
+
+// bindColumnValue returns the binding for the value |v| of |col|, as
+// bindValue does, checking that the value fits in the column if it is a key
+// column whose length was limited to maxKeyLength.
+func bindColumnValue(col relationColumn, v tupleValue) (sqlparser.Expr, error) {
+	if col.key && v.kind == tupleValueText {
+		switch columnType(col) {
+		case unboundedKeyStringType:
+			if n := utf8.RuneCount(v.text); n > maxKeyLength {
+				return nil, fmt.Errorf("%w: the key value is %d characters long, but key columns of this type are limited to %d", errUnsupportedValue, n, maxKeyLength)
+			}
+		case unboundedKeyBinaryType:
+			if n := (len(v.text) - 2) / 2; n > maxKeyLength {
+				return nil, fmt.Errorf("%w: the key value is %d bytes long, but key columns of this type are limited to %d", errUnsupportedValue, n, maxKeyLength)
+			}
+		}
+	}
+	return bindValue(col.typeOID, v)
+}
+
+// bindValue returns the binding for the value |v| of a Postgres column of
+// type |typeOID|, converted from its text representation to one the Dolt
+// column created for it by columnType accepts.
+func bindValue(typeOID uint32, v tupleValue) (sqlparser.Expr, error) {
+	if v.kind == tupleValueNull {
+		return &sqlparser.NullVal{}, nil
+	}
+	text := string(v.text)
+	switch oid.Oid(typeOID) {
+	case oid.T_numeric, oid.T_float4, oid.T_float8:
+		// Dolt's decimal and floating point columns have no special values.
+		switch text {
+		case "NaN", "Infinity", "-Infinity":
+			return nil, fmt.Errorf("%w: %s cannot be stored in a decimal or floating point column", errUnsupportedValue, text)
+		}
+	case oid.T_date, oid.T_timestamp:
+		if err := checkDateTime(text); err != nil {
+			return nil, err
+		}
+	case oid.T_bool:
+		switch text {
+		case "t":
+			return sqlparser.NewIntVal([]byte("1")), nil
+		case "f":
+			return sqlparser.NewIntVal([]byte("0")), nil
+		}
+		return nil, fmt.Errorf("invalid boolean value %q", text)
+	case oid.T_bytea:
+		// bytea is sent in its hex format, \x followed by two hex digits per byte.
+		if !strings.HasPrefix(text, `\x`) {
+			return nil, fmt.Errorf("unsupported bytea format %q; set bytea_output to hex", text)
+		}
+		b, err := hex.DecodeString(text[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytea value: %w", err)
+		}
+		return sqlparser.NewStrVal(b), nil
+	case oid.T_timestamptz:
+		if err := checkDateTime(text); err != nil {
+			return nil, err
+		}
+		t, err := parseTimestamptz(text)
+		if err != nil {
+			return nil, err
+		}
+		return sqlparser.NewStrVal([]byte(t.UTC().Format("2006-01-02 15:04:05.999999"))), nil
+	}
+	return sqlparser.NewStrVal(v.text), nil
+}
+
+// checkDateTime returns an error for the date and time values a Dolt date or
+// datetime column cannot store, which are the infinite ones and those BC.
+func checkDateTime(text string) error {
+	if text == "infinity" || text == "-infinity" || strings.HasSuffix(text, " BC") {
+		return fmt.Errorf("%w: %s cannot be stored in a date or datetime column", errUnsupportedValue, text)
+	}
+	return nil
+}
+
+// The layouts of timestamptz values in the ISO DateStyle, which is the one
+// walsender uses. The UTC offset has as many fields as it needs.
+var timestamptzLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+}
+
+func parseTimestamptz(text string) (time.Time, error) {
+	for _, layout := range timestamptzLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamptz value %q", text)
+}
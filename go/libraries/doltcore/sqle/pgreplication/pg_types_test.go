@@ -0,0 +1,164 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindValue(t *testing.T) {
+	tests := []struct {
+		typ      oid.Oid
+		text     string
+		expected sqlparser.Expr
+	}{
+		{oid.T_bool, "t", sqlparser.NewIntVal([]byte("1"))},
+		{oid.T_bool, "f", sqlparser.NewIntVal([]byte("0"))},
+		{oid.T_int4, "-42", sqlparser.NewStrVal([]byte("-42"))},
+		{oid.T_bytea, `\x00ff10`, sqlparser.NewStrVal([]byte{0x00, 0xff, 0x10})},
+		{oid.T_timestamptz, "2024-03-01 09:30:00.25+02", sqlparser.NewStrVal([]byte("2024-03-01 07:30:00.25"))},
+		{oid.T_timestamptz, "2024-03-01 09:30:00-03:30", sqlparser.NewStrVal([]byte("2024-03-01 13:00:00"))},
+		{oid.T_text, "hello", sqlparser.NewStrVal([]byte("hello"))},
+	}
+	for _, tt := range tests {
+		t.Run(oid.TypeName[tt.typ]+" "+tt.text, func(t *testing.T) {
+			actual, err := bindValue(uint32(tt.typ), text(tt.text))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	actual, err := bindValue(uint32(oid.T_int4), tupleValue{kind: tupleValueNull})
+	require.NoError(t, err)
+	assert.Equal(t, &sqlparser.NullVal{}, actual)
+
+	_, err = bindValue(uint32(oid.T_bytea), text(`\001`))
+	assert.Error(t, err)
+}
+
+func TestBindValueUnsupported(t *testing.T) {
+	tests := []struct {
+		typ  oid.Oid
+		text string
+	}{
+		{oid.T_numeric, "NaN"},
+		{oid.T_numeric, "Infinity"},
+		{oid.T_numeric, "-Infinity"},
+		{oid.T_float8, "NaN"},
+		{oid.T_float4, "-Infinity"},
+		{oid.T_timestamp, "infinity"},
+		{oid.T_timestamptz, "-infinity"},
+		{oid.T_date, "infinity"},
+		{oid.T_date, "0044-03-15 BC"},
+		{oid.T_timestamptz, "0044-03-15 12:00:00+00 BC"},
+	}
+	for _, tt := range tests {
+		t.Run(oid.TypeName[tt.typ]+" "+tt.text, func(t *testing.T) {
+			_, err := bindValue(uint32(tt.typ), text(tt.text))
+			assert.ErrorIs(t, err, errUnsupportedValue)
+		})
+	}
+
+	_, err := bindValue(uint32(oid.T_numeric), text("12.5"))
+	assert.NoError(t, err)
+	_, err = bindValue(uint32(oid.T_date), text("2024-03-01"))
+	assert.NoError(t, err)
+}
+
+func TestBindColumnValueKeyLength(t *testing.T) {
+	textKey := relationColumn{key: true, typeOID: uint32(oid.T_text), typeMod: -1}
+	_, err := bindColumnValue(textKey, text(strings.Repeat("é", maxKeyLength)))
+	assert.NoError(t, err)
+	_, err = bindColumnValue(textKey, text(strings.Repeat("a", maxKeyLength+1)))
+	assert.ErrorIs(t, err, errUnsupportedValue)
+
+	longVarcharKey := relationColumn{key: true, typeOID: uint32(oid.T_varchar), typeMod: 2000 + 4}
+	_, err = bindColumnValue(longVarcharKey, text(strings.Repeat("a", maxKeyLength+1)))
+	assert.ErrorIs(t, err, errUnsupportedValue)
+
+	byteaKey := relationColumn{key: true, typeOID: uint32(oid.T_bytea), typeMod: -1}
+	_, err = bindColumnValue(byteaKey, text(`\x`+strings.Repeat("ff", maxKeyLength)))
+	assert.NoError(t, err)
+	_, err = bindColumnValue(byteaKey, text(`\x`+strings.Repeat("ff", maxKeyLength+1)))
+	assert.ErrorIs(t, err, errUnsupportedValue)
+
+	// Columns which are not part of the key are not limited.
+	textCol := relationColumn{typeOID: uint32(oid.T_text), typeMod: -1}
+	_, err = bindColumnValue(textCol, text(strings.Repeat("a", 100000)))
+	assert.NoError(t, err)
+}
+
+func TestColumnTypes(t *testing.T) {
+	varchar := func(name string, n int32, key bool) relationColumn {
+		return relationColumn{name: name, key: key, typeOID: uint32(oid.T_varchar), typeMod: n + 4}
+	}
+	tests := []struct {
+		name     string
+		columns  []relationColumn
+		expected []string
+	}{
+		{
+			name:     "short varchars",
+			columns:  []relationColumn{varchar("a", 10, true), varchar("b", 255, false)},
+			expected: []string{"varchar(10)", "varchar(255)"},
+		},
+		{
+			name:     "varchar too long for a row",
+			columns:  []relationColumn{varchar("a", 16384, false), varchar("b", 20000, false)},
+			expected: []string{"longtext", "longtext"},
+		},
+		{
+			name:     "varchars too long together",
+			columns:  []relationColumn{varchar("a", 100, true), varchar("b", 8000, false), varchar("c", 9000, false), varchar("d", 100, false)},
+			expected: []string{"varchar(100)", "varchar(8000)", "longtext", "varchar(100)"},
+		},
+		{
+			name:     "key varchar longer than the key limit",
+			columns:  []relationColumn{varchar("a", 2000, true), varchar("b", 2000, false)},
+			expected: []string{unboundedKeyStringType, "varchar(2000)"},
+		},
+		{
+			name: "unbounded key",
+			columns: []relationColumn{
+				{name: "a", key: true, typeOID: uint32(oid.T_text), typeMod: -1},
+				{name: "b", key: true, typeOID: uint32(oid.T_bytea), typeMod: -1},
+				{name: "c", typeOID: uint32(oid.T_text), typeMod: -1},
+			},
+			expected: []string{unboundedKeyStringType, unboundedKeyBinaryType, "longtext"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, columnTypes(relationMessage{columns: tt.columns}))
+		})
+	}
+
+}
+
+func TestParseLSN(t *testing.T) {
+	l, err := parseLSN("16/B374D848")
+	require.NoError(t, err)
+	assert.Equal(t, lsn(0x16B374D848), l)
+	assert.Equal(t, "16/B374D848", l.String())
+
+	_, err = parseLSN("B374D848")
+	assert.Error(t, err)
+}
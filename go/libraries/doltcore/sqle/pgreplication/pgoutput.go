@@ -0,0 +1,236 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"fmt"
+	"time"
+)
+
+// The messages of version 1 of the pgoutput logical replication protocol, as
+// documented at https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+
+type beginMessage struct {
+	finalLSN   lsn
+	commitTime time.Time
+	xid        uint32
+}
+
+type commitMessage struct {
+	commitLSN  lsn
+	endLSN     lsn
+	commitTime time.Time
+}
+
+// relationMessage describes a table. It is sent before the first change to
+// the table in a session, and again whenever the table's definition changes.
+type relationMessage struct {
+	id              uint32
+	namespace       string
+	name            string
+	replicaIdentity byte
+	columns         []relationColumn
+}
+
+type relationColumn struct {
+	// key is true if the column is part of the table's replica identity,
+	// which is usually its primary key.
+	key     bool
+	name    string
+	typeOID uint32
+	typeMod int32
+}
+
+type insertMessage struct {
+	relationID uint32
+	newTuple   tuple
+}
+
+type updateMessage struct {
+	relationID uint32
+	// oldTuple is the replica identity of the row before the update, or nil
+	// if the update did not change it, in which case it is in |newTuple|.
+	oldTuple tuple
+	newTuple tuple
+}
+
+type deleteMessage struct {
+	relationID uint32
+	oldTuple   tuple
+}
+
+type truncateMessage struct {
+	relationIDs []uint32
+}
+
+// tuple is the values of a row's columns, in the order of its relation's
+// columns.
+type tuple []tupleValue
+
+const (
+	tupleValueNull      = 'n'
+	tupleValueUnchanged = 'u'
+	tupleValueText      = 't'
+)
+
+type tupleValue struct {
+	// kind is tupleValueNull, tupleValueUnchanged for a TOASTed value the
+	// change did not include, or tupleValueText.
+	kind byte
+	text []byte
+}
+
+// xLogData is a chunk of WAL streamed by the server. For logical replication,
+// |data| is a single pgoutput message.
+type xLogData struct {
+	walStart lsn
+	walEnd   lsn
+	data     []byte
+}
+
+// primaryKeepalive is sent by the server when it has nothing else to send.
+type primaryKeepalive struct {
+	walEnd         lsn
+	replyRequested bool
+}
+
+// parseCopyData parses a CopyData message of the streaming replication
+// protocol, returning an xLogData or a primaryKeepalive.
+func parseCopyData(data []byte) (any, error) {
+	r := &reader{buf: data}
+	var msg any
+	switch typ := r.byte(); typ {
+	case 'w':
+		x := xLogData{
+			walStart: lsn(r.uint64()),
+			walEnd:   lsn(r.uint64()),
+		}
+		r.uint64() // send time
+		x.data = r.rest()
+		msg = x
+	case 'k':
+		k := primaryKeepalive{walEnd: lsn(r.uint64())}
+		r.uint64() // send time
+		k.replyRequested = r.byte() == 1
+		msg = k
+	default:
+		return nil, fmt.Errorf("unexpected replication message type %q", typ)
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("malformed replication message: %w", r.err)
+	}
+	return msg, nil
+}
+
+// parsePgoutputMessage parses the pgoutput message in |data|. Messages which
+// the applier does not need, such as Origin and Type, are returned as nil.
+func parsePgoutputMessage(data []byte) (any, error) {
+	r := &reader{buf: data}
+	var msg any
+	typ := r.byte()
+	switch typ {
+	case 'B':
+		msg = beginMessage{
+			finalLSN:   lsn(r.uint64()),
+			commitTime: fromPgTime(int64(r.uint64())),
+			xid:        r.uint32(),
+		}
+	case 'C':
+		r.byte() // flags
+		msg = commitMessage{
+			commitLSN:  lsn(r.uint64()),
+			endLSN:     lsn(r.uint64()),
+			commitTime: fromPgTime(int64(r.uint64())),
+		}
+	case 'R':
+		rel := relationMessage{
+			id:              r.uint32(),
+			namespace:       r.cstring(),
+			name:            r.cstring(),
+			replicaIdentity: r.byte(),
+		}
+		n := int(r.uint16())
+		for i := 0; i < n && r.err == nil; i++ {
+			rel.columns = append(rel.columns, relationColumn{
+				key:     r.byte()&1 == 1,
+				name:    r.cstring(),
+				typeOID: r.uint32(),
+				typeMod: int32(r.uint32()),
+			})
+		}
+		msg = rel
+	case 'I':
+		ins := insertMessage{relationID: r.uint32()}
+		if kind := r.byte(); kind != 'N' {
+			return nil, fmt.Errorf("malformed pgoutput Insert: unexpected tuple type %q", kind)
+		}
+		ins.newTuple = r.tuple()
+		msg = ins
+	case 'U':
+		upd := updateMessage{relationID: r.uint32()}
+		kind := r.byte()
+		if kind == 'K' || kind == 'O' {
+			upd.oldTuple = r.tuple()
+			kind = r.byte()
+		}
+		if kind != 'N' {
+			return nil, fmt.Errorf("malformed pgoutput Update: unexpected tuple type %q", kind)
+		}
+		upd.newTuple = r.tuple()
+		msg = upd
+	case 'D':
+		del := deleteMessage{relationID: r.uint32()}
+		if kind := r.byte(); kind != 'K' && kind != 'O' {
+			return nil, fmt.Errorf("malformed pgoutput Delete: unexpected tuple type %q", kind)
+		}
+		del.oldTuple = r.tuple()
+		msg = del
+	case 'T':
+		n := int(r.uint32())
+		r.byte() // options
+		trunc := truncateMessage{}
+		for i := 0; i < n && r.err == nil; i++ {
+			trunc.relationIDs = append(trunc.relationIDs, r.uint32())
+		}
+		msg = trunc
+	case 'O', 'Y':
+		// Origin and Type messages carry nothing the applier needs.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported pgoutput message type %q", typ)
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("malformed pgoutput message %q: %w", typ, r.err)
+	}
+	return msg, nil
+}
+
+func (r *reader) tuple() tuple {
+	n := int(r.uint16())
+	t := make(tuple, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		v := tupleValue{kind: r.byte()}
+		switch v.kind {
+		case tupleValueNull, tupleValueUnchanged:
+		case tupleValueText:
+			v.text = r.bytes(int(r.uint32()))
+		default:
+			r.err = fmt.Errorf("unsupported tuple value type %q", v.kind)
+			return nil
+		}
+		t = append(t, v)
+	}
+	return t
+}
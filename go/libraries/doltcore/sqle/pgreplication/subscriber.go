@@ -0,0 +1,583 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+const (
+	connectTimeout = 30 * time.Second
+	reconnectDelay = 5 * time.Second
+	// How often the subscriber reports its progress to the server when the
+	// server does not ask for it sooner.
+	statusInterval = 10 * time.Second
+)
+
+// The user changes are applied as. Like the binlog applier, it is a locked
+// superuser that clients cannot log in as.
+const applierUser = "dolt-postgres-applier"
+
+var errStopped = errors.New("postgres replication stopped")
+
+// Subscriber is a Postgres logical replication subscriber. It streams the
+// changes a publication makes from a replication slot using the pgoutput
+// plugin and applies them to the tables of a Dolt database, creating the
+// tables from the publication's relations as it first sees them. Each
+// Postgres transaction is applied in a SQL transaction. If a commit interval
+// is configured, the applied changes are also committed with dolt_commit so
+// that the replicated data has version history.
+//
+// Tables in the public schema keep their names. Tables in any other schema
+// are named <schema>_<table>.
+//
+// The end LSN of the last transaction applied from the slot is stored in the
+// pg_replication_lsn table of the target database, in the same transaction as
+// its changes, and reported to Postgres as flushed, so that replication
+// resumes where it left off after a restart.
+type Subscriber struct {
+	cfg    servercfg.PostgresReplicationConfig
+	engine *gms.Engine
+	lgr    *logrus.Entry
+
+	relations map[uint32]relationMessage
+	// The Dolt table names of the relations whose tables have been
+	// checked against their Relation message.
+	tables map[uint32]string
+	// The end LSN of the last transaction applied.
+	applied lsn
+	// The LSN reported to the server as flushed.
+	confirmed lsn
+	inTx      bool
+	skipTx    bool
+
+	uncommitted    bool
+	lastDoltCommit time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewSubscriber(cfg servercfg.PostgresReplicationConfig, engine *gms.Engine, lgr *logrus.Logger) *Subscriber {
+	return &Subscriber{
+		cfg:    cfg,
+		engine: engine,
+		lgr:    lgr.WithField("thread", "Postgres Replication"),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Run applies the changes streamed from Postgres in the session of |ctx|
+// until Stop is called, reconnecting whenever the stream fails.
+func (s *Subscriber) Run(ctx *sql.Context) {
+	defer close(s.done)
+	s.lastDoltCommit = time.Now()
+
+	mySQLDb := s.engine.Analyzer.Catalog.MySQLDb
+	ed := mySQLDb.Editor()
+	mySQLDb.AddLockedSuperUser(ed, applierUser, "localhost", "")
+	ed.Close()
+	ctx.SetClient(sql.Client{
+		User:    applierUser,
+		Address: "localhost",
+	})
+	for {
+		err := s.stream(ctx)
+		if errors.Is(err, errStopped) {
+			return
+		}
+		if errors.Is(err, errUnsupportedValue) {
+			s.lgr.Errorf("pgreplication: stopping replication from %s, since a change cannot be applied: %v", s.addr(), err)
+			return
+		}
+		s.lgr.Errorf("pgreplication: replication from %s failed; retrying in %v: %v", s.addr(), reconnectDelay, err)
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// Stop stops replication and waits for Run to return.
+func (s *Subscriber) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *Subscriber) addr() string {
+	return net.JoinHostPort(s.cfg.Host(), strconv.Itoa(s.cfg.Port()))
+}
+
+// stream connects to Postgres and applies the changes it streams until the
+// connection fails or the subscriber is stopped.
+func (s *Subscriber) stream(ctx *sql.Context) error {
+	target := s.cfg.TargetDatabase()
+	if err := s.exec(ctx, "CREATE DATABASE IF NOT EXISTS "+quoteName(target), nil); err != nil {
+		return err
+	}
+	ctx.SetCurrentDatabase(target)
+	applied, err := s.loadAppliedLSN(ctx)
+	if err != nil {
+		return fmt.Errorf("could not load the last applied LSN: %w", err)
+	}
+	s.applied = applied
+	if s.applied > s.confirmed {
+		s.confirmed = s.applied
+	}
+
+	tlsConfig, err := replicationTLSConfig(s.cfg.SSLMode(), s.cfg.Host(), s.cfg.SSLRootCert())
+	if err != nil {
+		return err
+	}
+	connCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	conn, err := connectReplication(connCtx, s.addr(), s.cfg.SSLMode(), tlsConfig, s.cfg.User(), s.cfg.Password(), s.cfg.Database())
+	cancel()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if s.cfg.CreateSlot() {
+		if err := conn.createSlot(s.cfg.Slot()); err != nil {
+			return err
+		}
+	}
+	if err := conn.startReplication(s.cfg.Slot(), s.cfg.Publication(), s.applied); err != nil {
+		return err
+	}
+	s.lgr.Infof("pgreplication: streaming publication %s from slot %s on %s, starting after LSN %s", s.cfg.Publication(), s.cfg.Slot(), s.addr(), s.applied)
+	s.relations = make(map[uint32]relationMessage)
+	s.tables = make(map[uint32]string)
+
+	received := make(chan []byte)
+	failed := make(chan error, 1)
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		for {
+			data, err := conn.receiveCopyData()
+			if err != nil {
+				if err == io.EOF {
+					err = errors.New("server ended the replication stream")
+				}
+				failed <- err
+				return
+			}
+			select {
+			case received <- data:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	tick := statusInterval
+	if ci := s.cfg.CommitInterval(); ci > 0 && ci < tick {
+		tick = ci
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case data := <-received:
+			if err := s.handleCopyData(ctx, conn, data); err != nil {
+				s.rollback(ctx)
+				return err
+			}
+		case err := <-failed:
+			s.rollback(ctx)
+			return err
+		case <-ticker.C:
+			if err := s.maybeDoltCommit(ctx); err != nil {
+				return err
+			}
+			if err := conn.sendStandbyStatus(s.confirmed); err != nil {
+				return err
+			}
+		case <-s.stop:
+			s.rollback(ctx)
+			return errStopped
+		}
+	}
+}
+
+func (s *Subscriber) handleCopyData(ctx *sql.Context, conn *pgConn, data []byte) error {
+	msg, err := parseCopyData(data)
+	if err != nil {
+		return err
+	}
+	switch msg := msg.(type) {
+	case primaryKeepalive:
+		// Every change before |walEnd| has been sent. When we are not in the
+		// middle of a transaction, we have applied all of those we need.
+		if !s.inTx && !s.skipTx && msg.walEnd > s.confirmed {
+			s.confirmed = msg.walEnd
+		}
+		if msg.replyRequested {
+			return conn.sendStandbyStatus(s.confirmed)
+		}
+		return nil
+	case xLogData:
+		change, err := parsePgoutputMessage(msg.data)
+		if err != nil {
+			return err
+		}
+		if change == nil {
+			return nil
+		}
+		if err := sql.SessionCommandBegin(ctx.Session); err != nil {
+			return err
+		}
+		defer sql.SessionCommandEnd(ctx.Session)
+		return s.apply(ctx, change)
+	}
+	return nil
+}
+
+// apply applies a single pgoutput message.
+func (s *Subscriber) apply(ctx *sql.Context, change any) error {
+	switch m := change.(type) {
+	case beginMessage:
+		// A transaction which committed before the end of the last one
+		// we applied is being streamed again after a restart.
+		s.skipTx = m.finalLSN < s.applied
+		if s.skipTx {
+			return nil
+		}
+		s.inTx = true
+		return s.exec(ctx, "START TRANSACTION", nil)
+	case commitMessage:
+		if s.skipTx {
+			s.skipTx = false
+			return nil
+		}
+		if err := s.saveAppliedLSN(ctx, m.endLSN); err != nil {
+			return err
+		}
+		if err := s.exec(ctx, "COMMIT", nil); err != nil {
+			return err
+		}
+		s.inTx = false
+		s.applied = m.endLSN
+		if s.applied > s.confirmed {
+			s.confirmed = s.applied
+		}
+		s.uncommitted = true
+		return s.maybeDoltCommit(ctx)
+	case relationMessage:
+		s.relations[m.id] = m
+		delete(s.tables, m.id)
+		return nil
+	}
+	if s.skipTx {
+		return nil
+	}
+
+	switch m := change.(type) {
+	case insertMessage:
+		rel, table, err := s.table(ctx, m.relationID)
+		if err != nil {
+			return err
+		}
+		return s.insert(ctx, rel, table, m.newTuple)
+	case updateMessage:
+		rel, table, err := s.table(ctx, m.relationID)
+		if err != nil {
+			return err
+		}
+		return s.update(ctx, rel, table, m.oldTuple, m.newTuple)
+	case deleteMessage:
+		rel, table, err := s.table(ctx, m.relationID)
+		if err != nil {
+			return err
+		}
+		return s.delete(ctx, rel, table, m.oldTuple)
+	case truncateMessage:
+		for _, id := range m.relationIDs {
+			_, table, err := s.table(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := s.exec(ctx, "DELETE FROM "+quoteName(table), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Subscriber) rollback(ctx *sql.Context) {
+	if !s.inTx {
+		return
+	}
+	s.inTx = false
+	if err := s.exec(ctx, "ROLLBACK", nil); err != nil {
+		s.lgr.Warnf("pgreplication: could not roll back partially applied transaction: %v", err)
+	}
+}
+
+// maybeDoltCommit commits the changes applied since the last Dolt commit if
+// the commit interval has passed.
+func (s *Subscriber) maybeDoltCommit(ctx *sql.Context) error {
+	interval := s.cfg.CommitInterval()
+	if interval == 0 || !s.uncommitted || s.inTx || time.Since(s.lastDoltCommit) < interval {
+		return nil
+	}
+	msg := fmt.Sprintf("Postgres logical replication through LSN %s", s.applied)
+	// The commits are authored by the Postgres user, in the user@address
+	// notation dolt_commit uses for SQL users.
+	author := fmt.Sprintf("%s <%s@%s>", s.cfg.User(), s.cfg.User(), s.cfg.Host())
+	err := s.exec(ctx, "CALL dolt_commit('-Am', ?, '--author', ?)", []sqlparser.Expr{
+		sqlparser.NewStrVal([]byte(msg)),
+		sqlparser.NewStrVal([]byte(author)),
+	})
+	if err != nil && err.Error() != "nothing to commit" {
+		return err
+	}
+	s.uncommitted = false
+	s.lastDoltCommit = time.Now()
+	return nil
+}
+
+// table returns the relation with id |id| and the name of its Dolt table,
+// creating the table, or adding the relation's new columns to it, the
+// first time it is used after a Relation message.
+func (s *Subscriber) table(ctx *sql.Context, id uint32) (relationMessage, string, error) {
+	rel, ok := s.relations[id]
+	if !ok {
+		return rel, "", fmt.Errorf("received a change to relation %d before its Relation message", id)
+	}
+	if name, ok := s.tables[id]; ok {
+		return rel, name, nil
+	}
+
+	name := rel.name
+	if rel.namespace != "" && rel.namespace != "public" {
+		name = rel.namespace + "_" + rel.name
+	}
+	db, err := s.engine.Analyzer.Catalog.Database(ctx, s.cfg.TargetDatabase())
+	if err != nil {
+		return rel, "", err
+	}
+	table, exists, err := db.GetTableInsensitive(ctx, name)
+	if err != nil {
+		return rel, "", err
+	}
+	if !exists {
+		if err := s.exec(ctx, createTableStatement(name, rel), nil); err != nil {
+			return rel, "", err
+		}
+	} else {
+		name = table.Name()
+		types := columnTypes(rel)
+		for i, col := range rel.columns {
+			if table.Schema().Contains(col.name, table.Name()) {
+				continue
+			}
+			col.key = false
+			stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", quoteName(name), quoteName(col.name), types[i])
+			if err := s.exec(ctx, stmt, nil); err != nil {
+				return rel, "", err
+			}
+		}
+	}
+	s.tables[id] = name
+	return rel, name, nil
+}
+
+func createTableStatement(name string, rel relationMessage) string {
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(quoteName(name))
+	b.WriteString(" (")
+	var keys []string
+	types := columnTypes(rel)
+	for i, col := range rel.columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteName(col.name))
+		b.WriteString(" ")
+		b.WriteString(types[i])
+		if col.key {
+			b.WriteString(" NOT NULL")
+			keys = append(keys, quoteName(col.name))
+		}
+	}
+	if len(keys) > 0 {
+		b.WriteString(", PRIMARY KEY (")
+		b.WriteString(strings.Join(keys, ", "))
+		b.WriteString(")")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func (s *Subscriber) insert(ctx *sql.Context, rel relationMessage, table string, row tuple) error {
+	if len(row) != len(rel.columns) {
+		return fmt.Errorf("insert into %s has %d values for %d columns", table, len(row), len(rel.columns))
+	}
+	var names, params []string
+	var bindings []sqlparser.Expr
+	for i, v := range row {
+		if v.kind == tupleValueUnchanged {
+			continue
+		}
+		b, err := bindColumnValue(rel.columns[i], v)
+		if err != nil {
+			return fmt.Errorf("column %s of %s: %w", rel.columns[i].name, table, err)
+		}
+		names = append(names, quoteName(rel.columns[i].name))
+		params = append(params, "?")
+		bindings = append(bindings, b)
+	}
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteName(table), strings.Join(names, ", "), strings.Join(params, ", "))
+	return s.exec(ctx, stmt, bindings)
+}
+
+func (s *Subscriber) update(ctx *sql.Context, rel relationMessage, table string, old, new tuple) error {
+	if len(new) != len(rel.columns) || (old != nil && len(old) != len(rel.columns)) {
+		return fmt.Errorf("update of %s has the wrong number of values for %d columns", table, len(rel.columns))
+	}
+	var sets []string
+	var bindings []sqlparser.Expr
+	for i, v := range new {
+		if v.kind == tupleValueUnchanged {
+			continue
+		}
+		b, err := bindColumnValue(rel.columns[i], v)
+		if err != nil {
+			return fmt.Errorf("column %s of %s: %w", rel.columns[i].name, table, err)
+		}
+		sets = append(sets, quoteName(rel.columns[i].name)+" = ?")
+		bindings = append(bindings, b)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+	identity := old
+	if identity == nil {
+		identity = new
+	}
+	where, whereBindings, err := identityCondition(rel, table, identity)
+	if err != nil {
+		return err
+	}
+	stmt := fmt.Sprintf("UPDATE %s SET %s WHERE %s", quoteName(table), strings.Join(sets, ", "), where)
+	return s.exec(ctx, stmt, append(bindings, whereBindings...))
+}
+
+func (s *Subscriber) delete(ctx *sql.Context, rel relationMessage, table string, old tuple) error {
+	if len(old) != len(rel.columns) {
+		return fmt.Errorf("delete from %s has %d values for %d columns", table, len(old), len(rel.columns))
+	}
+	where, bindings, err := identityCondition(rel, table, old)
+	if err != nil {
+		return err
+	}
+	return s.exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", quoteName(table), where), bindings)
+}
+
+// identityCondition returns a condition matching the row identified by
+// |identity|. Tables with a key are matched on their key columns. Tables
+// without one, which Postgres only replicates changes to with REPLICA
+// IDENTITY FULL, are matched on all of their columns, one row at a time.
+func identityCondition(rel relationMessage, table string, identity tuple) (string, []sqlparser.Expr, error) {
+	keyed := hasKey(rel)
+	var conds []string
+	var bindings []sqlparser.Expr
+	for i, v := range identity {
+		col := rel.columns[i]
+		if (keyed && !col.key) || v.kind == tupleValueUnchanged {
+			continue
+		}
+		b, err := bindColumnValue(col, v)
+		if err != nil {
+			return "", nil, fmt.Errorf("column %s of %s: %w", col.name, table, err)
+		}
+		conds = append(conds, quoteName(col.name)+" <=> ?")
+		bindings = append(bindings, b)
+	}
+	if len(conds) == 0 {
+		return "", nil, fmt.Errorf("cannot identify rows of %s; set its REPLICA IDENTITY to FULL or give it a primary key", table)
+	}
+	where := strings.Join(conds, " AND ")
+	if !keyed {
+		where += " LIMIT 1"
+	}
+	return where, bindings, nil
+}
+
+func hasKey(rel relationMessage) bool {
+	for _, col := range rel.columns {
+		if col.key {
+			return true
+		}
+	}
+	return false
+}
+
+// exec runs |query| with the positional |bindings| and discards its results.
+func (s *Subscriber) exec(ctx *sql.Context, query string, bindings []sqlparser.Expr) error {
+	_, err := s.query(ctx, query, bindings)
+	return err
+}
+
+// query runs |query| with the positional |bindings| and returns its results.
+func (s *Subscriber) query(ctx *sql.Context, query string, bindings []sqlparser.Expr) ([]sql.Row, error) {
+	// Create a sub-context when running queries against the engine, so that we get an accurate query start time.
+	queryCtx := sql.NewContext(ctx, sql.WithSession(ctx.Session))
+	var bindingsMap map[string]sqlparser.Expr
+	if len(bindings) > 0 {
+		bindingsMap = make(map[string]sqlparser.Expr, len(bindings))
+		for i, b := range bindings {
+			bindingsMap["v"+strconv.Itoa(i+1)] = b
+		}
+	}
+	_, iter, _, err := s.engine.QueryWithBindings(queryCtx, query, nil, bindingsMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sql.Row
+	for {
+		row, err := iter.Next(queryCtx)
+		if err == io.EOF {
+			return rows, iter.Close(queryCtx)
+		} else if err != nil {
+			iter.Close(queryCtx)
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,485 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/lib/pq/oid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+func TestSubscriber(t *testing.T) {
+	engine, sqlCtx := newTestEngine(t)
+	pg := newFakePostgres(t)
+	defer pg.Close()
+	cfg := pg.config(t)
+
+	users := relationMessage{
+		id:        16384,
+		namespace: "public",
+		name:      "users",
+		columns: []relationColumn{
+			{key: true, name: "id", typeOID: uint32(oid.T_int4), typeMod: -1},
+			{name: "name", typeOID: uint32(oid.T_text), typeMod: -1},
+			{name: "active", typeOID: uint32(oid.T_bool), typeMod: -1},
+			{name: "balance", typeOID: uint32(oid.T_numeric), typeMod: (10<<16 | 2) + 4},
+		},
+	}
+	// A table without a key, whose rows would be duplicated if a transaction were applied twice.
+	events := relationMessage{
+		id:        16390,
+		namespace: "public",
+		name:      "events",
+		columns: []relationColumn{
+			{name: "name", typeOID: uint32(oid.T_text), typeMod: -1},
+		},
+	}
+	firstStream := [][]byte{
+		encodeRelation(users),
+		encodeRelation(events),
+		encodeBegin(0x100),
+		encodeInsert(users.id, textTuple("1", "alice", "t", "10.50")),
+		encodeInsert(users.id, tuple{text("2"), text("bob"), text("f"), {kind: tupleValueNull}}),
+		encodeCommit(0x100, 0x110),
+		encodeBegin(0x200),
+		encodeUpdate(users.id, textTuple("1", "alicia", "t", "10.50")),
+		encodeDelete(users.id, tuple{text("2"), {kind: tupleValueNull}, {kind: tupleValueNull}, {kind: tupleValueNull}}),
+		encodeInsert(events.id, textTuple("renamed")),
+		encodeCommit(0x200, 0x210),
+	}
+
+	runSubscriber := func(stream [][]byte, flushedThrough lsn) {
+		sub := NewSubscriber(cfg, engine, logrus.StandardLogger())
+		go sub.Run(sqlCtx)
+		pg.stream(t, stream)
+		pg.waitForFlush(t, flushedThrough)
+		sub.Stop()
+	}
+	runSubscriber(firstStream, 0x210)
+	assert.Contains(t, pg.lastStart(), "START_REPLICATION SLOT \"dolt_slot\" LOGICAL 0/0")
+
+	rows := query(t, engine, sqlCtx, "SELECT id, name, active, balance FROM users ORDER BY id")
+	assert.Equal(t, "[[1 alicia 1 10.50]]", rowsString(rows))
+	rows = query(t, engine, sqlCtx, "SELECT message FROM dolt_log LIMIT 1")
+	assert.Equal(t, "[[Postgres logical replication through LSN 0/210]]", rowsString(rows))
+	// The LSN is committed along with the changes.
+	rows = query(t, engine, sqlCtx, "SELECT slot, lsn FROM pg_replication_lsn AS OF 'HEAD'")
+	assert.Equal(t, "[[dolt_slot 0/210]]", rowsString(rows))
+
+	// After a restart, replication resumes from the last transaction applied, and transactions the server
+	// streams again are skipped.
+	secondStream := [][]byte{
+		encodeRelation(users),
+		encodeRelation(events),
+		encodeBegin(0x200),
+		encodeDelete(users.id, tuple{text("1"), {kind: tupleValueNull}, {kind: tupleValueNull}, {kind: tupleValueNull}}),
+		encodeInsert(events.id, textTuple("renamed")),
+		encodeCommit(0x200, 0x210),
+		encodeBegin(0x300),
+		encodeInsert(users.id, textTuple("3", "carol", "t", "0.01")),
+		encodeCommit(0x300, 0x310),
+	}
+	runSubscriber(secondStream, 0x310)
+	assert.Contains(t, pg.lastStart(), "LOGICAL 0/210")
+
+	rows = query(t, engine, sqlCtx, "SELECT id, name FROM users ORDER BY id")
+	assert.Equal(t, "[[1 alicia] [3 carol]]", rowsString(rows))
+	rows = query(t, engine, sqlCtx, "SELECT name FROM events")
+	assert.Equal(t, "[[renamed]]", rowsString(rows))
+	rows = query(t, engine, sqlCtx, "SELECT slot, lsn FROM pg_replication_lsn")
+	assert.Equal(t, "[[dolt_slot 0/310]]", rowsString(rows))
+}
+
+// newTestEngine returns an engine with the Dolt database "dolt", and a
+// context for it.
+func newTestEngine(t *testing.T) (*gms.Engine, *sql.Context) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	t.Cleanup(func() { dEnv.DoltDB(ctx).Close() })
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	return engine, sqlCtx
+}
+
+func TestSubscriberStopsOnUnsupportedValue(t *testing.T) {
+	engine, sqlCtx := newTestEngine(t)
+	pg := newFakePostgres(t)
+	defer pg.Close()
+	cfg := pg.config(t)
+
+	prices := relationMessage{
+		id:   16400,
+		name: "prices",
+		columns: []relationColumn{
+			{key: true, name: "id", typeOID: uint32(oid.T_int4), typeMod: -1},
+			{name: "price", typeOID: uint32(oid.T_numeric), typeMod: -1},
+		},
+	}
+	sub := NewSubscriber(cfg, engine, logrus.StandardLogger())
+	go sub.Run(sqlCtx)
+	pg.stream(t, [][]byte{
+		encodeRelation(prices),
+		encodeBegin(0x100),
+		encodeInsert(prices.id, textTuple("1", "NaN")),
+		encodeCommit(0x100, 0x110),
+	})
+	select {
+	case <-sub.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("subscriber did not stop after a value it cannot apply")
+	}
+	sub.Stop()
+
+	rows := query(t, engine, sqlCtx, "SELECT COUNT(*) FROM prices")
+	assert.Equal(t, "[[0]]", rowsString(rows))
+}
+
+func TestCreateTableStatementRowSize(t *testing.T) {
+	engine, sqlCtx := newTestEngine(t)
+	varchar := func(name string, n int32, key bool) relationColumn {
+		return relationColumn{name: name, key: key, typeOID: uint32(oid.T_varchar), typeMod: n + 4}
+	}
+	tables := map[string][]relationColumn{
+		"one_long":      {varchar("id", 10, true), varchar("a", 16383, false)},
+		"many_long":     {varchar("id", 10, true), varchar("a", 8000, false), varchar("b", 9000, false), varchar("c", 10000, false)},
+		"long_key":      {varchar("id", 5000, true), varchar("a", 5000, false)},
+		"composite_key": {varchar("a", 767, true), varchar("b", 767, true), varchar("c", 767, true), varchar("d", 16000, false)},
+	}
+	for name, columns := range tables {
+		t.Run(name, func(t *testing.T) {
+			query(t, engine, sqlCtx, createTableStatement(name, relationMessage{name: name, columns: columns}))
+		})
+	}
+}
+
+func TestCreateTableStatement(t *testing.T) {
+	rel := relationMessage{
+		name: "orders",
+		columns: []relationColumn{
+			{key: true, name: "customer", typeOID: uint32(oid.T_text), typeMod: -1},
+			{key: true, name: "id", typeOID: uint32(oid.T_int8), typeMod: -1},
+			{name: "placed", typeOID: uint32(oid.T_timestamptz), typeMod: -1},
+			{name: "note", typeOID: uint32(oid.T_varchar), typeMod: 104},
+			{name: "payload", typeOID: uint32(oid.T_jsonb), typeMod: -1},
+		},
+	}
+	assert.Equal(t,
+		"CREATE TABLE `orders` (`customer` varchar(767) NOT NULL, `id` bigint NOT NULL, `placed` datetime(6), "+
+			"`note` varchar(100), `payload` json, PRIMARY KEY (`customer`, `id`))",
+		createTableStatement("orders", rel))
+}
+
+func query(t *testing.T, engine interface {
+	Query(*sql.Context, string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
+}, ctx *sql.Context, q string) []sql.Row {
+	_, iter, _, err := engine.Query(ctx, q)
+	require.NoError(t, err)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(t, err)
+	return rows
+}
+
+func rowsString(rows []sql.Row) string {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString("[")
+		for j, v := range row {
+			if j > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(sqlString(v))
+		}
+		b.WriteString("]")
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func sqlString(v any) string {
+	switch v := v.(type) {
+	case decimal.Decimal:
+		return v.StringFixed(v.Exponent() * -1)
+	case sql.StringWrapper:
+		s, _ := v.Unwrap(context.Background())
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+// fakePostgres is a Postgres server which accepts a logical replication
+// connection from any user and streams the pgoutput messages it is given.
+type fakePostgres struct {
+	ln    net.Listener
+	conns chan *pgConn
+	// If set, connections are secured with SSL when the client asks.
+	tlsConfig *tls.Config
+	// If set, clients are asked for a cleartext password.
+	cleartext bool
+
+	mu       sync.Mutex
+	start    string
+	flushed  lsn
+	password string
+	secured  bool
+}
+
+func newFakePostgres(t *testing.T) *fakePostgres {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakePostgres{ln: ln, conns: make(chan *pgConn, 1)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+// config returns the configuration of a subscriber replicating from f.
+func (f *fakePostgres) config(t *testing.T) *servercfg.PostgresReplicationYAMLConfig {
+	host, port, err := net.SplitHostPort(f.ln.Addr().String())
+	require.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return &servercfg.PostgresReplicationYAMLConfig{
+		Host_:                 &host,
+		Port_:                 &portNum,
+		User_:                 ptr("replicator"),
+		Database_:             ptr("app"),
+		Slot_:                 ptr("dolt_slot"),
+		Publication_:          ptr("dolt_pub"),
+		TargetDatabase_:       ptr("dolt"),
+		CommitIntervalMillis_: ptr(uint64(1)),
+	}
+}
+
+func (f *fakePostgres) Close() {
+	f.ln.Close()
+}
+
+func (f *fakePostgres) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+	secured := false
+	for {
+		var hdr [8]byte
+		if _, err := io.ReadFull(conn, hdr[:]); err != nil {
+			return
+		}
+		if binary.BigEndian.Uint32(hdr[4:]) != sslRequestCode {
+			if _, err := io.ReadFull(conn, make([]byte, binary.BigEndian.Uint32(hdr[:4])-8)); err != nil {
+				return
+			}
+			break
+		}
+		if f.tlsConfig == nil {
+			conn.Write([]byte{'N'})
+			continue
+		}
+		conn.Write([]byte{'S'})
+		conn = tls.Server(conn, f.tlsConfig)
+		secured = true
+	}
+	c := &pgConn{conn: conn, r: bufio.NewReader(conn), tls: secured}
+	f.mu.Lock()
+	f.secured = secured
+	f.mu.Unlock()
+	if f.cleartext {
+		c.send('R', binary.BigEndian.AppendUint32(nil, 3))
+		typ, body, err := c.receive()
+		if err != nil || typ != 'p' {
+			return
+		}
+		f.mu.Lock()
+		f.password = string(body[:len(body)-1])
+		f.mu.Unlock()
+	}
+	c.send('R', binary.BigEndian.AppendUint32(nil, 0))
+	c.send('Z', []byte{'I'})
+	for {
+		typ, body, err := c.receive()
+		if err != nil || typ == 'X' {
+			return
+		}
+		switch typ {
+		case 'Q':
+			q := string(body[:len(body)-1])
+			if !strings.HasPrefix(q, "START_REPLICATION") {
+				c.send('C', appendCString(nil, "OK"))
+				c.send('Z', []byte{'I'})
+				continue
+			}
+			f.mu.Lock()
+			f.start = q
+			f.mu.Unlock()
+			c.send('W', []byte{0, 0, 0})
+			f.conns <- c
+		case 'd':
+			if len(body) > 0 && body[0] == 'r' {
+				f.mu.Lock()
+				f.flushed = lsn(binary.BigEndian.Uint64(body[9:17]))
+				f.mu.Unlock()
+			}
+		}
+	}
+}
+
+// stream sends |messages| to the next connection to start replicating,
+// followed by a keepalive asking for its status.
+func (f *fakePostgres) stream(t *testing.T, messages [][]byte) {
+	var c *pgConn
+	select {
+	case c = <-f.conns:
+	case <-time.After(10 * time.Second):
+		t.Fatal("subscriber did not start replication")
+	}
+	var walEnd uint64
+	for _, m := range messages {
+		msg := []byte{'w'}
+		msg = binary.BigEndian.AppendUint64(msg, walEnd)
+		msg = binary.BigEndian.AppendUint64(msg, walEnd)
+		msg = binary.BigEndian.AppendUint64(msg, 0)
+		require.NoError(t, c.send('d', append(msg, m...)))
+		if m[0] == 'C' {
+			walEnd = binary.BigEndian.Uint64(m[10:18])
+		}
+	}
+	msg := []byte{'k'}
+	msg = binary.BigEndian.AppendUint64(msg, walEnd)
+	msg = binary.BigEndian.AppendUint64(msg, 0)
+	require.NoError(t, c.send('d', append(msg, 1)))
+}
+
+func (f *fakePostgres) waitForFlush(t *testing.T, l lsn) {
+	require.Eventually(t, func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return f.flushed >= l
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func (f *fakePostgres) lastStart() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.start
+}
+
+func text(s string) tupleValue {
+	return tupleValue{kind: tupleValueText, text: []byte(s)}
+}
+
+func textTuple(values ...string) tuple {
+	t := make(tuple, len(values))
+	for i, v := range values {
+		t[i] = text(v)
+	}
+	return t
+}
+
+func encodeRelation(rel relationMessage) []byte {
+	b := binary.BigEndian.AppendUint32([]byte{'R'}, rel.id)
+	b = appendCString(b, rel.namespace)
+	b = appendCString(b, rel.name)
+	b = append(b, 'd')
+	b = binary.BigEndian.AppendUint16(b, uint16(len(rel.columns)))
+	for _, col := range rel.columns {
+		var flags byte
+		if col.key {
+			flags = 1
+		}
+		b = append(b, flags)
+		b = appendCString(b, col.name)
+		b = binary.BigEndian.AppendUint32(b, col.typeOID)
+		b = binary.BigEndian.AppendUint32(b, uint32(col.typeMod))
+	}
+	return b
+}
+
+func encodeBegin(finalLSN lsn) []byte {
+	b := binary.BigEndian.AppendUint64([]byte{'B'}, uint64(finalLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(toPgTime(time.Now())))
+	return binary.BigEndian.AppendUint32(b, 1)
+}
+
+func encodeCommit(commitLSN, endLSN lsn) []byte {
+	b := binary.BigEndian.AppendUint64([]byte{'C', 0}, uint64(commitLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(endLSN))
+	return binary.BigEndian.AppendUint64(b, uint64(toPgTime(time.Now())))
+}
+
+func encodeInsert(id uint32, t tuple) []byte {
+	b := binary.BigEndian.AppendUint32([]byte{'I'}, id)
+	return appendTuple(append(b, 'N'), t)
+}
+
+func encodeUpdate(id uint32, t tuple) []byte {
+	b := binary.BigEndian.AppendUint32([]byte{'U'}, id)
+	return appendTuple(append(b, 'N'), t)
+}
+
+func encodeDelete(id uint32, key tuple) []byte {
+	b := binary.BigEndian.AppendUint32([]byte{'D'}, id)
+	return appendTuple(append(b, 'K'), key)
+}
+
+func appendTuple(b []byte, t tuple) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(t)))
+	for _, v := range t {
+		b = append(b, v.kind)
+		if v.kind == tupleValueText {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(v.text)))
+			b = append(b, v.text...)
+		}
+	}
+	return b
+}
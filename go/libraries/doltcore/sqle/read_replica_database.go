@@ -18,15 +18,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -34,10 +38,24 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+// MaxCountedReplicaCommits is the most commits a pull into a read replica counts per branch, so that pulling a long
+// history doesn't walk all of it.
+const MaxCountedReplicaCommits = 1000
+
+var countReplicaCommits atomic.Bool
+
+// SetCountReadReplicaCommits sets whether read replicas count the commits each pull brings into their existing
+// branches, which is reported by ReadReplicaStatus. Counting walks the pulled commits, so it is only enabled when
+// something reports the count.
+func SetCountReadReplicaCommits(enabled bool) {
+	countReplicaCommits.Store(enabled)
+}
+
 type ReadReplicaDatabase struct {
 	remote  env.Remote
 	srcDB   *doltdb.DoltDB
 	limiter *limiter
+	pulls   *replicaPulls
 	tmpDir  string
 	Database
 }
@@ -83,6 +101,7 @@ func NewReadReplicaDatabase(ctx context.Context, db Database, remoteName string,
 		tmpDir:   tmpDir,
 		srcDB:    srcDB,
 		limiter:  newLimiter(),
+		pulls:    &replicaPulls{},
 	}, nil
 }
 
@@ -112,8 +131,39 @@ func (rrd ReadReplicaDatabase) DoltDatabases() []*doltdb.DoltDB {
 	return []*doltdb.DoltDB{rrd.ddb, rrd.srcDB}
 }
 
+// PulledWithin implements dsess.RemoteReadReplicaDatabase
+func (rrd ReadReplicaDatabase) PulledWithin(d time.Duration) bool {
+	if rrd.pulls == nil {
+		return false
+	}
+	return time.Since(rrd.pulls.lastPull()) <= d
+}
+
 func (rrd ReadReplicaDatabase) PullFromRemote(ctx *sql.Context) error {
 	ctx.GetLogger().Tracef("pulling from remote %s for database %s", rrd.remote.Name, rrd.Name())
+	start := time.Now()
+	err := rrd.pullFromRemote(ctx)
+	if rrd.pulls != nil {
+		if err == nil {
+			// Everything on the remote as of |start| is now local.
+			rrd.pulls.pulled(start)
+		} else {
+			rrd.pulls.failed()
+		}
+	}
+	return err
+}
+
+// ReplicationStatus returns when this replica last finished a pull from its remote, which is the zero time if it has
+// not, and how many commits that pull brought into existing branches.
+func (rrd ReadReplicaDatabase) ReplicationStatus() (time.Time, int) {
+	if rrd.pulls == nil {
+		return time.Time{}, 0
+	}
+	return rrd.pulls.status()
+}
+
+func (rrd ReadReplicaDatabase) pullFromRemote(ctx *sql.Context) error {
 
 	_, headsArg, ok := sql.SystemVariables.GetGlobal(dsess.ReplicateHeads)
 	if !ok {
@@ -305,7 +355,8 @@ func pullBranches(
 	// back changes which were applied from another thread.
 
 	_, err := rrd.limiter.Run(ctx, "-all", func() (any, error) {
-		pullErr := rrd.ddb.PullChunks(ctx, rrd.tmpDir, rrd.srcDB, remoteHashes, nil, nil)
+		transferCtx := doltdb.WithTransferLabels(ctx, doltdb.TransferLabels{Database: rrd.Name(), Remote: rrd.remote.Name, Direction: doltdb.TransferPull})
+		pullErr := rrd.ddb.PullChunks(transferCtx, rrd.tmpDir, rrd.srcDB, remoteHashes, nil, nil)
 		if pullErr != nil {
 			return nil, pullErr
 		}
@@ -444,12 +495,41 @@ func (rrd ReadReplicaDatabase) pullLocalBranch(ctx *sql.Context, localRef doltdb
 			return false, err
 		}
 
+		if rrd.pulls != nil && countReplicaCommits.Load() {
+			commits, err := countCommitsNotIn(ctx, rrd.ddb, remoteRef.Hash, localRef.Hash, MaxCountedReplicaCommits)
+			if err != nil {
+				ctx.GetLogger().Debugf("unable to count commits pulled into %s: %v", localRef.Ref.String(), err)
+			} else {
+				rrd.pulls.pulledCommits(commits)
+			}
+		}
+
 		return true, nil
 	}
 
 	return false, nil
 }
 
+// countCommitsNotIn returns the number of commits reachable from |head| which are not reachable from |base|, counting
+// at most |limit| of them.
+func countCommitsNotIn(ctx *sql.Context, ddb *doltdb.DoltDB, head, base hash.Hash, limit int) (int, error) {
+	itr, err := commitwalk.GetDotDotRevisionsIterator[*sql.Context](ctx, ddb, []hash.Hash{head}, ddb, []hash.Hash{base}, nil)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for count < limit {
+		_, _, _, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, nil
+}
+
 // updateWorkingSet updates the working set for the branch ref given to the root value in that commit
 func (rrd ReadReplicaDatabase) updateWorkingSet(ctx *sql.Context, localRef doltdb.RefWithHash, behavior pullBehavior) error {
 	wsRef, err := ref.WorkingSetRefForHead(localRef.Ref)
@@ -559,6 +639,50 @@ type res struct {
 	err error
 }
 
+// replicaPulls records when a ReadReplicaDatabase last finished a pull from its remote, and how many commits that pull
+// brought into existing branches. It is shared by every copy of the database, including its branch revisions, since
+// they all pull the same heads.
+type replicaPulls struct {
+	mu             sync.Mutex
+	last           time.Time
+	lastCommits    int
+	pendingCommits int
+}
+
+func (p *replicaPulls) pulled(start time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if start.After(p.last) {
+		p.last = start
+		p.lastCommits = p.pendingCommits
+	}
+	p.pendingCommits = 0
+}
+
+func (p *replicaPulls) failed() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pendingCommits = 0
+}
+
+func (p *replicaPulls) pulledCommits(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pendingCommits += n
+}
+
+func (p *replicaPulls) status() (time.Time, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.last, p.lastCommits
+}
+
+func (p *replicaPulls) lastPull() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.last
+}
+
 type blocked struct {
 	f       func() (any, error)
 	waiters []chan res
@@ -22,8 +22,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/semaphore"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 func TestLimiter(t *testing.T) {
@@ -78,3 +85,78 @@ func TestLimiter(t *testing.T) {
 		assert.Equal(t, int32(1), numRuns)
 	})
 }
+
+func TestReadReplicaPulledWithin(t *testing.T) {
+	rrd := ReadReplicaDatabase{pulls: &replicaPulls{}}
+	assert.False(t, rrd.PulledWithin(time.Hour))
+
+	start := time.Now()
+	rrd.pulls.pulled(start)
+	assert.True(t, rrd.PulledWithin(time.Hour))
+	assert.False(t, rrd.PulledWithin(0))
+
+	// A pull which started earlier but finished later does not move the last pull back.
+	rrd.pulls.pulled(start.Add(-2 * time.Hour))
+	assert.True(t, rrd.PulledWithin(time.Hour))
+
+	assert.False(t, EmptyReadReplica.PulledWithin(time.Hour))
+}
+
+func TestReadReplicaReplicationStatus(t *testing.T) {
+	rrd := ReadReplicaDatabase{pulls: &replicaPulls{}}
+	lastPull, commits := rrd.ReplicationStatus()
+	assert.True(t, lastPull.IsZero())
+	assert.Equal(t, 0, commits)
+
+	start := time.Now()
+	rrd.pulls.pulledCommits(2)
+	rrd.pulls.pulledCommits(3)
+	rrd.pulls.pulled(start)
+	lastPull, commits = rrd.ReplicationStatus()
+	assert.Equal(t, start, lastPull)
+	assert.Equal(t, 5, commits)
+
+	// Commits counted by a failed pull are not reported.
+	rrd.pulls.pulledCommits(7)
+	rrd.pulls.failed()
+	rrd.pulls.pulled(start.Add(time.Second))
+	_, commits = rrd.ReplicationStatus()
+	assert.Equal(t, 0, commits)
+}
+
+func TestCountCommitsNotIn(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	dEnv := dtestutils.CreateTestEnv()
+	ddb := dEnv.DoltDB(ctx)
+
+	head, err := dEnv.HeadCommit(ctx)
+	require.NoError(t, err)
+	base, err := head.HashOf()
+	require.NoError(t, err)
+	root, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	var tip hash.Hash
+	for i := 0; i < 5; i++ {
+		meta, err := datas.NewCommitMeta("Bill Billerson", "bill@billerson.com", "empty commit")
+		require.NoError(t, err)
+		commit, err := ddb.Commit(ctx, valHash, ref.NewBranchRef("main"), meta)
+		require.NoError(t, err)
+		tip, err = commit.HashOf()
+		require.NoError(t, err)
+	}
+
+	count, err := countCommitsNotIn(ctx, ddb, tip, base, MaxCountedReplicaCommits)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	count, err = countCommitsNotIn(ctx, ddb, tip, base, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = countCommitsNotIn(ctx, ddb, base, tip, MaxCountedReplicaCommits)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// How often the read replica puller checks @@dolt_read_replica_max_staleness_millis while it is not set.
+const readReplicaPullerIdleInterval = time.Second
+
+// RunReadReplicaPuller starts a background thread which keeps the read replica databases of this provider pulled
+// from their remotes while @@dolt_read_replica_max_staleness_millis is set, so that transactions can begin on them
+// without pulling first. A replica is pulled once half of the max staleness has passed since its last pull, which
+// leaves the other half for the pull itself.
+func (p *DoltDatabaseProvider) RunReadReplicaPuller(bThreads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error)) error {
+	return bThreads.Add("read_replica_puller", func(ctx context.Context) {
+		timer := time.NewTimer(readReplicaPullerIdleInterval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+			interval := dsess.ReadReplicaMaxStaleness() / 2
+			if interval <= 0 {
+				timer.Reset(readReplicaPullerIdleInterval)
+				continue
+			}
+			p.pullStaleReplicas(ctx, ctxF, interval)
+			timer.Reset(interval)
+		}
+	})
+}
+
+// ReadReplicaStatus describes the replication state of a read replica database.
+type ReadReplicaStatus struct {
+	// The name of the read replica database.
+	Database string
+	// The remote the database pulls from.
+	Remote string
+	// When the database last finished a pull from its remote. The zero time if it has not.
+	LastPull time.Time
+	// The number of commits the last pull brought into the database's existing branches, which is how many commits
+	// behind its remote the database was when that pull started. At most MaxCountedReplicaCommits are counted per
+	// branch, and none are unless SetCountReadReplicaCommits has enabled counting.
+	CommitsBehind int
+}
+
+// ReadReplicaStatus returns the replication state of every read replica database of this provider.
+func (p *DoltDatabaseProvider) ReadReplicaStatus() []ReadReplicaStatus {
+	var statuses []ReadReplicaStatus
+	for _, db := range p.DoltDatabases() {
+		if rrd, ok := db.(ReadReplicaDatabase); ok && rrd.srcDB != nil {
+			lastPull, commits := rrd.ReplicationStatus()
+			statuses = append(statuses, ReadReplicaStatus{
+				Database:      rrd.Name(),
+				Remote:        rrd.remote.Name,
+				LastPull:      lastPull,
+				CommitsBehind: commits,
+			})
+		}
+	}
+	return statuses
+}
+
+// pullStaleReplicas pulls every read replica database which has not pulled within |interval|.
+func (p *DoltDatabaseProvider) pullStaleReplicas(ctx context.Context, ctxF func(context.Context) (*sql.Context, error), interval time.Duration) {
+	var replicas []ReadReplicaDatabase
+	for _, db := range p.DoltDatabases() {
+		if rrd, ok := db.(ReadReplicaDatabase); ok && rrd.srcDB != nil && !rrd.PulledWithin(interval) {
+			replicas = append(replicas, rrd)
+		}
+	}
+	if len(replicas) == 0 {
+		return
+	}
+
+	sqlCtx, err := ctxF(ctx)
+	if err != nil {
+		logrus.Warnf("sqle/read_replica_puller: could not create session to pull read replicas: %v", err)
+		return
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+	for _, rrd := range replicas {
+		if err := rrd.PullFromRemote(sqlCtx); err != nil {
+			// The next transaction on this replica will find it stale and pull itself,
+			// surfacing the error according to @@dolt_skip_replication_errors.
+			logrus.Warnf("sqle/read_replica_puller: pull of database %s from remote %s failed: %v", rrd.Name(), rrd.remote.Name, err)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// Storage maintenance extends auto GC with the compaction of the old
+// generation of each database. When it is enabled, the commit hook of
+// a database which does not currently need a GC periodically inspects
+// the storage files of the old generation and requests one of:
+//
+// * archive: the table files of the old generation are converted to
+// archives, compressed with zstd at the configured level.
+//
+// * conjoin: once enough small storage files have accumulated in the
+// old generation, they are conjoined into a single file.
+//
+// The requests are performed by the same background thread which
+// performs auto GC, so only one GC or maintenance operation runs at a
+// time. Every operation is recorded in a StorageMaintenanceLog, which
+// is exposed as the dolt_storage_maintenance_log system table.
+
+// StorageMaintenanceConfig configures the storage maintenance of an
+// AutoGCController.
+type StorageMaintenanceConfig struct {
+	// CheckInterval is how often each database is checked for
+	// maintenance work.
+	CheckInterval time.Duration
+	// ConjoinMinFiles is the number of small storage files which
+	// must accumulate in the old generation before they are
+	// conjoined.
+	ConjoinMinFiles int
+	// ConjoinMaxFileSize is the size at or below which a storage
+	// file is small.
+	ConjoinMaxFileSize uint64
+	// ArchiveZstdLevel is the zstd level used to compress
+	// archives. Zero disables archiving.
+	ArchiveZstdLevel int
+}
+
+type maintenanceOp string
+
+const (
+	maintenanceOpGC      maintenanceOp = "gc"
+	maintenanceOpConjoin maintenanceOp = "conjoin"
+	maintenanceOpArchive maintenanceOp = "archive"
+)
+
+const (
+	maintenanceStatusCompleted = "completed"
+	maintenanceStatusSkipped   = "skipped"
+	maintenanceStatusFailed    = "failed"
+)
+
+// errNothingToArchive is returned by an archive operation which did not
+// convert any table files, typically because they hold too few chunks
+// to build a compression dictionary.
+var errNothingToArchive = errors.New("no table files could be converted to archives")
+
+// maintenancePlan is the next maintenance operation to perform on a
+// database.
+type maintenancePlan struct {
+	op     maintenanceOp
+	files  []hash.Hash
+	reason string
+}
+
+// planStorageMaintenance returns the maintenance operation to perform
+// on an old generation made up of |files|, or false if there is
+// nothing to do. Table files are archived before any small files are
+// conjoined, so that conjoin works on the archives which result.
+// Table files in |unarchivable| were left in place by an earlier
+// archive operation and are not archived again.
+func planStorageMaintenance(files []nbs.StorageFileInfo, cfg StorageMaintenanceConfig, unarchivable hash.HashSet) (maintenancePlan, bool) {
+	if cfg.ArchiveZstdLevel > 0 {
+		tableFiles := 0
+		for _, f := range files {
+			if !f.IsArchive && !f.IsJournal && !unarchivable.Has(f.ID) {
+				tableFiles++
+			}
+		}
+		if tableFiles > 0 {
+			return maintenancePlan{
+				op:     maintenanceOpArchive,
+				reason: fmt.Sprintf("%d table files in old generation", tableFiles),
+			}, true
+		}
+	}
+
+	var small []hash.Hash
+	for _, f := range files {
+		if !f.IsJournal && f.Size <= cfg.ConjoinMaxFileSize {
+			small = append(small, f.ID)
+		}
+	}
+	if len(small) >= 2 && len(small) >= cfg.ConjoinMinFiles {
+		return maintenancePlan{
+			op:     maintenanceOpConjoin,
+			files:  small,
+			reason: fmt.Sprintf("%d storage files of at most %d bytes in old generation", len(small), cfg.ConjoinMaxFileSize),
+		}, true
+	}
+	return maintenancePlan{}, false
+}
+
+// oldGenBlockStore returns the block store of the old generation of
+// |db|, or false if |db| is not stored in generational table files.
+func oldGenBlockStore(db *doltdb.DoltDB) (*nbs.GenerationalNBS, *nbs.NomsBlockStore, bool) {
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(db))
+	gs, ok := cs.(*nbs.GenerationalNBS)
+	if !ok {
+		return nil, nil, false
+	}
+	oldGen, ok := gs.OldGen().(*nbs.NomsBlockStore)
+	if !ok {
+		return nil, nil, false
+	}
+	return gs, oldGen, true
+}
+
+// planMaintenance inspects the old generation of |db| and returns the
+// maintenance operation to perform on it, if any.
+func (c *AutoGCController) planMaintenance(db *doltdb.DoltDB, unarchivable hash.HashSet) (maintenancePlan, bool, error) {
+	if c.maintenance == nil {
+		return maintenancePlan{}, false, nil
+	}
+	_, oldGen, ok := oldGenBlockStore(db)
+	if !ok {
+		return maintenancePlan{}, false, nil
+	}
+	files, err := oldGen.StorageFiles()
+	if err != nil {
+		return maintenancePlan{}, false, err
+	}
+	plan, ok := planStorageMaintenance(files, *c.maintenance, unarchivable)
+	return plan, ok, nil
+}
+
+// tableFiles returns the ids of the table files, as opposed to
+// archives, in the old generation of |db|.
+func tableFiles(db *doltdb.DoltDB) (hash.HashSet, error) {
+	ret := hash.NewHashSet()
+	_, oldGen, ok := oldGenBlockStore(db)
+	if !ok {
+		return ret, nil
+	}
+	files, err := oldGen.StorageFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if !f.IsArchive && !f.IsJournal {
+			ret.Insert(f.ID)
+		}
+	}
+	return ret, nil
+}
+
+// runMaintenance performs the conjoin or archive operation of |work|.
+func (c *AutoGCController) runMaintenance(ctx context.Context, work autoGCWork) error {
+	gs, oldGen, ok := oldGenBlockStore(work.db)
+	if !ok {
+		return errors.New("database is not stored in generational table files")
+	}
+	switch work.op {
+	case maintenanceOpConjoin:
+		_, err := oldGen.ConjoinTableFiles(ctx, work.files)
+		return err
+	case maintenanceOpArchive:
+		progress := make(chan interface{})
+		drained := make(chan struct{})
+		go func() {
+			defer close(drained)
+			for range progress {
+			}
+		}()
+		archived, err := nbs.ArchiveOldGen(ctx, gs, c.maintenance.ArchiveZstdLevel, progress)
+		close(progress)
+		<-drained
+		if err == nil && archived == 0 {
+			err = errNothingToArchive
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown storage maintenance operation: %s", work.op)
+	}
+}
+
+// storageStats returns the number of storage files in the old
+// generation of |db| and the total size of |db|. Errors are ignored,
+// since the stats are only used for logging.
+func storageStats(ctx context.Context, db *doltdb.DoltDB) (int, uint64) {
+	var files int
+	if _, oldGen, ok := oldGenBlockStore(db); ok {
+		if fs, err := oldGen.StorageFiles(); err == nil {
+			files = len(fs)
+		}
+	}
+	sz, _ := db.StoreSizes(ctx)
+	return files, sz.TotalBytes
+}
+
+const storageMaintenanceLogSize = 1000
+
+// StorageMaintenanceLog is a bounded, in-memory record of the most
+// recent GC and storage maintenance operations of a sql-server.
+type StorageMaintenanceLog struct {
+	mu     sync.Mutex
+	events []dtables.StorageMaintenanceEvent
+	nextID uint64
+}
+
+func NewStorageMaintenanceLog() *StorageMaintenanceLog {
+	return &StorageMaintenanceLog{nextID: 1}
+}
+
+// Record adds |event| to the log, assigning it the next id. The
+// oldest event is dropped once the log is full.
+func (l *StorageMaintenanceLog) Record(event dtables.StorageMaintenanceEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	event.ID = l.nextID
+	l.nextID++
+	if len(l.events) == storageMaintenanceLogSize {
+		copy(l.events, l.events[1:])
+		l.events = l.events[:len(l.events)-1]
+	}
+	l.events = append(l.events, event)
+}
+
+// Events returns the logged events, oldest first.
+func (l *StorageMaintenanceLog) Events() []dtables.StorageMaintenanceEvent {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ret := make([]dtables.StorageMaintenanceEvent, len(l.events))
+	copy(ret, l.events)
+	return ret
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+func TestPlanStorageMaintenance(t *testing.T) {
+	cfg := StorageMaintenanceConfig{
+		CheckInterval:      time.Minute,
+		ConjoinMinFiles:    3,
+		ConjoinMaxFileSize: 1 << 20,
+		ArchiveZstdLevel:   3,
+	}
+	file := func(b byte, size uint64, isArchive bool) nbs.StorageFileInfo {
+		return nbs.StorageFileInfo{ID: hash.Hash{b}, Size: size, IsArchive: isArchive}
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := planStorageMaintenance(nil, cfg, nil)
+		assert.False(t, ok)
+	})
+	t.Run("ArchivesTableFiles", func(t *testing.T) {
+		plan, ok := planStorageMaintenance([]nbs.StorageFileInfo{file(1, 10, true), file(2, 10, false)}, cfg, nil)
+		require.True(t, ok)
+		assert.Equal(t, maintenanceOpArchive, plan.op)
+	})
+	t.Run("ArchivingDisabled", func(t *testing.T) {
+		cfg := cfg
+		cfg.ArchiveZstdLevel = 0
+		_, ok := planStorageMaintenance([]nbs.StorageFileInfo{file(1, 10, false), file(2, 10, false)}, cfg, nil)
+		assert.False(t, ok)
+	})
+	t.Run("SkipsUnarchivable", func(t *testing.T) {
+		files := []nbs.StorageFileInfo{file(1, 10, false), file(2, 10, true), file(3, 10, true)}
+		plan, ok := planStorageMaintenance(files, cfg, hash.NewHashSet(hash.Hash{1}))
+		require.True(t, ok)
+		assert.Equal(t, maintenanceOpConjoin, plan.op)
+		assert.Equal(t, []hash.Hash{{1}, {2}, {3}}, plan.files)
+	})
+	t.Run("ConjoinsSmallFiles", func(t *testing.T) {
+		files := []nbs.StorageFileInfo{file(1, 10, true), file(2, 2<<20, true), file(3, 1<<20, true), file(4, 10, true)}
+		plan, ok := planStorageMaintenance(files, cfg, nil)
+		require.True(t, ok)
+		assert.Equal(t, maintenanceOpConjoin, plan.op)
+		assert.Equal(t, []hash.Hash{{1}, {3}, {4}}, plan.files)
+	})
+	t.Run("TooFewSmallFiles", func(t *testing.T) {
+		files := []nbs.StorageFileInfo{file(1, 10, true), file(2, 2<<20, true), file(3, 10, true)}
+		_, ok := planStorageMaintenance(files, cfg, nil)
+		assert.False(t, ok)
+	})
+	t.Run("IgnoresJournal", func(t *testing.T) {
+		files := []nbs.StorageFileInfo{file(1, 10, true), file(2, 10, true), {ID: hash.Hash{3}, Size: 10, IsJournal: true}}
+		_, ok := planStorageMaintenance(files, cfg, nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestStorageMaintenanceLog(t *testing.T) {
+	log := NewStorageMaintenanceLog()
+	for i := 0; i < storageMaintenanceLogSize+10; i++ {
+		log.Record(dtables.StorageMaintenanceEvent{Database: "db"})
+	}
+	events := log.Events()
+	require.Len(t, events, storageMaintenanceLogSize)
+	assert.Equal(t, uint64(11), events[0].ID)
+	assert.Equal(t, uint64(storageMaintenanceLogSize+10), events[len(events)-1].ID)
+
+	var nilLog *StorageMaintenanceLog
+	assert.Empty(t, nilLog.Events())
+}
+
+func TestAutoGCControllerRecordsMaintenance(t *testing.T) {
+	lgr := logrus.New()
+	lgr.SetOutput(new(bytes.Buffer))
+	controller := NewAutoGCController(chunks.SimpleArchive, lgr)
+	controller.EnableStorageMaintenance(StorageMaintenanceConfig{ArchiveZstdLevel: 3})
+	log := NewStorageMaintenanceLog()
+	controller.SetStorageMaintenanceLog(log)
+	ctxF := func(ctx context.Context) (*sql.Context, error) {
+		return sql.NewContext(ctx, sql.WithSession(sql.NewBaseSession())), nil
+	}
+
+	ctx := context.Background()
+	dEnv := CreateTestEnvWithName("some_database")
+	// The test environment is not stored in generational table files, so the archive fails.
+	done := make(chan *gcWorkReport, 1)
+	controller.doWork(ctx, autoGCWork{
+		db:     dEnv.DoltDB(ctx),
+		done:   done,
+		name:   "some_database",
+		op:     maintenanceOpArchive,
+		reason: "testing",
+	}, ctxF)
+	report := <-done
+	require.NotNil(t, report)
+	assert.Equal(t, maintenanceOpArchive, report.op)
+
+	events := log.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "some_database", events[0].Database)
+	assert.Equal(t, "archive", events[0].Operation)
+	assert.Equal(t, "testing", events[0].Reason)
+	assert.Equal(t, maintenanceStatusFailed, events[0].Status)
+	assert.NotEmpty(t, events[0].Error)
+	assert.False(t, events[0].FinishedAt.Before(events[0].StartedAt))
+}
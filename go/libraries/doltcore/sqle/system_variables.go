@@ -39,6 +39,14 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemStringType("log_bin_branch"),
 		Default:           "main",
 	},
+	&sql.MysqlSystemVariable{
+		Name:              "log_bin_branch_databases",
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+		Dynamic:           false,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType("log_bin_branch_databases"),
+		Default:           "",
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.DoltOverrideSchema,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -103,6 +111,22 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemBoolType(dsess.ReplicateAllHeads),
 		Default:           int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReadReplicaMaxStalenessMillis,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.ReadReplicaMaxStalenessMillis, 0, math.MaxInt32, false),
+		Default:           int64(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReadReplicaConsistency,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemEnumType(dsess.ReadReplicaConsistency, dsess.ReadReplicaConsistencyEventual, dsess.ReadReplicaConsistencyStrong),
+		Default:           dsess.ReadReplicaConsistencyEventual,
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.AsyncReplication,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
@@ -111,6 +135,46 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 		Default:           int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaCommitMode,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemEnumType(dsess.BinlogReplicaCommitMode, dsess.BinlogReplicaCommitModeTransaction, dsess.BinlogReplicaCommitModeBatch, dsess.BinlogReplicaCommitModeOff),
+		Default:           dsess.BinlogReplicaCommitModeTransaction,
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaCommitIntervalSecs,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitIntervalSecs, 0, math.MaxInt32, false),
+		Default:           int64(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaCommitIntervalTxns,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitIntervalTxns, 0, math.MaxInt32, false),
+		Default:           int64(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaHeartbeatTagPrefix,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.BinlogReplicaHeartbeatTagPrefix),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaIgnoreGtids,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.BinlogReplicaIgnoreGtids),
+		Default:           "",
+	},
 	&sql.MysqlSystemVariable{
 		// MySQL exposes this as the --replicate-ignore-db CLI parameter, but we don't want to
 		// expose all the MySQL replication settings through the CLI, so we use this sys var.
@@ -292,6 +356,13 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType(dsess.AllowCICreation),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltOnlineIndexBuilds,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Session),
+		Type:    types.NewSystemBoolType(dsess.DoltOnlineIndexBuilds),
+		Default: int8(0),
+	},
 }
 
 func AddDoltSystemVariables() {
@@ -304,6 +375,14 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemStringType("log_bin_branch"),
 			Default:           "main",
 		},
+		&sql.MysqlSystemVariable{
+			Name:              "log_bin_branch_databases",
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+			Dynamic:           false,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType("log_bin_branch_databases"),
+			Default:           "",
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.DoltOverrideSchema,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -368,6 +447,22 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.ReplicateAllHeads),
 			Default:           int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReadReplicaMaxStalenessMillis,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.ReadReplicaMaxStalenessMillis, 0, math.MaxInt32, false),
+			Default:           int64(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReadReplicaConsistency,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemEnumType(dsess.ReadReplicaConsistency, dsess.ReadReplicaConsistencyEventual, dsess.ReadReplicaConsistencyStrong),
+			Default:           dsess.ReadReplicaConsistencyEventual,
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.AsyncReplication,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
@@ -376,6 +471,46 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 			Default:           int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaCommitMode,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemEnumType(dsess.BinlogReplicaCommitMode, dsess.BinlogReplicaCommitModeTransaction, dsess.BinlogReplicaCommitModeBatch, dsess.BinlogReplicaCommitModeOff),
+			Default:           dsess.BinlogReplicaCommitModeTransaction,
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaCommitIntervalSecs,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitIntervalSecs, 0, math.MaxInt32, false),
+			Default:           int64(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaCommitIntervalTxns,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitIntervalTxns, 0, math.MaxInt32, false),
+			Default:           int64(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaHeartbeatTagPrefix,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.BinlogReplicaHeartbeatTagPrefix),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaIgnoreGtids,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.BinlogReplicaIgnoreGtids),
+			Default:           "",
+		},
 		&sql.MysqlSystemVariable{ // If true, causes a Dolt commit to occur when you commit a transaction.
 			Name:              dsess.DoltCommitOnTransactionCommit,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -554,6 +689,13 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType(dsess.AllowCICreation),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltOnlineIndexBuilds,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Session),
+			Type:    types.NewSystemBoolType(dsess.DoltOnlineIndexBuilds),
+			Default: int8(0),
+		},
 	})
 	sql.SystemVariables.AddSystemVariables(DoltSystemVariables)
 }
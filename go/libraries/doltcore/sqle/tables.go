@@ -46,6 +46,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/fk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/indexbuild"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -2542,7 +2543,7 @@ func (t *AlterableDoltTable) createIndex(ctx *sql.Context, idx sql.IndexDef, key
 		}
 	}
 
-	ret, err := creation.CreateIndex(ctx, table, t.Name(), idx.Name, columns, allocatePrefixLengths(idx.Columns), schema.IndexProperties{
+	props := schema.IndexProperties{
 		IsUnique:      idx.Constraint == sql.IndexConstraint_Unique,
 		IsSpatial:     idx.Constraint == sql.IndexConstraint_Spatial,
 		IsFullText:    idx.Constraint == sql.IndexConstraint_Fulltext,
@@ -2560,7 +2561,13 @@ func (t *AlterableDoltTable) createIndex(ctx *sql.Context, idx sql.IndexDef, key
 			KeyPositions:     keyPositions,
 		},
 		VectorProperties: vectorProperties,
-	}, t.opts)
+	}
+
+	if submitted, err := t.submitOnlineIndexBuild(ctx, table, idx, columns, props); err != nil || submitted {
+		return err
+	}
+
+	ret, err := creation.CreateIndex(ctx, table, t.Name(), idx.Name, columns, allocatePrefixLengths(idx.Columns), props, t.opts)
 	if err != nil {
 		return err
 	}
@@ -2604,6 +2611,54 @@ func (t *AlterableDoltTable) createIndex(ctx *sql.Context, idx sql.IndexDef, key
 	return t.updateFromRoot(ctx, newRoot)
 }
 
+// submitOnlineIndexBuild submits the index |idx| to be built in the background when the session has enabled online
+// index builds and the index supports them. Returns whether the index was submitted, in which case the table must not
+// be modified.
+func (t *AlterableDoltTable) submitOnlineIndexBuild(ctx *sql.Context, table *doltdb.Table, idx sql.IndexDef, columns []string, props schema.IndexProperties) (bool, error) {
+	if !types.IsFormat_DOLT(table.Format()) || !indexbuild.SupportsOnlineBuild(props) {
+		return false, nil
+	}
+	enabled, err := indexbuild.Enabled(ctx)
+	if err != nil || !enabled {
+		return false, err
+	}
+	// engines which don't run background threads always build indexes synchronously
+	controller := indexbuild.GetController(ctx)
+	if controller == nil || !controller.Running() {
+		return false, nil
+	}
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+	indexName, realColNames, err := creation.ResolveIndexDefinition(sch, idx.Name, columns)
+	if err != nil {
+		return false, err
+	}
+	// replacing an implicit index requires foreign keys to be updated along with the table, so it's done synchronously
+	if existing, ok := sch.Indexes().GetIndexByColumnNames(realColNames...); ok && !existing.IsUserDefined() {
+		return false, nil
+	}
+	// validate the definition against a copy of the indexes, the build itself will add it to the table
+	prefixLengths := allocatePrefixLengths(idx.Columns)
+	if _, err = sch.Indexes().Copy().AddIndexByColNames(indexName, realColNames, prefixLengths, props); err != nil {
+		return false, err
+	}
+
+	id, err := controller.Submit(t.db.RevisionQualifiedName(), t.TableName(), indexbuild.Definition{
+		Name:          indexName,
+		Columns:       realColNames,
+		PrefixLengths: prefixLengths,
+		Props:         props,
+	})
+	if err != nil {
+		return false, err
+	}
+	ctx.Warn(0, "index `%s` is being built in the background as index build %d, see dolt_index_builds for its progress", indexName, id)
+	return true, nil
+}
+
 // createForeignKey creates a doltdb.ForeignKey from a sql.ForeignKeyConstraint
 func (t *WritableDoltTable) createForeignKey(
 	ctx *sql.Context,
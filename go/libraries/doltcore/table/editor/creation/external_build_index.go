@@ -35,16 +35,29 @@ import (
 const (
 	batchSize = 32 * 1024 * 1024 // 32MB
 	fileMax   = 128
+
+	// progressInterval is the number of primary rows read between progress reports.
+	progressInterval = 16 * 1024
 )
 
 // BuildProllyIndexExternal builds unique and non-unique indexes with a
 // single prolly tree materialization by presorting the index keys in an
 // intermediate file format.
 func BuildProllyIndexExternal(ctx *sql.Context, vrw types.ValueReadWriter, ns tree.NodeStore, sch schema.Schema, tableName string, idx schema.Index, primary prolly.Map, uniqCb DupEntryCb) (durable.Index, error) {
+	return buildProllyIndexExternal(ctx, vrw, ns, sch, tableName, idx, primary, uniqCb, nil)
+}
+
+// buildProllyIndexExternal is BuildProllyIndexExternal, calling |progress|, if it is not nil, with the number of
+// primary rows read so far every progressInterval rows.
+func buildProllyIndexExternal(ctx *sql.Context, vrw types.ValueReadWriter, ns tree.NodeStore, sch schema.Schema, tableName string, idx schema.Index, primary prolly.Map, uniqCb DupEntryCb, progress func(uint64)) (durable.Index, error) {
+	var iter prolly.MapIter
 	iter, err := primary.IterAll(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if progress != nil {
+		iter = &progressIter{iter: iter, progress: progress}
+	}
 	p := primary.Pool()
 
 	keyDesc, _ := idx.Schema().GetMapDescriptors(ns)
@@ -192,3 +205,22 @@ func (t *tupleIterWithCb) Next(ctx context.Context) (val.Tuple, val.Tuple) {
 		return curKey, val.EmptyTuple
 	}
 }
+
+// progressIter is a prolly.MapIter reporting the number of rows read from |iter| to |progress| every progressInterval
+// rows.
+type progressIter struct {
+	iter     prolly.MapIter
+	progress func(uint64)
+	rows     uint64
+}
+
+func (it *progressIter) Next(ctx context.Context) (val.Tuple, val.Tuple, error) {
+	k, v, err := it.iter.Next(ctx)
+	if err == nil {
+		it.rows++
+		if it.rows%progressInterval == 0 {
+			it.progress(it.rows)
+		}
+	}
+	return k, v, err
+}
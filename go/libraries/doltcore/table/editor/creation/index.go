@@ -57,29 +57,9 @@ func CreateIndex(
 		return nil, err
 	}
 
-	// get the real column names as CREATE INDEX columns are case-insensitive
-	var realColNames []string
-	allTableCols := sch.GetAllCols()
-	for _, indexCol := range columns {
-		tableCol, ok := allTableCols.GetByNameCaseInsensitive(indexCol)
-		if !ok {
-			return nil, fmt.Errorf("column `%s` does not exist for the table", indexCol)
-		}
-		realColNames = append(realColNames, tableCol.Name)
-	}
-
-	if indexName == "" {
-		indexName = strings.Join(realColNames, "")
-		_, ok := sch.Indexes().GetByNameCaseInsensitive(indexName)
-		var i int
-		for ok {
-			i++
-			indexName = fmt.Sprintf("%s_%d", strings.Join(realColNames, ""), i)
-			_, ok = sch.Indexes().GetByNameCaseInsensitive(indexName)
-		}
-	}
-	if !doltdb.IsValidIdentifier(indexName) {
-		return nil, fmt.Errorf("invalid index name `%s`", indexName)
+	indexName, realColNames, err := ResolveIndexDefinition(sch, indexName, columns)
+	if err != nil {
+		return nil, err
 	}
 
 	// if an index was already created for the column set but was not generated by the user then we replace it
@@ -132,6 +112,37 @@ func CreateIndex(
 	}, nil
 }
 
+// ResolveIndexDefinition resolves the case-insensitive |columns| of a new index on |sch| to the real column names, and
+// generates a name for the index if |indexName| is empty. Returns an error if a column does not exist or the index
+// name is invalid.
+func ResolveIndexDefinition(sch schema.Schema, indexName string, columns []string) (string, []string, error) {
+	// get the real column names as CREATE INDEX columns are case-insensitive
+	var realColNames []string
+	allTableCols := sch.GetAllCols()
+	for _, indexCol := range columns {
+		tableCol, ok := allTableCols.GetByNameCaseInsensitive(indexCol)
+		if !ok {
+			return "", nil, fmt.Errorf("column `%s` does not exist for the table", indexCol)
+		}
+		realColNames = append(realColNames, tableCol.Name)
+	}
+
+	if indexName == "" {
+		indexName = strings.Join(realColNames, "")
+		_, ok := sch.Indexes().GetByNameCaseInsensitive(indexName)
+		var i int
+		for ok {
+			i++
+			indexName = fmt.Sprintf("%s_%d", strings.Join(realColNames, ""), i)
+			_, ok = sch.Indexes().GetByNameCaseInsensitive(indexName)
+		}
+	}
+	if !doltdb.IsValidIdentifier(indexName) {
+		return "", nil, fmt.Errorf("invalid index name `%s`", indexName)
+	}
+	return indexName, realColNames, nil
+}
+
 func BuildSecondaryIndex(ctx *sql.Context, tbl *doltdb.Table, idx schema.Index, tableName string, opts editor.Options) (durable.Index, error) {
 	switch tbl.Format() {
 	case types.Format_LD_1:
@@ -155,7 +166,7 @@ func BuildSecondaryIndex(ctx *sql.Context, tbl *doltdb.Table, idx schema.Index,
 			return nil, err
 		}
 
-		return BuildSecondaryProllyIndex(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), sch, tableName, idx, primary)
+		return buildSecondaryProllyIndex(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), sch, tableName, idx, primary, opts.RowProgress)
 
 	default:
 		return nil, fmt.Errorf("unknown NomsBinFormat")
@@ -172,6 +183,19 @@ func BuildSecondaryProllyIndex(
 	tableName string,
 	idx schema.Index,
 	primary prolly.Map,
+) (durable.Index, error) {
+	return buildSecondaryProllyIndex(ctx, vrw, ns, sch, tableName, idx, primary, nil)
+}
+
+func buildSecondaryProllyIndex(
+	ctx *sql.Context,
+	vrw types.ValueReadWriter,
+	ns tree.NodeStore,
+	sch schema.Schema,
+	tableName string,
+	idx schema.Index,
+	primary prolly.Map,
+	progress func(uint64),
 ) (durable.Index, error) {
 	var uniqCb DupEntryCb
 	if idx.IsUnique() {
@@ -181,7 +205,62 @@ func BuildSecondaryProllyIndex(
 			return sql.NewUniqueKeyErr(msg, false, nil)
 		}
 	}
-	return BuildProllyIndexExternal(ctx, vrw, ns, sch, tableName, idx, primary, uniqCb)
+	return buildProllyIndexExternal(ctx, vrw, ns, sch, tableName, idx, primary, uniqCb, progress)
+}
+
+// CatchUpSecondaryProllyIndex updates |secondary|, an index built from the primary row data |from|, so that it
+// reflects the primary row data |to|. Both |from| and |to| must have been written with the schema |sch|. Returns the
+// updated index along with the number of primary rows that differed between |from| and |to|.
+func CatchUpSecondaryProllyIndex(
+	ctx *sql.Context,
+	sch schema.Schema,
+	tableName string,
+	idx schema.Index,
+	secondary prolly.Map,
+	from, to prolly.Map,
+) (prolly.Map, uint64, error) {
+	if from.HashOf() == to.HashOf() {
+		return secondary, 0, nil
+	}
+
+	secondaryBld, err := index.NewSecondaryKeyBuilder(ctx, tableName, sch, idx, secondary.KeyDesc(), to.Pool(), secondary.NodeStore())
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+
+	var changed uint64
+	mut := secondary.Mutate()
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, diff tree.Diff) error {
+		changed++
+		if diff.Type == tree.RemovedDiff || diff.Type == tree.ModifiedDiff {
+			idxKey, err := secondaryBld.SecondaryKeyFromRow(ctx, val.Tuple(diff.Key), val.Tuple(diff.From))
+			if err != nil {
+				return err
+			}
+			if err = mut.Delete(ctx, idxKey); err != nil {
+				return err
+			}
+		}
+		if diff.Type == tree.AddedDiff || diff.Type == tree.ModifiedDiff {
+			idxKey, err := secondaryBld.SecondaryKeyFromRow(ctx, val.Tuple(diff.Key), val.Tuple(diff.To))
+			if err != nil {
+				return err
+			}
+			if err = mut.Put(ctx, idxKey, val.EmptyTuple); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+	if err != nil && err != io.EOF {
+		return prolly.Map{}, 0, err
+	}
+
+	secondary, err = mut.Map(ctx)
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+	return secondary, changed, nil
 }
 
 // FormatKeyForUniqKeyErr formats the given tuple |key| using |d|. The resulting
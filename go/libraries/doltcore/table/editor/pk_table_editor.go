@@ -98,6 +98,9 @@ type Options struct {
 
 	// TargetStaging is true if the table is being edited in the staging root, as opposed to the working root (rare).
 	TargetStaging bool
+
+	// RowProgress, if set, is called periodically with the number of primary rows read while an index is built.
+	RowProgress func(rowsProcessed uint64)
 }
 
 // WithDeaf returns a new Options with the given  edit accumulator factory class
@@ -297,6 +297,11 @@ func (s *stats) read() Stats {
 	return ret
 }
 
+// Stats returns the progress the puller has made so far. Once |Pull| returns, it is the total for the pull.
+func (p *Puller) Stats() Stats {
+	return p.stats.read()
+}
+
 // Pull executes the sync operation
 func (p *Puller) Pull(ctx context.Context) error {
 	if p.statsCh != nil {
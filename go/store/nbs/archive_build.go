@@ -145,14 +145,17 @@ func unArchiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore,
 	return nil
 }
 
+// DefaultArchiveCompressionLevel is the zstd compression level used for chunks written to archives.
+const DefaultArchiveCompressionLevel = gozstd.DefaultCompressionLevel
+
 func BuildArchive(ctx context.Context, cs chunks.ChunkStore, dagGroups *ChunkRelations, purge bool, progress chan interface{}) (err error) {
 	if gs, ok := cs.(*GenerationalNBS); ok {
-		err = archiveSingleBlockStore(ctx, gs.newGen, dagGroups, purge, progress)
+		_, err = archiveSingleBlockStore(ctx, gs.newGen, dagGroups, purge, DefaultArchiveCompressionLevel, progress)
 		if err != nil {
 			return err
 		}
 
-		err = archiveSingleBlockStore(ctx, gs.oldGen, dagGroups, purge, progress)
+		_, err = archiveSingleBlockStore(ctx, gs.oldGen, dagGroups, purge, DefaultArchiveCompressionLevel, progress)
 		if err != nil {
 			return err
 		}
@@ -162,12 +165,25 @@ func BuildArchive(ctx context.Context, cs chunks.ChunkStore, dagGroups *ChunkRel
 	return nil
 }
 
-func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, dagGroups *ChunkRelations, purge bool, progress chan interface{}) error {
+// ArchiveOldGen converts the table files in the old generation of |cs| into archives, compressing their chunks with
+// zstd at |compressionLevel|. Chunks are not grouped, and the table files which are replaced are removed. Returns the
+// number of table files which were converted. Messages written to |progress| must be consumed by the caller.
+func ArchiveOldGen(ctx context.Context, cs chunks.ChunkStore, compressionLevel int, progress chan interface{}) (int, error) {
+	gs, ok := cs.(*GenerationalNBS)
+	if !ok {
+		return 0, errors.New("runtime error: GenerationalNBS Expected")
+	}
+	groupings := NewChunkRelations()
+	return archiveSingleBlockStore(ctx, gs.oldGen, &groupings, true, compressionLevel, progress)
+}
+
+func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, dagGroups *ChunkRelations, purge bool, compressionLevel int, progress chan interface{}) (int, error) {
 	// Currently, we don't have any stats to report. Required for calls to the lower layers tho.
 	var stats Stats
 
 	path, _ := blockStore.Path()
 
+	archived := 0
 	allFiles := make([]hash.Hash, 0, len(blockStore.tables.upstream))
 
 	// The source set changes out from under us, but the names of the table files will stay stable enough
@@ -191,7 +207,7 @@ func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, da
 
 		idx, err := cs.index()
 		if err != nil {
-			return err
+			return archived, err
 		}
 
 		originalSize := idx.tableFileSize()
@@ -199,26 +215,26 @@ func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, da
 		archivePath := ""
 		archiveName := hash.Hash{}
 		chkCnt := uint32(0)
-		archivePath, archiveName, chkCnt, err = convertTableFileToArchive(ctx, cs, idx, dagGroups, path, progress, &stats)
+		archivePath, archiveName, chkCnt, err = convertTableFileToArchive(ctx, cs, idx, dagGroups, path, compressionLevel, progress, &stats)
 		if err != nil {
 			if errors.Is(err, errNotEnoughChunks) {
 				progress <- fmt.Sprintf("Not enough chunks to build archive for %s. Skipping.", cs.hash().String())
 				continue
 			}
 
-			return err
+			return archived, err
 		}
 
 		fileInfo, err := os.Stat(archivePath)
 		if err != nil {
 			progress <- "Failed to stat archive file"
-			return err
+			return archived, err
 		}
 		archiveSize := fileInfo.Size()
 
 		err = verifyAllChunks(ctx, idx, archivePath, progress, &stats)
 		if err != nil {
-			return err
+			return archived, err
 		}
 
 		percentReduction := -100.0 * (float64(archiveSize)/float64(originalSize) - 1.0)
@@ -240,8 +256,9 @@ func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, da
 
 		err = blockStore.swapTables(ctx, newSpecs, chunks.GCMode_Default)
 		if err != nil {
-			return err
+			return archived, err
 		}
+		archived++
 
 		if len(purgeFile) > 0 {
 			err = os.Remove(purgeFile)
@@ -252,7 +269,7 @@ func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, da
 		}
 	}
 
-	return nil
+	return archived, nil
 }
 
 func convertTableFileToArchive(
@@ -261,6 +278,7 @@ func convertTableFileToArchive(
 	idx tableIndex,
 	dagGroups *ChunkRelations,
 	archivePath string,
+	compressionLevel int,
 	progress chan interface{},
 	stats *Stats,
 ) (string, hash.Hash, uint32, error) {
@@ -277,7 +295,7 @@ func convertTableFileToArchive(
 	}
 	defaultSamples = nil
 
-	defaultCDict, err := gozstd.NewCDict(defaultDict)
+	defaultCDict, err := gozstd.NewCDictLevel(defaultDict, compressionLevel)
 	if err != nil {
 		return "", hash.Hash{}, 0, err
 	}
@@ -35,6 +35,9 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/dolthub/dolt/go/libraries/utils/file"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -70,6 +73,8 @@ var _ tablePersister = &fsTablePersister{}
 var _ tableFilePersister = &fsTablePersister{}
 
 func (ftp *fsTablePersister) Open(ctx context.Context, name hash.Hash, chunkCount uint32, stats *Stats) (chunkSource, error) {
+	ctx, span := tracer.Start(ctx, "nbs.fsTablePersister.Open", trace.WithAttributes(attribute.String("name", name.String())))
+	defer span.End()
 	return newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, ftp.mmapArchiveIndexes, stats)
 }
 
@@ -114,7 +119,10 @@ func (ftp *fsTablePersister) Path() string {
 	return ftp.dir
 }
 
-func (ftp *fsTablePersister) CopyTableFile(_ context.Context, r io.Reader, fileId string, _ uint64, _ uint64) error {
+func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fileId string, _ uint64, _ uint64) error {
+	_, span := tracer.Start(ctx, "nbs.fsTablePersister.CopyTableFile", trace.WithAttributes(attribute.String("file_id", fileId)))
+	defer span.End()
+
 	tn, f, err := func() (n string, cleanup func(), err error) {
 		ftp.removeMu.Lock()
 		var temp *os.File
@@ -180,6 +188,12 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 		return emptyChunkSource{}, nil
 	}
 
+	ctx, span := tracer.Start(ctx, "nbs.fsTablePersister.persistTable", trace.WithAttributes(
+		attribute.Int("num_chunks", int(chunkCount)),
+		attribute.Int("bytes", len(data)),
+	))
+	defer span.End()
+
 	tempName, f, err := func() (tempName string, cleanup func(), ferr error) {
 		ftp.removeMu.Lock()
 		var temp *os.File
@@ -236,6 +250,9 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 }
 
 func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSources, stats *Stats) (chunkSource, cleanupFunc, error) {
+	ctx, span := tracer.Start(ctx, "nbs.fsTablePersister.ConjoinAll", trace.WithAttributes(attribute.Int("num_sources", len(sources))))
+	defer span.End()
+
 	plan, err := planRangeCopyConjoin(ctx, sources, ftp.q, stats)
 	if err != nil {
 		return emptyChunkSource{}, nil, err
@@ -334,6 +351,9 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 }
 
 func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, keeper func() []hash.Hash, mtime time.Time) error {
+	ctx, span := tracer.Start(ctx, "nbs.fsTablePersister.PruneTableFiles")
+	defer span.End()
+
 	ftp.removeMu.Lock()
 	if ftp.toKeep != nil {
 		ftp.removeMu.Unlock()
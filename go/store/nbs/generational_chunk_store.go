@@ -83,8 +83,38 @@ func (gcs *GenerationalNBS) OldGen() chunks.ChunkStoreGarbageCollector {
 	return gcs.oldGen
 }
 
+// isGhost returns true if |h| is the address of a ghost chunk. Ghost chunks take precedence over any chunks with
+// the same address which are still present in oldGen or newGen. Pruning history turns commits which are present
+// into ghosts, and their chunks must no longer be visible, so that GC does not retain the history behind them. A
+// ghost whose chunk is written to the store again, as when pruned history is fetched again, stops being a ghost; see
+// Put and AddTableFilesToManifest.
+func (gcs *GenerationalNBS) isGhost(h hash.Hash) bool {
+	return gcs.ghostGen != nil && gcs.ghostGen.has(h)
+}
+
+// splitGhosts returns the members of |hashes| which are not ghosts, and the members which are. |hashes| is not
+// modified.
+func (gcs *GenerationalNBS) splitGhosts(hashes hash.HashSet) (hash.HashSet, hash.HashSet) {
+	if gcs.ghostGen == nil {
+		return hashes, nil
+	}
+	ghosts := gcs.ghostGen.ghostsIn(hashes)
+	if ghosts == nil {
+		return hashes, nil
+	}
+	present := hashes.Copy()
+	for h := range ghosts {
+		present.Remove(h)
+	}
+	return present, ghosts
+}
+
 // Get the Chunk for the value of the hash in the store. If the hash is absent from the store EmptyChunk is returned.
 func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	if gcs.isGhost(h) {
+		return gcs.ghostGen.Get(ctx, h)
+	}
+
 	c, err := gcs.oldGen.Get(ctx, h)
 
 	if err != nil {
@@ -111,6 +141,17 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 // GetMany gets the Chunks with |hashes| from the store. On return, |foundChunks| will have been fully sent all chunks
 // which have been found. Any non-present chunks will silently be ignored.
 func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	hashes, ghosts := gcs.splitGhosts(hashes)
+	if len(ghosts) > 0 {
+		err := gcs.ghostGen.GetMany(ctx, ghosts, found)
+		if err != nil {
+			return err
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
 	mu := &sync.Mutex{}
 	notFound := hashes.Copy()
 	err := gcs.oldGen.GetMany(ctx, hashes, func(ctx context.Context, chunk *chunks.Chunk) {
@@ -160,6 +201,17 @@ func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.H
 }
 
 func (gcs *GenerationalNBS) getManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker), gcDepMode gcDependencyMode) error {
+	hashes, ghosts := gcs.splitGhosts(hashes)
+	if len(ghosts) > 0 {
+		err := gcs.ghostGen.getManyCompressed(ctx, ghosts, found, gcDepMode)
+		if err != nil {
+			return err
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
 	var mu sync.Mutex
 	notInOldGen := hashes.Copy()
 	err := gcs.oldGen.getManyCompressed(ctx, hashes, func(ctx context.Context, chunk ToChunker) {
@@ -269,7 +321,12 @@ func (gcs *GenerationalNBS) refCheck(recs []hasRecord) (hash.HashSet, error) {
 // to Flush(). Put may be called concurrently with other calls to Put(),
 // Get(), GetMany(), Has() and HasMany().
 func (gcs *GenerationalNBS) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCurry) error {
-	return gcs.newGen.putChunk(ctx, c, getAddrs, gcs.refCheck)
+	err := gcs.newGen.putChunk(ctx, c, getAddrs, gcs.refCheck)
+	if err == nil && gcs.isGhost(c.Hash()) {
+		// The chunk is a ghost no longer. This is persisted by the next Commit, along with the chunk itself.
+		gcs.ghostGen.removeGhostHash(c.Hash())
+	}
+	return err
 }
 
 // Returns the NomsBinFormat with which this ChunkSource is compatible.
@@ -306,7 +363,11 @@ func (gcs *GenerationalNBS) Root(ctx context.Context) (hash.Hash, error) {
 // persisted root hash from last to current (or keeps it the same).
 // If last doesn't match the root in persistent storage, returns false.
 func (gcs *GenerationalNBS) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
-	return gcs.newGen.commit(ctx, current, last, gcs.refCheck)
+	ok, err := gcs.newGen.commit(ctx, current, last, gcs.refCheck)
+	if err != nil || !ok || gcs.ghostGen == nil {
+		return ok, err
+	}
+	return ok, gcs.ghostGen.persistRemovals()
 }
 
 // Stats may return some kind of struct that reports statistics about the
@@ -424,7 +485,32 @@ func (gcs *GenerationalNBS) WriteTableFile(ctx context.Context, fileId string, s
 
 // AddTableFilesToManifest adds table files to the manifest of the newgen cs
 func (gcs *GenerationalNBS) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int, getAddrs chunks.GetAddrsCurry) error {
-	return gcs.newGen.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, gcs.refCheck)
+	if gcs.ghostGen == nil || gcs.ghostGen.size() == 0 {
+		return gcs.newGen.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, gcs.refCheck)
+	}
+
+	// Ghosts whose chunks are in the new table files are ghosts no longer.
+	absent, err := gcs.newGen.HasMany(ctx, gcs.ghostGen.ghosts())
+	if err != nil {
+		return err
+	}
+	err = gcs.newGen.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, gcs.refCheck)
+	if err != nil || len(absent) == 0 {
+		return err
+	}
+	stillAbsent, err := gcs.newGen.HasMany(ctx, absent)
+	if err != nil {
+		return err
+	}
+	added := absent.Copy()
+	for h := range stillAbsent {
+		added.Remove(h)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	gcs.ghostGen.removeGhostHashes(added)
+	return gcs.ghostGen.persistRemovals()
 }
 
 // PruneTableFiles deletes old table files that are no longer referenced in the manifest of the new or old gen chunkstores
@@ -17,6 +17,7 @@ package nbs
 import (
 	"context"
 	"math/rand"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -222,3 +223,158 @@ func TestGenerationalCSGetChunkLocationsDuplicates(t *testing.T) {
 	}
 	assert.Equal(t, 16, cnt)
 }
+
+func TestGenerationalCSGhostsTakePrecedence(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	ghostGen, err := NewGhostBlockStore(t.TempDir())
+	require.NoError(t, err)
+	inOld := make(map[int]bool)
+	inNew := make(map[int]bool)
+	chnks := genChunks(t, 4, 1024)
+
+	putChunks(t, ctx, chnks, oldGen, inOld, 0, 1)
+	putChunks(t, ctx, chnks, newGen, inNew, 2, 3)
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+	ghosts := hash.NewHashSet(chnks[1].Hash(), chnks[3].Hash())
+	require.NoError(t, ghostGen.PersistGhostHashes(ctx, ghosts))
+
+	for i, c := range chnks {
+		got, err := cs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		require.Equal(t, ghosts.Has(c.Hash()), got.IsGhost(), "chunk %d", i)
+		has, err := cs.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	all := hashesForChunks(chnks, mergeMaps(inOld, inNew))
+	gotGhosts := hash.NewHashSet()
+	gotChunks := hash.NewHashSet()
+	err = cs.GetMany(ctx, all, func(_ context.Context, c *chunks.Chunk) {
+		if c.IsGhost() {
+			gotGhosts.Insert(c.Hash())
+		} else {
+			gotChunks.Insert(c.Hash())
+		}
+	})
+	require.NoError(t, err)
+	require.Equal(t, ghosts, gotGhosts)
+	require.Len(t, gotChunks, 2)
+	require.Len(t, all, 4)
+
+	gotGhosts = hash.NewHashSet()
+	err = cs.GetManyCompressed(ctx, all, func(_ context.Context, c ToChunker) {
+		if c.IsGhost() {
+			gotGhosts.Insert(c.Hash())
+		}
+	})
+	require.NoError(t, err)
+	require.Equal(t, ghosts, gotGhosts)
+}
+
+func TestGenerationalCSRefetchedGhostIsNotAGhost(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	ghostDir := t.TempDir()
+	ghostGen, err := NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	inOld := make(map[int]bool)
+	chnks := genChunks(t, 3, 1024)
+
+	// Chunk 0 is pruned but has not been collected yet, while chunks 1 and 2 are pruned and gone.
+	putChunks(t, ctx, chnks, oldGen, inOld, 0)
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+	require.NoError(t, ghostGen.PersistGhostHashes(ctx, hashesForChunks(chnks, map[int]bool{0: true, 1: true, 2: true})))
+	for _, c := range chnks {
+		got, err := cs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		require.True(t, got.IsGhost())
+	}
+
+	// Fetching chunks 0 and 1 again makes them visible straight away.
+	require.NoError(t, cs.Put(ctx, chnks[0], noopGetAddrs))
+	require.NoError(t, cs.Put(ctx, chnks[1], noopGetAddrs))
+	for i, c := range chnks {
+		got, err := cs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		require.Equal(t, i == 2, got.IsGhost(), "chunk %d", i)
+		if i < 2 {
+			require.Equal(t, c.Data(), got.Data(), "chunk %d", i)
+		}
+	}
+	found := hash.NewHashSet()
+	err = cs.GetMany(ctx, hashesForChunks(chnks, map[int]bool{0: true, 1: true}), func(_ context.Context, c *chunks.Chunk) {
+		require.False(t, c.IsGhost())
+		found.Insert(c.Hash())
+	})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	// The ghost set on disk is only updated once the chunks are committed.
+	reopened, err := NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	require.Equal(t, 3, reopened.skippedRefs.Size())
+
+	root, err := cs.Root(ctx)
+	require.NoError(t, err)
+	ok, err := cs.Commit(ctx, root, root)
+	require.NoError(t, err)
+	require.True(t, ok)
+	reopened, err = NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	require.Equal(t, hash.NewHashSet(chnks[2].Hash()), *reopened.skippedRefs)
+}
+
+func TestGenerationalCSConcurrentGhostPuts(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	ghostDir := t.TempDir()
+	ghostGen, err := NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	chnks := genChunks(t, 64, 256)
+	all := hash.NewHashSet()
+	for _, c := range chnks {
+		all.Insert(c.Hash())
+	}
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+	require.NoError(t, ghostGen.PersistGhostHashes(ctx, all))
+
+	// Fetching pruned chunks again while they are being read must not lose any of the removals.
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(chnks); i += 4 {
+				assert.NoError(t, cs.Put(ctx, chnks[i], noopGetAddrs))
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for _, c := range chnks {
+				_, err := cs.Has(ctx, c.Hash())
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, cs.GetMany(ctx, all, func(context.Context, *chunks.Chunk) {}))
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range chnks {
+		got, err := cs.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		require.False(t, got.IsGhost())
+	}
+	root, err := cs.Root(ctx)
+	require.NoError(t, err)
+	ok, err := cs.Commit(ctx, root, root)
+	require.NoError(t, err)
+	require.True(t, ok)
+	reopened, err := NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	require.Equal(t, 0, reopened.skippedRefs.Size())
+}
@@ -22,6 +22,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/constants"
@@ -29,8 +30,12 @@ import (
 )
 
 type GhostBlockStore struct {
+	// mu guards |skippedRefs| and |unpersisted|, which are updated by Puts to the GenerationalNBS while it reads them.
+	mu               sync.RWMutex
 	skippedRefs      *hash.HashSet
 	ghostObjectsFile string
+	// unpersisted is true when hashes have been removed from |skippedRefs| but not yet from |ghostObjectsFile|.
+	unpersisted bool
 }
 
 // We use the Has, HasMany, Get, GetMany, GetManyCompressed, and PersistGhostHashes methods from the ChunkStore interface. All other methods are not supported.
@@ -74,31 +79,27 @@ func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
 
 // Get returns a ghost chunk if the hash is in the ghostObjectsFile. Otherwise, it returns an empty chunk. Chunks returned
 // by this code will always be ghost chunks, ie chunk.IsGhost() will always return true.
-func (g GhostBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
-	if g.skippedRefs.Has(h) {
+func (g *GhostBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	if g.has(h) {
 		return *chunks.NewGhostChunk(h), nil
 	}
 	return chunks.EmptyChunk, nil
 }
 
-func (g GhostBlockStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
-	for h := range hashes {
-		if g.skippedRefs.Has(h) {
-			found(ctx, chunks.NewGhostChunk(h))
-		}
+func (g *GhostBlockStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	for h := range g.ghostsIn(hashes) {
+		found(ctx, chunks.NewGhostChunk(h))
 	}
 	return nil
 }
 
-func (g GhostBlockStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker)) error {
+func (g *GhostBlockStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker)) error {
 	return g.getManyCompressed(ctx, hashes, found, gcDependencyMode_TakeDependency)
 }
 
-func (g GhostBlockStore) getManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker), gcDepMode gcDependencyMode) error {
-	for h := range hashes {
-		if g.skippedRefs.Has(h) {
-			found(ctx, NewGhostCompressedChunk(h))
-		}
+func (g *GhostBlockStore) getManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker), gcDepMode gcDependencyMode) error {
+	for h := range g.ghostsIn(hashes) {
+		found(ctx, NewGhostCompressedChunk(h))
 	}
 	return nil
 }
@@ -108,38 +109,131 @@ func (g *GhostBlockStore) PersistGhostHashes(ctx context.Context, hashes hash.Ha
 		return fmt.Errorf("runtime error. PersistGhostHashes called with empty hash set")
 	}
 
-	f, err := os.OpenFile(g.ghostObjectsFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.writeGhostObjectsFile(hashes); err != nil {
 		return err
 	}
-	defer f.Close()
 
+	g.skippedRefs = &hash.HashSet{}
 	for h := range hashes {
-		if _, err := f.WriteString(h.String() + "\n"); err != nil {
-			return err
+		g.skippedRefs.Insert(h)
+	}
+	g.unpersisted = false
+
+	return nil
+}
+
+// has returns true if |h| is a ghost.
+func (g *GhostBlockStore) has(h hash.Hash) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.skippedRefs.Has(h)
+}
+
+// size returns the number of ghosts.
+func (g *GhostBlockStore) size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.skippedRefs.Size()
+}
+
+// ghosts returns a copy of the set of ghosts.
+func (g *GhostBlockStore) ghosts() hash.HashSet {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.skippedRefs.Copy()
+}
+
+// ghostsIn returns the members of |hashes| which are ghosts, or nil if there are none.
+func (g *GhostBlockStore) ghostsIn(hashes hash.HashSet) hash.HashSet {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var ghosts hash.HashSet
+	if g.skippedRefs.Size() == 0 {
+		return nil
+	}
+	for h := range hashes {
+		if g.skippedRefs.Has(h) {
+			if ghosts == nil {
+				ghosts = hash.NewHashSet()
+			}
+			ghosts.Insert(h)
 		}
 	}
+	return ghosts
+}
 
-	g.skippedRefs = &hash.HashSet{}
+// removeGhostHashes removes |hashes| from the ghost set, so that the real chunks with those addresses are visible
+// again. ghostObjects.txt is only updated by the next call to persistRemovals, so a removal is lost, and the hashes
+// are ghosts again, if the store is closed without one.
+func (g *GhostBlockStore) removeGhostHashes(hashes hash.HashSet) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	for h := range hashes {
-		g.skippedRefs.Insert(h)
+		g.removeLocked(h)
 	}
+}
 
-	return nil
+// removeGhostHash removes the single hash |h| from the ghost set, like removeGhostHashes.
+func (g *GhostBlockStore) removeGhostHash(h hash.Hash) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.removeLocked(h)
 }
 
-func (g GhostBlockStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
+func (g *GhostBlockStore) removeLocked(h hash.Hash) {
 	if g.skippedRefs.Has(h) {
-		return true, nil
+		g.skippedRefs.Remove(h)
+		g.unpersisted = true
+	}
+}
+
+// persistRemovals writes the hashes removed by removeGhostHashes out to ghostObjects.txt.
+func (g *GhostBlockStore) persistRemovals() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.unpersisted {
+		return nil
 	}
-	return false, nil
+	if g.skippedRefs.Size() == 0 {
+		if err := os.Remove(g.ghostObjectsFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	} else if err := g.writeGhostObjectsFile(*g.skippedRefs); err != nil {
+		return err
+	}
+	g.unpersisted = false
+	return nil
+}
+
+func (g *GhostBlockStore) writeGhostObjectsFile(hashes hash.HashSet) error {
+	f, err := os.OpenFile(g.ghostObjectsFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for h := range hashes {
+		if _, err := f.WriteString(h.String() + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GhostBlockStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	return g.has(h), nil
 }
 
-func (g GhostBlockStore) HasMany(ctx context.Context, hashes hash.HashSet) (absent hash.HashSet, err error) {
+func (g *GhostBlockStore) HasMany(ctx context.Context, hashes hash.HashSet) (absent hash.HashSet, err error) {
 	return g.hasMany(hashes)
 }
 
-func (g GhostBlockStore) hasMany(hashes hash.HashSet) (absent hash.HashSet, err error) {
+func (g *GhostBlockStore) hasMany(hashes hash.HashSet) (absent hash.HashSet, err error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	absent = hash.HashSet{}
 	for h := range hashes {
 		if !g.skippedRefs.Has(h) {
@@ -149,7 +243,9 @@ func (g GhostBlockStore) hasMany(hashes hash.HashSet) (absent hash.HashSet, err
 	return absent, nil
 }
 
-func (g GhostBlockStore) refCheck(recs []hasRecord) (hash.HashSet, error) {
+func (g *GhostBlockStore) refCheck(recs []hasRecord) (hash.HashSet, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	absent := hash.HashSet{}
 	for i := range recs {
 		if !recs[i].has {
@@ -163,39 +259,39 @@ func (g GhostBlockStore) refCheck(recs []hasRecord) (hash.HashSet, error) {
 	return absent, nil
 }
 
-func (g GhostBlockStore) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCurry) error {
+func (g *GhostBlockStore) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCurry) error {
 	panic("GhostBlockStore does not support Put")
 }
 
-func (g GhostBlockStore) Version() string {
+func (g *GhostBlockStore) Version() string {
 	// This should never be used, but it makes testing a bit more ergonomic in a few places.
 	return constants.FormatDefaultString
 }
 
-func (g GhostBlockStore) AccessMode() chunks.ExclusiveAccessMode {
+func (g *GhostBlockStore) AccessMode() chunks.ExclusiveAccessMode {
 	panic("GhostBlockStore does not support AccessMode")
 }
 
-func (g GhostBlockStore) Rebase(ctx context.Context) error {
+func (g *GhostBlockStore) Rebase(ctx context.Context) error {
 	panic("GhostBlockStore does not support Rebase")
 }
 
-func (g GhostBlockStore) Root(ctx context.Context) (hash.Hash, error) {
+func (g *GhostBlockStore) Root(ctx context.Context) (hash.Hash, error) {
 	panic("GhostBlockStore does not support Root")
 }
 
-func (g GhostBlockStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+func (g *GhostBlockStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
 	panic("GhostBlockStore does not support Commit")
 }
 
-func (g GhostBlockStore) Stats() interface{} {
+func (g *GhostBlockStore) Stats() interface{} {
 	panic("GhostBlockStore does not support Stats")
 }
 
-func (g GhostBlockStore) StatsSummary() string {
+func (g *GhostBlockStore) StatsSummary() string {
 	panic("GhostBlockStore does not support StatsSummary")
 }
 
-func (g GhostBlockStore) Close() error {
+func (g *GhostBlockStore) Close() error {
 	panic("GhostBlockStore does not support Close")
 }
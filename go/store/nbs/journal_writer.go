@@ -27,6 +27,7 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -487,6 +488,8 @@ func (wr *journalWriter) commitRootHashUnlocked(ctx context.Context, root hash.H
 	}
 	func() {
 		defer trace.StartRegion(ctx, "sync").End()
+		_, span := tracer.Start(ctx, "nbs.journalWriter.sync")
+		defer span.End()
 
 		err = wr.journal.Sync()
 	}()
@@ -563,6 +566,9 @@ func (wr *journalWriter) getBytes(ctx context.Context, n int) (buf []byte, err e
 // flush writes buffered data into the journal file.
 func (wr *journalWriter) flush(ctx context.Context) (err error) {
 	defer trace.StartRegion(ctx, "flush journal").End()
+	_, span := tracer.Start(ctx, "nbs.journalWriter.flush")
+	defer span.End()
+	span.SetAttributes(attribute.Int("bytes", len(wr.buf)))
 	if _, err = wr.journal.WriteAt(wr.buf, wr.off); err != nil {
 		return err
 	}
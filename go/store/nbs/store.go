@@ -2323,13 +2323,60 @@ func CalcReads(nbs *NomsBlockStore, hashes hash.HashSet, blockSize uint64, keepe
 	return reads, split, gcb, err
 }
 
+// StorageFileInfo describes a storage file in the table set of a NomsBlockStore.
+type StorageFileInfo struct {
+	ID         hash.Hash
+	Size       uint64
+	ChunkCount uint32
+	IsArchive  bool
+	IsJournal  bool
+}
+
+// StorageFiles returns a description of each storage file in the persisted table set of the store, ordered by id.
+func (nbs *NomsBlockStore) StorageFiles() ([]StorageFileInfo, error) {
+	nbs.mu.RLock()
+	defer nbs.mu.RUnlock()
+
+	files := make([]StorageFileInfo, 0, len(nbs.tables.upstream))
+	for id, cs := range nbs.tables.upstream {
+		cnt, err := cs.count()
+		if err != nil {
+			return nil, err
+		}
+		_, isArchive := cs.(archiveChunkSource)
+		files = append(files, StorageFileInfo{
+			ID:         id,
+			Size:       cs.currentSize(),
+			ChunkCount: cnt,
+			IsArchive:  isArchive,
+			IsJournal:  isJournalAddr(id),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ID.Less(files[j].ID)
+	})
+	return files, nil
+}
+
 // ConjoinTableFiles conjoins the specified table files into a single new table file.
 // The storageIds slice contains the hash IDs of the table files to conjoin. If the slice is empty, then all
 // files in oldgen are conjoined together.
 // Returns the hash of the newly created conjoined table file.
-func (nbs *NomsBlockStore) ConjoinTableFiles(ctx context.Context, storageIds []hash.Hash) (hash.Hash, error) {
-	nbs.mu.RLock()
-	defer nbs.mu.RUnlock()
+func (nbs *NomsBlockStore) ConjoinTableFiles(ctx context.Context, storageIds []hash.Hash) (conjoinedHash hash.Hash, err error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	// Like a background conjoin or a GC, this replaces the store's table files, so it must not run alongside either.
+	for nbs.conjoinOp != nil {
+		nbs.conjoinOpCond.Wait()
+	}
+	if err := nbs.waitForGC(ctx); err != nil {
+		return hash.Hash{}, err
+	}
+
+	nbs.manifestMgr.LockForUpdate()
+	defer func() {
+		err = errors.Join(err, nbs.manifestMgr.UnlockForUpdate())
+	}()
 
 	// If no storageIds provided, collect all table files from the current table set
 	if len(storageIds) == 0 {
@@ -2360,12 +2407,16 @@ func (nbs *NomsBlockStore) ConjoinTableFiles(ctx context.Context, storageIds []h
 	}
 
 	// Update the in-memory state
-	nbs.upstream = newUpstream
 	newTables, err := nbs.tables.rebase(ctx, newUpstream.specs, nil, nbs.stats)
 	if err != nil {
 		return hash.Hash{}, err
 	}
+	nbs.upstream = newUpstream
+	oldTables := nbs.tables
 	nbs.tables = newTables
+	if err = oldTables.close(); err != nil {
+		return hash.Hash{}, err
+	}
 
 	// Cleanup of original files. This is destructive, so we only do it when the rebase doesn't error.
 	// Also note that nothing below here actually makes any changes to the db, so we can do the cleanup now.
@@ -2379,7 +2430,6 @@ func (nbs *NomsBlockStore) ConjoinTableFiles(ctx context.Context, storageIds []h
 		originalSpecSet[spec.name] = true
 	}
 
-	var conjoinedHash hash.Hash
 	for _, spec := range newUpstream.specs {
 		if !originalSpecSet[spec.name] {
 			conjoinedHash = spec.name
@@ -19,6 +19,10 @@ import (
 	"context"
 	"sync"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/pool"
@@ -71,6 +75,8 @@ var _ NodeStore = &nodeStore{}
 
 var sharedCache = newChunkCache(cacheSize)
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/store/prolly/tree")
+
 var sharedPool = pool.NewBuffPool()
 
 var blobBuilderPool = sync.Pool{
@@ -96,6 +102,9 @@ func (ns *nodeStore) Read(ctx context.Context, ref hash.Hash) (*Node, error) {
 		return n, nil
 	}
 
+	ctx, span := tracer.Start(ctx, "tree.nodeStore.Read")
+	defer span.End()
+
 	c, err := ns.store.Get(ctx, ref)
 	if err != nil {
 		return nil, err
@@ -125,6 +134,12 @@ func (ns *nodeStore) ReadMany(ctx context.Context, addrs hash.HashSlice) ([]*Nod
 		}
 	}
 
+	if len(gets) > 0 {
+		var span trace.Span
+		ctx, span = tracer.Start(ctx, "tree.nodeStore.ReadMany", trace.WithAttributes(attribute.Int("num_hashes", len(gets))))
+		defer span.End()
+	}
+
 	var nerr error
 	mu := new(sync.Mutex)
 	err := ns.store.GetMany(ctx, gets, func(ctx context.Context, chunk *chunks.Chunk) {
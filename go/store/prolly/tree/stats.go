@@ -164,3 +164,74 @@ func GetHistogramLevel[K, V ~[]byte, O Ordering[K]](ctx context.Context, m Stati
 	}
 	return currentLevel, nil
 }
+
+// StorageStats summarizes the physical shape of a prolly tree.
+type StorageStats struct {
+	// Height is the number of levels in the tree.
+	Height int
+	// NodeCount is the number of node references visited in a walk of the tree.
+	NodeCount uint64
+	// TotalBytes is the sum of the sizes of every node referenced by the tree.
+	TotalBytes uint64
+	// UniqueBytes is the sum of the sizes of the distinct chunks in the tree.
+	UniqueBytes uint64
+	// SharedBytes is the portion of UniqueBytes also reachable from a base tree.
+	SharedBytes uint64
+}
+
+// AverageFill returns the mean node size as a fraction of the chunker's
+// target chunk size.
+func (s StorageStats) AverageFill() float64 {
+	if s.NodeCount == 0 {
+		return 0
+	}
+	return float64(s.TotalBytes) / float64(s.NodeCount) / targetSize
+}
+
+// GetStorageStats walks every node of the tree rooted at |root| and returns
+// its StorageStats. If |base| is non-nil, chunks reachable from |base| are
+// counted towards SharedBytes.
+func GetStorageStats(ctx context.Context, ns NodeStore, root, base *Node) (StorageStats, error) {
+	var stats StorageStats
+	if root == nil {
+		return stats, nil
+	}
+
+	baseAddrs := make(hash.HashSet)
+	if base != nil && base.HashOf() != root.HashOf() {
+		err := WalkNodes(ctx, base, ns, func(ctx context.Context, nd *Node) error {
+			baseAddrs.Insert(nd.HashOf())
+			return nil
+		})
+		if err != nil {
+			return StorageStats{}, err
+		}
+	}
+
+	seen := make(hash.HashSet)
+	err := WalkNodes(ctx, root, ns, func(ctx context.Context, nd *Node) error {
+		sz := uint64(nd.Size())
+		stats.NodeCount++
+		stats.TotalBytes += sz
+
+		h := nd.HashOf()
+		if seen.Has(h) {
+			return nil
+		}
+		seen.Insert(h)
+		stats.UniqueBytes += sz
+		if baseAddrs.Has(h) {
+			stats.SharedBytes += sz
+		}
+		return nil
+	})
+	if err != nil {
+		return StorageStats{}, err
+	}
+
+	if base != nil && base.HashOf() == root.HashOf() {
+		stats.SharedBytes = stats.UniqueBytes
+	}
+	stats.Height = root.Level() + 1
+	return stats, nil
+}
@@ -91,3 +91,35 @@ func histLevelCount(t *testing.T, nodes []*Node) int {
 	}
 	return cnt
 }
+
+func TestGetStorageStats(t *testing.T) {
+	ctx := context.Background()
+
+	for _, count := range []int{10, 1e3, 1e5} {
+		t.Run(fmt.Sprintf("storage stats count: %d", count), func(t *testing.T) {
+			root, _, ns := randomTree(t, count*2)
+
+			var nodes uint64
+			var size uint64
+			err := WalkNodes(ctx, root, ns, func(ctx context.Context, nd *Node) error {
+				nodes++
+				size += uint64(nd.Size())
+				return nil
+			})
+			require.NoError(t, err)
+
+			stats, err := GetStorageStats(ctx, ns, root, nil)
+			require.NoError(t, err)
+			require.Equal(t, root.Level()+1, stats.Height)
+			require.Equal(t, nodes, stats.NodeCount)
+			require.Equal(t, size, stats.TotalBytes)
+			require.LessOrEqual(t, stats.UniqueBytes, stats.TotalBytes)
+			require.Zero(t, stats.SharedBytes)
+			require.Greater(t, stats.AverageFill(), 0.0)
+
+			shared, err := GetStorageStats(ctx, ns, root, root)
+			require.NoError(t, err)
+			require.Equal(t, shared.UniqueBytes, shared.SharedBytes)
+		})
+	}
+}
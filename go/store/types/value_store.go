@@ -27,6 +27,9 @@ import (
 	"fmt"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/d"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -549,6 +552,12 @@ type GCSafepointController interface {
 func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, cmp chunks.GCArchiveLevel, oldGenRefs, newGenRefs hash.HashSet, safepoint GCSafepointController) error {
 	lvs.versOnce.Do(lvs.expectVersion)
 
+	ctx, span := tracer.Start(ctx, "ValueStore.GC", trace.WithAttributes(
+		attribute.Bool("full", mode == GCModeFull),
+		attribute.Int("archive_level", int(cmp)),
+	))
+	defer span.End()
+
 	lvs.transitionToOldGenGC()
 	defer lvs.transitionToNoGC()
 
@@ -604,7 +613,7 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, cmp chunks.GCArchive
 			newGenRefs.Insert(root)
 
 			var oldGenFinalizer, newGenFinalizer chunks.GCFinalizer
-			oldGenFinalizer, err = lvs.gc(ctx, oldGenRefs, oldGenHasMany, chksMode, cmp, collector, oldGen, nil, func() hash.HashSet {
+			oldGenFinalizer, err = lvs.gc(ctx, "oldgen", oldGenRefs, oldGenHasMany, chksMode, cmp, collector, oldGen, nil, func() hash.HashSet {
 				n := lvs.transitionToNewGenGC()
 				newGenRefs.InsertAll(n)
 				return make(hash.HashSet)
@@ -630,19 +639,19 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, cmp chunks.GCArchive
 				oldGenHasMany = newFileHasMany
 			}
 
-			newGenFinalizer, err = lvs.gc(ctx, newGenRefs, oldGenHasMany, chksMode, cmp, collector, newGen, safepoint, lvs.transitionToFinalizingGC)
+			newGenFinalizer, err = lvs.gc(ctx, "newgen", newGenRefs, oldGenHasMany, chksMode, cmp, collector, newGen, safepoint, lvs.transitionToFinalizingGC)
 			if err != nil {
 				return err
 			}
 			callCancelSafepoint = false
 
-			err = newGenFinalizer.SwapChunksInStore(ctx)
+			err = swapChunksInStore(ctx, "newgen", newGenFinalizer)
 			if err != nil {
 				return err
 			}
 
 			if mode == GCModeFull {
-				err = oldGenFinalizer.SwapChunksInStore(ctx)
+				err = swapChunksInStore(ctx, "oldgen", oldGenFinalizer)
 				if err != nil {
 					return err
 				}
@@ -690,13 +699,13 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, cmp chunks.GCArchive
 			newGenRefs.Insert(root)
 
 			var finalizer chunks.GCFinalizer
-			finalizer, err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, chunks.GCMode_Full, cmp, collector, collector, safepoint, lvs.transitionToFinalizingGC)
+			finalizer, err = lvs.gc(ctx, "newgen", newGenRefs, unfilteredHashFunc, chunks.GCMode_Full, cmp, collector, collector, safepoint, lvs.transitionToFinalizingGC)
 			if err != nil {
 				return err
 			}
 			callCancelSafepoint = false
 
-			err = finalizer.SwapChunksInStore(ctx)
+			err = swapChunksInStore(ctx, "newgen", finalizer)
 			if err != nil {
 				return err
 			}
@@ -712,13 +721,23 @@ func (lvs *ValueStore) GC(ctx context.Context, mode GCMode, cmp chunks.GCArchive
 	}
 
 	if tfs, ok := lvs.cs.(chunks.TableFileStore); ok {
+		ctx, span := tracer.Start(ctx, "ValueStore.GC.prune")
+		defer span.End()
 		return tfs.PruneTableFiles(ctx)
 	}
 
 	return nil
 }
 
+// swapChunksInStore swaps the chunks collected into the |generation| store in for its existing ones.
+func swapChunksInStore(ctx context.Context, generation string, finalizer chunks.GCFinalizer) error {
+	ctx, span := tracer.Start(ctx, "ValueStore.GC.swap", trace.WithAttributes(attribute.String("generation", generation)))
+	defer span.End()
+	return finalizer.SwapChunksInStore(ctx)
+}
+
 func (lvs *ValueStore) gc(ctx context.Context,
+	generation string,
 	toVisit hash.HashSet,
 	hashFilter chunks.HasManyFunc,
 	chksMode chunks.GCMode,
@@ -726,6 +745,9 @@ func (lvs *ValueStore) gc(ctx context.Context,
 	src, dest chunks.ChunkStoreGarbageCollector,
 	safepointController GCSafepointController,
 	finalize func() hash.HashSet) (chunks.GCFinalizer, error) {
+	ctx, span := tracer.Start(ctx, "ValueStore.GC.markAndSweep", trace.WithAttributes(attribute.String("generation", generation)))
+	defer span.End()
+
 	sweeper, err := src.MarkAndSweepChunks(ctx, lvs.getAddrs, hashFilter, dest, chksMode, cmp)
 	if err != nil {
 		return nil, err
@@ -772,13 +794,19 @@ func (lvs *ValueStore) gc(ctx context.Context,
 			return nil, errors.Join(err, cErr)
 		}
 	}
-	finalizer, err := sweeper.Finalize(ctx)
+	finalizer, err := finalizeSweep(ctx, sweeper)
 	if err != nil {
 		return nil, err
 	}
 	return finalizer, sweeper.Close(ctx)
 }
 
+func finalizeSweep(ctx context.Context, sweeper chunks.MarkAndSweeper) (chunks.GCFinalizer, error) {
+	ctx, span := tracer.Start(ctx, "ValueStore.GC.finalize")
+	defer span.End()
+	return sweeper.Finalize(ctx)
+}
+
 func (lvs *ValueStore) PurgeCaches() {
 	lvs.decodedChunks.Purge()
 }
@@ -0,0 +1,181 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputpager
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// AutoPager is an io.Writer that only starts a pager once its output no longer fits on the terminal. Until then,
+// output is held back so that it can be handed to the pager in full, or written out as-is if it turns out to be short.
+type AutoPager struct {
+	out      io.Writer
+	width    int
+	maxLines int
+	buf      bytes.Buffer
+	lines    int
+	pager    *Pager
+
+	// col is the column the next rune is displayed in, and esc is the state of any ANSI escape sequence being
+	// written. partial holds the bytes of a rune split across writes.
+	col     int
+	esc     escState
+	partial []byte
+}
+
+type escState int
+
+const (
+	escNone escState = iota
+	escStart
+	escCSI
+)
+
+// tabWidth is the distance between the tab stops of the terminal.
+const tabWidth = 8
+
+// terminalSize returns the number of columns and lines of the terminal stdout writes to, or false if stdout is not a
+// terminal. Tests replace it to stand in for a terminal.
+var terminalSize = func() (int, int, bool) {
+	if !IsStdoutTty() {
+		return 0, 0, false
+	}
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// startPager starts the pager that output is handed to once it no longer fits on the terminal. Tests replace it to
+// avoid running a real pager.
+var startPager = Start
+
+// StartAuto returns an AutoPager writing to |out|. When paging is disabled or stdout is not a terminal, everything is
+// written straight through to |out|.
+func StartAuto(out io.Writer) *AutoPager {
+	if noPager {
+		return newAutoPager(out, 0, 0)
+	}
+	width, height, ok := terminalSize()
+	if !ok || height <= 1 {
+		return newAutoPager(out, 0, 0)
+	}
+	// leave a line for the prompt that follows the output
+	return newAutoPager(out, width, height-1)
+}
+
+// newAutoPager returns an AutoPager that pages once more than |maxLines| lines are written to a terminal |width|
+// columns wide, counting each line longer than |width| as the lines it wraps onto. A |maxLines| of zero disables
+// paging, and a |width| of zero counts only line breaks.
+func newAutoPager(out io.Writer, width, maxLines int) *AutoPager {
+	return &AutoPager{out: out, width: width, maxLines: maxLines}
+}
+
+// Write implements io.Writer.
+func (p *AutoPager) Write(b []byte) (int, error) {
+	if p.pager != nil {
+		return p.pager.Writer.Write(b)
+	} else if p.maxLines == 0 {
+		return p.out.Write(b)
+	}
+
+	p.buf.Write(b)
+	p.countLines(b)
+	if p.lines > p.maxLines {
+		p.pager = startPager()
+		if _, err := p.pager.Writer.Write(p.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		p.buf.Reset()
+	}
+	return len(b), nil
+}
+
+// countLines adds the lines |b| takes up on the terminal to |p.lines|. ANSI escape sequences take up no columns.
+func (p *AutoPager) countLines(b []byte) {
+	if len(p.partial) > 0 {
+		b = append(p.partial, b...)
+		p.partial = nil
+	}
+	for len(b) > 0 {
+		if !utf8.FullRune(b) {
+			p.partial = append([]byte(nil), b...)
+			return
+		}
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+
+		switch p.esc {
+		case escStart:
+			if r == '[' {
+				p.esc = escCSI
+			} else {
+				p.esc = escNone
+			}
+			continue
+		case escCSI:
+			if r >= 0x40 && r <= 0x7e {
+				p.esc = escNone
+			}
+			continue
+		}
+
+		var w int
+		switch r {
+		case '\x1b':
+			p.esc = escStart
+			continue
+		case '\n':
+			p.lines++
+			p.col = 0
+			continue
+		case '\r':
+			p.col = 0
+			continue
+		case '\t':
+			// tabs stop at the last column rather than wrapping
+			w = tabWidth - p.col%tabWidth
+			if p.width > 0 && p.col+w > p.width {
+				w = p.width - p.col
+			}
+		default:
+			w = runewidth.RuneWidth(r)
+		}
+		if p.width > 0 && p.col+w > p.width {
+			// the terminal wraps the rune onto the next line
+			p.lines++
+			p.col = 0
+		}
+		p.col += w
+	}
+}
+
+// Stop waits for the pager to exit if one was started, and otherwise writes out any output that was held back.
+func (p *AutoPager) Stop() error {
+	if p.pager != nil {
+		p.pager.Stop()
+		return nil
+	}
+	_, err := p.out.Write(p.buf.Bytes())
+	p.buf.Reset()
+	return err
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputpager
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTerminal stands in for a terminal of |height| lines and 80 columns, or for a non-terminal stdout if |isTty| is false, and
+// records what is written to the pager instead of starting one.
+func fakeTerminal(t *testing.T, height int, isTty bool) *bytes.Buffer {
+	prevSize, prevStart, prevNoPager := terminalSize, startPager, noPager
+	t.Cleanup(func() {
+		terminalSize, startPager, noPager = prevSize, prevStart, prevNoPager
+	})
+
+	terminalSize = func() (int, int, bool) {
+		return 80, height, isTty
+	}
+	paged := &bytes.Buffer{}
+	startPager = func() *Pager {
+		doneCh := make(chan struct{}, 1)
+		doneCh <- struct{}{}
+		return &Pager{Writer: paged, mtx: &sync.Mutex{}, doneCh: doneCh}
+	}
+	noPager = false
+	return paged
+}
+
+func lines(n int) string {
+	return strings.Repeat("row\n", n)
+}
+
+func TestAutoPagerMaxLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		height   int
+		isTty    bool
+		noPager  bool
+		maxLines int
+	}{
+		{name: "not a terminal", height: 40, isTty: false, maxLines: 0},
+		{name: "paging disabled", height: 40, isTty: true, noPager: true, maxLines: 0},
+		{name: "unknown height", height: 0, isTty: true, maxLines: 0},
+		{name: "single line terminal", height: 1, isTty: true, maxLines: 0},
+		{name: "leaves a line for the prompt", height: 40, isTty: true, maxLines: 39},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeTerminal(t, test.height, test.isTty)
+			noPager = test.noPager
+			assert.Equal(t, test.maxLines, StartAuto(&bytes.Buffer{}).maxLines)
+		})
+	}
+}
+
+func TestAutoPagerShortOutput(t *testing.T) {
+	paged := fakeTerminal(t, 11, true)
+	out := &bytes.Buffer{}
+	p := StartAuto(out)
+
+	_, err := p.Write([]byte(lines(6)))
+	require.NoError(t, err)
+	_, err = p.Write([]byte(lines(4)))
+	require.NoError(t, err)
+	assert.Empty(t, out.String(), "output that may still need paging is held back")
+
+	require.NoError(t, p.Stop())
+	assert.Equal(t, lines(10), out.String())
+	assert.Empty(t, paged.String())
+}
+
+func TestAutoPagerLongOutput(t *testing.T) {
+	paged := fakeTerminal(t, 11, true)
+	out := &bytes.Buffer{}
+	p := StartAuto(out)
+
+	_, err := p.Write([]byte(lines(10)))
+	require.NoError(t, err)
+	_, err = p.Write([]byte(lines(1)))
+	require.NoError(t, err)
+	_, err = p.Write([]byte(lines(5)))
+	require.NoError(t, err)
+
+	require.NoError(t, p.Stop())
+	assert.Empty(t, out.String())
+	assert.Equal(t, lines(16), paged.String())
+}
+
+func TestAutoPagerNotATerminal(t *testing.T) {
+	paged := fakeTerminal(t, 11, false)
+	out := &bytes.Buffer{}
+	p := StartAuto(out)
+
+	_, err := p.Write([]byte(lines(100)))
+	require.NoError(t, err)
+	assert.Equal(t, lines(100), out.String(), "output is written straight through")
+
+	require.NoError(t, p.Stop())
+	assert.Equal(t, lines(100), out.String())
+	assert.Empty(t, paged.String())
+}
+
+func TestAutoPagerCountsWrappedLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		lines  int
+	}{
+		{name: "short lines", writes: []string{"abc\n", "def\n"}, lines: 2},
+		{name: "line filling the terminal", writes: []string{strings.Repeat("a", 10) + "\n"}, lines: 1},
+		{name: "line wrapping once", writes: []string{strings.Repeat("a", 11) + "\n"}, lines: 2},
+		{name: "line wrapping twice", writes: []string{strings.Repeat("a", 25) + "\n"}, lines: 3},
+		{name: "line split across writes", writes: []string{"aaaaaa", "aaaaaa\n"}, lines: 2},
+		{name: "unterminated line", writes: []string{strings.Repeat("a", 15)}, lines: 2},
+		{name: "wide runes", writes: []string{"世界世界世界\n"}, lines: 2},
+		{name: "rune split across writes", writes: []string{"aaaaaaaaa\xe4\xb8", "\x96\n"}, lines: 2},
+		{name: "escape sequences take no columns", writes: []string{"\x1b[31m" + strings.Repeat("a", 10) + "\x1b[0m\n"}, lines: 1},
+		{name: "tabs", writes: []string{"a\tb\n", "\t\t\n"}, lines: 2},
+		{name: "carriage return", writes: []string{strings.Repeat("a", 8) + "\r" + strings.Repeat("a", 8) + "\n"}, lines: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newAutoPager(&bytes.Buffer{}, 10, 100)
+			for _, w := range test.writes {
+				_, err := p.Write([]byte(w))
+				require.NoError(t, err)
+			}
+			lines := p.lines
+			if p.col > 0 {
+				lines++
+			}
+			assert.Equal(t, test.lines, lines)
+		})
+	}
+}
+
+func TestAutoPagerLongLines(t *testing.T) {
+	paged := fakeTerminal(t, 11, true)
+	out := &bytes.Buffer{}
+	p := StartAuto(out)
+
+	// six lines which each wrap onto a second line of the 80 column terminal
+	long := strings.Repeat(strings.Repeat("a", 100)+"\n", 6)
+	_, err := p.Write([]byte(long))
+	require.NoError(t, err)
+
+	require.NoError(t, p.Stop())
+	assert.Empty(t, out.String())
+	assert.Equal(t, long, paged.String())
+}
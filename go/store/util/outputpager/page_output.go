@@ -38,8 +38,8 @@ import (
 )
 
 var (
-	noPager bool
-	testing = false
+	noPager      bool
+	testingPager = false
 )
 
 type Pager struct {
@@ -50,9 +50,9 @@ type Pager struct {
 }
 
 func Start() *Pager {
-	// `testing` is set to true only to test this function because when testing this function, stdout is not Terminal.
+	// `testingPager` is set to true only to test this function because when testing this function, stdout is not Terminal.
 	// otherwise, it must be always false.
-	if !testing {
+	if !testingPager {
 		if noPager || !IsStdoutTty() {
 			return &Pager{os.Stdout, nil, nil, nil, nil}
 		}
@@ -160,5 +160,5 @@ func IsStdoutTty() bool {
 }
 
 func SetTestingArg(s bool) {
-	testing = s
+	testingPager = s
 }
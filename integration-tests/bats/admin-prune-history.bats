@@ -0,0 +1,164 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+# The repository is 8 commits:
+# (init) <- (create table) <- (val 1) <- ... <- (val 6) [main]
+# Each of the val commits rewrites all 1000 rows, so that the storage of each commit is measurable.
+setup() {
+    setup_common
+
+    dolt sql -q "create table vals (i int primary key, s varchar(64))"
+    dolt commit -Am "create table"
+    for SEQ in $(seq 6); do
+        dolt sql -q "replace into vals with recursive n(i) as (select 1 union all select i + 1 from n where i < 1000) select i, sha2(concat($SEQ, i), 256) from n"
+        dolt commit -Am "Added Val: $SEQ"
+    done
+}
+
+teardown() {
+    teardown_common
+}
+
+noms_size() {
+    du -sk .dolt/noms | cut -f1
+}
+
+@test "admin-prune-history: --before is required" {
+    run dolt admin prune-history
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--before is required" ]] || false
+}
+
+@test "admin-prune-history: invalid cutoff" {
+    run dolt admin prune-history --before not_a_branch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'not_a_branch' is not a valid date or commit" ]] || false
+}
+
+@test "admin-prune-history: nothing to prune" {
+    run dolt admin prune-history --before 2000-01-01
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No commits to prune" ]] || false
+    [ ! -f .dolt/noms/ghostObjects.txt ]
+
+    run dolt admin prune-history --before "HEAD~7"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No commits to prune" ]] || false
+}
+
+@test "admin-prune-history: prune ancestors of a commit" {
+    dolt gc
+    before=$(noms_size)
+
+    run dolt admin prune-history --before "HEAD~2"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Pruned history before HEAD~2" ]] || false
+
+    after=$(noms_size)
+    [ "$after" -lt "$before" ]
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[0]}" =~ "Added Val: 6" ]] || false
+    [[ "${lines[2]}" =~ "Added Val: 4" ]] || false
+
+    run dolt sql -q "select count(*) from vals where s = sha2(concat(6, i), 256)" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_log()" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt diff HEAD~2 HEAD --stat
+    [ "$status" -eq 0 ]
+
+    run dolt diff HEAD~3 HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Commit not found" ]] || false
+
+    run dolt fsck
+    [ "$status" -eq 0 ]
+}
+
+@test "admin-prune-history: prune by date keeps branch heads and tags" {
+    dolt branch old_branch HEAD~4
+    dolt tag old_tag HEAD~5
+
+    run dolt admin prune-history --before 2099-01-01
+    [ "$status" -eq 0 ]
+
+    run dolt log --oneline main
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    # The tagged commit is the parent of the head of old_branch, and is kept.
+    run dolt log --oneline old_branch
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "Added Val: 2" ]] || false
+    [[ "${lines[1]}" =~ "Added Val: 1" ]] || false
+
+    run dolt sql -q "select count(*) from vals as of 'old_tag' where s = sha2(concat(1, i), 256)" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+
+    dolt checkout old_branch
+    run dolt sql -q "select count(*) from vals where s = sha2(concat(2, i), 256)" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+
+    run dolt fsck
+    [ "$status" -eq 0 ]
+}
+
+@test "admin-prune-history: not allowed during a merge" {
+    dolt checkout -b other HEAD~1
+    dolt sql -q "update vals set s = 'other' where i = 1"
+    dolt commit -Am "other"
+    dolt checkout main
+    dolt sql -q "update vals set s = 'main' where i = 1"
+    dolt commit -Am "main"
+    run dolt merge other
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt admin prune-history --before "HEAD~2"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot prune history while a merge or rebase is in progress" ]] || false
+}
+
+@test "admin-prune-history: push and fetch with a full history remote" {
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt push origin main
+
+    dolt admin prune-history --before "HEAD~1"
+
+    dolt sql -q "insert into vals values (1001, 'val 7')"
+    dolt commit -Am "Added Val: 7"
+    dolt push origin main
+
+    dolt clone file://./remote full
+    cd full
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 9 ]
+
+    dolt sql -q "insert into vals values (1002, 'val 8')"
+    dolt commit -Am "Added Val: 8"
+    dolt push origin main
+
+    cd ..
+    dolt pull origin main
+    run dolt sql -q "select count(*) from vals" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1002" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+
+    run dolt fsck
+    [ "$status" -eq 0 ]
+}
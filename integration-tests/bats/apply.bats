@@ -0,0 +1,223 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+create table test (pk int primary key, c1 varchar(20), c2 blob);
+insert into test values (1, 'one', null), (2, 'two', x'00ff'), (3, 'three', null);
+create table keyless (a int, b int);
+insert into keyless values (1, 1), (1, 1), (2, 2);
+create view v1 as select 1;
+SQL
+    dolt commit -Am "base"
+    dolt branch base
+
+    dolt sql <<SQL
+update test set c1 = 'TWO' where pk = 2;
+delete from test where pk = 3;
+insert into test values (4, 'four', x'ff');
+alter table test add column c3 int;
+delete from keyless where a = 1 limit 1;
+insert into keyless values (3, 3);
+create table added (pk int primary key);
+insert into added values (5);
+drop view v1;
+create view v1 as select 2;
+create trigger trg before insert on added for each row set new.pk = new.pk + 1;
+SQL
+    dolt commit -Am "changes"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "apply: patch file applies to its base" {
+    dolt diff --patch-file changes.dpatch base main
+
+    dolt checkout -b other base
+    run dolt apply --check changes.dpatch
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    run dolt apply changes.dpatch
+    [ "$status" -eq 0 ]
+
+    # triggers record when they were created, so only the tables are compared
+    run dolt diff main --stat test keyless added
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+    run dolt sql -q "select * from keyless order by a" -r csv
+    [[ "$output" =~ "a,b"$'\n'"1,1"$'\n'"2,2"$'\n'"3,3" ]] || false
+    run dolt sql -q "select hex(c2) from test where pk = 4" -r csv
+    [[ "$output" =~ "FF" ]] || false
+    run dolt sql -q "select fragment from dolt_schemas where name = 'v1'" -r csv
+    [[ "$output" =~ "create view v1 as select 2" ]] || false
+}
+
+@test "apply: working set changes" {
+    dolt checkout -b other base
+    dolt sql -q "insert into test values (10, 'ten', null)"
+    dolt diff --patch-file working.dpatch
+
+    dolt checkout test
+    dolt checkout main
+    run dolt apply working.dpatch
+    [ "$status" -eq 0 ]
+    run dolt sql -q "select c1 from test where pk = 10" -r csv
+    [[ "$output" =~ "ten" ]] || false
+}
+
+@test "apply: data only patch" {
+    dolt diff --data --patch-file data.dpatch base main keyless
+
+    dolt checkout -b other base
+    dolt apply data.dpatch
+    run dolt diff main --stat keyless
+    [ "$output" = "" ]
+
+    # the rows of a data only patch need the columns they were diffed with
+    dolt diff --data --patch-file data.dpatch base main test
+    run dolt apply data.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "column 'c3' does not exist in table 'test'" ]] || false
+}
+
+@test "apply: refuses a patch whose preimage does not match" {
+    dolt diff --patch-file changes.dpatch base main
+
+    run dolt apply changes.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table 'added' already exists" ]] || false
+    [[ "$output" =~ "schema of table 'test' does not match the patch" ]] || false
+    [[ "$output" =~ "view 'v1' does not match the patch" ]] || false
+    [[ "$output" =~ "patch does not apply" ]] || false
+
+    dolt checkout -b other base
+    dolt sql -q "update test set c1 = 'deux' where pk = 2"
+    dolt sql -q "delete from test where pk = 3"
+    run dolt apply --check changes.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "row (2) in table 'test' does not match the patch" ]] || false
+    [[ "$output" =~ "row (3) does not exist in table 'test'" ]] || false
+
+    # nothing was applied
+    run dolt sql -q "show tables"
+    [[ ! "$output" =~ "added" ]] || false
+}
+
+@test "apply: refuses a patch which violates a foreign key" {
+    dolt sql <<SQL
+create table parent (id int primary key);
+create table child (id int primary key, pid int, foreign key (pid) references parent(id));
+insert into parent values (1), (2);
+SQL
+    dolt commit -Am "parent and child"
+    dolt checkout -b fk
+    dolt sql -q "insert into child values (10, 2)"
+    dolt commit -Am "child row"
+    dolt diff --patch-file fk.dpatch main fk
+
+    dolt checkout main
+    dolt sql -q "delete from parent where id = 2"
+    run dolt apply fk.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "patch does not apply: it would violate constraints in child" ]] || false
+
+    # nothing was applied
+    run dolt sql -q "select count(*) from child" -r csv
+    [[ "$output" =~ "0" ]] || false
+    run dolt sql -q "select * from parent" -r csv
+    [[ ! "$output" =~ "2" ]] || false
+}
+
+@test "apply: 3way merges and records conflicts" {
+    dolt diff --patch-file changes.dpatch base main
+
+    dolt checkout -b other base
+    dolt sql -q "update test set c1 = 'uno' where pk = 1"
+    dolt sql -q "update test set c1 = 'deux' where pk = 2"
+    dolt commit -am "other changes"
+
+    run dolt apply --3way changes.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in test" ]] || false
+
+    run dolt sql -q "select base_c1, our_c1, their_c1 from dolt_conflicts_test" -r csv
+    [[ "$output" =~ "two,deux,TWO" ]] || false
+    run dolt sql -q "select c1 from test where pk = 1" -r csv
+    [[ "$output" =~ "uno" ]] || false
+    run dolt sql -q "select * from added" -r csv
+    [[ "$output" =~ "5" ]] || false
+
+    # the temporary branch is removed
+    run dolt branch
+    [[ ! "$output" =~ "dolt-apply" ]] || false
+}
+
+@test "apply: 3way applies cleanly" {
+    dolt diff --patch-file changes.dpatch base main
+
+    dolt checkout -b other base
+    dolt sql -q "update test set c1 = 'uno' where pk = 1"
+    dolt sql -q "create table unrelated (pk int primary key)"
+    dolt commit -Am "other changes"
+    dolt sql -q "update test set c2 = x'aa' where pk = 1"
+    dolt commit -Am "more changes"
+    dolt sql -q "alter table test add column c4 int"
+    dolt commit -Am "schema change"
+
+    run dolt apply -3 changes.dpatch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied patch cleanly with a 3-way merge." ]] || false
+    run dolt sql -q "select pk, c1 from test order by pk" -r csv
+    [ "$output" = "pk,c1"$'\n'"1,uno"$'\n'"2,TWO"$'\n'"4,four" ]
+}
+
+@test "apply: 3way requires a clean working set and the base commit" {
+    dolt diff --patch-file changes.dpatch base main
+    dolt sql -q "insert into test values (10, 'ten', null, null)"
+    run dolt apply --3way changes.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "your local changes would be overwritten" ]] || false
+
+    dolt diff --patch-file working.dpatch
+    dolt reset --hard
+    dolt sql -q "insert into test values (10, 'zehn', null, null)"
+    dolt commit -am "ten"
+    run dolt apply --3way working.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the patch was not created from a commit" ]] || false
+}
+
+@test "apply: invalid arguments" {
+    run dolt diff --patch-file p.dpatch --stat
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--patch-file cannot be combined with --stat" ]] || false
+
+    run dolt diff --patch-file p.dpatch -r sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--patch-file cannot be combined with --result-format" ]] || false
+
+    run dolt apply
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a patch file is required" ]] || false
+
+    run dolt apply --check --3way p.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--check cannot be combined with --3way" ]] || false
+
+    echo "not a patch" > p.dpatch
+    run dolt apply p.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'p.dpatch' is not a valid patch file" ]] || false
+
+    echo '{"version": 99}' > p.dpatch
+    run dolt apply p.dpatch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unsupported patch file version 99" ]] || false
+}
@@ -0,0 +1,165 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+# The repository is 10 commits:
+# (init) <- (create) <- (c1) <- ... <- (c8) [main]
+# c5 breaks the invariant that no value is negative, which the test "no_negatives" checks.
+setup() {
+    setup_common
+
+    dolt sql -q "create table t (i int primary key)"
+    dolt sql -q "insert into dolt_tests values ('no_negatives', 'invariants', 'select count(*) from t where i < 0', 'expected_single_value', '==', '0')"
+    dolt commit -Am "create"
+    for i in $(seq 8); do
+        dolt sql -q "insert into t values ($i)"
+        if [ "$i" -eq 5 ]; then
+            dolt sql -q "insert into t values (-1)"
+        fi
+        dolt commit -Am "c$i"
+    done
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "bisect: requires a session" {
+    run dolt bisect good
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not bisecting" ]] || false
+
+    run dolt bisect log
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not bisecting" ]] || false
+
+    run dolt bisect reset
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Not bisecting." ]] || false
+}
+
+@test "bisect: invalid arguments" {
+    run dolt bisect
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a bisect subcommand is required" ]] || false
+
+    run dolt bisect nope
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown bisect subcommand nope" ]] || false
+
+    run dolt bisect start --query "select 1"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only supported by run" ]] || false
+
+    dolt bisect start HEAD HEAD~8
+    run dolt bisect run
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "exactly one of --test and --query is required" ]] || false
+
+    run dolt bisect run --test invariants --query "select 1"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "exactly one of --test and --query is required" ]] || false
+
+    run dolt bisect bad HEAD HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only one commit can be marked bad" ]] || false
+}
+
+@test "bisect: waits for good and bad commits" {
+    run dolt bisect start
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "waiting for both good and bad commits" ]] || false
+
+    run dolt bisect bad
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "waiting for good commit(s), bad commit known" ]] || false
+
+    run dolt bisect good HEAD~8
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting: " ]] || false
+}
+
+@test "bisect: bad commit is an ancestor of a good commit" {
+    run dolt bisect start HEAD~8 HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the bad commit is an ancestor of a good commit" ]] || false
+}
+
+@test "bisect: manual good and bad" {
+    # Walk the session by reading the commit to test from the output of each step.
+    run dolt bisect start HEAD HEAD~8
+    [ "$status" -eq 0 ]
+    while [[ "$output" =~ "Bisecting: " ]]; do
+        commit=$(echo "$output" | tail -n 1 | sed -E 's/^\[([^]]+)\].*/\1/')
+        negatives=$(dolt sql -r csv -q "select count(*) from t as of '$commit' where i < 0" | tail -n 1)
+        if [ "$negatives" -eq 0 ]; then
+            run dolt bisect good
+        else
+            run dolt bisect bad
+        fi
+        [ "$status" -eq 0 ]
+    done
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "$output" =~ "c5" ]] || false
+
+    run dolt bisect log
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" = "dolt bisect start" ]] || false
+    [[ "$output" =~ "# first bad commit: [" ]] || false
+    [[ "$output" =~ "] c5" ]] || false
+
+    dolt bisect reset
+    run dolt bisect log
+    [ "$status" -eq 1 ]
+}
+
+@test "bisect: skip" {
+    dolt bisect start HEAD HEAD~2
+    run dolt bisect skip
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "There are only 'skip'ped commits left to test." ]] || false
+    [[ "$output" =~ "The first bad commit could be any of:" ]] || false
+    [ "${#lines[@]}" -eq 4 ]
+}
+
+@test "bisect: run with a test group" {
+    dolt bisect start HEAD HEAD~8
+    run dolt bisect run --test invariants
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "${lines[-1]}" =~ "c5" ]] || false
+
+    run dolt bisect run --test invariants
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no commits left to test" ]] || false
+}
+
+@test "bisect: run with a query" {
+    dolt bisect start main HEAD~8
+    run dolt bisect run --query "select * from t where i < 0"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "${lines[-1]}" =~ "c5" ]] || false
+}
+
+@test "bisect: run with a failing query" {
+    dolt bisect start HEAD HEAD~9
+    run dolt bisect run --query "select * from t where i < 0"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table not found: t" ]] || false
+}
+
+@test "bisect: log replays the session" {
+    dolt bisect start HEAD HEAD~8
+    dolt bisect run --test invariants
+    dolt bisect log > bisect.log
+
+    dolt bisect reset
+    grep "^dolt bisect" bisect.log | while read -r line; do
+        eval "$line"
+    done
+
+    run dolt bisect log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "# first bad commit: [" ]] || false
+    [[ "$output" =~ "] c5" ]] || false
+}
@@ -183,3 +183,45 @@ SQL
     [[ "${lines[9]}" =~ "| sub  | 2   |" ]] || false
     [[ "${lines[10]}" =~ "| zzz  | 4   |" ]] || false
 }
+
+@test "blame: --cells blames each column" {
+    dolt sql -q "alter table blame_test add column age int"
+    dolt sql -q "update blame_test set age = 40 where pk = 1"
+    dolt commit -am "add ages"
+
+    run dolt blame --cells blame_test
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ "pk".*"column_name".*"commit".*"commit_date".*"committer".*"email".*"message" ]] || false
+    [[ "$output" =~ "| 1  | name        |".*"create blame_test table" ]] || false
+    [[ "$output" =~ "| 1  | age         |".*"add ages" ]] || false
+    [[ "$output" =~ "| 2  | name        |".*"replace richard with harry" ]] || false
+    [[ "$output" =~ "| 4  | age         |".*"add ages" ]] || false
+
+    run dolt blame --columns name HEAD~1 blame_test
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "| age" ]] || false
+    [[ "$output" =~ "| 3  | name        |".*"add more people to blame_test" ]] || false
+
+    run dolt sql -q "select pk, message from dolt_blame('blame_test', '--columns', 'name') where pk = 2" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,replace richard with harry" ]] || false
+}
+
+@test "blame: --since and --until limit the blamed revisions" {
+    run dolt blame --since 2000-01-01 blame_test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "create blame_test table" ]] || false
+
+    # nothing was changed after now
+    run dolt blame --since "$(date -u -d '+1 day' +%Y-%m-%d)" blame_test
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "create blame_test table" ]] || false
+
+    run dolt blame --until 2000-01-01 blame_test
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no commits were made on or before 2000-01-01" ]] || false
+
+    run dolt blame --cells --columns missing blame_test
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'table "blame_test" does not have column "missing"' ]] || false
+}
@@ -0,0 +1,141 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    mkdir repo1
+    cd repo1
+    dolt init
+    dolt sql -q "create table test (pk int primary key, c1 int)"
+    dolt sql -q "insert into test values (1, 1)"
+    dolt commit -Am "first"
+    dolt tag v1
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "bundle: create, verify and unbundle" {
+    cd repo1
+    run dolt bundle create ../full.bundle main v1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/tags/v1" ]] || false
+    [[ "$output" =~ "Created bundle ../full.bundle with 2 refs" ]] || false
+    main=$(dolt sql -q "select hashof('main')" -r csv | tail -1)
+
+    cd ..
+    mkdir repo2
+    cd repo2
+    dolt init
+    run dolt bundle verify ../full.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "The bundle records a complete history." ]] || false
+    [[ "$output" =~ "../full.bundle is okay" ]] || false
+
+    run dolt bundle unbundle ../full.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$main refs/heads/main" ]] || false
+
+    # unbundling doesn't update refs, but the commits can be used
+    run dolt tag
+    [[ ! "$output" =~ "v1" ]] || false
+    dolt branch from-bundle $main
+    run dolt sql -q "select * from test as of 'from-bundle'" -r csv
+    [[ "$output" =~ "1,1" ]] || false
+}
+
+@test "bundle: fetch an incremental bundle" {
+    cp -r repo1 repo2
+
+    cd repo1
+    dolt sql -q "insert into test values (2, 2)"
+    dolt commit -am "second"
+    dolt branch feature
+    dolt tag v2
+    run dolt bundle create --since v1 ../inc.bundle main feature v2
+    [ "$status" -eq 0 ]
+
+    cd ../repo2
+    run dolt bundle verify ../inc.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "The bundle requires 1 commits:" ]] || false
+
+    run dolt fetch ../inc.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/remotes/bundle/main" ]] || false
+    [[ "$output" =~ "refs/remotes/bundle/feature" ]] || false
+    [[ "$output" =~ "refs/tags/v2" ]] || false
+
+    dolt merge bundle/main
+    run dolt sql -q "select * from test order by pk" -r csv
+    [[ "$output" =~ "1,1"$'\n'"2,2" ]] || false
+
+    # refspecs choose which branches to fetch, and where to
+    run dolt fetch ../inc.bundle feature:other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/other" ]] || false
+    run dolt branch
+    [[ "$output" =~ "other" ]] || false
+
+    run dolt fetch ../inc.bundle main:main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "refusing to fetch into checked out branch 'main'" ]] || false
+
+    run dolt fsck
+    [ "$status" -eq 0 ]
+}
+
+@test "bundle: missing prerequisites" {
+    cd repo1
+    dolt sql -q "insert into test values (2, 2)"
+    dolt commit -am "second"
+    dolt bundle create --since v1 ../inc.bundle main
+    v1=$(dolt sql -q "select hashof('v1')" -r csv | tail -1)
+
+    cd ..
+    mkdir repo2
+    cd repo2
+    dolt init
+    run dolt bundle verify ../inc.bundle
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "missing prerequisite commits of the bundle: [$v1]" ]] || false
+
+    run dolt fetch ../inc.bundle
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "missing prerequisite commits" ]] || false
+    run dolt branch -a
+    [[ ! "$output" =~ "bundle" ]] || false
+}
+
+@test "bundle: invalid arguments" {
+    cd repo1
+    run dolt bundle create ../empty.bundle --since main main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "refusing to create an empty bundle" ]] || false
+    [ ! -f ../empty.bundle ]
+
+    run dolt bundle create ../x.bundle
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "either refs or --all are required" ]] || false
+
+    run dolt bundle create ../x.bundle missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'missing' is not a branch or tag" ]] || false
+
+    echo "# dolt bundle" > ../bad.bundle
+    run dolt bundle verify ../bad.bundle
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "bundle is corrupt" ]] || false
+
+    run dolt bundle create --all ../all.bundle
+    [ "$status" -eq 0 ]
+    head -c 200 ../all.bundle > ../truncated.bundle
+    run dolt bundle verify ../truncated.bundle
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "bundle is corrupt" ]] || false
+}
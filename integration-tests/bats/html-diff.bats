@@ -0,0 +1,110 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+create table test (pk int primary key, c1 varchar(20), c2 int);
+insert into test values (1, 'one', 1), (2, 'two', 2), (3, 'three', 3);
+create view v1 as select 1;
+SQL
+    dolt commit -Am "initial"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "html-diff: no changes" {
+    run dolt diff -r html
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "<!DOCTYPE html>" ]] || false
+    [[ "$output" =~ "<p>No changes.</p>" ]] || false
+    [[ "$output" =~ "</html>" ]] || false
+}
+
+@test "html-diff: data changes" {
+    dolt sql -q "update test set c2 = 20 where pk = 2"
+    dolt sql -q "delete from test where pk = 3"
+    dolt sql -q "insert into test values (4, '<four>', null)"
+
+    run dolt diff -r html
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '<h2>Table <code>test</code> <span class="badge modified">modified</span></h2>' ]] || false
+    [[ "$output" =~ '<tr class="modified"><td class="marker">*</td><td class="from">2</td><td class="from">two</td><td class="from old">2</td><td class="sep"></td><td class="to">2</td><td class="to">two</td><td class="to new">20</td></tr>' ]] || false
+    [[ "$output" =~ '<tr class="removed"><td class="marker">-</td><td class="from">3</td>' ]] || false
+    [[ "$output" =~ '<td class="to">&lt;four&gt;</td><td class="to null">NULL</td></tr>' ]] || false
+
+    # the summary links to the table
+    [[ "$output" =~ '<tr><td><a href="#t1">test</a></td><td>table</td><td>modified</td><td></td><td>1</td><td>1</td><td>1</td><td>1</td></tr>' ]] || false
+}
+
+@test "html-diff: schema changes" {
+    dolt sql -q "alter table test add column c3 int"
+    dolt sql -q "create table added (pk int primary key)"
+    dolt sql -q "drop view v1"
+    dolt sql -q "create view v1 as select 2"
+
+    run dolt diff -r html
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '<tr><td class="empty"></td><td class="added">  `c3` int,</td></tr>' ]] || false
+    [[ "$output" =~ '<h2>Table <code>added</code> <span class="badge added">added</span></h2>' ]] || false
+    [[ "$output" =~ '<h2>View <code>v1</code> <span class="badge modified">modified</span></h2>' ]] || false
+    [[ "$output" =~ '<tr><td class="removed">create view v1 as select 1;</td><td class="added">create view v1 as select 2;</td></tr>' ]] || false
+
+    run dolt diff -r html --data
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "<h3>Schema</h3>" ]] || false
+}
+
+@test "html-diff: pages and limit" {
+    dolt sql -q "insert into test with recursive n(i) as (select 10 union all select i + 1 from n where i < 1209) select i, 'many', i from n"
+
+    run dolt diff -r html
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '<tbody id="t1-p1">' ]] || false
+    [[ "$output" =~ '<tbody id="t1-p2">' ]] || false
+    [[ "$output" =~ '<tbody id="t1-p3">' ]] || false
+    [[ ! "$output" =~ '<tbody id="t1-p4">' ]] || false
+    [[ "$output" =~ '<nav class="pages">Pages: <a href="#t1-p1">1</a> <a href="#t1-p2">2</a> <a href="#t1-p3">3</a></nav>' ]] || false
+
+    run dolt diff -r html --limit 10
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Row diffs are limited to the first 10 of each table." ]] || false
+    [[ "$output" =~ "Showing the first 10 row diffs." ]] || false
+    [[ ! "$output" =~ '<tbody id="t1-p2">' ]] || false
+    [ $(echo "$output" | grep -c '<tr class="added">') -eq 10 ]
+}
+
+@test "html-diff: stat" {
+    dolt sql -q "insert into test values (4, 'four', 4)"
+
+    run dolt diff -r html --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "<h3>Statistics</h3>" ]] || false
+    [[ "$output" =~ "<tr><th>Rows Added</th><td>1</td></tr>" ]] || false
+}
+
+@test "html-diff: show" {
+    dolt sql -q "insert into test values (4, 'four', 4)"
+    dolt commit -am "added four"
+
+    run dolt show -r html
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "<!DOCTYPE html>" ]] || false
+    [[ ! "$output" =~ "commit " ]] || false
+    [[ "$output" =~ "<tr><th>Message</th><td>added four</td></tr>" ]] || false
+    [[ "$output" =~ '<tr class="added"><td class="marker">+</td>' ]] || false
+
+    run dolt show -r html HEAD HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "html output supports showing a single commit" ]] || false
+}
+
+@test "html-diff: invalid format" {
+    run dolt diff -r htm
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid output format: htm" ]] || false
+}
@@ -1077,4 +1077,53 @@ export NO_COLOR=1
     [[ "$output" =~ "A table for br1" ]] || false
     ! [[ "$output" =~ "Initialize data repository" ]] || false
     ! [[ "$output" =~ "commit 1 br2" ]] || false
-}
\ No newline at end of file
+}
+@test "log: filters by author, message, value and row operation" {
+    dolt sql -q "create table t (pk int primary key, c1 int, c2 varchar(10)); insert into t values (1, 1, 'a'), (2, 2, 'b');"
+    dolt commit -Am "first"
+    dolt sql -q "update t set c1 = 10 where pk = 1"
+    dolt commit -am "bump c1" --author "Bob <bob@example.com>"
+    dolt sql -q "update t set c2 = 'zz' where pk = 2"
+    dolt commit -am "set zz"
+    dolt sql -q "delete from t where pk = 2"
+    dolt commit -am "remove two"
+
+    run dolt log --oneline --author Bob
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "bump c1" ]] || false
+
+    run dolt log --oneline --grep "^set"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "set zz" ]] || false
+
+    run dolt log --oneline -S zz
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "remove two" ]] || false
+    [[ "$output" =~ "set zz" ]] || false
+
+    run dolt log --oneline -S 10 t.c1
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "bump c1" ]] || false
+
+    run dolt log --oneline t.c2
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ ! "$output" =~ "bump c1" ]] || false
+
+    run dolt log --oneline --diff-filter=D
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "remove two" ]] || false
+
+    run dolt log --diff-filter=X
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid --diff-filter option: X" ]] || false
+
+    run dolt log nope.c1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table nope does not exist" ]] || false
+}
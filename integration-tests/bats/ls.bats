@@ -60,7 +60,7 @@ teardown() {
 @test "ls: --system shows system tables" {
     run dolt ls --system
     [ "$status" -eq 0 ]
-    [ "${#lines[@]}" -eq 26 ]
+    [ "${#lines[@]}" -eq 29 ]
     [[ "$output" =~ "System tables:" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_commits" ]] || false
@@ -87,6 +87,9 @@ teardown() {
     [[ "$output" =~ "dolt_workspace_table_one" ]] || false
     [[ "$output" =~ "dolt_workspace_table_two" ]] || false
     [[ "$output" =~ "dolt_stashes" ]] || false
+    [[ "$output" =~ "dolt_table_storage" ]] || false
+    [[ "$output" =~ "dolt_index_builds" ]] || false
+    [[ "$output" =~ "dolt_storage_maintenance_log" ]] || false
 }
 
 @test "ls: --all shows tables in working set and system tables" {
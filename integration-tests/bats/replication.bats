@@ -374,6 +374,47 @@ SQL
     [[ "$output" =~ "t1" ]] || false
 }
 
+@test "replication: pull on read within max staleness" {
+    dolt clone file://./rem1 repo2
+
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_read_replica_remote remote1
+    dolt config --local --add sqlserver.global.dolt_replicate_heads main
+    dolt config --local --add sqlserver.global.dolt_read_replica_max_staleness_millis 600000
+    start_sql_server
+    run dolt sql -q "show tables" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "t1" ]] || false
+
+    cd ../repo2
+    dolt sql -q "create table t1 (a int primary key)"
+    dolt commit -Am "new commit"
+    dolt push origin main
+
+    # The replica pulled within the max staleness, so it reads its local state.
+    cd ../repo1
+    run dolt sql -q "show tables" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "t1" ]] || false
+
+    run dolt sql -q "set @@dolt_read_replica_consistency = 'strong'; show tables" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1" ]] || false
+
+    cd ../repo2
+    dolt sql -q "create table t2 (a int primary key)"
+    dolt commit -Am "another commit"
+    dolt push origin main
+
+    # Transactions pull again once the last pull is older than the max staleness.
+    cd ../repo1
+    dolt sql -q "set @@global.dolt_read_replica_max_staleness_millis = 1000"
+    sleep 2
+    run dolt sql -q "show tables" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t2" ]] || false
+}
+
 @test "replication: push on call dolt_branch(..." {
     cd repo1
     dolt config --local --add sqlserver.global.dolt_replicate_to_remote backup1
@@ -0,0 +1,123 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    mkdir refdata
+    cd refdata
+    dolt init
+    dolt sql -q "create table codes (code varchar(2) primary key, name text)"
+    dolt sql -q "insert into codes values ('us', 'United States')"
+    dolt commit -Am "first"
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+
+    mkdir app
+    cd app
+    dolt init
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "submodule: add pins a database and attaches it read-only" {
+    cd app
+    run dolt submodule add refdata file://../remote
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Added submodule 'refdata' at" ]] || false
+
+    first=$(cd ../refdata && dolt sql -q "select hashof('main')" -r csv | tail -1)
+    run dolt sql -q "select name, url, commit_hash from dolt_submodules" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "refdata,file://../remote,$first" ]
+
+    run dolt sql -q "select count(*) from refdata.codes" -r csv
+    [ "${lines[1]}" = "1" ]
+
+    run dolt sql -q "insert into refdata.codes values ('fr', 'France')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "read-only" ]] || false
+
+    run dolt submodule add refdata file://../remote
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "submodule 'refdata' already exists" ]] || false
+}
+
+@test "submodule: status and update follow the pinned commit" {
+    cd app
+    dolt submodule add refdata file://../remote
+    dolt add .
+    dolt commit -m "add refdata"
+    first=$(dolt sql -q "select commit_hash from dolt_submodules" -r csv | tail -1)
+
+    cd ../refdata
+    dolt sql -q "insert into codes values ('fr', 'France')"
+    dolt commit -am "second"
+    dolt push origin main
+    second=$(dolt sql -q "select hashof('main')" -r csv | tail -1)
+
+    cd ../app
+    run dolt submodule status
+    [ "$status" -eq 0 ]
+    [ "$output" = " $first refdata (file://../remote)" ]
+
+    dolt sql -q "update dolt_submodules set commit_hash = '$second'"
+    run dolt submodule status
+    [ "$output" = "+$second refdata (file://../remote)" ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:         dolt_submodules" ]] || false
+    [[ "$output" =~ "Submodules pinned to new commits:" ]] || false
+    [[ "$output" =~ "refdata ($first -> $second)" ]] || false
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "diff --dolt a/dolt_submodules b/dolt_submodules" ]] || false
+    [[ "$output" =~ "$second" ]] || false
+
+    run dolt submodule update
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Submodule 'refdata': checked out '$second'" ]] || false
+
+    run dolt submodule status
+    [ "$output" = " $second refdata (file://../remote)" ]
+    run dolt sql -q "select count(*) from refdata.codes" -r csv
+    [ "${lines[1]}" = "2" ]
+
+    dolt checkout dolt_submodules
+    dolt submodule update refdata
+    run dolt sql -q "select count(*) from refdata.codes" -r csv
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "submodule: update clones submodules that are missing" {
+    cd app
+    dolt submodule add refdata file://../remote
+    dolt add .
+    dolt commit -m "add refdata"
+    rm -rf .dolt/submodules
+
+    run dolt submodule status
+    [ "$status" -eq 0 ]
+    [[ "${output:0:1}" = "-" ]] || false
+
+    run dolt sql -q "show databases"
+    [[ ! "$output" =~ "refdata" ]] || false
+
+    run dolt submodule update
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Submodule 'refdata': checked out" ]] || false
+
+    run dolt sql -q "select count(*) from refdata.codes" -r csv
+    [ "${lines[1]}" = "1" ]
+
+    run dolt submodule update nosuchmodule
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no submodule named 'nosuchmodule'" ]] || false
+}
@@ -0,0 +1,161 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    mkdir repo1
+    cd repo1
+    dolt init
+    dolt sql -q "create table test (pk int primary key, c1 int)"
+    dolt sql -q "insert into test values (1, 1)"
+    dolt commit -Am "first"
+    dolt branch feat
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "worktree: add, list and remove" {
+    cd repo1
+    run dolt worktree add ../feat feat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Checked out branch 'feat' in worktree" ]] || false
+    [ -f ../feat/.dolt/repo_state.json ]
+    [ ! -d ../feat/.dolt/noms ]
+
+    run dolt worktree add -b other ../other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Checked out branch 'other' in worktree" ]] || false
+
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[0]}" =~ "repo1" ]] || false
+    [[ "${lines[0]}" =~ "[main]" ]] || false
+    [[ "${lines[1]}" =~ "feat" ]] || false
+    [[ "${lines[1]}" =~ "[feat]" ]] || false
+    [[ "${lines[2]}" =~ "other" ]] || false
+    [[ "${lines[2]}" =~ "[other]" ]] || false
+
+    run dolt worktree remove ../other
+    [ "$status" -eq 0 ]
+    [ ! -d ../other ]
+
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt worktree remove ../other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "is not a worktree" ]] || false
+
+    run dolt worktree remove .
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot remove the current worktree" ]] || false
+}
+
+@test "worktree: worktrees share data but have their own working sets" {
+    cd repo1
+    dolt worktree add ../feat feat
+
+    cd ../feat
+    run dolt branch --show-current
+    [ "$output" = "feat" ]
+    dolt sql -q "insert into test values (2, 2)"
+    run dolt status
+    [[ "$output" =~ "modified:" ]] || false
+
+    cd ../repo1
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    cd ../feat
+    dolt commit -am "second"
+
+    cd ../repo1
+    run dolt sql -q "select count(*) from test as of 'feat'" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    run dolt log feat --oneline
+    [[ "$output" =~ "second" ]] || false
+}
+
+@test "worktree: a branch can only be checked out in one worktree" {
+    cd repo1
+    dolt worktree add ../feat feat
+
+    run dolt worktree add ../feat2 feat
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    run dolt checkout feat
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    run dolt branch -d feat
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    cd ../feat
+    run dolt checkout main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is already checked out at" ]] || false
+
+    cd ../repo1
+    dolt worktree remove ../feat
+    run dolt checkout feat
+    [ "$status" -eq 0 ]
+}
+
+@test "worktree: stored procedures can't check out, move or delete a branch checked out in another worktree" {
+    cd repo1
+    dolt worktree add ../feat feat
+
+    run dolt sql -q "call dolt_checkout('feat')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    run dolt sql -q "call dolt_checkout('-B', 'feat')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    run dolt sql -q "call dolt_branch('-d', 'feat')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    run dolt sql -q "call dolt_branch('-m', 'feat', 'feat2')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'feat' is already checked out at" ]] || false
+
+    cd ../feat
+    run dolt sql -q "call dolt_branch('-D', 'main')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is already checked out at" ]] || false
+
+    cd ../repo1
+    dolt worktree remove ../feat
+    dolt sql -q "call dolt_branch('-m', 'feat', 'feat2')"
+    run dolt branch
+    [[ "$output" =~ "feat2" ]] || false
+}
+
+@test "worktree: remove refuses worktrees with uncommitted changes" {
+    cd repo1
+    dolt worktree add ../feat feat
+    cd ../feat
+    dolt sql -q "insert into test values (3, 3)"
+
+    cd ../repo1
+    run dolt worktree remove ../feat
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "has uncommitted changes" ]] || false
+    [ -d ../feat ]
+
+    run dolt worktree remove -f ../feat
+    [ "$status" -eq 0 ]
+    [ ! -d ../feat ]
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	driver "github.com/dolthub/dolt/go/libraries/doltcore/dtestutils/sql_server_driver"
+)
+
+// autoFailoverConfig renders the config for server |n| of a three server
+// cluster running with auto_failover enabled. Each server lists the other two
+// as its standby remotes.
+func autoFailoverConfig(n int, role string) string {
+	others := []int{1, 2, 3}
+	others = append(others[:n-1], others[n:]...)
+	return fmt.Sprintf(`log_level: trace
+listener:
+  host: 0.0.0.0
+  port: {{get_port "server%[1]d"}}
+cluster:
+  standby_remotes:
+  - name: server%[2]d
+    remote_url_template: http://localhost:{{get_port "server%[2]d_cluster"}}/{database}
+  - name: server%[3]d
+    remote_url_template: http://localhost:{{get_port "server%[3]d_cluster"}}/{database}
+  bootstrap_role: %[4]s
+  bootstrap_epoch: 1
+  remotesapi:
+    port: {{get_port "server%[1]d_cluster"}}
+  auto_failover:
+    enable: true
+    heartbeat_interval_millis: 200
+    election_timeout_millis: 2000
+`, n, others[0], others[1], role)
+}
+
+func clusterRoleAndEpoch(ctx context.Context, db *sql.DB) (string, int, error) {
+	var role string
+	var epoch int
+	err := db.QueryRowContext(ctx, "select @@GLOBAL.dolt_cluster_role, @@GLOBAL.dolt_cluster_role_epoch").Scan(&role, &epoch)
+	return role, epoch, err
+}
+
+// TestClusterAutoFailover stops the primary of a three server cluster and
+// asserts that one of the standbys is elected primary at a higher epoch with
+// all of the primary's writes, and that the old primary rejoins the cluster as
+// a standby when it comes back.
+func TestClusterAutoFailover(t *testing.T) {
+	t.Parallel()
+	var ports DynamicResources
+	ports.global = &GlobalPorts
+	ports.t = t
+
+	var stores [3]driver.RepoStore
+	for i := range stores {
+		// Each server gets its own dolt config --global, where it
+		// persists its role and epoch.
+		u, err := driver.NewDoltUser()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			u.Cleanup()
+		})
+		stores[i], err = u.MakeRepoStore()
+		require.NoError(t, err)
+		role := "standby"
+		if i == 0 {
+			role = "primary"
+		}
+		f := driver.WithFile{
+			Name:     "server.yaml",
+			Contents: autoFailoverConfig(i+1, role),
+			Template: ports.ApplyTemplate,
+		}
+		require.NoError(t, f.WriteAtDir(stores[i].Dir))
+	}
+
+	// The standbys come up first, so that the primary can get a quorum
+	// for its heartbeats as soon as it is running.
+	var dbs [3]*sql.DB
+	var err error
+	for i := 1; i < 3; i++ {
+		name := "server" + strconv.Itoa(i+1)
+		server := MakeServer(t, stores[i], &driver.Server{
+			Name:        name,
+			Args:        []string{"--config", "server.yaml"},
+			DynamicPort: name,
+		}, &ports)
+		require.NotNil(t, server)
+		dbs[i], err = server.DB(driver.Connection{User: "root"})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			dbs[i].Close()
+		})
+	}
+
+	primaryPort, ok := ports.GetPort("server1")
+	require.True(t, ok)
+	startPrimary := func() *driver.SqlServer {
+		server, err := driver.StartSqlServer(stores[0],
+			driver.WithArgs("--config", "server.yaml"),
+			driver.WithName("server1"),
+			driver.WithPort(primaryPort))
+		require.NoError(t, err)
+		return server
+	}
+	primary := startPrimary()
+	dbs[0], err = primary.DB(driver.Connection{User: "root"})
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	conn, err := dbs[0].Conn(ctx)
+	require.NoError(t, err)
+	for _, q := range []string{
+		"SET @@GLOBAL.dolt_cluster_ack_writes_timeout_secs = 10",
+		"create database testdb",
+		"use testdb",
+		"create table vals (id int primary key)",
+		"insert into vals values (0),(1),(2)",
+	} {
+		_, err = conn.ExecContext(ctx, q)
+		require.NoError(t, err, q)
+	}
+	require.NoError(t, conn.Close())
+	// Let a heartbeat tell the standbys that they are caught up.
+	time.Sleep(time.Second)
+
+	require.NoError(t, dbs[0].Close())
+	require.NoError(t, primary.GracefulStop())
+
+	var newPrimary, newEpoch int
+	require.Eventually(t, func() bool {
+		newPrimary, newEpoch = 0, 0
+		for i := 1; i < 3; i++ {
+			role, epoch, err := clusterRoleAndEpoch(ctx, dbs[i])
+			if err != nil {
+				return false
+			}
+			if role == "primary" {
+				if newPrimary != 0 {
+					t.Errorf("both server2 and server3 are primary")
+					return false
+				}
+				newPrimary, newEpoch = i, epoch
+			}
+		}
+		return newPrimary != 0
+	}, 30*time.Second, 100*time.Millisecond)
+	assert.Greater(t, newEpoch, 1)
+
+	var cnt int
+	require.NoError(t, dbs[newPrimary].QueryRowContext(ctx, "select count(*) from testdb.vals").Scan(&cnt))
+	assert.Equal(t, 3, cnt)
+	_, err = dbs[newPrimary].ExecContext(ctx, "insert into testdb.vals values (3)")
+	require.NoError(t, err)
+
+	// The old primary comes back at epoch 1 and is fenced to standby at
+	// the new epoch.
+	primary = startPrimary()
+	t.Cleanup(func() {
+		assert.NoError(t, primary.GracefulStop())
+	})
+	dbs[0], err = primary.DB(driver.Connection{User: "root"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		dbs[0].Close()
+	})
+	require.Eventually(t, func() bool {
+		role, epoch, err := clusterRoleAndEpoch(ctx, dbs[0])
+		return err == nil && role == "standby" && epoch == newEpoch
+	}, 30*time.Second, 100*time.Millisecond)
+	role, epoch, err := clusterRoleAndEpoch(ctx, dbs[newPrimary])
+	require.NoError(t, err)
+	assert.Equal(t, "primary", role)
+	assert.Equal(t, newEpoch, epoch)
+}
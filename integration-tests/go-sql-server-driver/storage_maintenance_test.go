@@ -0,0 +1,139 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	sqldriver "database/sql/driver"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	driver "github.com/dolthub/dolt/go/libraries/doltcore/dtestutils/sql_server_driver"
+)
+
+func TestStorageMaintenance(t *testing.T) {
+	t.Parallel()
+	var ports DynamicResources
+	ports.global = &GlobalPorts
+	ports.t = t
+
+	u, err := driver.NewDoltUser()
+	require.NoError(t, err)
+	t.Cleanup(func() { u.Cleanup() })
+
+	rs, err := u.MakeRepoStore()
+	require.NoError(t, err)
+	repo, err := rs.MakeRepo("storage_maintenance_test")
+	require.NoError(t, err)
+
+	err = driver.WithFile{
+		Name: "server.yaml",
+		Contents: `
+behavior:
+  auto_gc_behavior:
+    enable: true
+    storage_maintenance:
+      enable: true
+      check_interval_secs: 1
+      conjoin_min_files: 2
+listener:
+  port: {{get_port "server"}}
+`,
+		Template: ports.ApplyTemplate,
+	}.WriteAtDir(repo.Dir)
+	require.NoError(t, err)
+
+	server := MakeServer(t, repo, &driver.Server{
+		Args:        []string{"--config", "server.yaml"},
+		DynamicPort: "server",
+	}, &ports)
+	server.DBName = "storage_maintenance_test"
+
+	db, err := server.DB(driver.Connection{User: "root"})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	func() {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.ExecContext(ctx, "create table vals (id bigint primary key, val bigint)")
+		require.NoError(t, err)
+	}()
+
+	// Each manual GC writes a new table file, rather than an archive, to the old generation.
+	for i := 0; i < 3; i++ {
+		var vals []string
+		for j := i * 1024; j < (i+1)*1024; j++ {
+			vals = append(vals, "("+strconv.Itoa(j)+",0)")
+		}
+		func() {
+			conn, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer func() {
+				// After calling dolt_gc, the connection is bad. Remove it from the connection pool.
+				conn.Raw(func(_ any) error {
+					return sqldriver.ErrBadConn
+				})
+			}()
+			_, err = conn.ExecContext(ctx, "insert into vals values "+strings.Join(vals, ","))
+			require.NoError(t, err)
+			_, err = conn.ExecContext(ctx, "call dolt_commit('-Am', 'insert from "+strconv.Itoa(i*1024)+"')")
+			require.NoError(t, err)
+			_, err = conn.ExecContext(ctx, "call dolt_gc('--archive-level', '0')")
+			require.NoError(t, err)
+		}()
+	}
+
+	// The table files are too small to be archived on their own. They are conjoined, and the
+	// resulting table file is then archived.
+	var ops []string
+	require.Eventually(t, func() bool {
+		rows, err := db.QueryContext(ctx, "select operation, status from dolt_storage_maintenance_log order by id")
+		if !assert.NoError(t, err) {
+			return false
+		}
+		defer rows.Close()
+		ops = nil
+		for rows.Next() {
+			var op, status string
+			if !assert.NoError(t, rows.Scan(&op, &status)) {
+				return false
+			}
+			ops = append(ops, op+":"+status)
+		}
+		return len(ops) >= 3
+	}, 60*time.Second, 500*time.Millisecond)
+	assert.Equal(t, []string{"archive:skipped", "conjoin:completed", "archive:completed"}, ops[:3])
+
+	entries, err := os.ReadDir(filepath.Join(repo.Dir, ".dolt/noms/oldgen"))
+	require.NoError(t, err)
+	var storageFiles []string
+	for _, e := range entries {
+		if e.Name() != "manifest" && e.Name() != "LOCK" {
+			storageFiles = append(storageFiles, e.Name())
+		}
+	}
+	require.Len(t, storageFiles, 1)
+	assert.True(t, strings.HasSuffix(storageFiles[0], ".darc"))
+}